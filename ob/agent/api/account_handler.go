@@ -0,0 +1,160 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/obshell/ob/agent/api/common"
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	accountexecutor "github.com/oceanbase/obshell/ob/agent/executor/account"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+// @ID listAccounts
+// @Summary list accounts
+// @Description list all the accounts of the agent
+// @Tags security
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Success 200 object http.OcsAgentResponse{data=[]account.Account}
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 403 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/security/accounts [get]
+func listAccountsHandler(c *gin.Context) {
+	data, err := accountexecutor.ListAccounts()
+	common.SendResponse(c, data, err)
+}
+
+// @ID createAccount
+// @Summary create account
+// @Description create a new account with a role and optional tenant scope
+// @Tags security
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param body body param.CreateAccountParam true "create account params"
+// @Success 200 object http.OcsAgentResponse{data=account.Account}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 403 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/security/account [post]
+func createAccountHandler(c *gin.Context) {
+	var p param.CreateAccountParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	data, err := accountexecutor.CreateAccount(&p)
+	common.SendResponse(c, data, err)
+}
+
+// @ID getAccount
+// @Summary get account
+// @Description get account by name
+// @Tags security
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "Account name"
+// @Success 200 object http.OcsAgentResponse{data=account.Account}
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 403 object http.OcsAgentResponse
+// @Failure 404 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/security/account/{name} [get]
+func getAccountHandler(c *gin.Context) {
+	data, err := accountexecutor.GetAccount(c.Param(constant.URI_PARAM_NAME))
+	common.SendResponse(c, data, err)
+}
+
+// @ID updateAccount
+// @Summary update account
+// @Description update the role, tenant scope or description of the account
+// @Tags security
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "Account name"
+// @Param body body param.UpdateAccountParam true "update account params"
+// @Success 200 object http.OcsAgentResponse{data=account.Account}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 403 object http.OcsAgentResponse
+// @Failure 404 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/security/account/{name} [patch]
+func updateAccountHandler(c *gin.Context) {
+	var p param.UpdateAccountParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	data, err := accountexecutor.UpdateAccount(c.Param(constant.URI_PARAM_NAME), &p)
+	common.SendResponse(c, data, err)
+}
+
+// @ID deleteAccount
+// @Summary delete account
+// @Description delete account by name
+// @Tags security
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "Account name"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 403 object http.OcsAgentResponse
+// @Failure 404 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/security/account/{name} [delete]
+func deleteAccountHandler(c *gin.Context) {
+	err := accountexecutor.DeleteAccount(c.Param(constant.URI_PARAM_NAME))
+	common.SendResponse(c, nil, err)
+}
+
+// @ID changeAccountPassword
+// @Summary change account password
+// @Description change the password of the account, an account can always change its own password
+// @Tags security
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "Account name"
+// @Param body body param.ChangeAccountPasswordParam true "new password"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 403 object http.OcsAgentResponse
+// @Failure 404 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/security/account/{name}/password [put]
+func changeAccountPasswordHandler(c *gin.Context) {
+	var p param.ChangeAccountPasswordParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	err := accountexecutor.ChangeAccountPassword(c.Param(constant.URI_PARAM_NAME), &p)
+	common.SendResponse(c, nil, err)
+}
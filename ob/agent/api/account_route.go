@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/obshell/ob/agent/api/common"
+	"github.com/oceanbase/obshell/ob/agent/constant"
+)
+
+func InitAccountRoutes(parentGroup *gin.RouterGroup, isLocalRoute bool) {
+	security := parentGroup.Group(constant.URI_SECURITY_GROUP)
+	account := security.Group(constant.URI_ACCOUNT)
+	accounts := security.Group(constant.URI_ACCOUNTS)
+
+	if !isLocalRoute {
+		account.Use(common.Verify())
+		accounts.Use(common.Verify())
+	}
+
+	accounts.GET("", checkClusterAgentWrapper(listAccountsHandler))
+	account.POST("", checkClusterAgentWrapper(createAccountHandler))
+	account.GET(constant.URI_PATH_PARAM_NAME, checkClusterAgentWrapper(getAccountHandler))
+	account.PATCH(constant.URI_PATH_PARAM_NAME, checkClusterAgentWrapper(updateAccountHandler))
+	account.DELETE(constant.URI_PATH_PARAM_NAME, checkClusterAgentWrapper(deleteAccountHandler))
+	account.PUT(constant.URI_PATH_PARAM_NAME+constant.URI_PASSWORD, checkClusterAgentWrapper(changeAccountPasswordHandler))
+}
@@ -123,14 +123,24 @@ func InitOcsAgentRoutes(s *http2.State, r *gin.Engine, isLocalRoute bool) {
 	InitTenantRoutes(v1, isLocalRoute)
 	InitBackupRoutes(v1, isLocalRoute)
 	InitRestoreRoutes(v1, isLocalRoute)
+	InitStandbyRoutes(v1, isLocalRoute)
+	InitSnapshotRoutes(v1, isLocalRoute)
 	InitObproxyRoutes(v1, isLocalRoute)
 	InitMetricRoutes(v1, isLocalRoute)
 	InitAlarmRoutes(v1, isLocalRoute)
 	InitCredentialRoutes(v1, isLocalRoute)
+	InitAccountRoutes(v1, isLocalRoute)
+	InitAuditRoutes(v1, isLocalRoute)
+	InitScheduleRoutes(v1, isLocalRoute)
 
 	system := v1.Group(constant.URI_SYSTEM_GROUP)
 	InitExternalRoutes(system, isLocalRoute)
 
+	// metrics exposed to Prometheus
+	if !isLocalRoute {
+		r.GET(constant.URI_METRIC_GROUP, ExportMetrics)
+	}
+
 	// ob routes
 	ob.POST(constant.URI_INIT, obInitHandler)
 	ob.POST(constant.URI_STOP, obStopHandler)
@@ -180,6 +190,7 @@ func InitOcsAgentRoutes(s *http2.State, r *gin.Engine, isLocalRoute bool) {
 	observer.PUT(constant.URI_CONFIG, obServerConfigHandler(true))
 	observer.POST(constant.URI_CONFIG, obServerConfigHandler(true))
 	observer.DELETE("", obClusterScaleInHandler)
+	observer.POST(constant.URI_REPLACE, obServerReplaceHandler)
 	observer.GET(constant.URI_INFO, observerInfoHandler)
 
 	// zone routes
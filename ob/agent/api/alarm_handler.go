@@ -17,12 +17,17 @@
 package api
 
 import (
+	"strings"
+
 	"github.com/gin-gonic/gin"
 
 	"github.com/oceanbase/obshell/ob/agent/api/common"
 	"github.com/oceanbase/obshell/ob/agent/constant"
 	"github.com/oceanbase/obshell/ob/agent/executor/alarm"
+	alarmconstant "github.com/oceanbase/obshell/ob/agent/executor/alarm/constant"
 	"github.com/oceanbase/obshell/ob/model/alarm/alert"
+	"github.com/oceanbase/obshell/ob/model/alarm/channel"
+	"github.com/oceanbase/obshell/ob/model/alarm/payload"
 	"github.com/oceanbase/obshell/ob/model/alarm/rule"
 	"github.com/oceanbase/obshell/ob/model/alarm/silence"
 )
@@ -158,3 +163,224 @@ func GetRule(ctx *gin.Context) {
 	data, err := alarm.GetRule(ctx, name)
 	common.SendResponse(ctx, data, err)
 }
+
+// CreateOrUpdateRule godoc
+// @ID CreateOrUpdateRule
+// @Summary Create or update a rule
+// @Description Create or update a rule, the rules are rendered to the rule file of prometheus
+// @Tags alarm
+// @Accept json
+// @Produce json
+// @Param rule body rule.RuleParam true "rule"
+// @Success 200 {object} http.OcsAgentResponse{data=rule.RuleResponse}
+// @Router /api/v1/alarm/rule [put]
+func CreateOrUpdateRule(ctx *gin.Context) {
+	param := &rule.RuleParam{}
+	err := ctx.Bind(param)
+	if err != nil {
+		common.SendResponse(ctx, nil, err)
+		return
+	}
+	data, err := alarm.CreateOrUpdateRule(ctx, param)
+	common.SendResponse(ctx, data, err)
+}
+
+// DeleteRule godoc
+// @ID DeleteRule
+// @Summary Delete a rule
+// @Description Delete a customized rule by name, or reset a built-in rule to default
+// @Tags alarm
+// @Accept json
+// @Produce json
+// @Param name path string true "rule name"
+// @Success 200 {object} http.OcsAgentResponse
+// @Router /api/v1/alarm/rule/{name} [delete]
+func DeleteRule(ctx *gin.Context) {
+	name := ctx.Param(constant.URI_PARAM_NAME)
+	err := alarm.DeleteRule(ctx, name)
+	common.SendResponse(ctx, nil, err)
+}
+
+// EnableRule godoc
+// @ID EnableRule
+// @Summary Enable a rule
+// @Description Enable a rule by name
+// @Tags alarm
+// @Accept json
+// @Produce json
+// @Param name path string true "rule name"
+// @Success 200 {object} http.OcsAgentResponse
+// @Router /api/v1/alarm/rule/{name}/enable [post]
+func EnableRule(ctx *gin.Context) {
+	name := ctx.Param(constant.URI_PARAM_NAME)
+	err := alarm.EnableRule(ctx, name)
+	common.SendResponse(ctx, nil, err)
+}
+
+// DisableRule godoc
+// @ID DisableRule
+// @Summary Disable a rule
+// @Description Disable a rule by name
+// @Tags alarm
+// @Accept json
+// @Produce json
+// @Param name path string true "rule name"
+// @Success 200 {object} http.OcsAgentResponse
+// @Router /api/v1/alarm/rule/{name}/disable [post]
+func DisableRule(ctx *gin.Context) {
+	name := ctx.Param(constant.URI_PARAM_NAME)
+	err := alarm.DisableRule(ctx, name)
+	common.SendResponse(ctx, nil, err)
+}
+
+// ListChannels godoc
+// @ID ListChannels
+// @Summary List all channels
+// @Description List all alarm notification channels
+// @Tags alarm
+// @Accept json
+// @Produce json
+// @Param filter body channel.ChannelFilter false "channel filter"
+// @Success 200 {object} http.OcsAgentResponse{data=[]channel.ChannelResponse}
+// @Router /api/v1/alarm/channels [post]
+func ListChannels(ctx *gin.Context) {
+	filter := &channel.ChannelFilter{}
+	err := ctx.Bind(filter)
+	if err != nil {
+		common.SendResponse(ctx, nil, err)
+		return
+	}
+	data, err := alarm.ListChannels(ctx, filter)
+	common.SendResponse(ctx, data, err)
+}
+
+// GetChannel godoc
+// @ID GetChannel
+// @Summary Get a channel
+// @Description Get an alarm notification channel by name
+// @Tags alarm
+// @Accept json
+// @Produce json
+// @Param name path string true "channel name"
+// @Success 200 {object} http.OcsAgentResponse{data=channel.ChannelResponse}
+// @Router /api/v1/alarm/channel/{name} [get]
+func GetChannel(ctx *gin.Context) {
+	name := ctx.Param(constant.URI_PARAM_NAME)
+	data, err := alarm.GetChannel(ctx, name)
+	common.SendResponse(ctx, data, err)
+}
+
+// CreateOrUpdateChannel godoc
+// @ID CreateOrUpdateChannel
+// @Summary Create or update a channel
+// @Description Create or update an alarm notification channel
+// @Tags alarm
+// @Accept json
+// @Produce json
+// @Param channel body channel.ChannelParam true "channel"
+// @Success 200 {object} http.OcsAgentResponse{data=channel.ChannelResponse}
+// @Router /api/v1/alarm/channel [put]
+func CreateOrUpdateChannel(ctx *gin.Context) {
+	param := &channel.ChannelParam{}
+	err := ctx.Bind(param)
+	if err != nil {
+		common.SendResponse(ctx, nil, err)
+		return
+	}
+	data, err := alarm.CreateOrUpdateChannel(ctx, param)
+	common.SendResponse(ctx, data, err)
+}
+
+// DeleteChannel godoc
+// @ID DeleteChannel
+// @Summary Delete a channel
+// @Description Delete an alarm notification channel by name
+// @Tags alarm
+// @Accept json
+// @Produce json
+// @Param name path string true "channel name"
+// @Success 200 {object} http.OcsAgentResponse
+// @Router /api/v1/alarm/channel/{name} [delete]
+func DeleteChannel(ctx *gin.Context) {
+	name := ctx.Param(constant.URI_PARAM_NAME)
+	err := alarm.DeleteChannel(ctx, name)
+	common.SendResponse(ctx, nil, err)
+}
+
+// TestChannel godoc
+// @ID TestChannel
+// @Summary Test a channel
+// @Description Send a test notification through the alarm notification channel
+// @Tags alarm
+// @Accept json
+// @Produce json
+// @Param name path string true "channel name"
+// @Success 200 {object} http.OcsAgentResponse
+// @Router /api/v1/alarm/channel/{name}/test [post]
+func TestChannel(ctx *gin.Context) {
+	name := ctx.Param(constant.URI_PARAM_NAME)
+	err := alarm.TestChannel(ctx, name)
+	common.SendResponse(ctx, nil, err)
+}
+
+// ListDeliveries godoc
+// @ID ListDeliveries
+// @Summary List deliveries
+// @Description List the delivery history of alarm notifications
+// @Tags alarm
+// @Accept json
+// @Produce json
+// @Param filter body channel.DeliveryFilter false "delivery filter"
+// @Success 200 {object} http.OcsAgentResponse{data=[]channel.Delivery}
+// @Router /api/v1/alarm/deliveries [post]
+func ListDeliveries(ctx *gin.Context) {
+	filter := &channel.DeliveryFilter{}
+	err := ctx.Bind(filter)
+	if err != nil {
+		common.SendResponse(ctx, nil, err)
+		return
+	}
+	data, err := alarm.ListDeliveries(ctx, filter)
+	common.SendResponse(ctx, data, err)
+}
+
+// GetReceiverInfo godoc
+// @ID GetReceiverInfo
+// @Summary Get receiver info
+// @Description Get the webhook url and token to be configured as the receiver of Alertmanager
+// @Tags alarm
+// @Accept json
+// @Produce json
+// @Success 200 {object} http.OcsAgentResponse{data=channel.ReceiverInfo}
+// @Router /api/v1/alarm/receiver [get]
+func GetReceiverInfo(ctx *gin.Context) {
+	data, err := alarm.GetReceiverInfo(ctx)
+	common.SendResponse(ctx, data, err)
+}
+
+// ReceiveWebhook godoc
+// @ID ReceiveWebhook
+// @Summary Receive alerts
+// @Description Receive alerts from Alertmanager and dispatch them to the matched channels
+// @Tags alarm
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token of the receiver"
+// @Param payload body payload.WebhookPayload true "alertmanager webhook payload"
+// @Success 200 {object} http.OcsAgentResponse
+// @Router /api/v1/alarm/receiver/webhook [post]
+func ReceiveWebhook(ctx *gin.Context) {
+	token := strings.TrimPrefix(ctx.GetHeader("Authorization"), alarmconstant.ReceiverAuthScheme)
+	if err := alarm.VerifyReceiverToken(token); err != nil {
+		common.SendResponse(ctx, nil, err)
+		return
+	}
+	pl := &payload.WebhookPayload{}
+	err := ctx.Bind(pl)
+	if err != nil {
+		common.SendResponse(ctx, nil, err)
+		return
+	}
+	err = alarm.HandleWebhookPayload(pl)
+	common.SendResponse(ctx, nil, err)
+}
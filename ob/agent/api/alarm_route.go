@@ -42,4 +42,21 @@ func InitAlarmRoutes(parentGroup *gin.RouterGroup, isLocalRoute bool) {
 	// rules
 	alarm.POST(constant.URI_RULES, ListRules)
 	alarm.GET(constant.URI_RULE+constant.URI_PATH_PARAM_NAME, GetRule)
+	alarm.PUT(constant.URI_RULE, CreateOrUpdateRule)
+	alarm.DELETE(constant.URI_RULE+constant.URI_PATH_PARAM_NAME, DeleteRule)
+	alarm.POST(constant.URI_RULE+constant.URI_PATH_PARAM_NAME+constant.URI_ENABLE, EnableRule)
+	alarm.POST(constant.URI_RULE+constant.URI_PATH_PARAM_NAME+constant.URI_DISABLE, DisableRule)
+
+	// channels
+	alarm.POST(constant.URI_CHANNELS, ListChannels)
+	alarm.GET(constant.URI_CHANNEL+constant.URI_PATH_PARAM_NAME, GetChannel)
+	alarm.PUT(constant.URI_CHANNEL, CreateOrUpdateChannel)
+	alarm.DELETE(constant.URI_CHANNEL+constant.URI_PATH_PARAM_NAME, DeleteChannel)
+	alarm.POST(constant.URI_CHANNEL+constant.URI_PATH_PARAM_NAME+constant.URI_TEST, TestChannel)
+	alarm.POST(constant.URI_DELIVERIES, ListDeliveries)
+	alarm.GET(constant.URI_RECEIVER, GetReceiverInfo)
+
+	// The receiver is called by Alertmanager, which is authenticated by the receiver token.
+	receiver := parentGroup.Group(constant.URI_ALARM_GROUP + constant.URI_RECEIVER)
+	receiver.POST(constant.URI_WEBHOOK, ReceiveWebhook)
 }
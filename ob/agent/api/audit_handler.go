@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/obshell/ob/agent/api/common"
+	auditexecutor "github.com/oceanbase/obshell/ob/agent/executor/audit"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+// @ID listAuditLogs
+// @Summary list audit logs
+// @Description list the audit logs of the mutating api requests, the latest first
+// @Tags audit
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param user query string false "Operator, the account name, root, agent or local"
+// @Param method query string false "HTTP method"
+// @Param key_word query string false "Keyword for searching the route or uri"
+// @Param dag_id query string false "Generic id of the task created by the request"
+// @Param successful query bool false "Whether the request succeeded"
+// @Param start_time query string false "Start time (RFC3339)"
+// @Param end_time query string false "End time (RFC3339)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 object http.OcsAgentResponse{data=bo.PaginatedAuditLogResponse}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/audit [get]
+func listAuditLogsHandler(c *gin.Context) {
+	var p param.ListAuditLogParam
+	if err := c.BindQuery(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	data, err := auditexecutor.ListAuditLogs(&p)
+	common.SendResponse(c, data, err)
+}
+
+// @ID getAuditRetention
+// @Summary get audit log retention
+// @Description get the retention days of the audit logs
+// @Tags audit
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Success 200 object http.OcsAgentResponse{data=bo.AuditRetention}
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/audit/retention [get]
+func getAuditRetentionHandler(c *gin.Context) {
+	data, err := auditexecutor.GetRetention()
+	common.SendResponse(c, data, err)
+}
+
+// @ID setAuditRetention
+// @Summary set audit log retention
+// @Description set the retention days of the audit logs, the expired logs are cleaned hourly
+// @Tags audit
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param body body param.AuditRetentionParam true "retention params"
+// @Success 200 object http.OcsAgentResponse{data=bo.AuditRetention}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/audit/retention [put]
+func setAuditRetentionHandler(c *gin.Context) {
+	var p param.AuditRetentionParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	data, err := auditexecutor.SetRetention(&p)
+	common.SendResponse(c, data, err)
+}
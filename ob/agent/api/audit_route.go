@@ -0,0 +1,36 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/obshell/ob/agent/api/common"
+	"github.com/oceanbase/obshell/ob/agent/constant"
+)
+
+func InitAuditRoutes(parentGroup *gin.RouterGroup, isLocalRoute bool) {
+	audit := parentGroup.Group(constant.URI_AUDIT_GROUP)
+
+	if !isLocalRoute {
+		audit.Use(common.Verify())
+	}
+
+	audit.GET("", listAuditLogsHandler)
+	audit.GET(constant.URI_RETENTION, getAuditRetentionHandler)
+	audit.PUT(constant.URI_RETENTION, checkClusterAgentWrapper(setAuditRetentionHandler))
+}
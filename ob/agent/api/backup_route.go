@@ -17,6 +17,8 @@
 package api
 
 import (
+	"strconv"
+
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 
@@ -56,6 +58,15 @@ func InitBackupRoutes(v1 *gin.RouterGroup, isLocalRoute bool) {
 	tenantGroup.GET(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_TASKS, listTenantBackupTasksHandler)
 	tenantGroup.GET(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_INFO, getTenantBackupInfoHandler)
 	tenantGroup.GET(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_ARCHIVE+constant.URI_TASKS, listTenantArchiveLogTasksHandler)
+	tenantGroup.GET(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_SETS, getTenantBackupCatalogHandler)
+	tenantGroup.POST(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_SETS+constant.URI_PATH_PARAM_ID+constant.URI_VALIDATE, validateTenantBackupSetHandler)
+	tenantGroup.DELETE(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_SETS+constant.URI_PATH_PARAM_ID, deleteTenantBackupSetHandler)
+	tenantGroup.DELETE(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_PIECES+constant.URI_PATH_PARAM_ID, deleteTenantBackupPieceHandler)
+	tenantGroup.PUT(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_RPO_TARGET, setTenantBackupRpoTargetHandler)
+	tenantGroup.GET(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_RPO_TARGET, getTenantBackupRpoTargetHandler)
+	tenantGroup.DELETE(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_RPO_TARGET, deleteTenantBackupRpoTargetHandler)
+	tenantGroup.GET(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_COMPLIANCE, getTenantBackupComplianceHandler)
+	tenantGroup.GET(constant.URI_PATH_PARAM_NAME+constant.URI_BACKUP+constant.URI_COMPLIANCE+constant.URI_REPORT, getTenantBackupComplianceReportHandler)
 
 	obclusterGroup.POST(constant.URI_BACKUP+constant.URI_CONFIG, obclusterBackupConfigHandler)
 	obclusterGroup.PATCH(constant.URI_BACKUP+constant.URI_CONFIG, patchObclusterBackupConfigHandler)
@@ -63,6 +74,8 @@ func InitBackupRoutes(v1 *gin.RouterGroup, isLocalRoute bool) {
 	obclusterGroup.PATCH(constant.URI_BACKUP, patchObclusterBackupHandler)
 	obclusterGroup.PATCH(constant.URI_BACKUP+constant.URI_ARCHIVE, patchObclusterArchiveLogHandler)
 	obclusterGroup.GET(constant.URI_BACKUP+constant.URI_OVERVIEW, obclusterBackupOverviewHandler)
+	obclusterGroup.GET(constant.URI_BACKUP+constant.URI_COMPLIANCE, obclusterBackupComplianceHandler)
+	obclusterGroup.GET(constant.URI_BACKUP+constant.URI_COMPLIANCE+constant.URI_REPORT, obclusterBackupComplianceReportHandler)
 }
 
 // @ID				obclusterBackupConfig
@@ -534,3 +547,314 @@ func getTenantBackupInfoHandler(c *gin.Context) {
 	info, err := ob.GetTenantBackupInfo(tenant.TenantName)
 	common.SendResponse(c, info, err)
 }
+
+// @ID				getTenantBackupCatalog
+// @Summary		Get backup sets and archive pieces of tenant
+// @Description	Get backup sets and archive pieces of tenant, with whether each of them can be deleted
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string	true	"Authorization"
+// @Param			name			path	string	true	"Tenant name"
+// @Success		200				object	http.OcsAgentResponse{data=bo.BackupCatalog}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/tenant/{name}/backup/sets [get]
+func getTenantBackupCatalogHandler(c *gin.Context) {
+	tenant, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	catalog, err := ob.GetTenantBackupCatalog(tenant)
+	common.SendResponse(c, catalog, err)
+}
+
+// @ID				validateTenantBackupSet
+// @Summary		Validate backup set of tenant
+// @Description	Validate backup set of tenant
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string	true	"Authorization"
+// @Param			name			path	string	true	"Tenant name"
+// @Param			id				path	int		true	"Backup set id"
+// @Success		200				object	http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/tenant/{name}/backup/sets/{id}/validate [post]
+func validateTenantBackupSetHandler(c *gin.Context) {
+	tenant, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	id, err := getBackupFileID(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	dag, err := ob.TenantValidateBackupSet(tenant, id)
+	common.SendResponse(c, dag, err)
+}
+
+// @ID				deleteTenantBackupSet
+// @Summary		Delete backup set of tenant
+// @Description	Delete backup set of tenant, only the backup set that no other available backup set depends on can be deleted
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string	true	"Authorization"
+// @Param			name			path	string	true	"Tenant name"
+// @Param			id				path	int		true	"Backup set id"
+// @Success		200				object	http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/tenant/{name}/backup/sets/{id} [delete]
+func deleteTenantBackupSetHandler(c *gin.Context) {
+	tenant, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	id, err := getBackupFileID(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	dag, err := ob.TenantDeleteBackupSet(tenant, id)
+	common.SendResponse(c, dag, err)
+}
+
+// @ID				deleteTenantBackupPiece
+// @Summary		Delete archive piece of tenant
+// @Description	Delete archive piece of tenant, only the piece whose logs are not needed by any available backup set can be deleted
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string	true	"Authorization"
+// @Param			name			path	string	true	"Tenant name"
+// @Param			id				path	int		true	"Archive piece id"
+// @Success		200				object	http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/tenant/{name}/backup/pieces/{id} [delete]
+func deleteTenantBackupPieceHandler(c *gin.Context) {
+	tenant, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	id, err := getBackupFileID(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	dag, err := ob.TenantDeleteBackupPiece(tenant, id)
+	common.SendResponse(c, dag, err)
+}
+
+func getBackupFileID(c *gin.Context) (int64, error) {
+	idStr := c.Param(constant.URI_PARAM_ID)
+	if idStr == "" {
+		return 0, errors.Occur(errors.ErrRequestPathParamEmpty, "id")
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, errors.Occur(errors.ErrCommonIllegalArgument, "invalid id")
+	}
+	return id, nil
+}
+
+// @ID				setTenantBackupRpoTarget
+// @Summary		Set RPO target of tenant
+// @Description	Set the recovery point objective and the backup frequency targets of tenant, which are checked every minute
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string						true	"Authorization"
+// @Param			name			path	string						true	"Tenant name"
+// @Param			body			body	param.BackupRpoTargetParam	true	"RPO target"
+// @Success		200				object	http.OcsAgentResponse{data=bo.BackupRpoTarget}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/tenant/{name}/backup/rpo-target [put]
+func setTenantBackupRpoTargetHandler(c *gin.Context) {
+	tenant, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	var p param.BackupRpoTargetParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	target, err := ob.SetTenantBackupRpoTarget(tenant, &p)
+	common.SendResponse(c, target, err)
+}
+
+// @ID				getTenantBackupRpoTarget
+// @Summary		Get RPO target of tenant
+// @Description	Get RPO target of tenant
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string	true	"Authorization"
+// @Param			name			path	string	true	"Tenant name"
+// @Success		200				object	http.OcsAgentResponse{data=bo.BackupRpoTarget}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		404				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/tenant/{name}/backup/rpo-target [get]
+func getTenantBackupRpoTargetHandler(c *gin.Context) {
+	tenant, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	target, err := ob.GetTenantBackupRpoTarget(tenant)
+	common.SendResponse(c, target, err)
+}
+
+// @ID				deleteTenantBackupRpoTarget
+// @Summary		Delete RPO target of tenant
+// @Description	Stop checking the backup compliance of tenant, the daily records are kept for the report
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string	true	"Authorization"
+// @Param			name			path	string	true	"Tenant name"
+// @Success		200				object	http.OcsAgentResponse
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/tenant/{name}/backup/rpo-target [delete]
+func deleteTenantBackupRpoTargetHandler(c *gin.Context) {
+	tenant, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	common.SendResponse(c, nil, ob.DeleteTenantBackupRpoTarget(tenant))
+}
+
+// @ID				getTenantBackupCompliance
+// @Summary		Get backup compliance of tenant
+// @Description	Check whether the archive lag and the latest successful backups of tenant meet its RPO target
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string	true	"Authorization"
+// @Param			name			path	string	true	"Tenant name"
+// @Success		200				object	http.OcsAgentResponse{data=bo.BackupCompliance}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		404				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/tenant/{name}/backup/compliance [get]
+func getTenantBackupComplianceHandler(c *gin.Context) {
+	tenant, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	compliance, err := ob.GetTenantBackupCompliance(tenant)
+	common.SendResponse(c, compliance, err)
+}
+
+// @ID				getTenantBackupComplianceReport
+// @Summary		Get daily backup compliance report of tenant
+// @Description	Get daily backup compliance report of tenant
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string	true	"Authorization"
+// @Param			name			path	string	true	"Tenant name"
+// @Param			start_date		query	string	false	"Start date, such as 2006-01-02"
+// @Param			end_date		query	string	false	"End date, such as 2006-01-02"
+// @Success		200				object	http.OcsAgentResponse{data=bo.BackupComplianceReport}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/tenant/{name}/backup/compliance/report [get]
+func getTenantBackupComplianceReportHandler(c *gin.Context) {
+	tenant, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	var p param.BackupComplianceReportParam
+	if err := c.BindQuery(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	report, err := ob.GetBackupComplianceReport(tenant, &p)
+	common.SendResponse(c, report, err)
+}
+
+// @ID				obclusterBackupCompliance
+// @Summary		Get backup compliance of all tenants
+// @Description	Get backup compliance of all tenants which have RPO targets
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string	true	"Authorization"
+// @Success		200				object	http.OcsAgentResponse{data=[]bo.BackupCompliance}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/obcluster/backup/compliance [get]
+func obclusterBackupComplianceHandler(c *gin.Context) {
+	if !meta.OCS_AGENT.IsClusterAgent() {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, meta.OCS_AGENT.String(), meta.OCS_AGENT.GetIdentity(), meta.CLUSTER_AGENT))
+		return
+	}
+
+	compliances, err := ob.GetObclusterBackupCompliance()
+	common.SendResponse(c, compliances, err)
+}
+
+// @ID				obclusterBackupComplianceReport
+// @Summary		Get daily backup compliance report of all tenants
+// @Description	Get daily backup compliance report of all tenants
+// @Tags			Backup
+// @Accept			application/json
+// @Produce		application/json
+// @Param			X-OCS-Header	header	string	true	"Authorization"
+// @Param			start_date		query	string	false	"Start date, such as 2006-01-02"
+// @Param			end_date		query	string	false	"End date, such as 2006-01-02"
+// @Success		200				object	http.OcsAgentResponse{data=bo.BackupComplianceReport}
+// @Failure		400				object	http.OcsAgentResponse
+// @Failure		401				object	http.OcsAgentResponse
+// @Failure		500				object	http.OcsAgentResponse
+// @Router			/api/v1/obcluster/backup/compliance/report [get]
+func obclusterBackupComplianceReportHandler(c *gin.Context) {
+	if !meta.OCS_AGENT.IsClusterAgent() {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, meta.OCS_AGENT.String(), meta.OCS_AGENT.GetIdentity(), meta.CLUSTER_AGENT))
+		return
+	}
+
+	var p param.BackupComplianceReportParam
+	if err := c.BindQuery(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	report, err := ob.GetBackupComplianceReport(nil, &p)
+	common.SendResponse(c, report, err)
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	auditexecutor "github.com/oceanbase/obshell/ob/agent/executor/audit"
+	ocshttp "github.com/oceanbase/obshell/ob/agent/lib/http"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/ob/agent/secure"
+	"github.com/oceanbase/obshell/ob/model/account"
+)
+
+// recordAudit records the mutating api requests into the audit log.
+func recordAudit(c *gin.Context, resp *ocshttp.OcsAgentResponse) {
+	if !strings.HasPrefix(c.Request.RequestURI, constant.URI_API_V1) || c.FullPath() == "" {
+		return
+	}
+	if routeAction(c) == account.ActionRead {
+		return
+	}
+
+	auditLog := &bo.AuditLog{
+		Time:       time.Now(),
+		ClientIp:   auditClientIp(c),
+		User:       auditUser(c),
+		Session:    auditSession(c),
+		Method:     c.Request.Method,
+		Route:      c.FullPath(),
+		Uri:        c.Request.RequestURI,
+		DagId:      auditDagId(resp.Data),
+		Status:     resp.Status,
+		Successful: resp.Successful,
+		TraceId:    resp.TraceId,
+	}
+	if body, ok := c.Get(constant.AUDIT_REQUEST_BODY); ok {
+		auditLog.Params, _ = body.(string)
+	}
+	if resp.Error != nil {
+		auditLog.ErrorCode = resp.Error.ErrCode
+		auditLog.ErrorMessage = resp.Error.Message
+	}
+	auditexecutor.Record(auditLog)
+}
+
+// forwardedHeader returns the header of the request if it is forwarded by another agent.
+func forwardedHeader(c *gin.Context) *secure.HttpHeader {
+	for _, key := range []string{constant.OCS_AGENT_HEADER, constant.OCS_HEADER} {
+		if value, ok := c.Get(key); ok {
+			if header, ok := value.(secure.HttpHeader); ok && header.ForwardType != secure.NotForward {
+				return &header
+			}
+		}
+	}
+	return nil
+}
+
+// auditOrigin returns the original requester to be carried by the forwarded request,
+// so that the agent handling it records the real user and client ip.
+func auditOrigin(c *gin.Context) secure.ForwardOrigin {
+	return secure.ForwardOrigin{
+		User:     auditUser(c),
+		ClientIp: auditClientIp(c),
+	}
+}
+
+func auditClientIp(c *gin.Context) string {
+	if header := forwardedHeader(c); header != nil && header.OriginIp != "" {
+		return header.OriginIp
+	}
+	return c.ClientIP()
+}
+
+func auditUser(c *gin.Context) string {
+	if header := forwardedHeader(c); header != nil && header.OriginUser != "" {
+		return header.OriginUser
+	}
+	if name, ok := c.Get(constant.HTTP_HEADER_ACCOUNT); ok {
+		return name.(string)
+	}
+	if IsLocalRoute(c) {
+		return constant.AUDIT_USER_LOCAL
+	}
+	if header, _ := c.Get(constant.OCS_AGENT_HEADER); header != nil {
+		return constant.AUDIT_USER_AGENT
+	}
+	return constant.AUDIT_USER_ROOT
+}
+
+// auditSession returns the fingerprint of the session, the session id is a credential and must not be recorded.
+func auditSession(c *gin.Context) string {
+	sessionID, ok := c.Get(constant.HTTP_HEADER_SESSION_ID)
+	if !ok || sessionID == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(sessionID.(string)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// auditDagId returns the generic id of the dag if the response is a dag.
+func auditDagId(data interface{}) string {
+	if data == nil {
+		return ""
+	}
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+	var dag struct {
+		GenericID string `json:"id"`
+		DagID     int64  `json:"dag_id"`
+	}
+	if err := json.Unmarshal(bytes, &dag); err != nil || dag.DagID == 0 {
+		return ""
+	}
+	return dag.GenericID
+}
@@ -83,7 +83,7 @@ func autoForward(c *gin.Context) {
 		return
 	}
 
-	headers, err := secure.RepackageHeaderForAutoForward(&header, master)
+	headers, err := secure.RepackageHeaderForAutoForward(&header, master, auditOrigin(c))
 	if err != nil {
 		SendResponse(c, nil, err)
 		return
@@ -127,7 +127,7 @@ func ForwardRequest(c *gin.Context, agentInfo meta.AgentInfoInterface, param int
 	log.WithContext(ctx).Infof("Forward request: [%v %v, client=%v, agent=%s]", c.Request.Method, c.Request.URL, c.ClientIP(), agentInfo.String())
 
 	// forward for local route or cluster agent
-	body, headers, err := buildForwardBodyAndHeader(agentInfo, c.Request.RequestURI, param)
+	body, headers, err := buildForwardBodyAndHeader(agentInfo, c.Request.RequestURI, auditOrigin(c), param)
 	if err != nil {
 		SendResponse(c, nil, err)
 		return
@@ -175,12 +175,12 @@ func sendRequsetForForward(c *gin.Context, ctx context.Context, agentInfo meta.A
 		c.Request.Method, c.Request.URL, c.ClientIP(), agentInfo.String(), traceId, duration, response.StatusCode())
 }
 
-func buildForwardBodyAndHeader(agentInfo meta.AgentInfoInterface, uri string, body interface{}) (interface{}, map[string]string, error) {
+func buildForwardBodyAndHeader(agentInfo meta.AgentInfoInterface, uri string, origin secure.ForwardOrigin, body interface{}) (interface{}, map[string]string, error) {
 	var headers = map[string]string{}
 
 	for _, route := range secure.GetSkipBodyEncryptRoutes() {
 		if route == uri {
-			headers = secure.BuildHeaderForForward(agentInfo, uri)
+			headers = secure.BuildHeaderForForward(agentInfo, uri, origin)
 			return body, headers, nil
 		}
 	}
@@ -189,6 +189,6 @@ func buildForwardBodyAndHeader(agentInfo meta.AgentInfoInterface, uri string, bo
 	if err != nil {
 		return nil, nil, err
 	}
-	headers = secure.BuildHeaderForForward(agentInfo, uri, Key, Iv)
+	headers = secure.BuildHeaderForForward(agentInfo, uri, origin, Key, Iv)
 	return body, headers, nil
 }
@@ -63,6 +63,12 @@ func SendNoContentResponse(c *gin.Context, err error) {
 	SendResponse(c, nil, err)
 }
 
+// MarkStreamed marks that the handler has written the response as a stream,
+// so that no response body is appended after it.
+func MarkStreamed(c *gin.Context) {
+	c.Set(streamedFlag, true)
+}
+
 func IsLocalRoute(c *gin.Context) bool {
 	_, isLocalRoute := c.Get(localRouteKey)
 	return isLocalRoute
@@ -60,6 +60,7 @@ const (
 
 	localRouteKey = constant.LOCAL_ROUTE_KEY
 	apiRouteKey   = constant.API_ROUTE_KEY
+	streamedFlag  = "streamed" // streamedFlag marks whether the response has been streamed by the handler
 
 	originalBody = constant.ORIGINAL_BODY
 
@@ -167,27 +168,36 @@ func readRequestBodyMaskPassword(c *gin.Context) string {
 	masked := false
 	// Use recursive masking for sensitive keys, including nested maps and arrays
 	sensitiveKeys := map[string]struct{}{
-		"context":              {},
-		"data_base_uri":        {},
-		"backup_base_uri":      {},
-		"archive_base_uri":     {},
-		"data_backup_uri":      {},
-		"archive_log_uri":      {},
-		"decryption":           {},
-		"encryption":           {},
-		"root_password":        {},
-		"password":             {},
-		"new_password":         {},
-		"old_password":         {},
-		"tenant_password":      {},
-		"token":                {},
-		"proxyro_password":     {},
-		"rootPwd":              {},
-		"obproxy_sys_password": {},
-		"masterPassword":       {},
-		"targetAgentPassword":  {},
-		"passphrase":           {},
-		"aes_key":              {},
+		"context":               {},
+		"data_base_uri":         {},
+		"backup_base_uri":       {},
+		"archive_base_uri":      {},
+		"data_backup_uri":       {},
+		"archive_log_uri":       {},
+		"decryption":            {},
+		"encryption":            {},
+		"root_password":         {},
+		"password":              {},
+		"new_password":          {},
+		"old_password":          {},
+		"tenant_password":       {},
+		"token":                 {},
+		"proxyro_password":      {},
+		"rootPwd":               {},
+		"obproxy_sys_password":  {},
+		"masterPassword":        {},
+		"targetAgentPassword":   {},
+		"target_agent_password": {},
+		"source_root_password":  {},
+		"passphrase":            {},
+		"aes_key":               {},
+		"secret":                {},
+	}
+	// The url and headers of the alarm channels may carry the access tokens,
+	// they are only masked on the channel routes to keep the other bodies readable.
+	if strings.HasPrefix(c.Request.URL.Path, constant.URI_API_V1+constant.URI_ALARM_GROUP+constant.URI_CHANNEL) {
+		sensitiveKeys["url"] = struct{}{}
+		sensitiveKeys["headers"] = struct{}{}
 	}
 
 	// Recursive mask function
@@ -265,6 +275,7 @@ func PreHandlers(maskBodyRoutes ...string) func(*gin.Context) {
 			body := readRequestBodyMaskPassword(c)
 			log.WithContext(ctx).Infof("API request: [%v %v, client=%v, traceId=%v, body=%v]",
 				c.Request.Method, c.Request.URL, c.ClientIP(), traceId, body)
+			c.Set(constant.AUDIT_REQUEST_BODY, body)
 		}
 
 		c.Next()
@@ -323,6 +334,9 @@ func PostHandlers(excludeRoutes ...string) func(*gin.Context) {
 		if _, ok := c.Get(needForwardedFlag); ok {
 			return
 		}
+		if _, ok := c.Get(streamedFlag); ok {
+			return
+		}
 
 		ctx := NewContextWithTraceId(c)
 		resp := getOcsResponseFromContext(c)
@@ -371,6 +385,7 @@ func PostHandlers(excludeRoutes ...string) func(*gin.Context) {
 			log.WithContext(ctx).Infof("API response error: [%v %v, client=%v, traceId=%v, duration=%v, status=%v,data=%+v, error=%v]",
 				c.Request.Method, c.Request.URL, c.ClientIP(), resp.TraceId, resp.Duration, resp.Status, resp.Data, resp.Error.String())
 		}
+		recordAudit(c, &resp)
 		c.JSON(resp.Status, resp)
 	}
 }
@@ -792,11 +807,30 @@ func Verify(routeType ...secure.RouteType) func(*gin.Context) {
 			c.Next()
 			return
 		}
+		if c.Request.RequestURI == constant.URI_API_V1+constant.URI_LOGIN &&
+			header.Account != "" &&
+			meta.OCS_AGENT.GetIdentity() == meta.CLUSTER_AGENT {
+			// login with the password of an account
+			VerifyAccountLogin(c, curTs, &header)
+			if c.IsAborted() {
+				return
+			}
+			c.Set(constant.HTTP_HEADER_ACCOUNT, header.Account)
+			c.Next()
+			return
+		}
 		if !(len(routeType) != 0 && routeType[0] == secure.ROUTE_LOGIN) &&
 			header.SessionID != "" &&
 			meta.OCS_AGENT.GetIdentity() == meta.CLUSTER_AGENT {
 			// login could not varified by session id
 			VerifySession(c, curTs, &header)
+			if c.IsAborted() {
+				return
+			}
+			AuthorizeSession(c, header.SessionID)
+			if c.IsAborted() {
+				return
+			}
 			// record session id for logout check
 			c.Set(constant.HTTP_HEADER_SESSION_ID, header.SessionID)
 			c.Next()
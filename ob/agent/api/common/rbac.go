@@ -0,0 +1,190 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	accountexecutor "github.com/oceanbase/obshell/ob/agent/executor/account"
+	"github.com/oceanbase/obshell/ob/agent/secure"
+	"github.com/oceanbase/obshell/ob/model/account"
+)
+
+const (
+	accountApiPrefix  = constant.URI_API_V1 + constant.URI_SECURITY_GROUP + constant.URI_ACCOUNT
+	accountsApiPrefix = constant.URI_API_V1 + constant.URI_SECURITY_GROUP + constant.URI_ACCOUNTS
+	tenantNameRoute   = constant.URI_TENANT_API_PREFIX + constant.URI_PATH_PARAM_NAME
+)
+
+// routeResources maps the route prefixes to the resources, the first matched one wins.
+// The routes not listed here are denied for the accounts.
+var routeResources = []struct {
+	prefixes []string
+	resource account.Resource
+}{
+	{[]string{accountApiPrefix, accountsApiPrefix}, account.ResourceAccount},
+	{[]string{
+		constant.URI_TASK_API_PREFIX + constant.URI_WEBHOOKS,
+		constant.URI_API_V1 + constant.URI_AUDIT_GROUP,
+		constant.URI_API_V1 + constant.URI_SYSTEM_GROUP,
+	}, account.ResourceSystem},
+	{[]string{constant.URI_TASK_API_PREFIX}, account.ResourceTask},
+	{[]string{
+		constant.URI_API_V1 + constant.URI_METRIC_GROUP,
+		constant.URI_API_V1 + constant.URI_ALARM_GROUP,
+	}, account.ResourceMonitor},
+	{[]string{
+		constant.URI_API_V1 + constant.URI_SECURITY_GROUP + constant.URI_CREDENTIAL,
+		constant.URI_API_V1 + constant.URI_SECURITY_GROUP + constant.URI_CREDENTIALS,
+	}, account.ResourceCredential},
+	{[]string{
+		constant.URI_API_V1 + constant.URI_RESTORE,
+		tenantNameRoute + constant.URI_BACKUP,
+		tenantNameRoute + constant.URI_RESTORE,
+		constant.URI_TENANT_API_PREFIX + constant.URI_RESTORE,
+		constant.URI_OBCLUSTER_API_PREFIX + constant.URI_BACKUP,
+	}, account.ResourceBackup},
+	{[]string{
+		constant.URI_TENANT_API_PREFIX,
+		constant.URI_API_V1 + constant.URI_TENANTS_GROUP,
+		constant.URI_API_V1 + constant.URI_UNIT_GROUP,
+		constant.URI_API_V1 + constant.URI_UNITS_GROUP,
+		constant.URI_API_V1 + constant.URI_POOL_GROUP,
+		constant.URI_API_V1 + constant.URI_POOLS_GROUP,
+		constant.URI_API_V1 + constant.URI_RECYCLEBIN_GROUP,
+	}, account.ResourceTenant},
+	{[]string{
+		constant.URI_OB_API_PREFIX,
+		constant.URI_OBCLUSTER_API_PREFIX,
+		constant.URI_OBSERVER_API_PREFIX,
+		constant.URI_ZONE_API_PREFIX,
+		constant.URI_API_V1 + constant.URI_UPGRADE,
+		constant.URI_API_V1 + constant.URI_PACKAGE,
+		constant.URI_API_V1 + constant.URI_SCHEDULES,
+	}, account.ResourceObcluster},
+	{[]string{
+		constant.URI_AGENT_API_PREFIX,
+		constant.URI_AGENTS_API_PREFIX,
+		constant.URI_OBPROXY_API_PREFIX,
+	}, account.ResourceAgent},
+}
+
+// readOnlyPostRoutes are the routes using POST to carry the query conditions.
+var readOnlyPostRoutes = map[string]bool{
+	constant.URI_API_V1 + constant.URI_METRIC_GROUP + "/query":                                          true,
+	constant.URI_API_V1 + constant.URI_ALARM_GROUP + constant.URI_ALERTS:                                true,
+	constant.URI_API_V1 + constant.URI_ALARM_GROUP + constant.URI_SILENCERS:                             true,
+	constant.URI_API_V1 + constant.URI_ALARM_GROUP + constant.URI_RULES:                                 true,
+	constant.URI_API_V1 + constant.URI_ALARM_GROUP + constant.URI_CHANNELS:                              true,
+	constant.URI_API_V1 + constant.URI_ALARM_GROUP + constant.URI_DELIVERIES:                            true,
+	constant.URI_API_V1 + constant.URI_RESTORE + constant.URI_SOURCE_INFO:                               true,
+	constant.URI_OB_API_PREFIX + constant.URI_UPGRADE + constant.URI_CHECK:                              true,
+	constant.URI_AGENT_API_PREFIX + constant.URI_UPGRADE + constant.URI_CHECK:                           true,
+	constant.URI_API_V1 + constant.URI_SECURITY_GROUP + constant.URI_CREDENTIAL + constant.URI_VALIDATE: true,
+}
+
+func hasRoutePrefix(route, prefix string) bool {
+	return route == prefix || strings.HasPrefix(route, prefix+"/")
+}
+
+func routeResource(route string) (account.Resource, bool) {
+	for _, item := range routeResources {
+		for _, prefix := range item.prefixes {
+			if hasRoutePrefix(route, prefix) {
+				return item.resource, true
+			}
+		}
+	}
+	return "", false
+}
+
+func routeAction(c *gin.Context) account.Action {
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead:
+		return account.ActionRead
+	}
+	if readOnlyPostRoutes[c.FullPath()] {
+		return account.ActionRead
+	}
+	return account.ActionWrite
+}
+
+// VerifyAccountLogin verifies the login request with the password of an account.
+func VerifyAccountLogin(c *gin.Context, curTs int64, header *secure.HttpHeader) {
+	if secure.VerifyTimeStamp(header.Ts, curTs) != nil {
+		c.Abort()
+		SendResponse(c, nil, errors.Occur(errors.ErrSecurityAuthenticationExpired))
+		return
+	}
+	if _, err := accountexecutor.VerifyAccountPassword(header.Account, header.Auth); err != nil {
+		log.WithContext(NewContextWithTraceId(c)).Errorf("account %s login failed: %v", header.Account, err)
+		c.Abort()
+		SendResponse(c, nil, errors.WrapRetain(errors.ErrCommonUnauthorized, err))
+		return
+	}
+}
+
+// AuthorizeSession checks the permission of the account bound to the session.
+// Sessions created by the root password are not restricted.
+func AuthorizeSession(c *gin.Context, sessionID string) {
+	name := secure.GetSessionAccount(sessionID)
+	if name == "" {
+		return
+	}
+	route := c.FullPath()
+	if route == constant.URI_API_V1+constant.URI_LOGOUT {
+		return
+	}
+	if route == accountApiPrefix+constant.URI_PATH_PARAM_NAME+constant.URI_PASSWORD && c.Param(constant.URI_PARAM_NAME) == name {
+		// Everyone can change the password of itself.
+		return
+	}
+
+	acc, err := accountexecutor.GetAccount(name)
+	if err != nil {
+		// The account may have been deleted after login.
+		secure.InvalidateSession(sessionID)
+		c.Abort()
+		SendResponse(c, nil, errors.WrapRetain(errors.ErrCommonUnauthorized, err))
+		return
+	}
+	c.Set(constant.HTTP_HEADER_ACCOUNT, name)
+
+	resource, ok := routeResource(route)
+	if !ok {
+		log.WithContext(NewContextWithTraceId(c)).Warnf("account %s is not allowed to access unmapped route %s %s", name, c.Request.Method, route)
+		c.Abort()
+		SendResponse(c, nil, errors.Occur(errors.ErrAccountPermissionDenied, name, acc.Role, routeAction(c), route))
+		return
+	}
+	var tenantName string
+	if hasRoutePrefix(route, tenantNameRoute) {
+		tenantName = c.Param(constant.URI_PARAM_NAME)
+	}
+	if err := accountexecutor.Authorize(acc, resource, routeAction(c), tenantName); err != nil {
+		log.WithContext(NewContextWithTraceId(c)).Warnf("account %s is not allowed to access %s %s: %v", name, c.Request.Method, route, err)
+		c.Abort()
+		SendResponse(c, nil, err)
+		return
+	}
+}
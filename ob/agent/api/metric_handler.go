@@ -17,6 +17,8 @@
 package api
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/oceanbase/obshell/ob/agent/api/common"
 	"github.com/oceanbase/obshell/ob/agent/constant"
@@ -87,3 +89,59 @@ func QueryMetrics(c *gin.Context) {
 	log.Debugf("Query metric data: %+v", metricDatas)
 	common.SendResponse(c, metricDatas, nil)
 }
+
+// @ID GetMetricCollectionConfig
+// @Summary get metric collection config
+// @Description get the config of the embedded metric collection, which serves the metric queries when no Prometheus is configured
+// @Tags Metric
+// @Accept application/json
+// @Produce application/json
+// @Success 200 object http.OcsAgentResponse{data=metric.CollectionConfig}
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/metrics/collection [GET]
+// @Security ApiKeyAuth
+func GetMetricCollectionConfig(c *gin.Context) {
+	cfg, err := metricexecutor.GetCollectionConfig()
+	common.SendResponse(c, cfg, err)
+}
+
+// @ID UpdateMetricCollectionConfig
+// @Summary update metric collection config
+// @Description update the config of the embedded metric collection, it takes effect on all agents at the next round of collection
+// @Tags Metric
+// @Accept application/json
+// @Produce application/json
+// @Param body body metric.CollectionConfig true "metric collection config"
+// @Success 200 object http.OcsAgentResponse{data=metric.CollectionConfig}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/metrics/collection [PUT]
+// @Security ApiKeyAuth
+func UpdateMetricCollectionConfig(c *gin.Context) {
+	cfg := &metric.CollectionConfig{}
+	if err := c.Bind(cfg); err != nil {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrCommonBadRequest, err.Error()))
+		return
+	}
+	log.Infof("Update metric collection config: %+v", cfg)
+	cfg, err := metricexecutor.SaveCollectionConfig(cfg)
+	common.SendResponse(c, cfg, err)
+}
+
+// @ID ExportMetrics
+// @Summary export metrics
+// @Description expose the metrics of the local observer and host in Prometheus text format, the metric names and labels are compatible with obagent
+// @Tags Metric
+// @Produce plain
+// @Success 200 {string} string "metrics in Prometheus text format"
+// @Router /metrics [GET]
+func ExportMetrics(c *gin.Context) {
+	samples := metricexecutor.CollectExporterSamples()
+	c.Header("Content-Type", metricexecutor.ExporterContentType)
+	c.Status(http.StatusOK)
+	if err := metricexecutor.WriteExposition(c.Writer, samples); err != nil {
+		log.WithError(err).Error("write metrics failed")
+	}
+}
@@ -29,4 +29,6 @@ func InitMetricRoutes(r *gin.RouterGroup, isLocalRoute bool) {
 	}
 	group.GET("", ListMetricMetas)
 	group.POST("/query", QueryMetrics)
+	group.GET(constant.URI_COLLECTION, GetMetricCollectionConfig)
+	group.PUT(constant.URI_COLLECTION, UpdateMetricCollectionConfig)
 }
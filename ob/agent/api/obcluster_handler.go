@@ -428,7 +428,29 @@ func obClusterScaleInHandler(c *gin.Context) {
 	}
 }
 
-// @ID GetObInfo
+// @ID ReplaceObserver
+// @Summary replace observer
+// @Description replace the observer with a new one on another host in the same zone
+// @Tags observer
+// @Accept application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param body body param.ReplaceObserverParam true "replace param"
+// @Produce application/json
+// @Success 200 object http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/observer/replace [post]
+func obServerReplaceHandler(c *gin.Context) {
+	var param param.ReplaceObserverParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	dag, err := ob.ReplaceObserver(param)
+	common.SendResponse(c, dag, err)
+}
+
 // @Summary get ob and agent info
 // @Description get ob and agent info
 // @Tags ob
@@ -740,36 +762,43 @@ func paramsRestoreHandler(c *gin.Context) {
 
 // @ID agentUpgrade
 // @Summary upgrade agent
-// @Description upgrade agent
+// @Description upgrade agent, or only return the upgrade plan if dry_run is true
 // @Tags upgrade
 // @Accept application/json
 // @Produce application/json
 // @Param X-OCS-Header header string true "Authorization"
-// @Param body body param.UpgradeCheckParam true "agent upgrade check params"
+// @Param body body param.AgentUpgradeParam true "agent upgrade params"
 // @Success 200 object http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Success 200 object http.OcsAgentResponse{data=param.UpgradePlan}
 // @Failure 400 object http.OcsAgentResponse
 // @Failure 401 object http.OcsAgentResponse
 // @Failure 500 object http.OcsAgentResponse
 // @Router /api/v1/agent/upgrade [post]
 func agentUpgradeHandler(c *gin.Context) {
-	var param param.UpgradeCheckParam
+	var param param.AgentUpgradeParam
 	if err := c.BindJSON(&param); err != nil {
 		common.SendResponse(c, nil, err)
 		return
 	}
-	dag, err := ob.AgentUpgrade(param)
+	if param.DryRun {
+		plan, err := ob.GetAgentUpgradePlan(param.UpgradeCheckParam)
+		common.SendResponse(c, plan, err)
+		return
+	}
+	dag, err := ob.AgentUpgrade(param.UpgradeCheckParam)
 	common.SendResponse(c, dag, err)
 }
 
 // @ID obUpgrade
 // @Summary upgrade ob
-// @Description upgrade ob
+// @Description upgrade ob, or only return the upgrade plan if dry_run is true
 // @Tags upgrade
 // @Accept application/json
 // @Produce application/json
 // @Param X-OCS-Header header string true "Authorization"
 // @Param body body param.ObUpgradeParam true "ob upgrade params"
 // @Success 200 object http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Success 200 object http.OcsAgentResponse{data=param.UpgradePlan}
 // @Failure 400 object http.OcsAgentResponse
 // @Failure 401 object http.OcsAgentResponse
 // @Failure 500 object http.OcsAgentResponse
@@ -780,6 +809,11 @@ func obUpgradeHandler(c *gin.Context) {
 		common.SendResponse(c, nil, err)
 		return
 	}
+	if param.DryRun {
+		plan, err := ob.GetObUpgradePlan(param)
+		common.SendResponse(c, plan, err)
+		return
+	}
 	dag, err := ob.CheckAndUpgradeOb(param)
 	common.SendResponse(c, dag, err)
 }
@@ -46,6 +46,8 @@ func InitRestoreRoutes(r *gin.RouterGroup, isLocalRoute bool) {
 	tenantGroup.POST(constant.URI_RESTORE, tenantRestoreHandler)
 	tenantGroup.DELETE(constant.URI_PATH_PARAM_NAME+constant.URI_RESTORE, cancelRestoreTaskHandler)
 	tenantGroup.GET(constant.URI_PATH_PARAM_NAME+constant.URI_RESTORE+constant.URI_OVERVIEW, getRestoreOverviewHandler)
+	tenantGroup.POST(constant.URI_PATH_PARAM_NAME+constant.URI_RESTORE+constant.URI_TABLE, tenantRestoreTableHandler)
+	tenantGroup.GET(constant.URI_PATH_PARAM_NAME+constant.URI_RESTORE+constant.URI_TABLE+constant.URI_OVERVIEW, getRestoreTableOverviewHandler)
 
 }
 
@@ -102,6 +104,59 @@ func checkRestoreParam(p *param.RestoreParam) error {
 	return nil
 }
 
+// @ID			tenantRestoreTable
+// @Summary	Restore tables into tenant
+// @Tags		Restore
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string					true	"Authorization"
+// @Param		name			path	string					true	"Target tenant name"
+// @Param		body			body	param.RestoreTableParam	true	"Restore tables"
+// @Success	200				object	http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/tenant/{name}/restore/table [post]
+func tenantRestoreTableHandler(c *gin.Context) {
+	tenant, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	var p param.RestoreTableParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	dag, err := ob.TenantRestoreTable(tenant, &p)
+	common.SendResponse(c, dag, err)
+}
+
+// @ID			getRestoreTableOverview
+// @Summary	Get restore table overview
+// @Tags		Restore
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string	true	"Authorization"
+// @Param		name			path	string	true	"Target tenant name"
+// @Success	200				object	http.OcsAgentResponse{data=bo.RecoverTableJob}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/tenant/{name}/restore/table/overview [get]
+func getRestoreTableOverviewHandler(c *gin.Context) {
+	tenant, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	overview, err := ob.GetRestoreTableOverview(tenant.TenantName)
+	common.SendResponse(c, overview, err)
+}
+
 // @ID			cancelRestoreTask
 // @Summary	Get restore task id
 // @Tags		Restore
@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/obshell/ob/agent/api/common"
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	scheduleexecutor "github.com/oceanbase/obshell/ob/agent/executor/schedule"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+// @ID listSchedules
+// @Summary list schedules
+// @Description list all the scheduled jobs
+// @Tags schedule
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Success 200 object http.OcsAgentResponse{data=[]bo.Schedule}
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/schedules [get]
+func listSchedulesHandler(c *gin.Context) {
+	data, err := scheduleexecutor.ListSchedules()
+	common.SendResponse(c, data, err)
+}
+
+// @ID createSchedule
+// @Summary create schedule
+// @Description create a scheduled backup, compaction or inspection job with a cron expression
+// @Tags schedule
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param body body param.CreateScheduleParam true "create schedule params"
+// @Success 200 object http.OcsAgentResponse{data=bo.Schedule}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/schedules [post]
+func createScheduleHandler(c *gin.Context) {
+	var p param.CreateScheduleParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	data, err := scheduleexecutor.CreateSchedule(&p)
+	common.SendResponse(c, data, err)
+}
+
+// @ID getSchedule
+// @Summary get schedule
+// @Description get scheduled job by name
+// @Tags schedule
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "Schedule name"
+// @Success 200 object http.OcsAgentResponse{data=bo.Schedule}
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 404 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/schedules/{name} [get]
+func getScheduleHandler(c *gin.Context) {
+	data, err := scheduleexecutor.GetSchedule(c.Param(constant.URI_PARAM_NAME))
+	common.SendResponse(c, data, err)
+}
+
+// @ID updateSchedule
+// @Summary update schedule
+// @Description update the cron expression, the parameters or enable/disable the scheduled job
+// @Tags schedule
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "Schedule name"
+// @Param body body param.UpdateScheduleParam true "update schedule params"
+// @Success 200 object http.OcsAgentResponse{data=bo.Schedule}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 404 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/schedules/{name} [patch]
+func updateScheduleHandler(c *gin.Context) {
+	var p param.UpdateScheduleParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	data, err := scheduleexecutor.UpdateSchedule(c.Param(constant.URI_PARAM_NAME), &p)
+	common.SendResponse(c, data, err)
+}
+
+// @ID deleteSchedule
+// @Summary delete schedule
+// @Description delete scheduled job and its run history
+// @Tags schedule
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "Schedule name"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 404 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/schedules/{name} [delete]
+func deleteScheduleHandler(c *gin.Context) {
+	err := scheduleexecutor.DeleteSchedule(c.Param(constant.URI_PARAM_NAME))
+	common.SendResponse(c, nil, err)
+}
+
+// @ID listScheduleRuns
+// @Summary list schedule runs
+// @Description list the latest runs of the scheduled job
+// @Tags schedule
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "Schedule name"
+// @Param limit query int false "Max number of the runs, default 20"
+// @Success 200 object http.OcsAgentResponse{data=[]bo.ScheduleRun}
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 404 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/schedules/{name}/runs [get]
+func listScheduleRunsHandler(c *gin.Context) {
+	var p param.ListScheduleRunsParam
+	if err := c.BindQuery(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	data, err := scheduleexecutor.ListScheduleRuns(c.Param(constant.URI_PARAM_NAME), &p)
+	common.SendResponse(c, data, err)
+}
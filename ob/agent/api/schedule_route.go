@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/obshell/ob/agent/api/common"
+	"github.com/oceanbase/obshell/ob/agent/constant"
+)
+
+func InitScheduleRoutes(parentGroup *gin.RouterGroup, isLocalRoute bool) {
+	schedules := parentGroup.Group(constant.URI_SCHEDULES)
+
+	if !isLocalRoute {
+		schedules.Use(common.Verify())
+	}
+
+	schedules.GET("", checkClusterAgentWrapper(listSchedulesHandler))
+	schedules.POST("", checkClusterAgentWrapper(createScheduleHandler))
+	schedules.GET(constant.URI_PATH_PARAM_NAME, checkClusterAgentWrapper(getScheduleHandler))
+	schedules.PATCH(constant.URI_PATH_PARAM_NAME, checkClusterAgentWrapper(updateScheduleHandler))
+	schedules.DELETE(constant.URI_PATH_PARAM_NAME, checkClusterAgentWrapper(deleteScheduleHandler))
+	schedules.GET(constant.URI_PATH_PARAM_NAME+constant.URI_RUNS, checkClusterAgentWrapper(listScheduleRunsHandler))
+}
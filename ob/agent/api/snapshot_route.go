@@ -0,0 +1,155 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/obshell/ob/agent/api/common"
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/executor/tenant"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+func InitSnapshotRoutes(r *gin.RouterGroup, isLocalRoute bool) {
+	tenantGroup := r.Group(constant.URI_TENANT_GROUP + constant.URI_PATH_PARAM_NAME)
+	if !isLocalRoute {
+		tenantGroup.Use(common.Verify())
+	}
+
+	tenantGroup.GET(constant.URI_SNAPSHOTS, listTenantSnapshotsHandler)
+	tenantGroup.POST(constant.URI_SNAPSHOTS, createTenantSnapshotHandler)
+	tenantGroup.DELETE(constant.URI_SNAPSHOTS+constant.URI_PATH_PARAM_SNAPSHOT, dropTenantSnapshotHandler)
+	tenantGroup.POST(constant.URI_CLONE, cloneTenantHandler)
+}
+
+// @ID			listTenantSnapshots
+// @Summary	List snapshots of the tenant
+// @Tags		Tenant
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string	true	"Authorization"
+// @Param		name			path	string	true	"Tenant name"
+// @Success	200				object	http.OcsAgentResponse{data=[]bo.TenantSnapshot}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	404				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/tenant/{name}/snapshots [get]
+func listTenantSnapshotsHandler(c *gin.Context) {
+	t, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	snapshots, err := tenant.ListTenantSnapshots(t.TenantName)
+	common.SendResponse(c, snapshots, err)
+}
+
+// @ID			createTenantSnapshot
+// @Summary	Create a snapshot for the tenant
+// @Tags		Tenant
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string							true	"Authorization"
+// @Param		name			path	string							true	"Tenant name"
+// @Param		body			body	param.CreateTenantSnapshotParam	false	"Create snapshot params"
+// @Success	200				object	http.OcsAgentResponse
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	404				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/tenant/{name}/snapshots [post]
+func createTenantSnapshotHandler(c *gin.Context) {
+	t, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	var p param.CreateTenantSnapshotParam
+	if c.Request.ContentLength != 0 {
+		if err := c.BindJSON(&p); err != nil {
+			common.SendResponse(c, nil, err)
+			return
+		}
+	}
+
+	common.SendResponse(c, nil, tenant.CreateTenantSnapshot(t.TenantName, &p))
+}
+
+// @ID			dropTenantSnapshot
+// @Summary	Drop the snapshot of the tenant
+// @Tags		Tenant
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string	true	"Authorization"
+// @Param		name			path	string	true	"Tenant name"
+// @Param		snapshot		path	string	true	"Snapshot name"
+// @Success	200				object	http.OcsAgentResponse
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	404				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/tenant/{name}/snapshots/{snapshot} [delete]
+func dropTenantSnapshotHandler(c *gin.Context) {
+	t, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	common.SendResponse(c, nil, tenant.DropTenantSnapshot(t.TenantName, c.Param(constant.URI_PARAM_SNAPSHOT)))
+}
+
+// @ID			cloneTenant
+// @Summary	Clone a new tenant from the tenant
+// @Tags		Tenant
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string					true	"Authorization"
+// @Param		name			path	string					true	"Source tenant name"
+// @Param		body			body	param.CloneTenantParam	true	"Clone tenant params"
+// @Success	200				object	http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	404				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/tenant/{name}/clone [post]
+func cloneTenantHandler(c *gin.Context) {
+	t, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	var p param.CloneTenantParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	if p.SourceRootPassword != nil {
+		if err := tenant.PersistTenantRootPassword(c, t.TenantName, *p.SourceRootPassword); err != nil {
+			common.SendResponse(c, nil, err)
+			return
+		}
+	}
+	dag, err := tenant.CloneTenant(t.TenantName, &p)
+	common.SendResponse(c, dag, err)
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/obshell/ob/agent/api/common"
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/executor/tenant"
+	"github.com/oceanbase/obshell/ob/agent/meta"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+func InitStandbyRoutes(r *gin.RouterGroup, isLocalRoute bool) {
+	standbyGroup := r.Group(constant.URI_TENANT_GROUP + constant.URI_PATH_PARAM_NAME + constant.URI_STANDBY)
+	if !isLocalRoute {
+		standbyGroup.Use(common.Verify())
+	}
+
+	standbyGroup.POST("", createStandbyTenantHandler)
+	standbyGroup.GET("", getStandbyTenantStatusHandler)
+	standbyGroup.POST(constant.URI_SWITCHOVER, standbySwitchoverHandler)
+	standbyGroup.POST(constant.URI_FAILOVER, standbyFailoverHandler)
+}
+
+// @ID			createStandbyTenant
+// @Summary	Create standby tenant
+// @Tags		Standby
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string							true	"Authorization"
+// @Param		name			path	string							true	"Standby tenant name"
+// @Param		body			body	param.CreateStandbyTenantParam	true	"Create standby tenant params"
+// @Success	200				object	http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/tenant/{name}/standby [post]
+func createStandbyTenantHandler(c *gin.Context) {
+	if !meta.OCS_AGENT.IsClusterAgent() {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, meta.OCS_AGENT.String(), meta.OCS_AGENT.GetIdentity(), meta.CLUSTER_AGENT))
+		return
+	}
+
+	tenantName := c.Param(constant.URI_PARAM_NAME)
+	if tenantName == constant.TENANT_SYS {
+		common.SendResponse(c, nil, errors.Occur(errors.ErrObTenantSysOperationNotAllowed))
+		return
+	}
+
+	var p param.CreateStandbyTenantParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	dag, err := tenant.CreateStandbyTenant(tenantName, &p)
+	common.SendResponse(c, dag, err)
+}
+
+// @ID			getStandbyTenantStatus
+// @Summary	Get the role and log synchronization status of the tenant
+// @Tags		Standby
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string	true	"Authorization"
+// @Param		name			path	string	true	"Tenant name"
+// @Success	200				object	http.OcsAgentResponse{data=param.StandbyTenantStatus}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	404				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/tenant/{name}/standby [get]
+func getStandbyTenantStatusHandler(c *gin.Context) {
+	t, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	status, err := tenant.GetStandbyTenantStatus(t.TenantName)
+	common.SendResponse(c, status, err)
+}
+
+// @ID			standbySwitchover
+// @Summary	Switchover the role of the tenant between primary and standby
+// @Tags		Standby
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string							true	"Authorization"
+// @Param		name			path	string							true	"Tenant name"
+// @Param		body			body	param.StandbySwitchoverParam	false	"Switchover params"
+// @Success	200				object	http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/tenant/{name}/standby/switchover [post]
+func standbySwitchoverHandler(c *gin.Context) {
+	t, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	var p param.StandbySwitchoverParam
+	if c.Request.ContentLength != 0 {
+		if err := c.BindJSON(&p); err != nil {
+			common.SendResponse(c, nil, err)
+			return
+		}
+	}
+
+	dag, err := tenant.StandbySwitchover(t.TenantName, &p)
+	common.SendResponse(c, dag, err)
+}
+
+// @ID			standbyFailover
+// @Summary	Failover the standby tenant to be primary
+// @Tags		Standby
+// @Accept		application/json
+// @Produce	application/json
+// @Param		X-OCS-Header	header	string	true	"Authorization"
+// @Param		name			path	string	true	"Standby tenant name"
+// @Success	200				object	http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure	400				object	http.OcsAgentResponse
+// @Failure	401				object	http.OcsAgentResponse
+// @Failure	500				object	http.OcsAgentResponse
+// @Router		/api/v1/tenant/{name}/standby/failover [post]
+func standbyFailoverHandler(c *gin.Context) {
+	t, err := checkTenantAndGetName(c)
+	if err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	dag, err := tenant.StandbyFailover(t.TenantName)
+	common.SendResponse(c, dag, err)
+}
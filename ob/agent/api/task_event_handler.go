@@ -0,0 +1,274 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/ob/agent/api/common"
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/engine/event"
+	webhookexecutor "github.com/oceanbase/obshell/ob/agent/executor/webhook"
+	"github.com/oceanbase/obshell/ob/agent/global"
+	libhttp "github.com/oceanbase/obshell/ob/agent/lib/http"
+	"github.com/oceanbase/obshell/ob/agent/meta"
+	"github.com/oceanbase/obshell/ob/agent/secure"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+const lastEventIdHeader = "Last-Event-ID"
+
+// sourcedEvent is an event with the agent whose event hub assigned its id.
+type sourcedEvent struct {
+	source string
+	evt    *event.Event
+}
+
+// eventCursor is the id of the last received event of each agent.
+// It is encoded as "agent=id,agent=id" in the id of the merged stream,
+// and a plain number is the id of the local agent.
+type eventCursor map[string]uint64
+
+func parseEventCursor(lastEventId string, local string) eventCursor {
+	cursor := make(eventCursor)
+	if lastEventId == "" {
+		return cursor
+	}
+	if id, err := strconv.ParseUint(lastEventId, 10, 64); err == nil {
+		cursor[local] = id
+		return cursor
+	}
+	for _, pair := range strings.Split(lastEventId, ",") {
+		agent, idStr, found := strings.Cut(pair, "=")
+		if !found {
+			continue
+		}
+		if id, err := strconv.ParseUint(idStr, 10, 64); err == nil {
+			cursor[agent] = id
+		}
+	}
+	return cursor
+}
+
+func (cursor eventCursor) String() string {
+	agents := make([]string, 0, len(cursor))
+	for agent := range cursor {
+		agents = append(agents, agent)
+	}
+	sort.Strings(agents)
+	pairs := make([]string, 0, len(agents))
+	for _, agent := range agents {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", agent, cursor[agent]))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// subscribeRemoteEvents receives the local events of the remote agent until ctx is done,
+// and reconnects from the last received event if the stream is broken.
+func subscribeRemoteEvents(ctx context.Context, agent meta.AgentInfo, p param.TaskEventStreamParam, lastId uint64, out chan<- sourcedEvent) {
+	for {
+		lastId = streamRemoteEvents(ctx, agent, p, lastId, out)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(constant.EVENT_STREAM_RECONNECT_INTERVAL):
+		}
+	}
+}
+
+func streamRemoteEvents(ctx context.Context, agent meta.AgentInfo, p param.TaskEventStreamParam, lastId uint64, out chan<- sourcedEvent) uint64 {
+	query := url.Values{}
+	query.Set("local", "true")
+	if p.DagId != "" {
+		query.Set("dag_id", p.DagId)
+	}
+	if p.Types != "" {
+		query.Set("types", p.Types)
+	}
+	uri := constant.URI_TASK_API_PREFIX + constant.URI_EVENTS + "?" + query.Encode()
+	request := libhttp.NewClient().SetTimeout(0).R().
+		SetContext(ctx).
+		SetDoNotParseResponse(true).
+		SetHeaders(secure.BuildHeader(&agent, uri, false)).
+		SetHeader(lastEventIdHeader, strconv.FormatUint(lastId, 10))
+	resp, err := request.Get(fmt.Sprintf("%s://%s%s", global.Protocol, agent.String(), uri))
+	if err != nil {
+		log.WithError(err).Debugf("subscribe task events of agent %s failed", agent.String())
+		return lastId
+	}
+	body := resp.RawBody()
+	defer body.Close()
+	if resp.StatusCode() != http.StatusOK {
+		log.Debugf("subscribe task events of agent %s failed, status: %d", agent.String(), resp.StatusCode())
+		return lastId
+	}
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), constant.EVENT_STREAM_MAX_LINE_SIZE)
+	for scanner.Scan() {
+		data, found := strings.CutPrefix(scanner.Text(), "data: ")
+		if !found {
+			continue
+		}
+		evt := &event.Event{}
+		if err := json.Unmarshal([]byte(data), evt); err != nil {
+			continue
+		}
+		lastId = evt.Id
+		select {
+		case out <- sourcedEvent{source: agent.String(), evt: evt}:
+		case <-ctx.Done():
+			return lastId
+		}
+	}
+	return lastId
+}
+
+// @ID streamTaskEvents
+// @Summary stream task events
+// @Description stream the state changes of the dags and the sub tasks as server-sent events, the events of all the agents in the cluster are merged.
+// @Description The id of each event is the cursor of the stream, reconnect with the Last-Event-ID header to receive the missed recent events.
+// @Tags task
+// @Produce text/event-stream
+// @Param X-OCS-Header header string true "Authorization"
+// @Param Last-Event-ID header string false "id of the last received event"
+// @Param dag_id query string false "generic id of the dag"
+// @Param types query string false "comma separated event types, such as dag.failed,dag.succeeded"
+// @Param local query bool false "only stream the events of this agent"
+// @Success 200 {string} string "event stream"
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Router /api/v1/task/events [get]
+func streamTaskEventsHandler(c *gin.Context) {
+	var p param.TaskEventStreamParam
+	if err := c.BindQuery(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	types := make(map[string]bool)
+	for _, t := range strings.Split(p.Types, ",") {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" {
+			continue
+		}
+		if err := webhookexecutor.CheckEventType(t); err != nil {
+			common.SendResponse(c, nil, err)
+			return
+		}
+		types[t] = true
+	}
+	local := meta.OCS_AGENT.String()
+	cursor := parseEventCursor(c.GetHeader(lastEventIdHeader), local)
+	if cursor[local] > event.LastId() {
+		// The ids are reset when the agent restarts.
+		cursor[local] = 0
+	}
+
+	// Subscribe before reading the history so that no event is missed between them.
+	events, cancel := event.Subscribe(constant.EVENT_STREAM_BUFFER_SIZE)
+	defer cancel()
+
+	// The events are published by the agent which changes the state, so merge the events of all the agents.
+	remoteEvents := make(chan sourcedEvent, constant.EVENT_STREAM_BUFFER_SIZE)
+	if !p.Local && meta.OCS_AGENT.IsClusterAgent() {
+		agents, err := agentService.GetAllAgentsInfo()
+		if err != nil {
+			common.SendResponse(c, nil, err)
+			return
+		}
+		for _, agent := range agents {
+			if agent.String() == local {
+				continue
+			}
+			go subscribeRemoteEvents(c.Request.Context(), agent, p, cursor[agent.String()], remoteEvents)
+		}
+	}
+
+	common.MarkStreamed(c)
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	write := func(source string, evt *event.Event) error {
+		if evt.Id <= cursor[source] {
+			return nil
+		}
+		cursor[source] = evt.Id
+		if (len(types) > 0 && !types[evt.Type]) || (p.DagId != "" && evt.DagId != p.DagId) {
+			return nil
+		}
+		data, err := json.Marshal(evt)
+		if err != nil {
+			return err
+		}
+		id := cursor.String()
+		if p.Local {
+			id = strconv.FormatUint(evt.Id, 10)
+		}
+		if _, err := fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", id, evt.Type, data); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	}
+
+	if lastId := cursor[local]; lastId > 0 {
+		for _, evt := range event.History(lastId) {
+			if err := write(local, evt); err != nil {
+				return
+			}
+		}
+	}
+
+	heartbeat := time.NewTicker(constant.EVENT_STREAM_HEARTBEAT_INTERVAL)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case evt := <-events:
+			if err := write(local, evt); err != nil {
+				return
+			}
+		case remote := <-remoteEvents:
+			if err := write(remote.source, remote.evt); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
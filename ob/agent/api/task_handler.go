@@ -45,5 +45,14 @@ func InitTaskRoutes(r *gin.RouterGroup, isLocalRoute bool) {
 	group.GET(constant.URI_DAG+constant.URI_OB_GROUP+constant.URI_UNFINISH, task.GetClusterUnfinishDags)
 	group.GET(constant.URI_DAG+constant.URI_AGENT_GROUP+constant.URI_UNFINISH, task.GetAgentUnfinishDags)
 	group.GET(constant.URI_DAG+constant.URI_AGENT_GROUP+constant.URI_MAIN_DAGS, task.GetAgentMainDags)
+	group.GET(constant.URI_EVENTS, streamTaskEventsHandler)
+
+	webhooks := group.Group(constant.URI_WEBHOOKS)
+	webhooks.GET("", checkClusterAgentWrapper(listTaskWebhooksHandler))
+	webhooks.POST("", checkClusterAgentWrapper(createTaskWebhookHandler))
+	webhooks.GET(constant.URI_PATH_PARAM_NAME, checkClusterAgentWrapper(getTaskWebhookHandler))
+	webhooks.PATCH(constant.URI_PATH_PARAM_NAME, checkClusterAgentWrapper(updateTaskWebhookHandler))
+	webhooks.DELETE(constant.URI_PATH_PARAM_NAME, checkClusterAgentWrapper(deleteTaskWebhookHandler))
+	webhooks.POST(constant.URI_PATH_PARAM_NAME+constant.URI_TEST, checkClusterAgentWrapper(testTaskWebhookHandler))
 
 }
@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/obshell/ob/agent/api/common"
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	webhookexecutor "github.com/oceanbase/obshell/ob/agent/executor/webhook"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+// @ID listTaskWebhooks
+// @Summary list task webhooks
+// @Description list the webhooks receiving the task events, the secrets are masked
+// @Tags task
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Success 200 object http.OcsAgentResponse{data=[]bo.TaskWebhook}
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/task/webhooks [get]
+func listTaskWebhooksHandler(c *gin.Context) {
+	data, err := webhookexecutor.ListWebhooks()
+	common.SendResponse(c, data, err)
+}
+
+// @ID createTaskWebhook
+// @Summary create task webhook
+// @Description register a webhook to receive the state changes of the dags and the sub tasks
+// @Tags task
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param body body param.CreateTaskWebhookParam true "create task webhook params"
+// @Success 200 object http.OcsAgentResponse{data=bo.TaskWebhook}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/task/webhooks [post]
+func createTaskWebhookHandler(c *gin.Context) {
+	var p param.CreateTaskWebhookParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	data, err := webhookexecutor.CreateWebhook(&p)
+	common.SendResponse(c, data, err)
+}
+
+// @ID getTaskWebhook
+// @Summary get task webhook
+// @Description get task webhook by name, the secret is masked
+// @Tags task
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "Webhook name"
+// @Success 200 object http.OcsAgentResponse{data=bo.TaskWebhook}
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 404 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/task/webhooks/{name} [get]
+func getTaskWebhookHandler(c *gin.Context) {
+	data, err := webhookexecutor.GetWebhook(c.Param(constant.URI_PARAM_NAME))
+	common.SendResponse(c, data, err)
+}
+
+// @ID updateTaskWebhook
+// @Summary update task webhook
+// @Description update the url, the subscribed events, the secret or enable/disable the task webhook
+// @Tags task
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "Webhook name"
+// @Param body body param.UpdateTaskWebhookParam true "update task webhook params"
+// @Success 200 object http.OcsAgentResponse{data=bo.TaskWebhook}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 404 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/task/webhooks/{name} [patch]
+func updateTaskWebhookHandler(c *gin.Context) {
+	var p param.UpdateTaskWebhookParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+
+	data, err := webhookexecutor.UpdateWebhook(c.Param(constant.URI_PARAM_NAME), &p)
+	common.SendResponse(c, data, err)
+}
+
+// @ID deleteTaskWebhook
+// @Summary delete task webhook
+// @Description delete task webhook
+// @Tags task
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "Webhook name"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 404 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/task/webhooks/{name} [delete]
+func deleteTaskWebhookHandler(c *gin.Context) {
+	err := webhookexecutor.DeleteWebhook(c.Param(constant.URI_PARAM_NAME))
+	common.SendResponse(c, nil, err)
+}
+
+// @ID testTaskWebhook
+// @Summary test task webhook
+// @Description send a test event to the task webhook
+// @Tags task
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "Webhook name"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 404 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/task/webhooks/{name}/test [post]
+func testTaskWebhookHandler(c *gin.Context) {
+	err := webhookexecutor.TestWebhook(c, c.Param(constant.URI_PARAM_NAME))
+	common.SendResponse(c, nil, err)
+}
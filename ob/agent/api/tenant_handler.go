@@ -101,6 +101,22 @@ func InitTenantRoutes(v1 *gin.RouterGroup, isLocalRoute bool) {
 	tenant.DELETE(constant.URI_PATH_PARAM_NAME+constant.URI_ROLE+constant.URI_PATH_PARAM_ROLE+constant.URI_OBJECT_PRIVILEGES, tenantHandlerWrapper(revokeRoleObjectPrivilege, constant.ORACLE_MODE))
 	tenant.POST(constant.URI_PATH_PARAM_NAME+constant.URI_ROLE+constant.URI_PATH_PARAM_ROLE+constant.URI_OBJECT_PRIVILEGES, tenantHandlerWrapper(grantRoleObjectPrivilege, constant.ORACLE_MODE))
 
+	// for resource manager
+	resourceManager := constant.URI_PATH_PARAM_NAME + constant.URI_RESOURCE_MANAGER
+	tenant.GET(resourceManager+constant.URI_PLANS, tenantHandlerWrapper(listResourcePlans))
+	tenant.POST(resourceManager+constant.URI_PLANS, tenantHandlerWrapper(createResourcePlan))
+	tenant.DELETE(resourceManager+constant.URI_PLANS+constant.URI_PATH_PARAM_PLAN, tenantHandlerWrapper(deleteResourcePlan))
+	tenant.POST(resourceManager+constant.URI_PLANS+constant.URI_PATH_PARAM_PLAN+constant.URI_DIRECTIVES, tenantHandlerWrapper(createResourcePlanDirective))
+	tenant.PUT(resourceManager+constant.URI_PLANS+constant.URI_PATH_PARAM_PLAN+constant.URI_DIRECTIVES+constant.URI_PATH_PARAM_CONSUMER_GROUP, tenantHandlerWrapper(modifyResourcePlanDirective))
+	tenant.DELETE(resourceManager+constant.URI_PLANS+constant.URI_PATH_PARAM_PLAN+constant.URI_DIRECTIVES+constant.URI_PATH_PARAM_CONSUMER_GROUP, tenantHandlerWrapper(deleteResourcePlanDirective))
+	tenant.PUT(resourceManager+constant.URI_ACTIVE_PLAN, tenantHandlerWrapper(activateResourcePlan))
+	tenant.GET(resourceManager+constant.URI_CONSUMER_GROUPS, tenantHandlerWrapper(listConsumerGroups))
+	tenant.POST(resourceManager+constant.URI_CONSUMER_GROUPS, tenantHandlerWrapper(createConsumerGroup))
+	tenant.DELETE(resourceManager+constant.URI_CONSUMER_GROUPS+constant.URI_PATH_PARAM_CONSUMER_GROUP, tenantHandlerWrapper(deleteConsumerGroup))
+	tenant.GET(resourceManager+constant.URI_MAPPINGS, tenantHandlerWrapper(listResourceMappings))
+	tenant.PUT(resourceManager+constant.URI_MAPPINGS, tenantHandlerWrapper(setResourceMapping))
+	tenant.GET(resourceManager+constant.URI_IO_USAGE, tenantExistHandlerWrapper(getResourceManagerIoUsage))
+
 	// for compaction
 	tenant.GET(constant.URI_PATH_PARAM_NAME+constant.URI_COMPACTION, tenantExistHandlerWrapper(getTenantCompactionHandler))
 	tenant.POST(constant.URI_PATH_PARAM_NAME+constant.URI_COMPACT, tenantExistHandlerWrapper(tenantMajorCompactionHandler))
@@ -1810,3 +1826,330 @@ func ListTenantDeadlocksHandler(c *gin.Context) {
 	deadlocks, err := tenant.ListTenantDeadLocks(name, p)
 	common.SendResponse(c, deadlocks, err)
 }
+
+// @ID listResourcePlans
+// @Summary list resource plans
+// @Description list resource plans of tenant with their directives
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Success 200 object http.OcsAgentResponse{data=[]bo.ResourcePlan}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/plans [GET]
+func listResourcePlans(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	var param param.TenantRootPasswordParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	plans, err := tenant.ListResourcePlans(name, param.RootPassword)
+	common.SendResponse(c, plans, err)
+}
+
+// @ID createResourcePlan
+// @Summary create resource plan
+// @Description create resource plan
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param body body param.CreateResourcePlanParam true "create resource plan param"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/plans [POST]
+func createResourcePlan(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	var param param.CreateResourcePlanParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.CreateResourcePlan(name, &param)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID deleteResourcePlan
+// @Summary delete resource plan
+// @Description delete resource plan with its directives
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param plan path string true "resource plan name"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/plans/{plan} [DELETE]
+func deleteResourcePlan(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	plan := c.Param(constant.URI_PARAM_PLAN)
+	var param param.TenantRootPasswordParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.DeleteResourcePlan(name, plan, param.RootPassword)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID createResourcePlanDirective
+// @Summary create resource plan directive
+// @Description create the directive of consumer group in resource plan
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param plan path string true "resource plan name"
+// @Param body body param.CreateResourcePlanDirectiveParam true "create resource plan directive param"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/plans/{plan}/directives [POST]
+func createResourcePlanDirective(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	plan := c.Param(constant.URI_PARAM_PLAN)
+	var param param.CreateResourcePlanDirectiveParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.CreateResourcePlanDirective(name, plan, &param)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID modifyResourcePlanDirective
+// @Summary modify resource plan directive
+// @Description modify the directive of consumer group in resource plan
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param plan path string true "resource plan name"
+// @Param consumer_group path string true "consumer group name"
+// @Param body body param.ModifyResourcePlanDirectiveParam true "modify resource plan directive param"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/plans/{plan}/directives/{consumer_group} [PUT]
+func modifyResourcePlanDirective(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	plan := c.Param(constant.URI_PARAM_PLAN)
+	group := c.Param(constant.URI_PARAM_CONSUMER_GROUP)
+	var param param.ModifyResourcePlanDirectiveParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.ModifyResourcePlanDirective(name, plan, group, &param)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID deleteResourcePlanDirective
+// @Summary delete resource plan directive
+// @Description delete the directive of consumer group in resource plan
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param plan path string true "resource plan name"
+// @Param consumer_group path string true "consumer group name"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/plans/{plan}/directives/{consumer_group} [DELETE]
+func deleteResourcePlanDirective(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	plan := c.Param(constant.URI_PARAM_PLAN)
+	group := c.Param(constant.URI_PARAM_CONSUMER_GROUP)
+	var param param.TenantRootPasswordParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.DeleteResourcePlanDirective(name, plan, group, param.RootPassword)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID activateResourcePlan
+// @Summary activate resource plan
+// @Description activate resource plan of tenant, deactivate the current plan if plan is empty
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param body body param.ActivateResourcePlanParam true "activate resource plan param"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/active-plan [PUT]
+func activateResourcePlan(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	var param param.ActivateResourcePlanParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.ActivateResourcePlan(name, &param)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID listConsumerGroups
+// @Summary list consumer groups
+// @Description list consumer groups of tenant
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Success 200 object http.OcsAgentResponse{data=[]bo.ConsumerGroup}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/consumer-groups [GET]
+func listConsumerGroups(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	var param param.TenantRootPasswordParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	groups, err := tenant.ListConsumerGroups(name, param.RootPassword)
+	common.SendResponse(c, groups, err)
+}
+
+// @ID createConsumerGroup
+// @Summary create consumer group
+// @Description create consumer group
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param body body param.CreateConsumerGroupParam true "create consumer group param"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/consumer-groups [POST]
+func createConsumerGroup(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	var param param.CreateConsumerGroupParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.CreateConsumerGroup(name, &param)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID deleteConsumerGroup
+// @Summary delete consumer group
+// @Description delete consumer group
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param consumer_group path string true "consumer group name"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/consumer-groups/{consumer_group} [DELETE]
+func deleteConsumerGroup(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	group := c.Param(constant.URI_PARAM_CONSUMER_GROUP)
+	var param param.TenantRootPasswordParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.DeleteConsumerGroup(name, group, param.RootPassword)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID listResourceMappings
+// @Summary list resource mappings
+// @Description list the mappings from user, function or column to consumer group
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Success 200 object http.OcsAgentResponse{data=[]bo.ResourceMapping}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/mappings [GET]
+func listResourceMappings(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	var param param.TenantRootPasswordParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	mappings, err := tenant.ListResourceMappings(name, param.RootPassword)
+	common.SendResponse(c, mappings, err)
+}
+
+// @ID setResourceMapping
+// @Summary set resource mapping
+// @Description map user, function or column to consumer group, remove the mapping if consumer group is empty
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Param body body param.SetResourceMappingParam true "set resource mapping param"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/mappings [PUT]
+func setResourceMapping(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	var param param.SetResourceMappingParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := tenant.SetResourceMapping(name, &param)
+	common.SendResponse(c, nil, err)
+}
+
+// @ID getResourceManagerIoUsage
+// @Summary get resource manager io usage
+// @Description get the active resource plan and the io usage of each consumer group
+// @Tags tenant
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param name path string true "tenant name"
+// @Success 200 object http.OcsAgentResponse{data=bo.ResourceManagerIoUsage}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/tenant/{name}/resource-manager/io-usage [GET]
+func getResourceManagerIoUsage(c *gin.Context) {
+	name := c.Param(constant.URI_PARAM_NAME)
+	usage, err := tenant.GetResourceManagerIoUsage(name)
+	common.SendResponse(c, usage, err)
+}
@@ -244,6 +244,7 @@ func (a *Agent) initTask() {
 	ob.RegisterObInitTask()
 	ob.RegisterObScaleOutTask()
 	ob.RegisterObScaleInTask()
+	ob.RegisterReplaceObserverTask()
 	ob.RegisterUpgradeTask()
 	ob.RegisterBackupTask()
 	ob.RegisterRestoreTask()
@@ -24,7 +24,11 @@ import (
 	"github.com/oceanbase/obshell/ob/agent/constant"
 	"github.com/oceanbase/obshell/ob/agent/engine"
 	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/executor/audit"
+	"github.com/oceanbase/obshell/ob/agent/executor/metric"
 	"github.com/oceanbase/obshell/ob/agent/executor/ob"
+	"github.com/oceanbase/obshell/ob/agent/executor/schedule"
+	"github.com/oceanbase/obshell/ob/agent/executor/webhook"
 	"github.com/oceanbase/obshell/ob/agent/lib/process"
 	"github.com/oceanbase/obshell/ob/agent/meta"
 	"github.com/oceanbase/obshell/ob/agent/repository/db/oceanbase"
@@ -51,6 +55,13 @@ func (a *Agent) run() (err error) {
 	go a.syncPublicKeyToOBWhenReady()
 
 	a.handleOBMeta()
+
+	// The embedded metric collection keeps idle until it is enabled.
+	metric.StartCollection()
+	audit.Start()
+	schedule.Start()
+	ob.StartBackupComplianceCheck()
+	webhook.Start()
 	return nil
 }
 
@@ -136,6 +136,7 @@ const (
 	DIR_BIN         = "bin"
 	DIR_CA          = "ca"
 	DIR_LOG_OBSHELL = "log_obshell"
+	DIR_METRIC      = "metric_obshell"
 )
 
 // exit code
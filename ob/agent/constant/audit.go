@@ -0,0 +1,41 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package constant
+
+import "time"
+
+const (
+	AUDIT_RETENTION_CONFIG_KEY   = "audit_log_retention_days"
+	AUDIT_DEFAULT_RETENTION_DAYS = 90
+	AUDIT_MAX_RETENTION_DAYS     = 3650
+
+	// audit logs are written asynchronously, the entries exceeding the queue are dropped
+	AUDIT_QUEUE_SIZE       = 1024
+	AUDIT_CLEANUP_INTERVAL = 1 * time.Hour
+
+	// audit logs recorded in sqlite before bootstrap or takeover are moved into the meta table
+	AUDIT_MIGRATE_INTERVAL   = 1 * time.Minute
+	AUDIT_MIGRATE_BATCH_SIZE = 500
+
+	AUDIT_PARAMS_MAX_LENGTH = 8192
+	AUDIT_DEFAULT_PAGE_SIZE = 20
+	AUDIT_MAX_PAGE_SIZE     = 1000
+
+	AUDIT_USER_ROOT  = "root"
+	AUDIT_USER_AGENT = "agent"
+	AUDIT_USER_LOCAL = "local"
+)
@@ -56,15 +56,22 @@ const (
 	TIME_UNIT_HOUR        = "h"
 	TIME_UNIT_DAY         = "d"
 
-	PREFIX_OSS  = "oss://"
-	PREFIX_COS  = "cos://"
-	PREFIX_S3   = "s3://"
-	PREFIX_FILE = "file://"
-
-	PROTOCOL_OSS  = "oss"
-	PROTOCOL_COS  = "cos"
-	PROTOCOL_S3   = "s3"
-	PROTOCOL_FILE = "file"
+	PREFIX_OSS    = "oss://"
+	PREFIX_COS    = "cos://"
+	PREFIX_S3     = "s3://"
+	PREFIX_FILE   = "file://"
+	PREFIX_AZBLOB = "azblob://"
+	PREFIX_GCS    = "gs://"
+
+	PROTOCOL_OSS    = "oss"
+	PROTOCOL_COS    = "cos"
+	PROTOCOL_S3     = "s3"
+	PROTOCOL_FILE   = "file"
+	PROTOCOL_AZBLOB = "azblob"
+	PROTOCOL_GCS    = "gs"
+
+	// GCS_DEFAULT_HOST is the endpoint of the S3-interoperable XML API of Google Cloud Storage.
+	GCS_DEFAULT_HOST = "https://storage.googleapis.com"
 
 	BACKUP_DIR_CLOG = "clog"
 	BACKUP_DIR_DATA = "data"
@@ -73,6 +80,29 @@ const (
 	BACKUP_MODE_INCREMENTAL = "incremental"
 
 	BACKUP_CANCELED = "canceled"
+
+	BACKUP_SET_STATUS_SUCCESS = "SUCCESS"
+	BACKUP_TYPE_FULL          = "FULL"
+
+	BACKUP_FILE_STATUS_AVAILABLE = "AVAILABLE"
+	BACKUP_FILE_STATUS_DELETING  = "DELETING"
+	BACKUP_FILE_STATUS_DELETED   = "DELETED"
+
+	BACKUP_PIECE_STATUS_ACTIVE = "ACTIVE"
+
+	RECOVER_TABLE_STATUS_COMPLETED = "COMPLETED"
+)
+
+const (
+	BACKUP_COMPLIANCE_STATUS_COMPLIANT = "COMPLIANT"
+	BACKUP_COMPLIANCE_STATUS_VIOLATED  = "VIOLATED"
+
+	BACKUP_COMPLIANCE_CHECK_INTERVAL      = time.Minute
+	BACKUP_COMPLIANCE_DATE_FORMAT         = "2006-01-02"
+	BACKUP_COMPLIANCE_REPORT_DEFAULT_DAYS = 7
+	BACKUP_COMPLIANCE_REPORT_MAX_DAYS     = 366
+	BACKUP_COMPLIANCE_REASON_LENGTH       = 512
+	BACKUP_COMPLIANCE_CHECKS_PER_DAY      = int(time.Hour * 24 / BACKUP_COMPLIANCE_CHECK_INTERVAL)
 )
 
 const (
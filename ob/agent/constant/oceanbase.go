@@ -282,6 +282,9 @@ const (
 	// env
 	OB_ROOT_PASSWORD = "OB_ROOT_PASSWORD"
 
+	OB_VERSION_4_2_0_0 = "4.2.0.0"
+	OB_VERSION_4_2_1_0 = "4.2.1.0"
+	OB_VERSION_4_3_0_0 = "4.3.0.0"
 	OB_VERSION_4_3_5_2 = "4.3.5.2"
 )
 
@@ -301,11 +304,15 @@ const (
 	ROLE_PATTERN        = "^[a-zA-Z][a-zA-Z0-9_]{0,30}[a-zA-Z0-9]$"
 	DATABASE_PATTERN    = "^[a-zA-Z_0-9-]{2,64}$"
 	TENANT_NAME_PATTERN = "^[a-zA-Z0-9-_~#+]+$"
+	// pattern of the name of resource plan and consumer group
+	RESOURCE_MANAGER_NAME_PATTERN = "^[a-zA-Z][a-zA-Z0-9_]{0,127}$"
 )
 
 var PATH_PARAM_PATTERN = map[string]string{
-	URI_PARAM_USER:     USERNAME_PATTERN,
-	URI_PARAM_ROLE:     ROLE_PATTERN,
-	URI_PARAM_DATABASE: DATABASE_PATTERN,
-	URI_PARAM_NAME:     TENANT_NAME_PATTERN,
+	URI_PARAM_USER:           USERNAME_PATTERN,
+	URI_PARAM_ROLE:           ROLE_PATTERN,
+	URI_PARAM_DATABASE:       DATABASE_PATTERN,
+	URI_PARAM_NAME:           TENANT_NAME_PATTERN,
+	URI_PARAM_PLAN:           RESOURCE_MANAGER_NAME_PATTERN,
+	URI_PARAM_CONSUMER_GROUP: RESOURCE_MANAGER_NAME_PATTERN,
 }
@@ -27,4 +27,6 @@ const (
 	HTTP_HEADER_AES_KEY    = "AES_KEY"
 	HTTP_HEADER_SESSION_ID = "SESSION_ID"
 	HTTP_HEADER_SSO_TOKEN  = "SSO_TOKEN"
+	HTTP_HEADER_ACCOUNT    = "ACCOUNT"
+	AUDIT_REQUEST_BODY     = "AUDIT_REQUEST_BODY"
 )
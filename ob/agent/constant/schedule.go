@@ -0,0 +1,37 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package constant
+
+import "time"
+
+const (
+	SCHEDULE_JOB_TYPE_BACKUP     = "backup"
+	SCHEDULE_JOB_TYPE_COMPACTION = "compaction"
+	SCHEDULE_JOB_TYPE_INSPECTION = "inspection"
+
+	SCHEDULE_RUN_STATUS_SUBMITTED = "SUBMITTED"
+	SCHEDULE_RUN_STATUS_SKIPPED   = "SKIPPED"
+	SCHEDULE_RUN_STATUS_FAILED    = "FAILED"
+
+	// the schedules are checked by the maintainer every interval, so the precision of cron is one minute
+	SCHEDULE_CHECK_INTERVAL = 30 * time.Second
+
+	// only the latest runs of each schedule are kept
+	SCHEDULE_RUN_HISTORY_LIMIT  = 100
+	SCHEDULE_RUN_DEFAULT_LIMIT  = 20
+	SCHEDULE_RUN_MESSAGE_LENGTH = 1024
+)
@@ -25,6 +25,21 @@ const (
 	TENANT_STATUS_NORMAL = "NORMAL"
 
 	TENANT_ROLE_PRIMARY = "PRIMARY"
+	TENANT_ROLE_STANDBY = "STANDBY"
+
+	TENANT_SWITCHOVER_STATUS_NORMAL = "NORMAL"
+
+	// log restore source type of standby tenant
+	STANDBY_SOURCE_TYPE_SERVICE  = "SERVICE"
+	STANDBY_SOURCE_TYPE_LOCATION = "LOCATION"
+
+	TENANT_SNAPSHOT_STATUS_NORMAL = "NORMAL"
+	CLONE_JOB_STATUS_SUCCESS      = "SUCCESS"
+
+	// attributes of the consumer group mapping in DBMS_RESOURCE_MANAGER
+	RESOURCE_MAPPING_ATTRIBUTE_USER     = "USER"
+	RESOURCE_MAPPING_ATTRIBUTE_FUNCTION = "FUNCTION"
+	RESOURCE_MAPPING_ATTRIBUTE_COLUMN   = "COLUMN"
 
 	TENANT_TYPE_USER = "USER"
 	TENANT_TYPE_META = "META"
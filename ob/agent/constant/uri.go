@@ -48,6 +48,7 @@ const (
 	URI_GIT_INFO  = "/git-info"
 	URI_HOST_INFO = "/host-info"
 	URI_STATUS    = "/status"
+	URI_SERIES    = "/series"
 	URI_SECRET    = "secret"
 	URI_LOGIN     = "/login"
 	URI_LOGOUT    = "/logout"
@@ -85,6 +86,7 @@ const (
 	URI_DESTROY     = "/destroy"
 	URI_SCALE_OUT   = "/scale_out"
 	URI_SCALE_IN    = "/scale_in"
+	URI_REPLACE     = "/replace"
 	URI_AGENTS      = "/agents"
 	URI_CHARSETS    = "/charsets"
 	URI_STATISTICS  = "/statistics"
@@ -97,8 +99,11 @@ const (
 	URI_PARAMS  = "/params"
 	URI_BACKUP  = "/backup"
 	URI_RESTORE = "/restore"
+	URI_TABLE   = "/table"
 	URI_WINDOWS = "/windows"
 	URI_TASKS   = "/tasks"
+	URI_SETS    = "/sets"
+	URI_PIECES  = "/pieces"
 	URI_ENV     = "/env"
 
 	// Used for tenant
@@ -139,33 +144,57 @@ const (
 	URI_SESSIONS          = "/sessions"
 	URI_QUERIES           = "/queries"
 	URI_DEADLOCKS         = "/deadlocks"
+	URI_STANDBY           = "/standby"
+	URI_SWITCHOVER        = "/switchover"
+	URI_FAILOVER          = "/failover"
+	URI_SNAPSHOTS         = "/snapshots"
+	URI_CLONE             = "/clone"
+	URI_RESOURCE_MANAGER  = "/resource-manager"
+	URI_PLANS             = "/plans"
+	URI_ACTIVE_PLAN       = "/active-plan"
+	URI_DIRECTIVES        = "/directives"
+	URI_CONSUMER_GROUPS   = "/consumer-groups"
+	URI_MAPPINGS          = "/mappings"
+	URI_IO_USAGE          = "/io-usage"
+	URI_RPO_TARGET        = "/rpo-target"
+	URI_COMPLIANCE        = "/compliance"
 
 	URI_UNIT_CONFIG_LIMIT = "/unit-config-limit"
 	URI_LICENSE           = "/license"
 	URI_CREDENTIAL        = "/credential"
 	URI_CREDENTIALS       = "/credentials"
+	URI_ACCOUNT           = "/account"
+	URI_ACCOUNTS          = "/accounts"
+	URI_AUDIT_GROUP       = "/audit"
+	URI_RETENTION         = "/retention"
 	URI_VALIDATE          = "/validate"
 	URI_ENCRYPT_SECRETKEY = "/encrypt-secret-key"
 	URI_INSPECTION        = "/inspection"
 	URI_REPORTS           = "/reports"
 	URI_REPORT            = "/report"
 
-	URI_PARAM_NAME            = "name"
-	URI_PATH_PARAM_NAME       = "/:" + URI_PARAM_NAME
-	URI_PARAM_ROLE            = "role"
-	URI_PATH_PARAM_ROLE       = "/:" + URI_PARAM_ROLE
-	URI_PARAM_VAR             = "variable"
-	URI_PATH_PARAM_VAR        = "/:" + URI_PARAM_VAR
-	URI_PARAM_PARA            = "parameter"
-	URI_PATH_PARAM_PARA       = "/:" + URI_PARAM_PARA
-	URI_PARAM_USER            = "user"
-	URI_PATH_PARAM_USER       = "/:" + URI_PARAM_USER
-	URI_PARAM_DATABASE        = "database"
-	URI_PATH_PARAM_DATABASE   = "/:" + URI_PARAM_DATABASE
-	URI_PARAM_SESSION_ID      = "session_id"
-	URI_PATH_PARAM_SESSION_ID = "/:" + URI_PARAM_SESSION_ID
-	URI_PARAM_ZONE_NAME       = "zone_name"
-	URI_PATH_PARAM_ZONE_NAME  = "/:" + URI_PARAM_ZONE_NAME
+	URI_PARAM_NAME                = "name"
+	URI_PATH_PARAM_NAME           = "/:" + URI_PARAM_NAME
+	URI_PARAM_ROLE                = "role"
+	URI_PATH_PARAM_ROLE           = "/:" + URI_PARAM_ROLE
+	URI_PARAM_VAR                 = "variable"
+	URI_PATH_PARAM_VAR            = "/:" + URI_PARAM_VAR
+	URI_PARAM_PARA                = "parameter"
+	URI_PATH_PARAM_PARA           = "/:" + URI_PARAM_PARA
+	URI_PARAM_USER                = "user"
+	URI_PATH_PARAM_USER           = "/:" + URI_PARAM_USER
+	URI_PARAM_DATABASE            = "database"
+	URI_PATH_PARAM_DATABASE       = "/:" + URI_PARAM_DATABASE
+	URI_PARAM_SESSION_ID          = "session_id"
+	URI_PATH_PARAM_SESSION_ID     = "/:" + URI_PARAM_SESSION_ID
+	URI_PARAM_ZONE_NAME           = "zone_name"
+	URI_PATH_PARAM_ZONE_NAME      = "/:" + URI_PARAM_ZONE_NAME
+	URI_PARAM_SNAPSHOT            = "snapshot"
+	URI_PATH_PARAM_SNAPSHOT       = "/:" + URI_PARAM_SNAPSHOT
+	URI_PARAM_PLAN                = "plan"
+	URI_PATH_PARAM_PLAN           = "/:" + URI_PARAM_PLAN
+	URI_PARAM_CONSUMER_GROUP      = "consumer_group"
+	URI_PATH_PARAM_CONSUMER_GROUP = "/:" + URI_PARAM_CONSUMER_GROUP
 
 	// Used for backup
 	URI_ARCHIVE = "/log"
@@ -188,6 +217,7 @@ const (
 	URI_AGENT_RPC_PREFIX    = URI_RPC_V1 + URI_AGENT_GROUP
 	URI_OBSERVER_RPC_PREFIX = URI_RPC_V1 + URI_OBSERVER_GROUP
 	URI_OB_RPC_PREFIX       = URI_RPC_V1 + URI_OB_GROUP
+	URI_METRIC_RPC_PREFIX   = URI_RPC_V1 + URI_METRIC_GROUP
 
 	// Used for alarm
 	URI_ALARM_GROUP = "/alarm"
@@ -197,6 +227,25 @@ const (
 	URI_SILENCERS   = "/silencers"
 	URI_RULE        = "/rule"
 	URI_RULES       = "/rules"
+	URI_CHANNEL     = "/channel"
+	URI_CHANNELS    = "/channels"
+	URI_DELIVERIES  = "/deliveries"
+	URI_RECEIVER    = "/receiver"
+	URI_WEBHOOK     = "/webhook"
+	URI_TEST        = "/test"
+	URI_ENABLE      = "/enable"
+	URI_DISABLE     = "/disable"
+
+	// Used for metric
+	URI_COLLECTION = "/collection"
+
+	// Used for schedule
+	URI_SCHEDULES = "/schedules"
+	URI_RUNS      = "/runs"
+
+	// Used for task event
+	URI_EVENTS   = "/events"
+	URI_WEBHOOKS = "/webhooks"
 
 	URI_PARAM_ID      = "id"
 	URI_PATH_PARAM_ID = "/:" + URI_PARAM_ID
@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package constant
+
+import "time"
+
+const (
+	// the webhooks are cached by every agent and reloaded every interval
+	WEBHOOK_RELOAD_INTERVAL = 30 * time.Second
+
+	WEBHOOK_DELIVERY_TIMEOUT  = 10 * time.Second
+	WEBHOOK_MAX_RETRIES       = 3
+	WEBHOOK_RETRY_INTERVAL    = 2 * time.Second // doubled after every failed attempt
+	WEBHOOK_EVENT_BUFFER_SIZE = 1024
+	WEBHOOK_QUEUE_SIZE        = 1024 // the events are delivered to each webhook in order through its queue
+
+	WEBHOOK_HEADER_EVENT     = "X-Obshell-Event"
+	WEBHOOK_HEADER_DELIVERY  = "X-Obshell-Delivery"
+	WEBHOOK_HEADER_SIGNATURE = "X-Obshell-Signature" // "sha256=" + hex encoded HMAC-SHA256 of the body
+	WEBHOOK_SIGNATURE_PREFIX = "sha256="
+	WEBHOOK_MASKED_SECRET    = "******"
+
+	// a comment is sent to keep the event stream alive through the proxies
+	EVENT_STREAM_HEARTBEAT_INTERVAL = 15 * time.Second
+	EVENT_STREAM_BUFFER_SIZE        = 256
+	EVENT_STREAM_RECONNECT_INTERVAL = 3 * time.Second // reconnect to the event stream of the other agent
+	EVENT_STREAM_MAX_LINE_SIZE      = 1024 * 1024
+)
@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package event
+
+import (
+	"sync"
+	"time"
+)
+
+// Event types of the task lifecycle.
+const (
+	DAG_CREATED     = "dag.created"
+	DAG_RUNNING     = "dag.running"
+	DAG_SUCCEEDED   = "dag.succeeded"
+	DAG_FAILED      = "dag.failed"
+	DAG_ROLLED_BACK = "dag.rolled_back"
+	DAG_CANCELLED   = "dag.cancelled"
+
+	TASK_RUNNING   = "task.running"
+	TASK_SUCCEEDED = "task.succeeded"
+	TASK_FAILED    = "task.failed"
+)
+
+var ALL_TYPES = []string{
+	DAG_CREATED, DAG_RUNNING, DAG_SUCCEEDED, DAG_FAILED, DAG_ROLLED_BACK, DAG_CANCELLED,
+	TASK_RUNNING, TASK_SUCCEEDED, TASK_FAILED,
+}
+
+const (
+	// the recent events are kept for the reconnecting subscribers
+	historySize = 256
+)
+
+// Event is a state change of a dag or a sub task, published by the agent which changes the state.
+type Event struct {
+	Id       uint64    `json:"id"` // increasing sequence of the events published by the agent, reset when the agent restarts
+	Type     string    `json:"type"`
+	Time     time.Time `json:"time"`
+	Agent    string    `json:"agent"`
+	DagId    string    `json:"dag_id,omitempty"`
+	DagName  string    `json:"dag_name,omitempty"`
+	DagType  string    `json:"dag_type,omitempty"`
+	Stage    int       `json:"stage,omitempty"`
+	MaxStage int       `json:"max_stage,omitempty"`
+	TaskId   string    `json:"task_id,omitempty"`
+	TaskName string    `json:"task_name,omitempty"`
+	State    string    `json:"state"`
+	Operator string    `json:"operator"`
+}
+
+type hub struct {
+	lock        sync.RWMutex
+	seq         uint64
+	subscribers map[chan *Event]struct{}
+	history     []*Event
+}
+
+var defaultHub = &hub{subscribers: make(map[chan *Event]struct{})}
+
+// Subscribe returns a channel receiving the events published since now, and the function to cancel the subscription.
+// The events are dropped for the subscriber when its buffer is full, so a slow subscriber never blocks the task engine.
+func Subscribe(buffer int) (<-chan *Event, func()) {
+	ch := make(chan *Event, buffer)
+	defaultHub.lock.Lock()
+	defaultHub.subscribers[ch] = struct{}{}
+	defaultHub.lock.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			defaultHub.lock.Lock()
+			delete(defaultHub.subscribers, ch)
+			defaultHub.lock.Unlock()
+		})
+	}
+}
+
+// Publish assigns the id and the time to the event and sends it to all the subscribers.
+func Publish(evt *Event) {
+	defaultHub.lock.Lock()
+	defer defaultHub.lock.Unlock()
+
+	defaultHub.seq++
+	evt.Id = defaultHub.seq
+	if evt.Time.IsZero() {
+		evt.Time = time.Now()
+	}
+	defaultHub.history = append(defaultHub.history, evt)
+	if len(defaultHub.history) > historySize {
+		defaultHub.history = defaultHub.history[len(defaultHub.history)-historySize:]
+	}
+	for ch := range defaultHub.subscribers {
+		select {
+		case ch <- evt:
+		default:
+		}
+	}
+}
+
+// History returns the kept events whose id is greater than afterId.
+func History(afterId uint64) []*Event {
+	defaultHub.lock.RLock()
+	defer defaultHub.lock.RUnlock()
+
+	events := make([]*Event, 0)
+	for _, evt := range defaultHub.history {
+		if evt.Id > afterId {
+			events = append(events, evt)
+		}
+	}
+	return events
+}
+
+// LastId returns the id of the latest event published by the agent.
+func LastId() uint64 {
+	defaultHub.lock.RLock()
+	defer defaultHub.lock.RUnlock()
+	return defaultHub.seq
+}
@@ -33,6 +33,9 @@ func (s *Scheduler) advanceDag(dag *task.Dag) error {
 	}
 
 	log.withScheduler(s).Infof("advance dag %d", dag.GetID())
+	if dag.IsGraph() {
+		return s.advanceGraphDag(dag)
+	}
 	stage := getCurrentStage(dag)
 	node, err := s.service.GetNodeByStage(dag.GetID(), stage)
 	if err != nil {
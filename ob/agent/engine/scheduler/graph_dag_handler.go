@@ -0,0 +1,193 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scheduler
+
+import (
+	"github.com/oceanbase/obshell/ob/agent/engine/task"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+)
+
+// advanceGraphDag advances all the nodes of a graph dag whose upstreams are ready,
+// so that the independent branches run concurrently.
+// The nodes returned by GetNodes are ordered by stage, which is a topological order.
+func (s *Scheduler) advanceGraphDag(dag *task.Dag) error {
+	nodes, err := s.service.GetNodes(dag)
+	if err != nil {
+		return errors.Wrapf(err, "dag %d get nodes error", dag.GetID())
+	}
+	if dag.IsRollback() {
+		return s.rollbackGraphDag(dag, nodes)
+	}
+
+	hasFailed := len(getFailedGraphNodes(nodes)) != 0
+	for _, node := range nodes {
+		if node.IsFinished() {
+			continue
+		}
+		// Pending nodes won't be started once a node failed or the dag is canceled,
+		// except the nodes to be canceled.
+		if node.IsPending() && !node.IsCancel() && (hasFailed || dag.IsCancel() || !isUpstreamsSucceed(node)) {
+			continue
+		}
+		if err = s.advanceNode(node); err != nil {
+			return errors.Wrapf(err, "dag %d advance node error", dag.GetID())
+		}
+		if node.IsFail() {
+			hasFailed = true
+		}
+	}
+
+	if isGraphNodesActive(nodes) {
+		return s.updateGraphDagStage(dag, nodes, false)
+	}
+	if failedNodes := getFailedGraphNodes(nodes); len(failedNodes) != 0 {
+		dag.SetStage(getGraphNodeStage(nodes, failedNodes[0]))
+		err = s.service.FinishDagAsFailed(dag)
+	} else if isGraphNodesSucceed(nodes) {
+		dag.SetStage(dag.GetMaxStage())
+		err = s.service.FinishDagAsSucceed(dag)
+	} else {
+		return s.updateGraphDagStage(dag, nodes, false)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "update dag %d error", dag.GetID())
+	}
+	return nil
+}
+
+// rollbackGraphDag rolls back the nodes of a graph dag in reverse topological order.
+// A node will be rolled back after all of its downstreams have been rolled back,
+// so the branches are rolled back independently.
+func (s *Scheduler) rollbackGraphDag(dag *task.Dag, nodes []*task.Node) error {
+	var err error
+	for idx := len(nodes) - 1; idx >= 0; idx-- {
+		node := nodes[idx]
+		if !node.IsRollback() || node.IsFinished() {
+			continue
+		}
+		if node.IsPending() && !isDownstreamsRolledBack(node) {
+			continue
+		}
+		if err = s.advanceNode(node); err != nil {
+			return errors.Wrapf(err, "dag %d rollback node error", dag.GetID())
+		}
+	}
+
+	if isGraphNodesActive(nodes) {
+		return s.updateGraphDagStage(dag, nodes, true)
+	}
+	for _, node := range nodes {
+		if node.IsRollback() && node.IsFail() {
+			dag.SetStage(getGraphNodeStage(nodes, node))
+			if err = s.service.FinishDagAsFailed(dag); err != nil {
+				return errors.Wrapf(err, "update dag %d error", dag.GetID())
+			}
+			return nil
+		}
+	}
+	for _, node := range nodes {
+		if node.IsRollback() && !node.IsSuccess() {
+			return s.updateGraphDagStage(dag, nodes, true)
+		}
+	}
+	dag.SetStage(1)
+	if err = s.service.FinishDagAsSucceed(dag); err != nil {
+		return errors.Wrapf(err, "update dag %d error", dag.GetID())
+	}
+	return nil
+}
+
+// updateGraphDagStage records the first unfinished node as the stage of the dag,
+// or the last one when rolling back.
+func (s *Scheduler) updateGraphDagStage(dag *task.Dag, nodes []*task.Node, isRollback bool) error {
+	stage := dag.GetStage()
+	for idx, node := range nodes {
+		if isRollback && (!node.IsRollback() || node.IsSuccess()) {
+			continue
+		}
+		if !isRollback && node.IsFinished() {
+			continue
+		}
+		stage = idx + 1
+		if !isRollback {
+			break
+		}
+	}
+	if stage == dag.GetStage() {
+		return nil
+	}
+	if err := s.service.UpdateDagStage(dag, stage); err != nil {
+		return errors.Wrapf(err, "update dag %d error", dag.GetID())
+	}
+	return nil
+}
+
+func isUpstreamsSucceed(node *task.Node) bool {
+	for _, upstream := range node.GetUpstreams() {
+		if !upstream.IsSuccess() {
+			return false
+		}
+	}
+	return true
+}
+
+func isDownstreamsRolledBack(node *task.Node) bool {
+	for _, downstream := range node.GetDownstreams() {
+		if downstream.IsRollback() && !downstream.IsSuccess() {
+			return false
+		}
+	}
+	return true
+}
+
+// isGraphNodesActive returns whether there is any node running or waiting to be canceled.
+func isGraphNodesActive(nodes []*task.Node) bool {
+	for _, node := range nodes {
+		if node.IsRunning() || (node.IsPending() && node.IsCancel()) {
+			return true
+		}
+	}
+	return false
+}
+
+func isGraphNodesSucceed(nodes []*task.Node) bool {
+	for _, node := range nodes {
+		if !node.IsSuccess() {
+			return false
+		}
+	}
+	return true
+}
+
+func getFailedGraphNodes(nodes []*task.Node) []*task.Node {
+	failedNodes := make([]*task.Node, 0)
+	for _, node := range nodes {
+		if node.IsFail() {
+			failedNodes = append(failedNodes, node)
+		}
+	}
+	return failedNodes
+}
+
+func getGraphNodeStage(nodes []*task.Node, target *task.Node) int {
+	for idx, node := range nodes {
+		if node == target {
+			return idx + 1
+		}
+	}
+	return 1
+}
@@ -0,0 +1,283 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/oceanbase/obshell/ob/agent/engine/executor"
+	"github.com/oceanbase/obshell/ob/agent/engine/task"
+	"github.com/oceanbase/obshell/ob/agent/meta"
+	taskservice "github.com/oceanbase/obshell/ob/agent/service/task"
+)
+
+type graphTestTask struct {
+	task.Task
+}
+
+func (t *graphTestTask) Execute() error {
+	return nil
+}
+
+// fakeGraphTaskService keeps the dag in memory and records the sub tasks set ready.
+type fakeGraphTaskService struct {
+	taskservice.TaskServiceInterface
+	nodes []*task.Node
+	ready []string
+}
+
+func (s *fakeGraphTaskService) GetNodes(dag *task.Dag) ([]*task.Node, error) {
+	return s.nodes, nil
+}
+
+func (s *fakeGraphTaskService) GetSubTasks(node *task.Node) ([]task.ExecutableTask, error) {
+	return node.GetSubTasks(), nil
+}
+
+func (s *fakeGraphTaskService) StartNode(node *task.Node) error {
+	node.SetStartTime(time.Now())
+	return nil
+}
+
+func (s *fakeGraphTaskService) FinishNode(node *task.Node) error {
+	node.SetEndTime(time.Now())
+	return nil
+}
+
+func (s *fakeGraphTaskService) SetSubTaskReady(subTask task.ExecutableTask, operator int) error {
+	subTask.(*graphTestTask).SetOperator(operator)
+	subTask.SetState(task.READY)
+	s.ready = append(s.ready, subTask.GetName())
+	return nil
+}
+
+func (s *fakeGraphTaskService) FinishSubTask(subTask task.ExecutableTask, state int) error {
+	subTask.SetState(state)
+	return nil
+}
+
+func (s *fakeGraphTaskService) SetSubTaskFailed(subTask task.ExecutableTask, msg string) error {
+	subTask.SetState(task.FAILED)
+	return nil
+}
+
+func (s *fakeGraphTaskService) UpdateDagStage(dag *task.Dag, stage int) error {
+	dag.SetStage(stage)
+	return nil
+}
+
+func (s *fakeGraphTaskService) FinishDagAsFailed(dag *task.Dag) error {
+	dag.SetState(task.FAILED)
+	return nil
+}
+
+func (s *fakeGraphTaskService) FinishDagAsSucceed(dag *task.Dag) error {
+	dag.SetState(task.SUCCEED)
+	return nil
+}
+
+type graphTest struct {
+	t         *testing.T
+	scheduler *Scheduler
+	service   *fakeGraphTaskService
+	dag       *task.Dag
+	nodes     map[string]*task.Node
+}
+
+func newGraphTestNode(name string) *task.Node {
+	return task.NewNodeWithContext(&graphTestTask{Task: *task.NewSubTask(name)}, false, task.NewTaskContext())
+}
+
+func newGraphTest(t *testing.T, template *task.Template) *graphTest {
+	if !template.IsGraph() {
+		t.Fatalf("template %s is not a graph", template.Name)
+	}
+	if executor.OCS_EXECUTOR_POOL == nil {
+		executor.OCS_EXECUTOR_POOL = executor.NewExecutorPool()
+	}
+	if meta.OCS_AGENT == nil {
+		meta.OCS_AGENT = meta.NewAgentInstance("127.0.0.1", 2886, "zone1", meta.SINGLE, "")
+	}
+	service := &fakeGraphTaskService{nodes: template.GetNodes()}
+	dag := task.NewDag(1, template.Name, "", task.RUNNING, 1, len(service.nodes), task.RUN, nil, task.NewTaskContext(), true, time.Now(), time.Time{})
+	dag.SetGraph(true)
+	test := &graphTest{
+		t:         t,
+		scheduler: &Scheduler{isLocal: true, service: service},
+		service:   service,
+		dag:       dag,
+		nodes:     make(map[string]*task.Node),
+	}
+	// The nodes and sub tasks are pending to run as they are created.
+	for _, node := range service.nodes {
+		node.SetOperator(task.RUN)
+		node.SetState(task.PENDING)
+		for _, subTask := range node.GetSubTasks() {
+			subTask.(*graphTestTask).SetOperator(task.RUN)
+			subTask.SetState(task.PENDING)
+		}
+		test.nodes[node.GetName()] = node
+	}
+	return test
+}
+
+// advance runs one round of the scheduler and returns the sub tasks set ready in the round.
+func (test *graphTest) advance() []string {
+	test.service.ready = nil
+	if err := test.scheduler.advanceDag(test.dag); err != nil {
+		test.t.Fatalf("advance dag failed: %v", err)
+	}
+	return test.service.ready
+}
+
+// finish simulates the executor finishing the sub task of the node.
+func (test *graphTest) finish(name string, state int) {
+	subTask := test.nodes[name].GetSubTasks()[0].(*graphTestTask)
+	if !subTask.IsReady() {
+		test.t.Fatalf("sub task %s is not ready", name)
+	}
+	subTask.SetState(state)
+	subTask.SetStartTime(time.Now().Add(time.Second))
+}
+
+func (test *graphTest) expectReady(ready []string, expected ...string) {
+	test.t.Helper()
+	if len(ready) != len(expected) {
+		test.t.Fatalf("expect %v to be ready, got %v", expected, ready)
+	}
+	for idx := range expected {
+		if ready[idx] != expected[idx] {
+			test.t.Fatalf("expect %v to be ready, got %v", expected, ready)
+		}
+	}
+}
+
+func (test *graphTest) expectDag(state int, stage int) {
+	test.t.Helper()
+	if test.dag.GetState() != state || test.dag.GetStage() != stage {
+		test.t.Fatalf("expect dag state %d stage %d, got state %d stage %d", state, stage, test.dag.GetState(), test.dag.GetStage())
+	}
+}
+
+// buildDiamondTemplate builds a -> (b, c) -> d.
+func buildDiamondTemplate() *task.Template {
+	a := newGraphTestNode("a")
+	return task.NewTemplateBuilder("diamond").
+		AddNode(a).
+		AddNodeAfter(newGraphTestNode("b"), a).
+		AddNodeAfter(newGraphTestNode("c"), a).
+		AddNode(newGraphTestNode("d")).
+		Build()
+}
+
+func TestGraphDagFanOut(t *testing.T) {
+	test := newGraphTest(t, buildDiamondTemplate())
+
+	test.expectReady(test.advance(), "a")
+	test.expectReady(test.advance())
+	test.finish("a", task.SUCCEED)
+	// Both branches start in the same round once their upstream succeeded.
+	test.expectReady(test.advance(), "b", "c")
+	test.expectDag(task.RUNNING, 2)
+}
+
+func TestGraphDagJoin(t *testing.T) {
+	test := newGraphTest(t, buildDiamondTemplate())
+
+	test.advance()
+	test.finish("a", task.SUCCEED)
+	test.advance()
+	test.finish("c", task.SUCCEED)
+	// The join node waits for all of its upstreams.
+	test.expectReady(test.advance())
+	test.expectDag(task.RUNNING, 2)
+	test.finish("b", task.SUCCEED)
+	test.expectReady(test.advance(), "d")
+	test.expectDag(task.RUNNING, 4)
+	test.finish("d", task.SUCCEED)
+	test.expectReady(test.advance())
+	test.expectDag(task.SUCCEED, 4)
+}
+
+func TestGraphDagFailure(t *testing.T) {
+	// a -> (b1 -> b2, c) -> d
+	a := newGraphTestNode("a")
+	branchB := task.NewTemplateBuilder("b").
+		AddNode(newGraphTestNode("b1")).
+		AddNode(newGraphTestNode("b2")).
+		Build()
+	branchC := task.NewTemplateBuilder("c").
+		AddNode(newGraphTestNode("c")).
+		Build()
+	template := task.NewTemplateBuilder("failure").
+		AddNode(a).
+		AddBranches(branchB, branchC).
+		AddNode(newGraphTestNode("d")).
+		Build()
+	test := newGraphTest(t, template)
+
+	test.advance()
+	test.finish("a", task.SUCCEED)
+	test.expectReady(test.advance(), "b1", "c")
+	test.finish("c", task.FAILED)
+	// The running branch goes on, and the dag waits for it.
+	test.expectReady(test.advance())
+	test.expectDag(task.RUNNING, 2)
+	test.finish("b1", task.SUCCEED)
+	// No pending node is started after a node failed.
+	test.expectReady(test.advance())
+	if !test.nodes["b2"].IsPending() || !test.nodes["d"].IsPending() {
+		t.Fatalf("expect b2 and d to be pending")
+	}
+	// The dag fails at the stage of the failed node.
+	test.expectDag(task.FAILED, 4)
+}
+
+func TestGraphDagRollback(t *testing.T) {
+	test := newGraphTest(t, buildDiamondTemplate())
+	test.advance()
+	test.finish("a", task.SUCCEED)
+	test.advance()
+	test.finish("b", task.SUCCEED)
+	test.finish("c", task.SUCCEED)
+	test.advance()
+	test.finish("d", task.FAILED)
+	test.advance()
+	test.expectDag(task.FAILED, 4)
+
+	// Set the dag and all the finished nodes to be rolled back.
+	test.dag.SetOperator(task.ROLLBACK)
+	test.dag.SetState(task.RUNNING)
+	for _, node := range test.service.nodes {
+		node.SetOperator(task.ROLLBACK)
+		node.SetState(task.PENDING)
+	}
+
+	// The nodes are rolled back in reverse topological order,
+	// and the branches are rolled back independently.
+	test.expectReady(test.advance(), "d")
+	test.finish("d", task.SUCCEED)
+	test.expectReady(test.advance(), "c", "b")
+	test.finish("c", task.SUCCEED)
+	test.expectReady(test.advance())
+	test.finish("b", task.SUCCEED)
+	test.expectReady(test.advance(), "a")
+	test.finish("a", task.SUCCEED)
+	test.expectReady(test.advance())
+	test.expectDag(task.SUCCEED, 1)
+}
@@ -54,8 +54,10 @@ func (s *Scheduler) advanceNode(node *task.Node) error {
 }
 
 func (s *Scheduler) mergeContext(node *task.Node) error {
-	upstreamNode := node.GetUpstream()
-	if upstreamNode != nil {
+	if node.IsRollback() {
+		return nil
+	}
+	for _, upstreamNode := range node.GetUpstreams() {
 		subTasks, err := s.service.GetSubTasks(upstreamNode)
 		if err != nil {
 			return errors.Wrap(err, "get sub tasks error")
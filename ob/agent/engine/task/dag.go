@@ -41,6 +41,7 @@ type Dag struct {
 	TaskInfo
 	maintenance Maintainer
 	ctx         *TaskContext
+	isGraph     bool
 }
 
 func NewDag(dagId int64, dagName string, dagType string, state int, stage int, maxStage int, operator int, maintenance Maintainer, ctx *TaskContext, isLocalTask bool, startTime time.Time, endTime time.Time) *Dag {
@@ -62,6 +63,16 @@ func NewDag(dagId int64, dagName string, dagType string, state int, stage int, m
 	}
 }
 
+// IsGraph returns whether the nodes of the dag are connected by the declared edges
+// instead of running one after another by stage.
+func (dag *Dag) IsGraph() bool {
+	return dag.isGraph
+}
+
+func (dag *Dag) SetGraph(isGraph bool) {
+	dag.isGraph = isGraph
+}
+
 func (dag *Dag) GetDagType() string {
 	return dag.dagType
 }
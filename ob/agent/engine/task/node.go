@@ -28,12 +28,13 @@ const (
 )
 
 type Node struct {
-	subtasks   []ExecutableTask
-	taskType   reflect.Type
-	nodeType   string
-	upStream   *Node
-	downStream *Node
-	dagId      int
+	subtasks       []ExecutableTask
+	taskType       reflect.Type
+	nodeType       string
+	upStreams      []*Node
+	downStreams    []*Node
+	upstreamStages []int // stages of the upstream nodes, only persisted for graph dags
+	dagId          int
 	TaskInfo
 	ctx *TaskContext
 }
@@ -81,25 +82,56 @@ func (node *Node) AddSubTask(task ExecutableTask) error {
 }
 
 func (node *Node) GetUpstream() *Node {
-	return node.upStream
+	if len(node.upStreams) == 0 {
+		return nil
+	}
+	return node.upStreams[0]
 }
 
 func (node *Node) GetDownstream() *Node {
-	return node.downStream
+	if len(node.downStreams) == 0 {
+		return nil
+	}
+	return node.downStreams[0]
+}
+
+// GetUpstreams returns all the upstreams, a join node in a graph dag has several upstreams.
+func (node *Node) GetUpstreams() []*Node {
+	return node.upStreams
+}
+
+// GetDownstreams returns all the downstreams, a fan-out node in a graph dag has several downstreams.
+func (node *Node) GetDownstreams() []*Node {
+	return node.downStreams
 }
 
 func (node *Node) AddUpstream(upstream *Node) {
-	if node.upStream != nil {
+	if len(node.upStreams) != 0 {
 		panic("node already has upstream")
 	}
-	node.upStream = upstream
+	node.upStreams = append(node.upStreams, upstream)
 }
 
 func (node *Node) AddDownstream(downstream *Node) {
-	if node.downStream != nil {
+	if len(node.downStreams) != 0 {
 		panic("node already has downStream")
 	}
-	node.downStream = downstream
+	node.downStreams = append(node.downStreams, downstream)
+}
+
+// Connect adds the edge from the node to the downstream.
+// Unlike AddUpstream and AddDownstream, the nodes could have several edges.
+func (node *Node) Connect(downstream *Node) {
+	node.downStreams = append(node.downStreams, downstream)
+	downstream.upStreams = append(downstream.upStreams, node)
+}
+
+func (node *Node) GetUpstreamStages() []int {
+	return node.upstreamStages
+}
+
+func (node *Node) SetUpstreamStages(stages []int) {
+	node.upstreamStages = stages
 }
 
 func (node *Node) CanCancel() bool {
@@ -22,17 +22,77 @@ type Template struct {
 	Name        string
 	maintenance Maintainer
 	Type        string
+	isGraph     bool
 }
 
+// AddNode adds the node after all the nodes which have no downstream,
+// so the node joins all the branches of the template.
 func (template *Template) AddNode(node *Node) {
-	if template.tailNode != nil {
-		template.tailNode.AddDownstream(node)
-		node.AddUpstream(template.tailNode)
+	for _, sink := range template.getSinks() {
+		sink.Connect(node)
 	}
 	template.tailNode = node
 	template.nodes = append(template.nodes, node)
 }
 
+// AddNodeAfter adds the node with the explicit upstreams.
+// The template becomes a graph when an upstream forks or the node starts a new branch.
+func (template *Template) AddNodeAfter(node *Node, upstreams ...*Node) {
+	if len(upstreams) == 0 && !template.IsEmpty() {
+		template.isGraph = true
+	}
+	for _, upstream := range upstreams {
+		if len(upstream.GetDownstreams()) != 0 || len(upstreams) > 1 {
+			template.isGraph = true
+		}
+		upstream.Connect(node)
+	}
+	template.tailNode = node
+	template.nodes = append(template.nodes, node)
+}
+
+// AddBranches adds the templates as branches running concurrently after the current nodes.
+// The edges inside each branch are kept, and the next added node waits for all the branches.
+func (template *Template) AddBranches(branches ...*Template) {
+	sinks := template.getSinks()
+	for _, branch := range branches {
+		if branch == nil || branch.IsEmpty() {
+			continue
+		}
+		if branch.isGraph {
+			template.isGraph = true
+		}
+		for _, node := range branch.nodes {
+			if len(node.GetUpstreams()) == 0 {
+				for _, sink := range sinks {
+					sink.Connect(node)
+				}
+			}
+			template.tailNode = node
+			template.nodes = append(template.nodes, node)
+		}
+		template.maintenance = mergeMaintainers(template.maintenance, branch.maintenance)
+	}
+	if len(branches) > 1 {
+		template.isGraph = true
+	}
+}
+
+// IsGraph returns whether the template has concurrent branches.
+func (template *Template) IsGraph() bool {
+	return template.isGraph
+}
+
+func (template *Template) getSinks() []*Node {
+	sinks := make([]*Node, 0)
+	for _, node := range template.nodes {
+		if len(node.GetDownstreams()) == 0 {
+			sinks = append(sinks, node)
+		}
+	}
+	return sinks
+}
+
 func (template *Template) GetNodes() []*Node {
 	return template.nodes
 }
@@ -76,10 +136,24 @@ func (builder *TemplateBuilder) AddTask(task ExecutableTask, parallel bool) *Tem
 	return builder
 }
 
+func (builder *TemplateBuilder) AddNodeAfter(node *Node, upstreams ...*Node) *TemplateBuilder {
+	builder.Template.AddNodeAfter(node, upstreams...)
+	return builder
+}
+
+// AddBranches adds the templates as concurrent branches, see Template.AddBranches.
+func (builder *TemplateBuilder) AddBranches(branches ...*Template) *TemplateBuilder {
+	builder.Template.AddBranches(branches...)
+	return builder
+}
+
 func (builder *TemplateBuilder) AddTemplate(template *Template) *TemplateBuilder {
+	if template.IsGraph() {
+		return builder.AddBranches(template)
+	}
 	for _, node := range template.nodes {
-		node.downStream = nil
-		node.upStream = nil
+		node.downStreams = nil
+		node.upStreams = nil
 		builder.AddNode(node)
 	}
 	builder.Template.maintenance = mergeMaintainers(builder.Template.maintenance, template.maintenance)
@@ -24,6 +24,7 @@ const (
 	badRequest      ErrorKind = http.StatusBadRequest
 	illegalArgument ErrorKind = http.StatusBadRequest
 	unauthorized    ErrorKind = http.StatusUnauthorized
+	forbidden       ErrorKind = http.StatusForbidden
 	notFound        ErrorKind = http.StatusNotFound
 	unexpected      ErrorKind = http.StatusInternalServerError
 	known           ErrorKind = http.StatusInternalServerError
@@ -74,6 +75,7 @@ var (
 	ErrCommonUnexpected                 = NewErrorCode("Common.Unexpected", unexpected, "err.common.unexpected")                              // "unexpected error: %s"
 	ErrCommonUnauthorized               = NewErrorCode("Common.Unauthorized", unauthorized, "err.common.unauthorized", 10008)                 // "unauthorized"
 	ErrCommonInvalidTimeDuration        = NewErrorCode("Common.InvalidTimeDuration", illegalArgument, "err.common.invalid.time.duration")     // "time duration '%s' is invalid: %s"
+	ErrCommonInvalidCronExpression      = NewErrorCode("Common.InvalidCronExpression", illegalArgument, "err.common.invalid.cron.expression") // "cron expression '%s' is invalid: %s"
 	ErrJsonMarshal                      = NewErrorCode("Common.JsonMarshal", unexpected, "err.common.json.marshal")                           // "json marshal failed: %s"
 	ErrJsonUnmarshal                    = NewErrorCode("Common.JsonUnmarshal", unexpected, "err.common.json.unmarshal")                       // "json unmarshal failed: %s"
 	// Log
@@ -151,6 +153,21 @@ var (
 	ErrObTenantReplicaOnlyOne     = NewErrorCode("OB.Tenant.OnlyOneReplica", illegalArgument, "err.ob.tenant.only.one.replica")
 	ErrObTenantReplicaDeleteAll   = NewErrorCode("OB.Tenant.Replica.DeleteAll", badRequest, "err.ob.tenant.replica.delete.all")
 
+	// OB.Tenant.Snapshot
+	ErrObTenantSnapshotVersionNotSupported = NewErrorCode("OB.Tenant.Snapshot.Version.NotSupported", badRequest, "err.ob.tenant.snapshot.version.not.supported")
+	ErrObTenantSnapshotNameInvalid         = NewErrorCode("OB.Tenant.Snapshot.Name.Invalid", illegalArgument, "err.ob.tenant.snapshot.name.invalid")
+	ErrObTenantSnapshotNotExist            = NewErrorCode("OB.Tenant.Snapshot.NotExist", notFound, "err.ob.tenant.snapshot.not.exist")
+	ErrObTenantSnapshotStatusNotNormal     = NewErrorCode("OB.Tenant.Snapshot.Status.NotNormal", badRequest, "err.ob.tenant.snapshot.status.not.normal")
+	ErrObTenantCloneFailed                 = NewErrorCode("OB.Tenant.Clone.Failed", unexpected, "err.ob.tenant.clone.failed")
+
+	// OB.Tenant.ResourceManager
+	ErrObResourceManagerNameInvalid      = NewErrorCode("OB.Tenant.ResourceManager.Name.Invalid", illegalArgument, "err.ob.tenant.resource.manager.name.invalid")
+	ErrObResourcePlanNotExist            = NewErrorCode("OB.Tenant.ResourceManager.Plan.NotExist", notFound, "err.ob.tenant.resource.manager.plan.not.exist")
+	ErrObResourcePlanActive              = NewErrorCode("OB.Tenant.ResourceManager.Plan.Active", badRequest, "err.ob.tenant.resource.manager.plan.active")
+	ErrObConsumerGroupNotExist           = NewErrorCode("OB.Tenant.ResourceManager.ConsumerGroup.NotExist", notFound, "err.ob.tenant.resource.manager.consumer.group.not.exist")
+	ErrObResourcePlanDirectiveNotExist   = NewErrorCode("OB.Tenant.ResourceManager.Directive.NotExist", notFound, "err.ob.tenant.resource.manager.directive.not.exist")
+	ErrObResourceMappingAttributeInvalid = NewErrorCode("OB.Tenant.ResourceManager.Mapping.Attribute.Invalid", illegalArgument, "err.ob.tenant.resource.manager.mapping.attribute.invalid")
+
 	// OB.Backup
 	ErrObBackupBaseUriEmpty                 = NewErrorCode("OB.Backup.BaseUriEmpty", illegalArgument, "err.ob.backup.base.uri.empty")
 	ErrObBackupArchiveBaseUriEmpty          = NewErrorCode("OB.Backup.ArchiveBaseUriEmpty", illegalArgument, "err.ob.backup.archive.base.uri.empty")
@@ -168,13 +185,30 @@ var (
 	ErrObBackupArchiveLogStatusInvalid      = NewErrorCode("OB.Backup.ArchiveLogStatus.Invalid", illegalArgument, "err.ob.backup.archive.log.status.invalid")
 	ErrObBackupArchiveDestEmpty             = NewErrorCode("OB.Backup.ArchiveDestEmpty", illegalArgument, "err.ob.backup.archive.dest.empty")
 	ErrObBackupDataDestEmpty                = NewErrorCode("OB.Backup.DataDestEmpty", illegalArgument, "err.ob.backup.data.dest.empty")
+	ErrObBackupSetNotExist                  = NewErrorCode("OB.Backup.Set.NotExist", notFound, "err.ob.backup.set.not.exist")
+	ErrObBackupSetNotDeletable              = NewErrorCode("OB.Backup.Set.NotDeletable", illegalArgument, "err.ob.backup.set.not.deletable")
+	ErrObBackupSetNotValidatable            = NewErrorCode("OB.Backup.Set.NotValidatable", illegalArgument, "err.ob.backup.set.not.validatable")
+	ErrObBackupPieceNotExist                = NewErrorCode("OB.Backup.Piece.NotExist", notFound, "err.ob.backup.piece.not.exist")
+	ErrObBackupPieceNotDeletable            = NewErrorCode("OB.Backup.Piece.NotDeletable", illegalArgument, "err.ob.backup.piece.not.deletable")
+	ErrObBackupRpoTargetInvalid             = NewErrorCode("OB.Backup.RpoTarget.Invalid", illegalArgument, "err.ob.backup.rpo.target.invalid")
+	ErrObBackupRpoTargetNotExist            = NewErrorCode("OB.Backup.RpoTarget.NotExist", notFound, "err.ob.backup.rpo.target.not.exist")
 
 	// Ob.Restore
-	ErrObStorageURIInvalid         = NewErrorCode("OB.Storage.URI.Invalid", illegalArgument, "err.ob.storage.uri.invalid")
-	ErrObRestoreNotRecovering      = NewErrorCode("OB.Restore.NotRecovering", illegalArgument, "err.ob.restore.not.recovering")
-	ErrObRestoreTimeNotValid       = NewErrorCode("OB.Restore.TimeNotValid", illegalArgument, "err.ob.restore.time.not.valid")
-	ErrObRestoreTaskNotExist       = NewErrorCode("OB.Restore.Task.NotExist", illegalArgument, "err.ob.restore.task.not.exist")
-	ErrObRestoreTaskAlreadySucceed = NewErrorCode("OB.Restore.Task.AlreadySucceed", illegalArgument, "err.ob.restore.task.already.succeed")
+	ErrObStorageURIInvalid               = NewErrorCode("OB.Storage.URI.Invalid", illegalArgument, "err.ob.storage.uri.invalid")
+	ErrObRestoreNotRecovering            = NewErrorCode("OB.Restore.NotRecovering", illegalArgument, "err.ob.restore.not.recovering")
+	ErrObRestoreTimeNotValid             = NewErrorCode("OB.Restore.TimeNotValid", illegalArgument, "err.ob.restore.time.not.valid")
+	ErrObRestoreTaskNotExist             = NewErrorCode("OB.Restore.Task.NotExist", illegalArgument, "err.ob.restore.task.not.exist")
+	ErrObRestoreTaskAlreadySucceed       = NewErrorCode("OB.Restore.Task.AlreadySucceed", illegalArgument, "err.ob.restore.task.already.succeed")
+	ErrObRestoreTableVersionNotSupported = NewErrorCode("OB.Restore.Table.VersionNotSupported", illegalArgument, "err.ob.restore.table.version.not.supported")
+	ErrObRestoreTableJobExist            = NewErrorCode("OB.Restore.Table.JobExist", illegalArgument, "err.ob.restore.table.job.exist")
+	ErrObRestoreTableFailed              = NewErrorCode("OB.Restore.Table.Failed", unexpected, "err.ob.restore.table.failed")
+
+	// OB.Standby
+	ErrObStandbySourceTypeInvalid       = NewErrorCode("OB.Standby.SourceType.Invalid", illegalArgument, "err.ob.standby.source.type.invalid")
+	ErrObStandbyPrimaryInfoEmpty        = NewErrorCode("OB.Standby.PrimaryInfo.Empty", illegalArgument, "err.ob.standby.primary.info.empty")
+	ErrObStandbyTenantRoleUnexpected    = NewErrorCode("OB.Standby.TenantRole.Unexpected", badRequest, "err.ob.standby.tenant.role.unexpected")
+	ErrObStandbySwitchoverStatusInvalid = NewErrorCode("OB.Standby.SwitchoverStatus.Invalid", badRequest, "err.ob.standby.switchover.status.invalid")
+	ErrObStandbyVersionNotSupported     = NewErrorCode("OB.Standby.Version.NotSupported", badRequest, "err.ob.standby.version.not.supported")
 
 	// OB.Cluster
 	ErrObClusterUnderMaintenance                        = NewErrorCode("OB.Cluster.UnderMaintenance", known, "err.ob.cluster.under.maintenance")
@@ -211,6 +245,8 @@ var (
 	ErrObServerHasNotBeenStarted  = NewErrorCode("OB.Server.HasNotBeenStarted", unexpected, "err.ob.server.has.not.been.started")        // "observer has not started yet, please start it with normal way"
 	ErrObServerUnavailable        = NewErrorCode("OB.Server.Unavailable", unexpected, "err.ob.server.unavailable")                       // "observer '%s' is not available"
 	ErrObServerStoppedInMultiZone = NewErrorCode("OB.Server.StoppedInMultiZone", illegalArgument, "err.ob.server.stopped.in.multi.zone") // "cannot stop server or stop zone in multiple zones"
+	ErrObServerReplaceSameAgent   = NewErrorCode("OB.Server.Replace.SameAgent", illegalArgument, "err.ob.server.replace.same.agent")     // "cannot replace observer '%s' with itself"
+	ErrObServerReplaceVersion     = NewErrorCode("OB.Server.Replace.Version", illegalArgument, "err.ob.server.replace.version")          // "agent '%s' must be upgraded to the version of cluster agent '%s'(%s) before replacing observer"
 
 	// OB.Parameter
 	ErrObParameterScopeInvalid  = NewErrorCode("OB.Parameter.Scope.Invalid", illegalArgument, "err.ob.parameter.scope.invalid")
@@ -364,6 +400,28 @@ var (
 	ErrSecuritySSOTokenExpired                           = NewErrorCode("Security.SSO.Token.Expired", unauthorized, "err.security.sso.token.expired")
 	ErrSecuritySSOTokenManagerNotReady                   = NewErrorCode("Security.SSO.Token.ManagerNotReady", unexpected, "err.security.sso.token.manager.not.ready")
 
+	// account related
+	ErrAccountNotFound             = NewErrorCode("Account.NotFound", notFound, "err.account.not.found")
+	ErrAccountAlreadyExists        = NewErrorCode("Account.AlreadyExists", illegalArgument, "err.account.already.exists")
+	ErrAccountRoleInvalid          = NewErrorCode("Account.RoleInvalid", illegalArgument, "err.account.role.invalid")
+	ErrAccountPasswordInvalid      = NewErrorCode("Account.PasswordInvalid", illegalArgument, "err.account.password.invalid")
+	ErrAccountAuthenticationFailed = NewErrorCode("Account.AuthenticationFailed", unauthorized, "err.account.authentication.failed", 10008)
+	ErrAccountPermissionDenied     = NewErrorCode("Account.PermissionDenied", forbidden, "err.account.permission.denied")
+	ErrAccountTenantNotAllowed     = NewErrorCode("Account.TenantNotAllowed", forbidden, "err.account.tenant.not.allowed")
+
+	// schedule related
+	ErrScheduleNotFound       = NewErrorCode("Schedule.NotFound", notFound, "err.schedule.not.found")
+	ErrScheduleAlreadyExists  = NewErrorCode("Schedule.AlreadyExists", illegalArgument, "err.schedule.already.exists")
+	ErrScheduleJobTypeInvalid = NewErrorCode("Schedule.JobTypeInvalid", illegalArgument, "err.schedule.job.type.invalid")
+	ErrScheduleTenantRequired = NewErrorCode("Schedule.TenantRequired", illegalArgument, "err.schedule.tenant.required")
+
+	// task webhook related
+	ErrTaskWebhookNotFound      = NewErrorCode("TaskWebhook.NotFound", notFound, "err.task.webhook.not.found")
+	ErrTaskWebhookAlreadyExists = NewErrorCode("TaskWebhook.AlreadyExists", illegalArgument, "err.task.webhook.already.exists")
+	ErrTaskWebhookUrlInvalid    = NewErrorCode("TaskWebhook.UrlInvalid", illegalArgument, "err.task.webhook.url.invalid")
+	ErrTaskWebhookSendFailed    = NewErrorCode("TaskWebhook.SendFailed", unexpected, "err.task.webhook.send.failed")
+	ErrTaskEventTypeInvalid     = NewErrorCode("TaskEvent.TypeInvalid", illegalArgument, "err.task.event.type.invalid")
+
 	// Task
 	ErrTaskExpired                         = NewErrorCode("Task.Expired", known, "err.task.expired")
 	ErrTaskNotFound                        = NewErrorCode("Task.NotFound", notFound, "err.task.not.found")
@@ -465,9 +523,20 @@ var (
 	ErrAlarmQueryFailed                  = NewErrorCode("Alarm.QueryFailed", unexpected, "err.alarm.query.failed")
 	ErrAlarmUnexpectedStatus             = NewErrorCode("Alarm.UnexpectedStatus", unexpected, "err.alarm.unexpected.status")
 	ErrAlarmRuleNotFound                 = NewErrorCode("Alarm.RuleNotFound", notFound, "err.alarm.rule.not.found")
+	ErrAlarmRuleMetricNotFound           = NewErrorCode("Alarm.Rule.MetricNotFound", illegalArgument, "err.alarm.rule.metric.not.found")
+	ErrAlarmRuleQueryMissing             = NewErrorCode("Alarm.Rule.QueryMissing", illegalArgument, "err.alarm.rule.query.missing")
+	ErrAlarmRuleQueryInvalid             = NewErrorCode("Alarm.Rule.QueryInvalid", illegalArgument, "err.alarm.rule.query.invalid")
+	ErrAlarmRuleOperatorNotSupported     = NewErrorCode("Alarm.Rule.OperatorNotSupported", illegalArgument, "err.alarm.rule.operator.not.supported")
+	ErrAlarmRuleReloadFailed             = NewErrorCode("Alarm.Rule.ReloadFailed", unexpected, "err.alarm.rule.reload.failed")
 	ErrAlarmSilencerInstanceTypeMismatch = NewErrorCode("Alarm.Silencer.InstanceTypeMismatch", illegalArgument, "err.alarm.silencer.instance.type.mismatch")
 	ErrAlarmSilencerOBClusterMismatch    = NewErrorCode("Alarm.Silencer.OBClusterMismatch", illegalArgument, "err.alarm.silencer.obcluster.mismatch")
 	ErrAlarmSilencerUnknownInstanceType  = NewErrorCode("Alarm.Silencer.UnknownInstanceType", illegalArgument, "err.alarm.silencer.unknown.instance.type")
+	ErrAlarmChannelNotFound              = NewErrorCode("Alarm.Channel.NotFound", notFound, "err.alarm.channel.not.found")
+	ErrAlarmChannelTypeNotSupported      = NewErrorCode("Alarm.Channel.TypeNotSupported", illegalArgument, "err.alarm.channel.type.not.supported")
+	ErrAlarmChannelConfigMissing         = NewErrorCode("Alarm.Channel.ConfigMissing", illegalArgument, "err.alarm.channel.config.missing")
+	ErrAlarmChannelTemplateInvalid       = NewErrorCode("Alarm.Channel.TemplateInvalid", illegalArgument, "err.alarm.channel.template.invalid")
+	ErrAlarmChannelSendFailed            = NewErrorCode("Alarm.Channel.SendFailed", unexpected, "err.alarm.channel.send.failed")
+	ErrAlarmReceiverTokenInvalid         = NewErrorCode("Alarm.Receiver.TokenInvalid", unauthorized, "err.alarm.receiver.token.invalid")
 
 	// metric related
 	ErrMetricConfigNotFound           = NewErrorCode("Metric.ConfigNotFound", unexpected, "err.metric.config.not.found")
@@ -476,6 +545,8 @@ var (
 	ErrMetricQueryFailed              = NewErrorCode("Metric.QueryFailed", unexpected, "err.metric.query.failed")
 	ErrMetricUnexpectedStatus         = NewErrorCode("Metric.UnexpectedStatus", unexpected, "err.metric.unexpected.status")
 	ErrMetricParseValueFailed         = NewErrorCode("Metric.ParseValueFailed", unexpected, "err.metric.parse.value.failed")
+	ErrMetricCollectionConfigInvalid  = NewErrorCode("Metric.Collection.ConfigInvalid", illegalArgument, "err.metric.collection.config.invalid")
+	ErrMetricCollectionStorageFailed  = NewErrorCode("Metric.Collection.StorageFailed", unexpected, "err.metric.collection.storage.failed")
 
 	// credential related
 	ErrCredentialNotFound               = NewErrorCode("Credential.NotFound", notFound, "err.credential.not.found")                                       // "credential not found"
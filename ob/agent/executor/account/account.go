@@ -0,0 +1,206 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	obmodel "github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+	accountservice "github.com/oceanbase/obshell/ob/agent/service/account"
+	"github.com/oceanbase/obshell/ob/model/account"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+const (
+	passwordMinLength = 8
+	passwordMaxLength = 72 // bcrypt only uses the first 72 bytes
+)
+
+var (
+	accountService = accountservice.AccountService{}
+
+	accountNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_.-]{0,63}$`)
+)
+
+func ListAccounts() ([]account.Account, error) {
+	models, err := accountService.List()
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]account.Account, 0, len(models))
+	for i := range models {
+		accounts = append(accounts, *convertToAccount(&models[i]))
+	}
+	return accounts, nil
+}
+
+func GetAccount(name string) (*account.Account, error) {
+	model, err := getAccountModel(name)
+	if err != nil {
+		return nil, err
+	}
+	return convertToAccount(model), nil
+}
+
+func CreateAccount(p *param.CreateAccountParam) (*account.Account, error) {
+	if !accountNamePattern.MatchString(p.Name) {
+		return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "name",
+			"should start with a letter and only contain letters, digits, '_', '.' and '-', at most 64 characters")
+	}
+	if err := checkRole(p.Role); err != nil {
+		return nil, err
+	}
+	hash, err := hashPassword(p.Password)
+	if err != nil {
+		return nil, err
+	}
+	exist, err := accountService.GetByName(p.Name)
+	if err != nil {
+		return nil, err
+	}
+	if exist != nil {
+		return nil, errors.Occur(errors.ErrAccountAlreadyExists, p.Name)
+	}
+
+	model := &obmodel.AgentAccount{
+		Name:        p.Name,
+		Password:    hash,
+		Role:        p.Role,
+		Tenants:     encodeTenants(p.Tenants),
+		Description: p.Description,
+	}
+	if err := accountService.Create(model); err != nil {
+		return nil, err
+	}
+	return GetAccount(p.Name)
+}
+
+func UpdateAccount(name string, p *param.UpdateAccountParam) (*account.Account, error) {
+	model, err := getAccountModel(name)
+	if err != nil {
+		return nil, err
+	}
+	if p.Role != nil {
+		if err := checkRole(*p.Role); err != nil {
+			return nil, err
+		}
+		model.Role = *p.Role
+	}
+	if p.Tenants != nil {
+		model.Tenants = encodeTenants(*p.Tenants)
+	}
+	if p.Description != nil {
+		model.Description = *p.Description
+	}
+	if err := accountService.Update(model); err != nil {
+		return nil, err
+	}
+	return GetAccount(name)
+}
+
+func ChangeAccountPassword(name string, p *param.ChangeAccountPasswordParam) error {
+	if _, err := getAccountModel(name); err != nil {
+		return err
+	}
+	hash, err := hashPassword(p.Password)
+	if err != nil {
+		return err
+	}
+	return accountService.UpdatePassword(name, hash)
+}
+
+func DeleteAccount(name string) error {
+	if _, err := getAccountModel(name); err != nil {
+		return err
+	}
+	return accountService.Delete(name)
+}
+
+// VerifyAccountPassword checks the password of the account, used for login.
+func VerifyAccountPassword(name, password string) (*account.Account, error) {
+	model, err := accountService.GetByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if model == nil || bcrypt.CompareHashAndPassword([]byte(model.Password), []byte(password)) != nil {
+		return nil, errors.Occur(errors.ErrAccountAuthenticationFailed)
+	}
+	return convertToAccount(model), nil
+}
+
+func getAccountModel(name string) (*obmodel.AgentAccount, error) {
+	model, err := accountService.GetByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if model == nil {
+		return nil, errors.Occur(errors.ErrAccountNotFound, name)
+	}
+	return model, nil
+}
+
+func checkRole(role string) error {
+	roles := make([]string, 0, len(account.AllRoles))
+	for _, r := range account.AllRoles {
+		if string(r) == role {
+			return nil
+		}
+		roles = append(roles, string(r))
+	}
+	return errors.Occur(errors.ErrAccountRoleInvalid, role, strings.Join(roles, ", "))
+}
+
+func hashPassword(password string) (string, error) {
+	if len(password) < passwordMinLength || len(password) > passwordMaxLength {
+		return "", errors.Occur(errors.ErrAccountPasswordInvalid,
+			fmt.Sprintf("the length should be between %d and %d", passwordMinLength, passwordMaxLength))
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", errors.Wrap(err, "hash password failed")
+	}
+	return string(hash), nil
+}
+
+func encodeTenants(tenants []string) string {
+	if len(tenants) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(tenants)
+	return string(data)
+}
+
+func convertToAccount(model *obmodel.AgentAccount) *account.Account {
+	tenants := make([]string, 0)
+	if model.Tenants != "" {
+		_ = json.Unmarshal([]byte(model.Tenants), &tenants)
+	}
+	return &account.Account{
+		Name:        model.Name,
+		Role:        account.Role(model.Role),
+		Tenants:     tenants,
+		Description: model.Description,
+		CreateTime:  model.CreateTime,
+		UpdateTime:  model.UpdateTime,
+	}
+}
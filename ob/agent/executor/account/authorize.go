@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package account
+
+import (
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/model/account"
+)
+
+var (
+	readWrite = []account.Action{account.ActionRead, account.ActionWrite}
+	readOnly  = []account.Action{account.ActionRead}
+)
+
+// rolePermissions defines the actions each role can perform on each resource.
+// The resources not listed are not accessible to the role, so the system resource,
+// which covers the audit log, the external integrations and the task webhooks, is admin only.
+var rolePermissions = map[account.Role]map[account.Resource][]account.Action{
+	account.RoleAdmin: {
+		account.ResourceTenant:     readWrite,
+		account.ResourceBackup:     readWrite,
+		account.ResourceObcluster:  readWrite,
+		account.ResourceAgent:      readWrite,
+		account.ResourceCredential: readWrite,
+		account.ResourceAccount:    readWrite,
+		account.ResourceTask:       readWrite,
+		account.ResourceMonitor:    readWrite,
+		account.ResourceSystem:     readWrite,
+	},
+	account.RoleOperator: {
+		account.ResourceTenant:     readWrite,
+		account.ResourceBackup:     readWrite,
+		account.ResourceObcluster:  readOnly,
+		account.ResourceAgent:      readOnly,
+		account.ResourceCredential: readOnly,
+		account.ResourceTask:       readOnly,
+		account.ResourceMonitor:    readOnly,
+	},
+	account.RoleReadonly: {
+		account.ResourceTenant:    readOnly,
+		account.ResourceBackup:    readOnly,
+		account.ResourceObcluster: readOnly,
+		account.ResourceAgent:     readOnly,
+		account.ResourceTask:      readOnly,
+		account.ResourceMonitor:   readOnly,
+	},
+}
+
+// tenantScopedPermissions limits the accounts scoped to some tenants further,
+// they can only manage the tenants in scope and read the overall information.
+var tenantScopedPermissions = map[account.Resource][]account.Action{
+	account.ResourceTenant:  readWrite,
+	account.ResourceBackup:  readWrite,
+	account.ResourceTask:    readOnly,
+	account.ResourceMonitor: readOnly,
+}
+
+// Authorize checks whether the account can perform the action on the resource.
+// The tenantName is the tenant the request targets, empty if the request is not for a specific tenant.
+func Authorize(acc *account.Account, resource account.Resource, action account.Action, tenantName string) error {
+	if !allowed(rolePermissions[acc.Role][resource], action) {
+		return errors.Occur(errors.ErrAccountPermissionDenied, acc.Name, acc.Role, action, resource)
+	}
+	if !acc.IsTenantScoped() {
+		return nil
+	}
+	if !allowed(tenantScopedPermissions[resource], action) {
+		return errors.Occur(errors.ErrAccountPermissionDenied, acc.Name, acc.Role, action, resource)
+	}
+	if tenantName == "" {
+		// Only the overall information can be read without a specific tenant.
+		if action != account.ActionRead {
+			return errors.Occur(errors.ErrAccountPermissionDenied, acc.Name, acc.Role, action, resource)
+		}
+		return nil
+	}
+	if !acc.HasTenant(tenantName) {
+		return errors.Occur(errors.ErrAccountTenantNotAllowed, acc.Name, tenantName)
+	}
+	return nil
+}
+
+func allowed(actions []account.Action, action account.Action) bool {
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
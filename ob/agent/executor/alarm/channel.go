@@ -0,0 +1,381 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alarm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	alarmconstant "github.com/oceanbase/obshell/ob/agent/executor/alarm/constant"
+	obmodel "github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/ob/agent/secure"
+	alarmservice "github.com/oceanbase/obshell/ob/agent/service/alarm"
+	"github.com/oceanbase/obshell/ob/model/alarm/channel"
+)
+
+var alarmService alarmservice.AlarmService
+
+// channelConfig is the part of the channel stored encrypted, since it may contain secrets.
+type channelConfig struct {
+	Webhook *channel.WebhookConfig `json:"webhook,omitempty"`
+	Email   *channel.EmailConfig   `json:"email,omitempty"`
+	Robot   *channel.RobotConfig   `json:"robot,omitempty"`
+}
+
+func ListChannels(ctx context.Context, filter *channel.ChannelFilter) ([]channel.ChannelResponse, error) {
+	channels, err := alarmService.ListChannels(string(filter.Type), filter.Keyword)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]channel.ChannelResponse, 0, len(channels))
+	for i := range channels {
+		resp, err := convertToChannelResponse(&channels[i])
+		if err != nil {
+			return nil, err
+		}
+		maskChannelSecrets(&resp.Channel)
+		responses = append(responses, *resp)
+	}
+	return responses, nil
+}
+
+func GetChannel(ctx context.Context, name string) (*channel.ChannelResponse, error) {
+	resp, err := getChannel(name)
+	if err != nil {
+		return nil, err
+	}
+	maskChannelSecrets(&resp.Channel)
+	return resp, nil
+}
+
+func CreateOrUpdateChannel(ctx context.Context, param *channel.ChannelParam) (*channel.ChannelResponse, error) {
+	existed, err := alarmService.GetChannelByName(param.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := &param.Channel
+	if existed != nil {
+		old, err := convertToChannelResponse(existed)
+		if err != nil {
+			return nil, err
+		}
+		keepChannelSecrets(ch, &old.Channel)
+	}
+	if err := checkChannel(ch); err != nil {
+		return nil, err
+	}
+
+	model, err := convertToChannelModel(ch)
+	if err != nil {
+		return nil, err
+	}
+	if existed != nil {
+		model.Id = existed.Id
+	}
+	if err := alarmService.SaveChannel(model); err != nil {
+		return nil, errors.Wrap(err, "save alarm channel failed")
+	}
+	return GetChannel(ctx, param.Name)
+}
+
+func DeleteChannel(ctx context.Context, name string) error {
+	existed, err := alarmService.GetChannelByName(name)
+	if err != nil {
+		return err
+	}
+	if existed == nil {
+		return errors.Occur(errors.ErrAlarmChannelNotFound, name)
+	}
+	return alarmService.DeleteChannel(name)
+}
+
+// TestChannel sends a test notification through the channel immediately without retry.
+func TestChannel(ctx context.Context, name string) error {
+	resp, err := getChannel(name)
+	if err != nil {
+		return err
+	}
+	now := time.Now().Format(time.RFC3339)
+	alert := newTestAlert(name, now)
+	return newNotifier(&resp.Channel).notify(ctx, alert, buildNotificationData(alert))
+}
+
+func ListDeliveries(ctx context.Context, filter *channel.DeliveryFilter) ([]channel.Delivery, error) {
+	var startTime, endTime time.Time
+	if filter.StartTime != 0 {
+		startTime = time.Unix(filter.StartTime, 0)
+	}
+	if filter.EndTime != 0 {
+		endTime = time.Unix(filter.EndTime, 0)
+	}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = alarmconstant.DefaultDeliveryListLimit
+	}
+	records, err := alarmService.ListDeliveries(filter.ChannelName, string(filter.Status), startTime, endTime, limit)
+	if err != nil {
+		return nil, err
+	}
+	deliveries := make([]channel.Delivery, 0, len(records))
+	for _, record := range records {
+		deliveries = append(deliveries, channel.Delivery{
+			Id:           record.Id,
+			ChannelName:  record.ChannelName,
+			ChannelType:  channel.ChannelType(record.ChannelType),
+			RuleName:     record.RuleName,
+			Severity:     alarmSeverity(record.Severity),
+			AlertStatus:  record.AlertStatus,
+			Summary:      record.Summary,
+			Status:       channel.DeliveryStatus(record.Status),
+			Attempts:     record.Attempts,
+			ErrorMessage: record.ErrorMessage,
+			CreatedAt:    record.CreateTime.Unix(),
+		})
+	}
+	return deliveries, nil
+}
+
+func getChannel(name string) (*channel.ChannelResponse, error) {
+	model, err := alarmService.GetChannelByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if model == nil {
+		return nil, errors.Occur(errors.ErrAlarmChannelNotFound, name)
+	}
+	return convertToChannelResponse(model)
+}
+
+func checkChannel(ch *channel.Channel) error {
+	switch ch.Type {
+	case channel.TypeWebhook:
+		if ch.Webhook == nil || ch.Webhook.Url == "" {
+			return errors.Occur(errors.ErrAlarmChannelConfigMissing, "webhook.url", ch.Type)
+		}
+		if ch.Webhook.Method == "" {
+			ch.Webhook.Method = http.MethodPost
+		}
+		ch.Webhook.Method = strings.ToUpper(ch.Webhook.Method)
+		if err := checkTemplates(ch.Name, ch.Webhook.BodyTemplate); err != nil {
+			return err
+		}
+	case channel.TypeEmail:
+		if ch.Email == nil || ch.Email.SmtpHost == "" || ch.Email.SmtpPort == 0 {
+			return errors.Occur(errors.ErrAlarmChannelConfigMissing, "email.smtp_host and email.smtp_port", ch.Type)
+		}
+		if ch.Email.From == "" || len(ch.Email.To) == 0 {
+			return errors.Occur(errors.ErrAlarmChannelConfigMissing, "email.from and email.to", ch.Type)
+		}
+		if err := checkTemplates(ch.Name, ch.Email.SubjectTemplate, ch.Email.BodyTemplate); err != nil {
+			return err
+		}
+	case channel.TypeDingTalk, channel.TypeFeishu, channel.TypeWeCom, channel.TypeSlack:
+		if ch.Robot == nil || ch.Robot.Url == "" {
+			return errors.Occur(errors.ErrAlarmChannelConfigMissing, "robot.url", ch.Type)
+		}
+		if err := checkTemplates(ch.Name, ch.Robot.TextTemplate); err != nil {
+			return err
+		}
+	default:
+		types := make([]string, 0, len(channel.AllChannelTypes))
+		for _, t := range channel.AllChannelTypes {
+			types = append(types, string(t))
+		}
+		return errors.Occur(errors.ErrAlarmChannelTypeNotSupported, ch.Type, strings.Join(types, ", "))
+	}
+
+	for i := range ch.Route.Matchers {
+		if _, err := ch.Route.Matchers[i].ToAmMatcher(); err != nil {
+			return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "route.matchers", err.Error())
+		}
+	}
+	if ch.Retry.MaxRetries < 0 || ch.Retry.MaxRetries > alarmconstant.MaxRetries {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "retry.max_retries", fmt.Sprintf("should be in [0, %d]", alarmconstant.MaxRetries))
+	}
+	if ch.Retry.BackoffSeconds <= 0 {
+		ch.Retry.BackoffSeconds = alarmconstant.DefaultRetryBackoffSeconds
+	}
+	return nil
+}
+
+func checkTemplates(channelName string, templates ...string) error {
+	for _, text := range templates {
+		if text == "" {
+			continue
+		}
+		if _, err := template.New(channelName).Funcs(templateFuncs).Parse(text); err != nil {
+			return errors.Occur(errors.ErrAlarmChannelTemplateInvalid, channelName, err.Error())
+		}
+	}
+	return nil
+}
+
+// keepChannelSecrets keeps the stored secrets if they are not specified or masked when updating the channel.
+func keepChannelSecrets(ch *channel.Channel, old *channel.Channel) {
+	if ch.Type != old.Type {
+		return
+	}
+	isUnchanged := func(secret string) bool {
+		return secret == "" || secret == alarmconstant.MaskedSecret
+	}
+	if ch.Email != nil && old.Email != nil && isUnchanged(ch.Email.Password) {
+		ch.Email.Password = old.Email.Password
+	}
+	if ch.Robot != nil && old.Robot != nil {
+		if isUnchanged(ch.Robot.Secret) {
+			ch.Robot.Secret = old.Robot.Secret
+		}
+		if ch.Robot.Url == maskRobotUrl(old.Robot.Url) {
+			ch.Robot.Url = old.Robot.Url
+		}
+	}
+	if ch.Webhook != nil && old.Webhook != nil {
+		if ch.Webhook.Url == maskWebhookUrl(old.Webhook.Url) {
+			ch.Webhook.Url = old.Webhook.Url
+		}
+		for k, v := range ch.Webhook.Headers {
+			if v == alarmconstant.MaskedSecret {
+				ch.Webhook.Headers[k] = old.Webhook.Headers[k]
+			}
+		}
+	}
+}
+
+func maskChannelSecrets(ch *channel.Channel) {
+	if ch.Email != nil && ch.Email.Password != "" {
+		ch.Email.Password = alarmconstant.MaskedSecret
+	}
+	if ch.Robot != nil {
+		if ch.Robot.Secret != "" {
+			ch.Robot.Secret = alarmconstant.MaskedSecret
+		}
+		ch.Robot.Url = maskRobotUrl(ch.Robot.Url)
+	}
+	if ch.Webhook != nil {
+		ch.Webhook.Url = maskWebhookUrl(ch.Webhook.Url)
+		// Headers usually carry the authorization of the endpoint.
+		for k := range ch.Webhook.Headers {
+			ch.Webhook.Headers[k] = alarmconstant.MaskedSecret
+		}
+	}
+}
+
+// maskRobotUrl masks the path and query of the robot url,
+// the token of DingTalk and WeCom is in the query, while the one of Feishu and Slack is in the path.
+func maskRobotUrl(rawUrl string) string {
+	u, err := url.Parse(rawUrl)
+	if err != nil || u.Host == "" {
+		return alarmconstant.MaskedSecret
+	}
+	return fmt.Sprintf("%s://%s/%s", u.Scheme, u.Host, alarmconstant.MaskedSecret)
+}
+
+// maskWebhookUrl masks the query values and the user info of the webhook url, which may carry the access token.
+func maskWebhookUrl(rawUrl string) string {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return alarmconstant.MaskedSecret
+	}
+	if u.User != nil {
+		u.User = url.User(alarmconstant.MaskedSecret)
+	}
+	if u.RawQuery != "" {
+		pairs := strings.Split(u.RawQuery, "&")
+		for i, pair := range pairs {
+			key, _, _ := strings.Cut(pair, "=")
+			pairs[i] = key + "=" + alarmconstant.MaskedSecret
+		}
+		u.RawQuery = strings.Join(pairs, "&")
+	}
+	return u.String()
+}
+
+func convertToChannelModel(ch *channel.Channel) (*obmodel.AlarmChannel, error) {
+	route, err := json.Marshal(ch.Route)
+	if err != nil {
+		return nil, errors.Occur(errors.ErrJsonMarshal, err.Error())
+	}
+	retry, err := json.Marshal(ch.Retry)
+	if err != nil {
+		return nil, errors.Occur(errors.ErrJsonMarshal, err.Error())
+	}
+	config, err := json.Marshal(channelConfig{
+		Webhook: ch.Webhook,
+		Email:   ch.Email,
+		Robot:   ch.Robot,
+	})
+	if err != nil {
+		return nil, errors.Occur(errors.ErrJsonMarshal, err.Error())
+	}
+	encryptedConfig, err := secure.EncryptCredentialPassphrase(string(config))
+	if err != nil {
+		return nil, errors.Wrap(err, "encrypt alarm channel config failed")
+	}
+	return &obmodel.AlarmChannel{
+		Name:        ch.Name,
+		Type:        string(ch.Type),
+		Description: ch.Description,
+		Enabled:     ch.Enabled,
+		Route:       string(route),
+		Retry:       string(retry),
+		Config:      encryptedConfig,
+	}, nil
+}
+
+func convertToChannelResponse(model *obmodel.AlarmChannel) (*channel.ChannelResponse, error) {
+	resp := &channel.ChannelResponse{
+		Id:        model.Id,
+		CreatedAt: model.CreateTime.Unix(),
+		UpdatedAt: model.UpdateTime.Unix(),
+		Channel: channel.Channel{
+			Name:        model.Name,
+			Type:        channel.ChannelType(model.Type),
+			Description: model.Description,
+			Enabled:     model.Enabled,
+		},
+	}
+	if model.Route != "" {
+		if err := json.Unmarshal([]byte(model.Route), &resp.Route); err != nil {
+			return nil, errors.Occur(errors.ErrJsonUnmarshal, err.Error())
+		}
+	}
+	if model.Retry != "" {
+		if err := json.Unmarshal([]byte(model.Retry), &resp.Retry); err != nil {
+			return nil, errors.Occur(errors.ErrJsonUnmarshal, err.Error())
+		}
+	}
+	config, err := secure.DecryptCredentialPassphrase(model.Config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decrypt config of alarm channel %s failed", model.Name)
+	}
+	var cfg channelConfig
+	if err := json.Unmarshal([]byte(config), &cfg); err != nil {
+		return nil, errors.Occur(errors.ErrJsonUnmarshal, err.Error())
+	}
+	resp.Webhook = cfg.Webhook
+	resp.Email = cfg.Email
+	resp.Robot = cfg.Robot
+	return resp, nil
+}
@@ -50,9 +50,22 @@ const (
 )
 
 const (
-	OBRuleGroupName = "ob-rule"
+	OBRuleGroupName     = "ob-rule"
+	DefaultRuleInterval = 60 // seconds, used to replace @INTERVAL in the rule template
 )
 
 const (
 	RegexOR = "|"
 )
+
+const (
+	ReceiverTokenConfigKey = "alarm_receiver_token"
+	ReceiverAuthScheme     = "Bearer "
+
+	DefaultNotifyTimeout       = 10 // seconds
+	DefaultRetryBackoffSeconds = 5
+	MaxRetries                 = 10
+	DefaultDeliveryListLimit   = 100
+	DeliveryRetentionDays      = 30
+	MaskedSecret               = "******"
+)
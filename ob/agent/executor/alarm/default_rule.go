@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alarm
+
+import (
+	alarmconstant "github.com/oceanbase/obshell/ob/agent/executor/alarm/constant"
+	"github.com/oceanbase/obshell/ob/model/alarm"
+	"github.com/oceanbase/obshell/ob/model/alarm/rule"
+	"github.com/oceanbase/obshell/ob/model/oceanbase"
+)
+
+// defaultRules are the built-in rules of OceanBase, they are disabled until turned on,
+// and only match the metrics of the cluster managed by the agent.
+var defaultRules = []rule.RuleParam{
+	{
+		Name:         "ob_data_disk_full",
+		InstanceType: oceanbase.TypeOBServer,
+		Template: &rule.RuleTemplate{
+			Metric:      "ob_data_disk_usage_percent",
+			GroupLabels: []string{alarmconstant.LabelOBCluster, alarmconstant.LabelOBServer},
+			Operator:    rule.OperatorGE,
+			Threshold:   90,
+		},
+		Duration:    300,
+		Severity:    alarm.SeverityCritical,
+		Summary:     "Data disk of observer {{ $labels.svr_ip }} is almost full",
+		Description: "Data disk usage of observer {{ $labels.svr_ip }} in cluster {{ $labels.ob_cluster_name }} is {{ $value | printf \"%.2f\" }}%",
+	},
+	{
+		Name:         "ob_clog_sync_lag",
+		InstanceType: oceanbase.TypeOBTenant,
+		Template: &rule.RuleTemplate{
+			Metric:      "sync_delay_time",
+			GroupLabels: []string{alarmconstant.LabelOBCluster, alarmconstant.LabelOBTenant},
+			Operator:    rule.OperatorGT,
+			Threshold:   60,
+		},
+		Duration:    300,
+		Severity:    alarm.SeverityWarning,
+		Summary:     "Clog synchronization of tenant {{ $labels.tenant_name }} lags behind",
+		Description: "Clog synchronization delay of tenant {{ $labels.tenant_name }} in cluster {{ $labels.ob_cluster_name }} is {{ $value }} seconds",
+	},
+	{
+		Name:         "ob_backup_rpo_violation",
+		InstanceType: oceanbase.TypeOBTenant,
+		Template: &rule.RuleTemplate{
+			Metric:      "ob_backup_compliant",
+			GroupLabels: []string{alarmconstant.LabelOBCluster, alarmconstant.LabelOBTenant},
+			Operator:    rule.OperatorLT,
+			Threshold:   1,
+		},
+		Severity:    alarm.SeverityCritical,
+		Summary:     "Backup of tenant {{ $labels.tenant_name }} violates the RPO target",
+		Description: "Backup of tenant {{ $labels.tenant_name }} in cluster {{ $labels.ob_cluster_name }} violates the RPO or backup frequency target, check the backup compliance for details",
+	},
+	{
+		Name:         "ob_compaction_error",
+		InstanceType: oceanbase.TypeOBZone,
+		Template: &rule.RuleTemplate{
+			Metric:      "compaction_error",
+			GroupLabels: []string{alarmconstant.LabelOBCluster, alarmconstant.LabelOBZone},
+			Operator:    rule.OperatorGT,
+			Threshold:   0,
+		},
+		Severity:    alarm.SeverityCritical,
+		Summary:     "Compaction error occurred in zone {{ $labels.obzone }}",
+		Description: "Compaction of zone {{ $labels.obzone }} in cluster {{ $labels.ob_cluster_name }} failed",
+	},
+	{
+		Name:         "ob_observer_down",
+		InstanceType: oceanbase.TypeOBServer,
+		Template: &rule.RuleTemplate{
+			// The process is down when it is reported as not existing, or when the series which was
+			// reported in the interval disappears, e.g. the host or the agent of the observer is down.
+			Metric:      "observer_process_down",
+			GroupLabels: []string{alarmconstant.LabelOBCluster, alarmconstant.LabelOBServer},
+			Interval:    3600,
+			Operator:    rule.OperatorGT,
+			Threshold:   0,
+		},
+		Duration:    60,
+		Severity:    alarm.SeverityCritical,
+		Summary:     "Observer {{ $labels.svr_ip }} is down",
+		Description: "Observer process on {{ $labels.svr_ip }} in cluster {{ $labels.ob_cluster_name }} does not exist or is no longer reported",
+	},
+}
+
+func getDefaultRule(name string) *rule.RuleParam {
+	for i := range defaultRules {
+		if defaultRules[i].Name == name {
+			return &defaultRules[i]
+		}
+	}
+	return nil
+}
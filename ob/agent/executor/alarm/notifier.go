@@ -0,0 +1,348 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alarm
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	alarmconstant "github.com/oceanbase/obshell/ob/agent/executor/alarm/constant"
+	"github.com/oceanbase/obshell/ob/model/alarm"
+	"github.com/oceanbase/obshell/ob/model/alarm/channel"
+	"github.com/oceanbase/obshell/ob/model/alarm/payload"
+)
+
+const (
+	defaultTextTemplate = `[{{ .Status | upper }}][{{ .Severity }}] {{ .RuleName }}
+{{ .Summary }}
+{{ .Description }}
+Starts at: {{ .StartsAt }}{{ if .EndsAt }}
+Ends at: {{ .EndsAt }}{{ end }}`
+	defaultSubjectTemplate = `[{{ .Status | upper }}][{{ .Severity }}] {{ .RuleName }}: {{ .Summary }}`
+)
+
+var templateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// notificationData is the data used to render the templates of channels.
+type notificationData struct {
+	Status       string
+	Severity     string
+	RuleName     string
+	Summary      string
+	Description  string
+	StartsAt     string
+	EndsAt       string
+	GeneratorURL string
+	Labels       map[string]string
+	Annotations  map[string]string
+}
+
+func buildNotificationData(alert *payload.Alert) *notificationData {
+	data := &notificationData{
+		Status:       alert.Status,
+		Severity:     alert.Labels[alarmconstant.LabelSeverity],
+		RuleName:     alert.Labels[alarmconstant.LabelRuleName],
+		Summary:      alert.Annotations[alarmconstant.AnnoSummary],
+		Description:  alert.Annotations[alarmconstant.AnnoDescription],
+		StartsAt:     alert.StartsAt,
+		GeneratorURL: alert.GeneratorURL,
+		Labels:       alert.Labels,
+		Annotations:  alert.Annotations,
+	}
+	// Alertmanager sets the zero time as the end time of firing alerts.
+	if endsAt, err := time.Parse(time.RFC3339, alert.EndsAt); err == nil && endsAt.Year() > 1 {
+		data.EndsAt = alert.EndsAt
+	}
+	if data.RuleName == "" {
+		data.RuleName = alert.Labels["alertname"]
+	}
+	return data
+}
+
+func newTestAlert(channelName, now string) *payload.Alert {
+	return &payload.Alert{
+		Status: "firing",
+		Labels: map[string]string{
+			alarmconstant.LabelRuleName: "test",
+			alarmconstant.LabelSeverity: string(alarm.SeverityInfo),
+		},
+		Annotations: map[string]string{
+			alarmconstant.AnnoSummary:     "Test notification",
+			alarmconstant.AnnoDescription: fmt.Sprintf("This is a test notification sent through alarm channel %s", channelName),
+		},
+		StartsAt: now,
+	}
+}
+
+func alarmSeverity(severity string) alarm.Severity {
+	return alarm.Severity(severity)
+}
+
+type notifier struct {
+	channel *channel.Channel
+	client  *resty.Client
+}
+
+func newNotifier(ch *channel.Channel) *notifier {
+	return &notifier{
+		channel: ch,
+		client:  resty.New().SetTimeout(alarmconstant.DefaultNotifyTimeout * time.Second),
+	}
+}
+
+func (n *notifier) render(text, defaultText string, data interface{}) (string, error) {
+	if text == "" {
+		text = defaultText
+	}
+	tmpl, err := template.New(n.channel.Name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return "", errors.Occur(errors.ErrAlarmChannelTemplateInvalid, n.channel.Name, err.Error())
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", errors.Occur(errors.ErrAlarmChannelTemplateInvalid, n.channel.Name, err.Error())
+	}
+	return buf.String(), nil
+}
+
+// notify sends the alert through the channel once.
+func (n *notifier) notify(ctx context.Context, alert *payload.Alert, data *notificationData) error {
+	var err error
+	switch n.channel.Type {
+	case channel.TypeWebhook:
+		err = n.sendWebhook(ctx, alert, data)
+	case channel.TypeEmail:
+		err = n.sendEmail(data)
+	case channel.TypeDingTalk, channel.TypeFeishu, channel.TypeWeCom, channel.TypeSlack:
+		err = n.sendRobot(ctx, data)
+	default:
+		return errors.Occur(errors.ErrAlarmChannelTypeNotSupported, n.channel.Type, "")
+	}
+	if err != nil {
+		return errors.Occur(errors.ErrAlarmChannelSendFailed, n.channel.Name, err.Error())
+	}
+	return nil
+}
+
+func (n *notifier) sendWebhook(ctx context.Context, alert *payload.Alert, data *notificationData) error {
+	cfg := n.channel.Webhook
+	var body string
+	if cfg.BodyTemplate == "" {
+		// Keep the format of the Alertmanager webhook.
+		content, err := json.Marshal(payload.WebhookPayload{
+			Version:           "4",
+			Status:            alert.Status,
+			Receiver:          n.channel.Name,
+			CommonLabels:      alert.Labels,
+			CommonAnnotations: alert.Annotations,
+			Alerts:            []payload.Alert{*alert},
+		})
+		if err != nil {
+			return err
+		}
+		body = string(content)
+	} else {
+		var err error
+		if body, err = n.render(cfg.BodyTemplate, "", data); err != nil {
+			return err
+		}
+	}
+
+	req := n.client.R().SetContext(ctx).SetHeader("Content-Type", "application/json").SetBody(body)
+	for k, v := range cfg.Headers {
+		req.SetHeader(k, v)
+	}
+	resp, err := req.Execute(cfg.Method, cfg.Url)
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode(), resp.String())
+	}
+	return nil
+}
+
+func (n *notifier) sendRobot(ctx context.Context, data *notificationData) error {
+	cfg := n.channel.Robot
+	text, err := n.render(cfg.TextTemplate, defaultTextTemplate, data)
+	if err != nil {
+		return err
+	}
+
+	reqUrl := cfg.Url
+	var body map[string]interface{}
+	switch n.channel.Type {
+	case channel.TypeDingTalk:
+		body = map[string]interface{}{
+			"msgtype": "markdown",
+			"markdown": map[string]string{
+				"title": data.Summary,
+				"text":  text,
+			},
+		}
+		if cfg.Secret != "" {
+			timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+			sign := hmacSha256Base64([]byte(cfg.Secret), timestamp+"\n"+cfg.Secret)
+			reqUrl = fmt.Sprintf("%s&timestamp=%s&sign=%s", reqUrl, timestamp, url.QueryEscape(sign))
+		}
+	case channel.TypeFeishu:
+		body = map[string]interface{}{
+			"msg_type": "text",
+			"content":  map[string]string{"text": text},
+		}
+		if cfg.Secret != "" {
+			timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+			body["timestamp"] = timestamp
+			body["sign"] = hmacSha256Base64([]byte(timestamp+"\n"+cfg.Secret), "")
+		}
+	case channel.TypeWeCom:
+		body = map[string]interface{}{
+			"msgtype":  "markdown",
+			"markdown": map[string]string{"content": text},
+		}
+	default:
+		body = map[string]interface{}{"text": text}
+	}
+
+	resp, err := n.client.R().SetContext(ctx).SetHeader("Content-Type", "application/json").SetBody(body).Post(reqUrl)
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode(), resp.String())
+	}
+
+	// DingTalk, Feishu and WeCom respond 200 with an error code in the body.
+	var result struct {
+		ErrCode *int   `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+		Code    *int   `json:"code"`
+		Msg     string `json:"msg"`
+	}
+	if json.Unmarshal(resp.Body(), &result) == nil {
+		if result.ErrCode != nil && *result.ErrCode != 0 {
+			return fmt.Errorf("errcode %d: %s", *result.ErrCode, result.ErrMsg)
+		}
+		if result.Code != nil && *result.Code != 0 {
+			return fmt.Errorf("code %d: %s", *result.Code, result.Msg)
+		}
+	}
+	return nil
+}
+
+func hmacSha256Base64(key []byte, message string) string {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(message))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+func (n *notifier) sendEmail(data *notificationData) error {
+	cfg := n.channel.Email
+	subject, err := n.render(cfg.SubjectTemplate, defaultSubjectTemplate, data)
+	if err != nil {
+		return err
+	}
+	content, err := n.render(cfg.BodyTemplate, defaultTextTemplate, data)
+	if err != nil {
+		return err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", cfg.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(cfg.To, ","))
+	fmt.Fprintf(&msg, "Subject: =?UTF-8?B?%s?=\r\n", base64.StdEncoding.EncodeToString([]byte(strings.ReplaceAll(subject, "\n", " "))))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+	msg.WriteString(base64.StdEncoding.EncodeToString([]byte(content)))
+
+	addr := net.JoinHostPort(cfg.SmtpHost, strconv.Itoa(cfg.SmtpPort))
+	timeout := alarmconstant.DefaultNotifyTimeout * time.Second
+	var conn net.Conn
+	if cfg.UseTLS {
+		conn, err = tls.DialWithDialer(&net.Dialer{Timeout: timeout}, "tcp", addr, &tls.Config{ServerName: cfg.SmtpHost})
+	} else {
+		conn, err = net.DialTimeout("tcp", addr, timeout)
+	}
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	client, err := smtp.NewClient(conn, cfg.SmtpHost)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if !cfg.UseTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(&tls.Config{ServerName: cfg.SmtpHost}); err != nil {
+				return err
+			}
+		}
+	}
+	if cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.SmtpHost)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(cfg.From); err != nil {
+		return err
+	}
+	for _, to := range cfg.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg.Bytes()); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -19,16 +19,35 @@ package alarm
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 
+	"github.com/oceanbase/obshell/ob/agent/constant"
 	errors "github.com/oceanbase/obshell/ob/agent/errors"
 	alarmconstant "github.com/oceanbase/obshell/ob/agent/executor/alarm/constant"
 	"github.com/oceanbase/obshell/ob/agent/executor/external"
+	"github.com/oceanbase/obshell/ob/agent/executor/metric"
+	"github.com/oceanbase/obshell/ob/agent/lib/path"
+	obmodel "github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/ob/agent/service/obcluster"
 	"github.com/oceanbase/obshell/ob/model/alarm/rule"
+	"github.com/oceanbase/obshell/ob/model/common"
+	"github.com/oceanbase/obshell/ob/model/oceanbase"
 
+	"github.com/prometheus/prometheus/promql/parser"
 	promv1 "github.com/prometheus/prometheus/web/api/v1"
 	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	observerService = obcluster.ObserverService{}
+	ruleFileLock    sync.Mutex
 )
 
 func GetRule(ctx context.Context, name string) (*rule.RuleResponse, error) {
@@ -44,7 +63,52 @@ func GetRule(ctx context.Context, name string) (*rule.RuleResponse, error) {
 	return nil, errors.Occur(errors.ErrAlarmRuleNotFound, name)
 }
 
+// ListRules lists the rules managed by obshell together with the other rules loaded by Prometheus.
+// The state of the managed rules is filled from Prometheus if it is available.
 func ListRules(ctx context.Context, filter *rule.RuleFilter) ([]rule.RuleResponse, error) {
+	managedRules, err := listManagedRules()
+	if err != nil {
+		return nil, err
+	}
+	promRules, err := listPrometheusRules(ctx)
+	if err != nil {
+		if len(managedRules) == 0 {
+			return nil, err
+		}
+		log.WithError(err).Warn("Query rules from prometheus failed, list the managed rules only")
+	}
+
+	promRuleMap := make(map[string]*rule.RuleResponse, len(promRules))
+	for i := range promRules {
+		promRuleMap[promRules[i].Name] = &promRules[i]
+	}
+	filteredRules := make([]rule.RuleResponse, 0)
+	for i := range managedRules {
+		ruleResp := &managedRules[i]
+		if promRule, ok := promRuleMap[ruleResp.Name]; ok {
+			if ruleResp.Enabled {
+				ruleResp.State = promRule.State
+				ruleResp.KeepFiringFor = promRule.KeepFiringFor
+				ruleResp.Health = promRule.Health
+				ruleResp.LastEvaluation = promRule.LastEvaluation
+				ruleResp.EvaluationTime = promRule.EvaluationTime
+				ruleResp.LastError = promRule.LastError
+			}
+			delete(promRuleMap, ruleResp.Name)
+		}
+		if filterRule(ruleResp, filter) {
+			filteredRules = append(filteredRules, *ruleResp)
+		}
+	}
+	for i := range promRules {
+		if _, ok := promRuleMap[promRules[i].Name]; ok && filterRule(&promRules[i], filter) {
+			filteredRules = append(filteredRules, promRules[i])
+		}
+	}
+	return filteredRules, nil
+}
+
+func listPrometheusRules(ctx context.Context) ([]rule.RuleResponse, error) {
 	promRuleResponse := &rule.PromRuleResponse{}
 	client, err := external.GetPrometheusClientFromConfig()
 	if err != nil {
@@ -57,7 +121,7 @@ func ListRules(ctx context.Context, filter *rule.RuleFilter) ([]rule.RuleRespons
 		return nil, errors.Occur(errors.ErrAlarmUnexpectedStatus, resp.StatusCode())
 	}
 	log.Debugf("Response from prometheus: %v", resp)
-	filteredRules := make([]rule.RuleResponse, 0)
+	rules := make([]rule.RuleResponse, 0)
 	for _, ruleGroup := range promRuleResponse.Data.RuleGroups {
 		for _, promRule := range ruleGroup.Rules {
 			encodedPromRule, err := json.Marshal(promRule)
@@ -74,12 +138,10 @@ func ListRules(ctx context.Context, filter *rule.RuleFilter) ([]rule.RuleRespons
 			}
 			ruleResp := rule.NewRuleResponse(alertingRule)
 			log.Debugf("Parsed prometheus rule: %v", ruleResp)
-			if filterRule(ruleResp, filter) {
-				filteredRules = append(filteredRules, *ruleResp)
-			}
+			rules = append(rules, *ruleResp)
 		}
 	}
-	return filteredRules, nil
+	return rules, nil
 }
 
 func filterRule(rule *rule.RuleResponse, filter *rule.RuleFilter) bool {
@@ -94,6 +156,305 @@ func filterRule(rule *rule.RuleResponse, filter *rule.RuleFilter) bool {
 		if filter.Severity != "" {
 			matched = matched && (rule.Severity == filter.Severity)
 		}
+		if filter.Type != "" {
+			matched = matched && (rule.Type == filter.Type)
+		}
 	}
 	return matched
 }
+
+// CreateOrUpdateRule saves the rule, then renders the rule file and reloads Prometheus.
+// Saving a built-in rule overrides its default definition.
+func CreateOrUpdateRule(ctx context.Context, param *rule.RuleParam) (*rule.RuleResponse, error) {
+	ruleType := rule.RuleTypeCustomized
+	if getDefaultRule(param.Name) != nil {
+		ruleType = rule.RuleTypeBuiltIn
+	}
+	if _, err := renderRuleQuery(param, ruleType, ""); err != nil {
+		return nil, err
+	}
+	model, err := convertToRuleModel(param, ruleType)
+	if err != nil {
+		return nil, err
+	}
+	existed, err := alarmService.GetStoredRuleByName(param.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existed != nil {
+		model.Id = existed.Id
+	}
+	if err := alarmService.SaveRule(model); err != nil {
+		return nil, errors.Wrap(err, "save alarm rule failed")
+	}
+	if err := SyncRules(ctx); err != nil {
+		return nil, err
+	}
+	return GetRule(ctx, param.Name)
+}
+
+// DeleteRule deletes the customized rule, or resets the built-in rule to its default definition.
+func DeleteRule(ctx context.Context, name string) error {
+	existed, err := alarmService.GetStoredRuleByName(name)
+	if err != nil {
+		return err
+	}
+	if existed == nil {
+		if getDefaultRule(name) != nil {
+			return nil
+		}
+		return errors.Occur(errors.ErrAlarmRuleNotFound, name)
+	}
+	if err := alarmService.DeleteRule(name); err != nil {
+		return errors.Wrap(err, "delete alarm rule failed")
+	}
+	return SyncRules(ctx)
+}
+
+func EnableRule(ctx context.Context, name string) error {
+	return setRuleEnabled(ctx, name, true)
+}
+
+func DisableRule(ctx context.Context, name string) error {
+	return setRuleEnabled(ctx, name, false)
+}
+
+func setRuleEnabled(ctx context.Context, name string, enabled bool) error {
+	existed, err := alarmService.GetStoredRuleByName(name)
+	if err != nil {
+		return err
+	}
+	if existed == nil {
+		defaultRule := getDefaultRule(name)
+		if defaultRule == nil {
+			return errors.Occur(errors.ErrAlarmRuleNotFound, name)
+		}
+		if existed, err = convertToRuleModel(defaultRule, rule.RuleTypeBuiltIn); err != nil {
+			return err
+		}
+	}
+	existed.Enabled = enabled
+	if err := alarmService.SaveRule(existed); err != nil {
+		return errors.Wrap(err, "save alarm rule failed")
+	}
+	return SyncRules(ctx)
+}
+
+// SyncRules renders the enabled rules to the rule file and reloads Prometheus.
+func SyncRules(ctx context.Context) error {
+	ruleFileLock.Lock()
+	defer ruleFileLock.Unlock()
+
+	cfg, err := external.GetPrometheusConfig(ctx)
+	if err != nil {
+		return err
+	}
+	managedRules, err := listManagedRules()
+	if err != nil {
+		return err
+	}
+	group := rule.ConfigRuleGroup{Name: alarmconstant.OBRuleGroupName}
+	for i := range managedRules {
+		if managedRules[i].Enabled {
+			group.Rules = append(group.Rules, *managedRules[i].ToPromRule())
+		}
+	}
+	content, err := yaml.Marshal(rule.ConfigRuleGroups{Groups: []rule.ConfigRuleGroup{group}})
+	if err != nil {
+		return errors.Wrap(err, "marshal alarm rules failed")
+	}
+
+	ruleFile := cfg.RuleFile
+	if ruleFile == "" {
+		ruleFile = path.ObshellAlertRulePath()
+	}
+	if err := writeRuleFile(ruleFile, content); err != nil {
+		return errors.Wrapf(err, "write rule file %s failed", ruleFile)
+	}
+	log.Infof("Rendered %d alarm rules to %s", len(group.Rules), ruleFile)
+
+	client, err := external.GetPrometheusClientFromConfig()
+	if err != nil {
+		return errors.WrapRetain(errors.ErrAlarmClientFailed, err)
+	}
+	resp, err := client.R().SetContext(ctx).Post(alarmconstant.PrometheusReloadUrl)
+	if err != nil {
+		return errors.Occur(errors.ErrAlarmRuleReloadFailed, err.Error())
+	} else if resp.StatusCode() != http.StatusOK {
+		return errors.Occur(errors.ErrAlarmRuleReloadFailed, fmt.Sprintf("status %d: %s", resp.StatusCode(), resp.String()))
+	}
+	return nil
+}
+
+// writeRuleFile replaces the rule file atomically, so that Prometheus never loads a partial file.
+func writeRuleFile(ruleFile string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(ruleFile), 0755); err != nil {
+		return err
+	}
+	tmpFile := ruleFile + ".tmp"
+	if err := os.WriteFile(tmpFile, content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, ruleFile)
+}
+
+// listManagedRules lists the stored rules and the built-in rules which are not stored yet.
+func listManagedRules() ([]rule.RuleResponse, error) {
+	models, err := alarmService.ListStoredRules()
+	if err != nil {
+		return nil, err
+	}
+	clusterName, err := observerService.GetOBStringParatemerByName(constant.OB_PARAM_CLUSTER_NAME)
+	if err != nil {
+		log.WithError(err).Warn("Get cluster name failed, built-in rules will not be limited to the cluster")
+	}
+
+	stored := make(map[string]bool, len(models))
+	rules := make([]rule.RuleResponse, 0, len(models)+len(defaultRules))
+	for i := range models {
+		param, err := convertToRuleParam(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		stored[param.Name] = true
+		ruleResp, err := newManagedRuleResponse(param, rule.RuleType(models[i].Type), clusterName)
+		if err != nil {
+			log.WithError(err).Errorf("Render alarm rule %s failed", param.Name)
+			continue
+		}
+		rules = append(rules, *ruleResp)
+	}
+	for i := range defaultRules {
+		if stored[defaultRules[i].Name] {
+			continue
+		}
+		ruleResp, err := newManagedRuleResponse(&defaultRules[i], rule.RuleTypeBuiltIn, clusterName)
+		if err != nil {
+			log.WithError(err).Errorf("Render alarm rule %s failed", defaultRules[i].Name)
+			continue
+		}
+		rules = append(rules, *ruleResp)
+	}
+	return rules, nil
+}
+
+func newManagedRuleResponse(param *rule.RuleParam, ruleType rule.RuleType, clusterName string) (*rule.RuleResponse, error) {
+	query, err := renderRuleQuery(param, ruleType, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return &rule.RuleResponse{
+		Enabled:  param.Enabled,
+		Template: param.Template,
+		State:    rule.StateInactive,
+		Health:   rule.HealthUnknown,
+		Rule: rule.Rule{
+			Name:         param.Name,
+			InstanceType: param.InstanceType,
+			Type:         ruleType,
+			Query:        query,
+			Duration:     param.Duration,
+			Labels:       param.Labels,
+			Severity:     param.Severity,
+			Summary:      param.Summary,
+			Description:  param.Description,
+		},
+	}, nil
+}
+
+// renderRuleQuery renders the query of the rule from its template, the built-in rules are limited to the cluster.
+func renderRuleQuery(param *rule.RuleParam, ruleType rule.RuleType, clusterName string) (string, error) {
+	var query string
+	if param.Template != nil {
+		tmpl := param.Template
+		if !isOperatorSupported(tmpl.Operator) {
+			operators := make([]string, 0, len(rule.AllOperators))
+			for _, op := range rule.AllOperators {
+				operators = append(operators, string(op))
+			}
+			return "", errors.Occur(errors.ErrAlarmRuleOperatorNotSupported, tmpl.Operator, strings.Join(operators, ", "))
+		}
+		filters := tmpl.Filters
+		if ruleType == rule.RuleTypeBuiltIn && clusterName != "" {
+			filters = append([]common.KVPair{{Key: alarmconstant.LabelOBCluster, Value: clusterName}}, filters...)
+		}
+		interval := int64(tmpl.Interval)
+		if interval <= 0 {
+			interval = alarmconstant.DefaultRuleInterval
+		}
+		expr, ok := metric.RenderMetricExpr(tmpl.Metric, filters, tmpl.GroupLabels, interval)
+		if !ok {
+			return "", errors.Occur(errors.ErrAlarmRuleMetricNotFound, tmpl.Metric)
+		}
+		query = fmt.Sprintf("(%s) %s %s", expr, tmpl.Operator, strconv.FormatFloat(tmpl.Threshold, 'f', -1, 64))
+	} else if param.Query != "" {
+		query = param.Query
+	} else {
+		return "", errors.Occur(errors.ErrAlarmRuleQueryMissing, param.Name)
+	}
+	if _, err := parser.ParseExpr(query); err != nil {
+		return "", errors.Occur(errors.ErrAlarmRuleQueryInvalid, param.Name, err.Error())
+	}
+	return query, nil
+}
+
+func isOperatorSupported(operator rule.Operator) bool {
+	for _, op := range rule.AllOperators {
+		if op == operator {
+			return true
+		}
+	}
+	return false
+}
+
+func convertToRuleModel(param *rule.RuleParam, ruleType rule.RuleType) (*obmodel.AlarmRule, error) {
+	model := &obmodel.AlarmRule{
+		Name:         param.Name,
+		InstanceType: string(param.InstanceType),
+		Type:         string(ruleType),
+		Query:        param.Query,
+		Duration:     param.Duration,
+		Severity:     string(param.Severity),
+		Summary:      param.Summary,
+		Description:  param.Description,
+		Enabled:      param.Enabled,
+	}
+	if param.Template != nil {
+		template, err := json.Marshal(param.Template)
+		if err != nil {
+			return nil, errors.Occur(errors.ErrJsonMarshal, err.Error())
+		}
+		model.Template = string(template)
+	}
+	labels, err := json.Marshal(param.Labels)
+	if err != nil {
+		return nil, errors.Occur(errors.ErrJsonMarshal, err.Error())
+	}
+	model.Labels = string(labels)
+	return model, nil
+}
+
+func convertToRuleParam(model *obmodel.AlarmRule) (*rule.RuleParam, error) {
+	param := &rule.RuleParam{
+		Name:         model.Name,
+		InstanceType: oceanbase.OBInstanceType(model.InstanceType),
+		Query:        model.Query,
+		Duration:     model.Duration,
+		Severity:     alarmSeverity(model.Severity),
+		Summary:      model.Summary,
+		Description:  model.Description,
+		Enabled:      model.Enabled,
+	}
+	if model.Template != "" {
+		param.Template = &rule.RuleTemplate{}
+		if err := json.Unmarshal([]byte(model.Template), param.Template); err != nil {
+			return nil, errors.Occur(errors.ErrJsonUnmarshal, err.Error())
+		}
+	}
+	if model.Labels != "" {
+		if err := json.Unmarshal([]byte(model.Labels), &param.Labels); err != nil {
+			return nil, errors.Occur(errors.ErrJsonUnmarshal, err.Error())
+		}
+	}
+	return param, nil
+}
@@ -17,13 +17,32 @@
 package alarm
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
 
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	alarmconstant "github.com/oceanbase/obshell/ob/agent/executor/alarm/constant"
+	"github.com/oceanbase/obshell/ob/agent/meta"
+	obmodel "github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+	configservice "github.com/oceanbase/obshell/ob/agent/service/config"
+	"github.com/oceanbase/obshell/ob/model/alarm/channel"
 	"github.com/oceanbase/obshell/ob/model/alarm/payload"
 
 	log "github.com/sirupsen/logrus"
 )
 
+var (
+	lastPurgeTime time.Time
+	purgeLock     sync.Mutex
+)
+
 func LogPayload(pl *payload.WebhookPayload) error {
 	for _, alert := range pl.Alerts {
 		alertContent, err := json.Marshal(alert)
@@ -35,3 +54,160 @@ func LogPayload(pl *payload.WebhookPayload) error {
 	}
 	return nil
 }
+
+// HandleWebhookPayload dispatches the alerts received from Alertmanager to the matched channels.
+// Notifications are sent asynchronously, the result of each one is recorded as a delivery.
+func HandleWebhookPayload(pl *payload.WebhookPayload) error {
+	LogPayload(pl)
+
+	models, err := alarmService.ListEnabledChannels()
+	if err != nil {
+		return err
+	}
+	channels := make([]*channel.Channel, 0, len(models))
+	for i := range models {
+		resp, err := convertToChannelResponse(&models[i])
+		if err != nil {
+			log.WithError(err).Errorf("Load alarm channel %s failed", models[i].Name)
+			continue
+		}
+		channels = append(channels, &resp.Channel)
+	}
+
+	for i := range pl.Alerts {
+		alert := &pl.Alerts[i]
+		for _, ch := range channels {
+			if !matchRoute(&ch.Route, alert.Labels) {
+				continue
+			}
+			go deliver(ch, alert)
+		}
+	}
+
+	go purgeDeliveries()
+	return nil
+}
+
+func matchRoute(route *channel.Route, labels map[string]string) bool {
+	if len(route.Severities) > 0 {
+		matched := false
+		for _, severity := range route.Severities {
+			if string(severity) == labels[alarmconstant.LabelSeverity] {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	for i := range route.Matchers {
+		matcher, err := route.Matchers[i].ToAmMatcher()
+		if err != nil || !matcher.Matches(labels[matcher.Name]) {
+			return false
+		}
+	}
+	return true
+}
+
+// deliver sends the alert through the channel with retry and records the result.
+func deliver(ch *channel.Channel, alert *payload.Alert) {
+	data := buildNotificationData(alert)
+	n := newNotifier(ch)
+	backoff := time.Duration(ch.Retry.BackoffSeconds) * time.Second
+	if backoff <= 0 {
+		backoff = alarmconstant.DefaultRetryBackoffSeconds * time.Second
+	}
+
+	var err error
+	attempts := 0
+	for attempts <= ch.Retry.MaxRetries {
+		if attempts > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		attempts++
+		if err = n.notify(context.Background(), alert, data); err == nil {
+			break
+		}
+		log.WithError(err).Warnf("Send alert %s through channel %s failed, attempt %d", data.RuleName, ch.Name, attempts)
+	}
+
+	delivery := &obmodel.AlarmDelivery{
+		ChannelName: ch.Name,
+		ChannelType: string(ch.Type),
+		RuleName:    data.RuleName,
+		Severity:    data.Severity,
+		AlertStatus: data.Status,
+		Summary:     data.Summary,
+		Status:      string(channel.DeliverySucceeded),
+		Attempts:    attempts,
+	}
+	if err != nil {
+		delivery.Status = string(channel.DeliveryFailed)
+		delivery.ErrorMessage = err.Error()
+	}
+	if err := alarmService.CreateDelivery(delivery); err != nil {
+		log.WithError(err).Errorf("Record delivery of channel %s failed", ch.Name)
+	}
+}
+
+// purgeDeliveries removes the expired deliveries at most once an hour.
+func purgeDeliveries() {
+	purgeLock.Lock()
+	defer purgeLock.Unlock()
+	if time.Since(lastPurgeTime) < time.Hour {
+		return
+	}
+	lastPurgeTime = time.Now()
+	if err := alarmService.PurgeDeliveries(time.Now().AddDate(0, 0, -alarmconstant.DeliveryRetentionDays)); err != nil {
+		log.WithError(err).Warn("Purge alarm deliveries failed")
+	}
+}
+
+// GetReceiverInfo returns the webhook url and token which should be configured in Alertmanager.
+// The token will be generated if not exists.
+func GetReceiverInfo(ctx context.Context) (*channel.ReceiverInfo, error) {
+	token, err := getReceiverToken()
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, errors.Wrap(err, "generate alarm receiver token failed")
+		}
+		token = hex.EncodeToString(buf)
+		if err := configservice.SaveOcsConfig(alarmconstant.ReceiverTokenConfigKey, token, "token of the alarm receiver"); err != nil {
+			return nil, errors.Wrap(err, "save alarm receiver token failed")
+		}
+	}
+	return &channel.ReceiverInfo{
+		Url: fmt.Sprintf("http://%s%s%s%s%s",
+			meta.OCS_AGENT.String(), constant.URI_API_V1, constant.URI_ALARM_GROUP, constant.URI_RECEIVER, constant.URI_WEBHOOK),
+		Token: token,
+	}, nil
+}
+
+// VerifyReceiverToken checks the bearer token carried by the request from Alertmanager.
+func VerifyReceiverToken(token string) error {
+	expected, err := getReceiverToken()
+	if err != nil {
+		return err
+	}
+	if expected == "" || subtle.ConstantTimeCompare([]byte(expected), []byte(token)) != 1 {
+		return errors.Occur(errors.ErrAlarmReceiverTokenInvalid)
+	}
+	return nil
+}
+
+func getReceiverToken() (string, error) {
+	cfg, err := configservice.GetOcsConfig(alarmconstant.ReceiverTokenConfigKey)
+	if err != nil {
+		return "", err
+	}
+	if cfg == nil {
+		return "", nil
+	}
+	return cfg.Value, nil
+}
@@ -0,0 +1,174 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	executorcommon "github.com/oceanbase/obshell/ob/agent/executor/common"
+	"github.com/oceanbase/obshell/ob/agent/meta"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+	auditservice "github.com/oceanbase/obshell/ob/agent/service/audit"
+	configservice "github.com/oceanbase/obshell/ob/agent/service/config"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+var (
+	auditService = auditservice.AuditService{}
+
+	auditQueue = make(chan *bo.AuditLog, constant.AUDIT_QUEUE_SIZE)
+	auditOnce  sync.Once
+)
+
+// Start starts the audit log writer and the cleanup of the expired audit logs in background.
+func Start() {
+	auditOnce.Do(func() {
+		go writeLoop()
+		go cleanupLoop()
+		go migrateLoop()
+	})
+}
+
+// Record records the audit log asynchronously, so that the request is not slowed down by the meta db.
+func Record(auditLog *bo.AuditLog) {
+	Start()
+	if len(auditLog.Params) > constant.AUDIT_PARAMS_MAX_LENGTH {
+		auditLog.Params = auditLog.Params[:constant.AUDIT_PARAMS_MAX_LENGTH]
+	}
+	select {
+	case auditQueue <- auditLog:
+	default:
+		log.Warnf("audit queue is full, drop audit log: %s %s by %s", auditLog.Method, auditLog.Uri, auditLog.User)
+	}
+}
+
+func writeLoop() {
+	for auditLog := range auditQueue {
+		if err := auditService.Create(auditLog); err != nil {
+			log.WithError(err).Warnf("write audit log failed: %s %s by %s", auditLog.Method, auditLog.Uri, auditLog.User)
+		}
+	}
+}
+
+// migrateLoop keeps moving the audit logs recorded in sqlite into the meta table,
+// so that the logs recorded before the cluster is bootstrapped or taken over are kept.
+func migrateLoop() {
+	for {
+		count, err := auditService.MigrateLocalLogs(constant.AUDIT_MIGRATE_BATCH_SIZE)
+		if err != nil {
+			log.WithError(err).Warn("migrate local audit logs failed")
+		} else if count > 0 {
+			log.Infof("migrated %d local audit logs into the meta table", count)
+		}
+		time.Sleep(constant.AUDIT_MIGRATE_INTERVAL)
+	}
+}
+
+func cleanupLoop() {
+	for {
+		retentionDays := constant.AUDIT_DEFAULT_RETENTION_DAYS
+		if retention, err := GetRetention(); err != nil {
+			log.WithError(err).Debug("get audit retention failed, use the default one")
+		} else {
+			retentionDays = retention.RetentionDays
+		}
+		count, err := auditService.DeleteBefore(time.Now().AddDate(0, 0, -retentionDays))
+		if err != nil {
+			log.WithError(err).Warn("clean expired audit logs failed")
+		} else if count > 0 {
+			log.Infof("cleaned %d expired audit logs", count)
+		}
+		time.Sleep(constant.AUDIT_CLEANUP_INTERVAL)
+	}
+}
+
+// GetRetention returns the retention of the audit logs.
+// Only the cluster agent supports customizing it, the others use the default one.
+func GetRetention() (*bo.AuditRetention, error) {
+	retention := &bo.AuditRetention{RetentionDays: constant.AUDIT_DEFAULT_RETENTION_DAYS}
+	if meta.OCS_AGENT == nil || !meta.OCS_AGENT.IsClusterAgent() {
+		return retention, nil
+	}
+	ocsConfig, err := configservice.GetOcsConfig(constant.AUDIT_RETENTION_CONFIG_KEY)
+	if err != nil {
+		return nil, errors.WrapRetain(errors.ErrConfigGetFailed, err, constant.AUDIT_RETENTION_CONFIG_KEY, err.Error())
+	}
+	if ocsConfig == nil {
+		return retention, nil
+	}
+	days, err := strconv.Atoi(ocsConfig.Value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid audit retention '%s'", ocsConfig.Value)
+	}
+	retention.RetentionDays = days
+	return retention, nil
+}
+
+func SetRetention(p *param.AuditRetentionParam) (*bo.AuditRetention, error) {
+	if p.RetentionDays < 1 || p.RetentionDays > constant.AUDIT_MAX_RETENTION_DAYS {
+		return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "retention_days",
+			fmt.Sprintf("should be between 1 and %d", constant.AUDIT_MAX_RETENTION_DAYS))
+	}
+	if err := configservice.SaveOcsConfig(constant.AUDIT_RETENTION_CONFIG_KEY, strconv.Itoa(p.RetentionDays), "retention days of the audit logs"); err != nil {
+		return nil, err
+	}
+	return &bo.AuditRetention{RetentionDays: p.RetentionDays}, nil
+}
+
+func ListAuditLogs(p *param.ListAuditLogParam) (*bo.PaginatedAuditLogResponse, error) {
+	if p.Page <= 0 {
+		p.Page = 1
+	}
+	if p.PageSize <= 0 {
+		p.PageSize = constant.AUDIT_DEFAULT_PAGE_SIZE
+	}
+	if p.PageSize > constant.AUDIT_MAX_PAGE_SIZE {
+		p.PageSize = constant.AUDIT_MAX_PAGE_SIZE
+	}
+
+	logs, total, err := auditService.List(&auditservice.ListQuery{
+		User:       p.User,
+		Method:     strings.ToUpper(p.Method),
+		KeyWord:    p.KeyWord,
+		DagId:      p.DagId,
+		Successful: p.Successful,
+		StartTime:  p.StartTime,
+		EndTime:    p.EndTime,
+		Page:       p.Page,
+		PageSize:   p.PageSize,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "list audit logs failed")
+	}
+	return &bo.PaginatedAuditLogResponse{
+		Contents: logs,
+		Page: bo.CustomPage{
+			Number:        uint64(p.Page),
+			Size:          uint64(p.PageSize),
+			TotalPages:    executorcommon.CalculateTotalPages(uint64(total), uint64(p.PageSize)),
+			TotalElements: uint64(total),
+		},
+	}, nil
+}
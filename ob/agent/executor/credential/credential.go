@@ -33,6 +33,7 @@ import (
 	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
 	obmodel "github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
 	"github.com/oceanbase/obshell/ob/agent/secure"
+	alarmservice "github.com/oceanbase/obshell/ob/agent/service/alarm"
 	credentialservice "github.com/oceanbase/obshell/ob/agent/service/credential"
 	"github.com/oceanbase/obshell/ob/model/oceanbase"
 	"github.com/oceanbase/obshell/ob/param"
@@ -41,6 +42,7 @@ import (
 
 var (
 	credentialService = credentialservice.CredentialService{}
+	alarmService      = alarmservice.AlarmService{}
 )
 
 const (
@@ -653,6 +655,25 @@ func UpdateCredentialEncryptSecretKey(p *param.UpdateCredentialEncryptSecretKeyP
 			}
 		}
 
+		// Alarm channels are encrypted with the same key.
+		channels, err := alarmService.ListAllChannelsTx(tx)
+		if err != nil {
+			return err
+		}
+		for i := range channels {
+			config, decErr := secure.DecryptCredentialPassphraseWithKey(channels[i].Config, oldKey)
+			if decErr != nil {
+				return errors.Wrap(decErr, "decrypt alarm channel config failed")
+			}
+			newConfig, encErr := secure.EncryptCredentialPassphraseWithKey(config, newKey)
+			if encErr != nil {
+				return errors.Wrap(encErr, "encrypt alarm channel config failed")
+			}
+			if err := alarmService.UpdateChannelConfigTx(tx, channels[i].Id, newConfig); err != nil {
+				return err
+			}
+		}
+
 		encodedKey := crypto.CaesarBase64Encode(string(newKey), constant.CAESAR_SHIFT)
 		if err := credentialService.SaveCredentialAESKeyTx(tx, encodedKey); err != nil {
 			return err
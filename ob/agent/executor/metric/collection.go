@@ -0,0 +1,161 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metric
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	metricconstant "github.com/oceanbase/obshell/ob/agent/executor/metric/constant"
+	"github.com/oceanbase/obshell/ob/agent/meta"
+	agentservice "github.com/oceanbase/obshell/ob/agent/service/agent"
+	configservice "github.com/oceanbase/obshell/ob/agent/service/config"
+	"github.com/oceanbase/obshell/ob/agent/service/obcluster"
+	model "github.com/oceanbase/obshell/ob/model/metric"
+)
+
+var (
+	agentService    = agentservice.AgentService{}
+	observerService = obcluster.ObserverService{}
+
+	collectionOnce sync.Once
+)
+
+func defaultCollectionConfig() *model.CollectionConfig {
+	return &model.CollectionConfig{
+		Enabled:        false,
+		Interval:       metricconstant.DEFAULT_COLLECT_INTERVAL,
+		RetentionHours: metricconstant.DEFAULT_RETENTION_HOURS,
+		MaxSizeMB:      metricconstant.DEFAULT_MAX_SIZE_MB,
+	}
+}
+
+// GetCollectionConfig returns the config of the embedded metric collection,
+// the default one will be returned if not configured.
+func GetCollectionConfig() (*model.CollectionConfig, error) {
+	ocsConfig, err := configservice.GetOcsConfig(metricconstant.COLLECTION_CONFIG_KEY)
+	if err != nil {
+		return nil, errors.WrapRetain(errors.ErrConfigGetFailed, err, metricconstant.COLLECTION_CONFIG_KEY, err.Error())
+	}
+	cfg := defaultCollectionConfig()
+	if ocsConfig == nil {
+		return cfg, nil
+	}
+	if err := json.Unmarshal([]byte(ocsConfig.Value), cfg); err != nil {
+		return nil, errors.Occur(errors.ErrJsonUnmarshal, err.Error())
+	}
+	return cfg, nil
+}
+
+// SaveCollectionConfig saves the config of the embedded metric collection,
+// the zero fields will be filled with the default values.
+// It takes effect on all agents at the next round of collection.
+func SaveCollectionConfig(cfg *model.CollectionConfig) (*model.CollectionConfig, error) {
+	defaultCfg := defaultCollectionConfig()
+	if cfg.Interval == 0 {
+		cfg.Interval = defaultCfg.Interval
+	}
+	if cfg.RetentionHours == 0 {
+		cfg.RetentionHours = defaultCfg.RetentionHours
+	}
+	if cfg.MaxSizeMB == 0 {
+		cfg.MaxSizeMB = defaultCfg.MaxSizeMB
+	}
+	if cfg.Interval < metricconstant.MIN_COLLECT_INTERVAL || cfg.Interval > metricconstant.MAX_COLLECT_INTERVAL {
+		return nil, errors.Occur(errors.ErrMetricCollectionConfigInvalid,
+			fmt.Sprintf("interval should be between %d and %d seconds", metricconstant.MIN_COLLECT_INTERVAL, metricconstant.MAX_COLLECT_INTERVAL))
+	}
+	if cfg.RetentionHours < 1 || cfg.RetentionHours > metricconstant.MAX_RETENTION_HOURS {
+		return nil, errors.Occur(errors.ErrMetricCollectionConfigInvalid,
+			fmt.Sprintf("retention_hours should be between 1 and %d", metricconstant.MAX_RETENTION_HOURS))
+	}
+	if cfg.MaxSizeMB < metricconstant.MIN_MAX_SIZE_MB {
+		return nil, errors.Occur(errors.ErrMetricCollectionConfigInvalid,
+			fmt.Sprintf("max_size_mb should not be less than %d", metricconstant.MIN_MAX_SIZE_MB))
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, errors.Occur(errors.ErrJsonMarshal, err.Error())
+	}
+	if err := configservice.SaveOcsConfig(metricconstant.COLLECTION_CONFIG_KEY, string(data), "embedded metric collection configuration"); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// isCollectionEnabled checks whether the metric queries can be served by the local storage.
+func isCollectionEnabled() bool {
+	cfg, err := GetCollectionConfig()
+	if err != nil {
+		log.WithError(err).Warn("get metric collection config failed")
+		return false
+	}
+	return cfg.Enabled && isLocalStorageOpened()
+}
+
+// StartCollection starts the embedded metric collection in background,
+// it keeps idle until the collection is enabled.
+func StartCollection() {
+	collectionOnce.Do(func() {
+		go collectionLoop()
+	})
+}
+
+func collectionLoop() {
+	for {
+		interval := time.Duration(metricconstant.DEFAULT_COLLECT_INTERVAL) * time.Second
+		if meta.OCS_AGENT != nil && meta.OCS_AGENT.IsClusterAgent() {
+			cfg, err := GetCollectionConfig()
+			if err != nil {
+				log.WithError(err).Debug("get metric collection config failed, skip collection")
+			} else if !cfg.Enabled {
+				closeLocalStorage()
+			} else {
+				interval = time.Duration(cfg.Interval) * time.Second
+				if err := collectOnce(cfg); err != nil {
+					log.WithError(err).Warn("collect metrics failed")
+				}
+			}
+		}
+		time.Sleep(interval)
+	}
+}
+
+func collectOnce(cfg *model.CollectionConfig) error {
+	if err := openLocalStorage(cfg); err != nil {
+		return err
+	}
+	// Each agent only collects its own observer and host, the same as the ones exposed to Prometheus,
+	// and the cluster-wide series are only collected by the maintainer.
+	// The queries are evaluated over the local storages of all the agents.
+	return appendSamples(CollectExporterSamples(), time.Now())
+}
+
+func getClusterName() string {
+	clusterName, err := observerService.GetOBStringParatemerByName(constant.OB_PARAM_CLUSTER_NAME)
+	if err != nil {
+		log.WithError(err).Debug("get cluster name failed")
+	}
+	return clusterName
+}
@@ -0,0 +1,296 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metric
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/load"
+	"github.com/shirou/gopsutil/v3/mem"
+	"github.com/shirou/gopsutil/v3/net"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	metricconstant "github.com/oceanbase/obshell/ob/agent/executor/metric/constant"
+	"github.com/oceanbase/obshell/ob/agent/lib/path"
+	"github.com/oceanbase/obshell/ob/agent/lib/process"
+	"github.com/oceanbase/obshell/ob/agent/meta"
+	metricservice "github.com/oceanbase/obshell/ob/agent/service/metric"
+	model "github.com/oceanbase/obshell/ob/model/metric"
+)
+
+var metricService = metricservice.MetricService{}
+
+// collectedStatIds returns the stat ids of ob_sysstat used by the metric expressions.
+func collectedStatIds() []int64 {
+	re := regexp.MustCompile(metricconstant.STAT_ID_PATTERN)
+	idSet := make(map[int64]struct{})
+	for _, expr := range metricExprConfig {
+		for _, match := range re.FindAllStringSubmatch(expr, -1) {
+			if id, err := strconv.ParseInt(match[1], 10, 64); err == nil {
+				idSet[id] = struct{}{}
+			}
+		}
+	}
+	ids := make([]int64, 0, len(idSet))
+	for id := range idSet {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func newSample(name string, value float64, labels map[string]string) model.Sample {
+	return model.Sample{Name: name, Labels: labels, Value: value}
+}
+
+func serverLabels(clusterName, zone, svrIp string, svrPort int) map[string]string {
+	return map[string]string{
+		metricconstant.LABEL_OB_CLUSTER_NAME: clusterName,
+		metricconstant.LABEL_OBZONE:          zone,
+		metricconstant.LABEL_SVR_IP:          svrIp,
+		metricconstant.LABEL_SVR_PORT:        strconv.Itoa(svrPort),
+	}
+}
+
+func withLabels(base map[string]string, kvs ...string) map[string]string {
+	labels := make(map[string]string, len(base)+len(kvs)/2)
+	for k, v := range base {
+		labels[k] = v
+	}
+	for i := 0; i+1 < len(kvs); i += 2 {
+		labels[kvs[i]] = kvs[i+1]
+	}
+	return labels
+}
+
+// CollectObSamples collects the metrics of the server through the sys tenant,
+// all servers in the cluster will be collected if the server is nil.
+// A failed view will be skipped so that the others can still be collected.
+func CollectObSamples(clusterName string, server *meta.ObserverSvrInfo) []model.Sample {
+	samples := make([]model.Sample, 0)
+
+	if stats, err := metricService.GetSysStats(collectedStatIds(), server); err != nil {
+		log.WithError(err).Warn("collect sysstat failed")
+	} else {
+		for _, stat := range stats {
+			labels := withLabels(serverLabels(clusterName, stat.Zone, stat.SvrIp, stat.SvrPort),
+				metricconstant.LABEL_TENANT_ID, strconv.FormatInt(stat.TenantId, 10),
+				metricconstant.LABEL_TENANT_NAME, stat.TenantName,
+				metricconstant.LABEL_STAT_ID, strconv.FormatInt(stat.StatId, 10))
+			samples = append(samples, newSample(metricconstant.METRIC_OB_SYSSTAT, stat.Value, labels))
+		}
+	}
+
+	if counts, err := metricService.GetTenantSessionCounts(metricconstant.SESSION_COMMAND_SLEEP, server); err != nil {
+		log.WithError(err).Warn("collect session count failed")
+	} else {
+		for _, count := range counts {
+			labels := withLabels(serverLabels(clusterName, count.Zone, count.SvrIp, count.SvrPort),
+				metricconstant.LABEL_TENANT_NAME, count.TenantName)
+			samples = append(samples,
+				newSample(metricconstant.METRIC_OB_ACTIVE_SESSION_NUM, float64(count.ActiveCount), labels),
+				newSample(metricconstant.METRIC_OB_ALL_SESSION_NUM, float64(count.TotalCount), labels))
+		}
+	}
+
+	if sizes, err := metricService.GetKvCacheSizes(server); err != nil {
+		log.WithError(err).Warn("collect kv cache size failed")
+	} else {
+		for _, size := range sizes {
+			labels := withLabels(serverLabels(clusterName, size.Zone, size.SvrIp, size.SvrPort),
+				metricconstant.LABEL_TENANT_ID, strconv.FormatInt(size.TenantId, 10),
+				metricconstant.LABEL_TENANT_NAME, size.TenantName,
+				metricconstant.LABEL_CACHE_NAME, size.CacheName)
+			samples = append(samples, newSample(metricconstant.METRIC_OB_CACHE_SIZE_BYTES, float64(size.CacheSize), labels))
+		}
+	}
+
+	if capacities, err := metricService.GetServerCapacities(server); err != nil {
+		log.WithError(err).Warn("collect server capacity failed")
+	} else {
+		for _, c := range capacities {
+			labels := serverLabels(clusterName, c.Zone, c.SvrIp, c.SvrPort)
+			samples = append(samples,
+				newSample(metricconstant.METRIC_OB_SERVER_RESOURCE_CPU, c.CpuCapacity, labels),
+				newSample(metricconstant.METRIC_OB_SERVER_RESOURCE_CPU_ASSIGN, c.CpuAssigned, labels),
+				newSample(metricconstant.METRIC_OB_SERVER_RESOURCE_MEM, float64(c.MemCapacity), labels),
+				newSample(metricconstant.METRIC_OB_SERVER_RESOURCE_MEM_ASSIGN, float64(c.MemAssigned), labels),
+				newSample(metricconstant.METRIC_OB_SERVER_RESOURCE_DISK, float64(c.DataDiskCapacity), labels),
+				newSample(metricconstant.METRIC_OB_DISK_TOTAL_BYTES, float64(c.DataDiskCapacity), labels),
+				newSample(metricconstant.METRIC_OB_DISK_FREE_BYTES, float64(c.DataDiskCapacity-c.DataDiskAssigned), labels))
+		}
+	}
+	return samples
+}
+
+// CollectHostSamples collects the metrics of the host where the agent is located.
+func CollectHostSamples(clusterName string) []model.Sample {
+	base := map[string]string{
+		metricconstant.LABEL_OB_CLUSTER_NAME: clusterName,
+		metricconstant.LABEL_OBZONE:          meta.OCS_AGENT.GetZone(),
+		metricconstant.LABEL_SVR_IP:          meta.OCS_AGENT.GetIp(),
+	}
+	samples := make([]model.Sample, 0)
+
+	if times, err := cpu.Times(true); err != nil {
+		log.WithError(err).Warn("collect cpu times failed")
+	} else {
+		for _, t := range times {
+			modes := map[string]float64{
+				"user": t.User, "system": t.System, "idle": t.Idle, "nice": t.Nice,
+				"iowait": t.Iowait, "irq": t.Irq, "softirq": t.Softirq, "steal": t.Steal,
+			}
+			for mode, value := range modes {
+				labels := withLabels(base, metricconstant.LABEL_CPU, strings.TrimPrefix(t.CPU, "cpu"), metricconstant.LABEL_MODE, mode)
+				samples = append(samples, newSample(metricconstant.METRIC_NODE_CPU_SECONDS_TOTAL, value, labels))
+			}
+		}
+		samples = append(samples, newSample(metricconstant.METRIC_CPU_COUNT, float64(len(times)), base))
+	}
+
+	if avg, err := load.Avg(); err != nil {
+		log.WithError(err).Warn("collect load average failed")
+	} else {
+		samples = append(samples,
+			newSample(metricconstant.METRIC_NODE_LOAD1, avg.Load1, base),
+			newSample(metricconstant.METRIC_NODE_LOAD5, avg.Load5, base),
+			newSample(metricconstant.METRIC_NODE_LOAD15, avg.Load15, base))
+	}
+
+	if vm, err := mem.VirtualMemory(); err != nil {
+		log.WithError(err).Warn("collect memory failed")
+	} else {
+		samples = append(samples,
+			newSample(metricconstant.METRIC_NODE_MEMORY_TOTAL_BYTES, float64(vm.Total), base),
+			newSample(metricconstant.METRIC_NODE_MEMORY_FREE_BYTES, float64(vm.Free), base),
+			newSample(metricconstant.METRIC_NODE_MEMORY_CACHED_BYTES, float64(vm.Cached), base),
+			newSample(metricconstant.METRIC_NODE_MEMORY_BUFFERS_BYTES, float64(vm.Buffers), base))
+	}
+
+	samples = append(samples, collectFilesystemSamples(base)...)
+
+	if counters, err := disk.IOCounters(); err != nil {
+		log.WithError(err).Warn("collect disk io failed")
+	} else {
+		for name, c := range counters {
+			labels := withLabels(base, metricconstant.LABEL_DEVICE, name)
+			samples = append(samples,
+				newSample(metricconstant.METRIC_NODE_DISK_READS_COMPLETED, float64(c.ReadCount), labels),
+				newSample(metricconstant.METRIC_NODE_DISK_WRITES_COMPLETED, float64(c.WriteCount), labels),
+				newSample(metricconstant.METRIC_NODE_DISK_READ_BYTES, float64(c.ReadBytes), labels),
+				newSample(metricconstant.METRIC_NODE_DISK_WRITTEN_BYTES, float64(c.WriteBytes), labels),
+				newSample(metricconstant.METRIC_NODE_DISK_READ_TIME_SECONDS, float64(c.ReadTime)/1000, labels),
+				newSample(metricconstant.METRIC_NODE_DISK_WRITE_TIME_SECONDS, float64(c.WriteTime)/1000, labels))
+		}
+	}
+
+	if counters, err := net.IOCounters(true); err != nil {
+		log.WithError(err).Warn("collect network io failed")
+	} else {
+		for _, c := range counters {
+			labels := withLabels(base, metricconstant.LABEL_DEVICE, c.Name)
+			samples = append(samples,
+				newSample(metricconstant.METRIC_NODE_NETWORK_RECEIVE_BYTES, float64(c.BytesRecv), labels),
+				newSample(metricconstant.METRIC_NODE_NETWORK_TRANSMIT_BYTES, float64(c.BytesSent), labels))
+		}
+	}
+
+	exists := 0.0
+	if ok, err := process.CheckObserverProcess(); err != nil {
+		log.WithError(err).Warn("check observer process failed")
+	} else if ok {
+		exists = 1
+	}
+	samples = append(samples, newSample(metricconstant.METRIC_PROCESS_EXISTS, exists,
+		withLabels(base, metricconstant.LABEL_PROCESS_NAME, metricconstant.OBSERVER_PROCESS_NAME)))
+	return samples
+}
+
+// collectFilesystemSamples collects the usage of the mounted filesystems,
+// the ones holding the install, data and log directories of observer are marked by mount_label.
+func collectFilesystemSamples(base map[string]string) []model.Sample {
+	samples := make([]model.Sample, 0)
+	partitions, err := disk.Partitions(false)
+	if err != nil {
+		log.WithError(err).Warn("collect filesystem failed")
+		return samples
+	}
+
+	obDirs := observerDirs()
+	for _, p := range partitions {
+		usage, err := disk.Usage(p.Mountpoint)
+		if err != nil || usage.Total == 0 {
+			continue
+		}
+		labels := withLabels(base,
+			metricconstant.LABEL_DEVICE, p.Device,
+			metricconstant.LABEL_MOUNTPOINT, p.Mountpoint,
+			metricconstant.LABEL_FSTYPE, p.Fstype)
+		samples = append(samples, filesystemSamples(usage, labels)...)
+	}
+
+	// The directories of observer are reported with the filesystem they belong to.
+	for mountLabel, dir := range obDirs {
+		usage, err := disk.Usage(dir)
+		if err != nil || usage.Total == 0 {
+			continue
+		}
+		labels := withLabels(base,
+			metricconstant.LABEL_MOUNTPOINT, dir,
+			metricconstant.LABEL_IS_OB_DISK, "1",
+			metricconstant.LABEL_MOUNT_LABEL, mountLabel)
+		samples = append(samples, filesystemSamples(usage, labels)...)
+	}
+	return samples
+}
+
+func filesystemSamples(usage *disk.UsageStat, labels map[string]string) []model.Sample {
+	return []model.Sample{
+		newSample(metricconstant.METRIC_NODE_FILESYSTEM_SIZE_BYTES, float64(usage.Total), labels),
+		newSample(metricconstant.METRIC_NODE_FILESYSTEM_AVAIL_BYTES, float64(usage.Free), labels),
+		newSample(metricconstant.METRIC_NODE_FILESYSTEM_FILES, float64(usage.InodesTotal), labels),
+		newSample(metricconstant.METRIC_NODE_FILESYSTEM_FILES_FREE, float64(usage.InodesFree), labels),
+	}
+}
+
+// observerDirs returns the install, data and log directories of the local observer.
+func observerDirs() map[string]string {
+	dirs := map[string]string{
+		metricconstant.MOUNT_LABEL_INSTALL_PATH:   path.AgentDir(),
+		metricconstant.MOUNT_LABEL_DATA_DISK_PATH: filepath.Join(path.AgentDir(), constant.OB_DIR_STORE),
+	}
+	params, err := metricService.GetServerParameters(meta.OCS_AGENT.GetIp(), meta.RPC_PORT,
+		[]string{constant.CONFIG_DATA_DIR, constant.CONFIG_REDO_DIR})
+	if err != nil {
+		log.WithError(err).Debug("get observer directories failed, use the default ones")
+	}
+	if dataDir := params[constant.CONFIG_DATA_DIR]; dataDir != "" {
+		dirs[metricconstant.MOUNT_LABEL_DATA_DISK_PATH] = dataDir
+	}
+	dirs[metricconstant.MOUNT_LABEL_LOG_DISK_PATH] = filepath.Join(dirs[metricconstant.MOUNT_LABEL_DATA_DISK_PATH], constant.OB_DIR_CLOG)
+	if redoDir := params[constant.CONFIG_REDO_DIR]; redoDir != "" {
+		dirs[metricconstant.MOUNT_LABEL_LOG_DISK_PATH] = redoDir
+	}
+	return dirs
+}
@@ -35,3 +35,82 @@ const (
 	KEY_LABELS              = "@LABELS"
 	KEY_GROUP_LABELS        = "@GBLABELS"
 )
+
+// Used for the embedded metric collection.
+const (
+	COLLECTION_CONFIG_KEY      = "metric_collection_config"
+	DEFAULT_COLLECT_INTERVAL   = 15
+	MIN_COLLECT_INTERVAL       = 5
+	MAX_COLLECT_INTERVAL       = 300
+	DEFAULT_RETENTION_HOURS    = 72
+	MAX_RETENTION_HOURS        = 720
+	DEFAULT_MAX_SIZE_MB        = 512
+	MIN_MAX_SIZE_MB            = 64
+	LOCAL_QUERY_MAX_SAMPLES    = 5000000
+	LOCAL_QUERY_LOOKBACK_DELTA = 300
+	METRIC_STORAGE_DIR         = "metric_obshell"
+	OBSERVER_PROCESS_NAME      = "observer"
+	MOUNT_LABEL_INSTALL_PATH   = "install_path"
+	MOUNT_LABEL_DATA_DISK_PATH = "data_disk_path"
+	MOUNT_LABEL_LOG_DISK_PATH  = "log_disk_path"
+	SESSION_COMMAND_SLEEP      = "Sleep"
+	STAT_ID_PATTERN            = `stat_id="(\d+)"`
+)
+
+// Metric names and labels compatible with obagent.
+const (
+	METRIC_OB_SYSSTAT                    = "ob_sysstat"
+	METRIC_OB_ACTIVE_SESSION_NUM         = "ob_active_session_num"
+	METRIC_OB_ALL_SESSION_NUM            = "ob_all_session_num"
+	METRIC_OB_CACHE_SIZE_BYTES           = "ob_cache_size_bytes"
+	METRIC_OB_SERVER_RESOURCE_CPU        = "ob_server_resource_cpu"
+	METRIC_OB_SERVER_RESOURCE_CPU_ASSIGN = "ob_server_resource_cpu_assigned"
+	METRIC_OB_SERVER_RESOURCE_MEM        = "ob_server_resource_memory_bytes"
+	METRIC_OB_SERVER_RESOURCE_MEM_ASSIGN = "ob_server_resource_memory_assigned_bytes"
+	METRIC_OB_SERVER_RESOURCE_DISK       = "ob_server_resource_disk_bytes"
+	METRIC_OB_DISK_TOTAL_BYTES           = "ob_disk_total_bytes"
+	METRIC_OB_DISK_FREE_BYTES            = "ob_disk_free_bytes"
+	METRIC_CPU_COUNT                     = "cpu_count"
+	METRIC_NODE_CPU_SECONDS_TOTAL        = "node_cpu_seconds_total"
+	METRIC_NODE_LOAD1                    = "node_load1"
+	METRIC_NODE_LOAD5                    = "node_load5"
+	METRIC_NODE_LOAD15                   = "node_load15"
+	METRIC_NODE_MEMORY_TOTAL_BYTES       = "node_memory_MemTotal_bytes"
+	METRIC_NODE_MEMORY_FREE_BYTES        = "node_memory_MemFree_bytes"
+	METRIC_NODE_MEMORY_CACHED_BYTES      = "node_memory_Cached_bytes"
+	METRIC_NODE_MEMORY_BUFFERS_BYTES     = "node_memory_Buffers_bytes"
+	METRIC_NODE_FILESYSTEM_SIZE_BYTES    = "node_filesystem_size_bytes"
+	METRIC_NODE_FILESYSTEM_AVAIL_BYTES   = "node_filesystem_avail_bytes"
+	METRIC_NODE_FILESYSTEM_FILES         = "node_filesystem_files"
+	METRIC_NODE_FILESYSTEM_FILES_FREE    = "node_filesystem_files_free"
+	METRIC_NODE_DISK_READS_COMPLETED     = "node_disk_reads_completed_total"
+	METRIC_NODE_DISK_WRITES_COMPLETED    = "node_disk_writes_completed_total"
+	METRIC_NODE_DISK_READ_BYTES          = "node_disk_read_bytes_total"
+	METRIC_NODE_DISK_WRITTEN_BYTES       = "node_disk_written_bytes_total"
+	METRIC_NODE_DISK_READ_TIME_SECONDS   = "node_disk_read_time_seconds_total"
+	METRIC_NODE_DISK_WRITE_TIME_SECONDS  = "node_disk_write_time_seconds_total"
+	METRIC_NODE_NETWORK_RECEIVE_BYTES    = "node_network_receive_bytes_total"
+	METRIC_NODE_NETWORK_TRANSMIT_BYTES   = "node_network_transmit_bytes_total"
+	METRIC_PROCESS_EXISTS                = "process_exists"
+	METRIC_OB_BACKUP_RPO_SECONDS         = "ob_backup_rpo_seconds"
+	METRIC_OB_BACKUP_RPO_TARGET_SECONDS  = "ob_backup_rpo_target_seconds"
+	METRIC_OB_BACKUP_COMPLIANT           = "ob_backup_compliant"
+
+	LABEL_NAME            = "__name__"
+	LABEL_OB_CLUSTER_NAME = "ob_cluster_name"
+	LABEL_OBZONE          = "obzone"
+	LABEL_SVR_IP          = "svr_ip"
+	LABEL_SVR_PORT        = "svr_port"
+	LABEL_TENANT_ID       = "tenant_id"
+	LABEL_TENANT_NAME     = "tenant_name"
+	LABEL_STAT_ID         = "stat_id"
+	LABEL_CACHE_NAME      = "cache_name"
+	LABEL_CPU             = "cpu"
+	LABEL_MODE            = "mode"
+	LABEL_DEVICE          = "device"
+	LABEL_MOUNTPOINT      = "mountpoint"
+	LABEL_FSTYPE          = "fstype"
+	LABEL_IS_OB_DISK      = "is_ob_disk"
+	LABEL_MOUNT_LABEL     = "mount_label"
+	LABEL_PROCESS_NAME    = "name"
+)
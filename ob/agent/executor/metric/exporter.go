@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metric
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	metricconstant "github.com/oceanbase/obshell/ob/agent/executor/metric/constant"
+	"github.com/oceanbase/obshell/ob/agent/executor/ob"
+	"github.com/oceanbase/obshell/ob/agent/meta"
+	model "github.com/oceanbase/obshell/ob/model/metric"
+)
+
+// ExporterContentType is the content type of the metrics exposed to Prometheus.
+var ExporterContentType = string(expfmt.NewFormat(expfmt.TypeTextPlain))
+
+// CollectExporterSamples collects the metrics of the local observer and host,
+// which are exposed to Prometheus by the agent.
+func CollectExporterSamples() []model.Sample {
+	clusterName := ""
+	samples := make([]model.Sample, 0)
+	if meta.OCS_AGENT.IsClusterAgent() {
+		clusterName = getClusterName()
+		server := meta.NewAgentInfo(meta.OCS_AGENT.GetIp(), meta.RPC_PORT)
+		samples = append(samples, CollectObSamples(clusterName, server)...)
+		samples = append(samples, collectBackupComplianceSamples(clusterName)...)
+	}
+	return append(samples, CollectHostSamples(clusterName)...)
+}
+
+// collectBackupComplianceSamples exposes the latest backup compliance checks,
+// which only exist on the maintainer, so that every tenant is reported once.
+func collectBackupComplianceSamples(clusterName string) []model.Sample {
+	samples := make([]model.Sample, 0)
+	for _, compliance := range ob.GetLatestBackupCompliances() {
+		labels := map[string]string{
+			metricconstant.LABEL_OB_CLUSTER_NAME: clusterName,
+			metricconstant.LABEL_TENANT_ID:       strconv.Itoa(compliance.TenantID),
+			metricconstant.LABEL_TENANT_NAME:     compliance.TenantName,
+		}
+		compliant := 0.0
+		if compliance.Status == constant.BACKUP_COMPLIANCE_STATUS_COMPLIANT {
+			compliant = 1
+		}
+		samples = append(samples,
+			newSample(metricconstant.METRIC_OB_BACKUP_RPO_SECONDS, float64(compliance.Rpo), labels),
+			newSample(metricconstant.METRIC_OB_BACKUP_RPO_TARGET_SECONDS, float64(compliance.Target.RpoTarget), labels),
+			newSample(metricconstant.METRIC_OB_BACKUP_COMPLIANT, compliant, labels))
+	}
+	return samples
+}
+
+// WriteExposition writes the samples in the Prometheus text format.
+func WriteExposition(w io.Writer, samples []model.Sample) error {
+	families := make(map[string]*dto.MetricFamily)
+	seen := make(map[string]struct{})
+	for _, sample := range samples {
+		family, ok := families[sample.Name]
+		if !ok {
+			metricType := dto.MetricType_GAUGE
+			if strings.HasSuffix(sample.Name, "_total") {
+				metricType = dto.MetricType_COUNTER
+			}
+			family = &dto.MetricFamily{Name: proto.String(sample.Name), Type: metricType.Enum()}
+			families[sample.Name] = family
+		}
+
+		metric := &dto.Metric{}
+		for k, v := range sample.Labels {
+			if v == "" {
+				continue
+			}
+			metric.Label = append(metric.Label, &dto.LabelPair{Name: proto.String(k), Value: proto.String(v)})
+		}
+		sort.Slice(metric.Label, func(i, j int) bool { return metric.Label[i].GetName() < metric.Label[j].GetName() })
+		// Prometheus rejects the whole scrape if there are duplicated series.
+		signature := seriesSignature(sample.Name, metric.Label)
+		if _, ok := seen[signature]; ok {
+			continue
+		}
+		seen[signature] = struct{}{}
+		if family.GetType() == dto.MetricType_COUNTER {
+			metric.Counter = &dto.Counter{Value: proto.Float64(sample.Value)}
+		} else {
+			metric.Gauge = &dto.Gauge{Value: proto.Float64(sample.Value)}
+		}
+		family.Metric = append(family.Metric, metric)
+	}
+
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := expfmt.MetricFamilyToText(w, families[name]); err != nil {
+			return errors.Wrapf(err, "write metric family %s failed", name)
+		}
+	}
+	return nil
+}
+
+func seriesSignature(name string, labels []*dto.LabelPair) string {
+	var sb strings.Builder
+	sb.WriteString(name)
+	for _, label := range labels {
+		sb.WriteString("\xff")
+		sb.WriteString(label.GetName())
+		sb.WriteString("=")
+		sb.WriteString(label.GetValue())
+	}
+	return sb.String()
+}
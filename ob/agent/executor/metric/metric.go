@@ -17,6 +17,7 @@
 package metric
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"net/http"
@@ -126,6 +127,16 @@ func replaceQueryVariables(exprTemplate string, labels []common.KVPair, groupLab
 	return replacer.Replace(exprTemplate)
 }
 
+// RenderMetricExpr renders the expression of the metric defined in metric_expr.yaml,
+// returns false if the metric is not defined.
+func RenderMetricExpr(metric string, labels []common.KVPair, groupLabels []string, interval int64) (string, bool) {
+	exprTemplate, ok := metricExprConfig[metric]
+	if !ok {
+		return "", false
+	}
+	return replaceQueryVariables(exprTemplate, labels, groupLabels, interval), true
+}
+
 func extractMetricData(name string, resp *model.PrometheusQueryRangeResponse, groupLabels []string) []model.MetricData {
 	metricDatas := make([]model.MetricData, 0)
 	for _, result := range resp.Data.Result {
@@ -203,6 +214,9 @@ func QueryMetricData(queryParam *model.MetricQuery) []model.MetricData {
 	metricDatas := make([]model.MetricData, 0, len(queryParam.Metrics))
 	client, err := external.GetPrometheusClientFromConfig()
 	if err != nil {
+		if isCollectionEnabled() {
+			return queryLocalMetricData(queryParam)
+		}
 		return metricDatas
 	}
 	wg := sync.WaitGroup{}
@@ -243,3 +257,35 @@ func QueryMetricData(queryParam *model.MetricQuery) []model.MetricData {
 	}
 	return metricDatas
 }
+
+// queryLocalMetricData queries the metric data from the local storage of the embedded metric collection.
+func queryLocalMetricData(queryParam *model.MetricQuery) []model.MetricData {
+	metricDatas := make([]model.MetricData, 0, len(queryParam.Metrics))
+	wg := sync.WaitGroup{}
+	metricDataCh := make(chan []model.MetricData, len(queryParam.Metrics))
+	for _, m := range queryParam.Metrics {
+		exprTemplate, found := metricExprConfig[m]
+		if !found {
+			log.Errorf("Metric expression for %s not found", m)
+			continue
+		}
+		wg.Add(1)
+		go func(m string) {
+			defer wg.Done()
+			expr := replaceQueryVariables(exprTemplate, queryParam.Labels, queryParam.GroupLabels, queryParam.QueryRange.Step)
+			log.Infof("Query local storage with expr: %s, range: %v", expr, queryParam.QueryRange)
+			queryRangeResp, err := queryLocalStorage(context.Background(), expr, &queryParam.QueryRange)
+			if err != nil {
+				log.WithError(err).Error("Query expression from local storage got error")
+				return
+			}
+			metricDataCh <- extractMetricData(m, queryRangeResp, queryParam.GroupLabels)
+		}(m)
+	}
+	wg.Wait()
+	close(metricDataCh)
+	for metricDataArray := range metricDataCh {
+		metricDatas = append(metricDatas, metricDataArray...)
+	}
+	return metricDatas
+}
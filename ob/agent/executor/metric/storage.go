@@ -0,0 +1,342 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metric
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/prometheus/model/histogram"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/tsdb"
+	"github.com/prometheus/prometheus/tsdb/chunkenc"
+	"github.com/prometheus/prometheus/tsdb/chunks"
+	"github.com/prometheus/prometheus/util/annotations"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	metricconstant "github.com/oceanbase/obshell/ob/agent/executor/metric/constant"
+	"github.com/oceanbase/obshell/ob/agent/lib/path"
+	"github.com/oceanbase/obshell/ob/agent/meta"
+	"github.com/oceanbase/obshell/ob/agent/secure"
+	model "github.com/oceanbase/obshell/ob/model/metric"
+)
+
+// localStorage keeps the samples of the embedded metric collection in a bounded tsdb,
+// and evaluates the metric expressions with the promql engine.
+var (
+	localDB     *tsdb.DB
+	localDBCfg  model.CollectionConfig
+	localDBLock sync.RWMutex
+
+	queryEngine = promql.NewEngine(promql.EngineOpts{
+		MaxSamples:    metricconstant.LOCAL_QUERY_MAX_SAMPLES,
+		Timeout:       metricconstant.DEFAULT_TIMEOUT * time.Second,
+		LookbackDelta: metricconstant.LOCAL_QUERY_LOOKBACK_DELTA * time.Second,
+	})
+)
+
+// openLocalStorage opens the local storage, it will be reopened when the bounds changed.
+func openLocalStorage(cfg *model.CollectionConfig) error {
+	localDBLock.Lock()
+	defer localDBLock.Unlock()
+	if localDB != nil {
+		if localDBCfg.RetentionHours == cfg.RetentionHours && localDBCfg.MaxSizeMB == cfg.MaxSizeMB {
+			return nil
+		}
+		if err := localDB.Close(); err != nil {
+			log.WithError(err).Warn("close local metric storage failed")
+		}
+		localDB = nil
+	}
+
+	opts := tsdb.DefaultOptions()
+	opts.RetentionDuration = int64(time.Duration(cfg.RetentionHours) * time.Hour / time.Millisecond)
+	opts.MaxBytes = cfg.MaxSizeMB << 20
+	db, err := tsdb.Open(path.MetricDir(), nil, nil, opts, nil)
+	if err != nil {
+		return errors.Occur(errors.ErrMetricCollectionStorageFailed, err.Error())
+	}
+	log.Infof("local metric storage opened, retention %dh, max size %dMB", cfg.RetentionHours, cfg.MaxSizeMB)
+	localDB = db
+	localDBCfg = *cfg
+	return nil
+}
+
+// closeLocalStorage closes the local storage, the collected samples are kept on disk.
+func closeLocalStorage() {
+	localDBLock.Lock()
+	defer localDBLock.Unlock()
+	if localDB == nil {
+		return
+	}
+	if err := localDB.Close(); err != nil {
+		log.WithError(err).Warn("close local metric storage failed")
+	}
+	localDB = nil
+	log.Info("local metric storage closed")
+}
+
+func isLocalStorageOpened() bool {
+	localDBLock.RLock()
+	defer localDBLock.RUnlock()
+	return localDB != nil
+}
+
+func appendSamples(samples []model.Sample, ts time.Time) error {
+	localDBLock.RLock()
+	defer localDBLock.RUnlock()
+	if localDB == nil {
+		return errors.Occur(errors.ErrMetricCollectionStorageFailed, "storage is not opened")
+	}
+
+	app := localDB.Appender(context.Background())
+	t := ts.UnixMilli()
+	failed := 0
+	for _, sample := range samples {
+		m := make(map[string]string, len(sample.Labels)+1)
+		for k, v := range sample.Labels {
+			if v != "" {
+				m[k] = v
+			}
+		}
+		m[metricconstant.LABEL_NAME] = sample.Name
+		if _, err := app.Append(0, labels.FromMap(m), t, sample.Value); err != nil {
+			failed++
+			log.WithError(err).Debugf("append sample %s failed", sample.Name)
+		}
+	}
+	if err := app.Commit(); err != nil {
+		return errors.Occur(errors.ErrMetricCollectionStorageFailed, err.Error())
+	}
+	if failed > 0 {
+		log.Warnf("%d of %d samples are dropped by local metric storage", failed, len(samples))
+	}
+	return nil
+}
+
+// SelectLocalSeries returns the raw series in the local storage which match the query,
+// used by the other agents to evaluate the queries.
+func SelectLocalSeries(query *model.SeriesQuery) ([]model.Series, error) {
+	matchers := make([]*labels.Matcher, 0, len(query.Matchers))
+	for _, m := range query.Matchers {
+		matchType, ok := matchTypes[m.Type]
+		if !ok {
+			return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "matchers", "unknown match type "+m.Type)
+		}
+		matcher, err := labels.NewMatcher(matchType, m.Name, m.Value)
+		if err != nil {
+			return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "matchers", err.Error())
+		}
+		matchers = append(matchers, matcher)
+	}
+
+	localDBLock.RLock()
+	defer localDBLock.RUnlock()
+	if localDB == nil {
+		return nil, errors.Occur(errors.ErrMetricCollectionStorageFailed, "storage is not opened")
+	}
+	querier, err := localDB.Querier(query.Start, query.End)
+	if err != nil {
+		return nil, errors.Occur(errors.ErrMetricCollectionStorageFailed, err.Error())
+	}
+	defer querier.Close()
+
+	result := make([]model.Series, 0)
+	set := querier.Select(context.Background(), false, nil, matchers...)
+	var it chunkenc.Iterator
+	for set.Next() {
+		s := set.At()
+		series := model.Series{Labels: s.Labels().Map()}
+		it = s.Iterator(it)
+		for it.Next() == chunkenc.ValFloat {
+			t, v := it.At()
+			series.Timestamps = append(series.Timestamps, t)
+			series.Values = append(series.Values, v)
+		}
+		if err := it.Err(); err != nil {
+			return nil, errors.Occur(errors.ErrMetricCollectionStorageFailed, err.Error())
+		}
+		result = append(result, series)
+	}
+	if err := set.Err(); err != nil {
+		return nil, errors.Occur(errors.ErrMetricCollectionStorageFailed, err.Error())
+	}
+	return result, nil
+}
+
+// queryLocalStorage evaluates the expression over the range, the result is in the format of
+// the Prometheus range query so that it can be handled as the one from Prometheus.
+// The series collected by the other agents are merged into the local ones,
+// the unreachable agents are skipped.
+func queryLocalStorage(ctx context.Context, expr string, queryRange *model.QueryRange) (*model.PrometheusQueryRangeResponse, error) {
+	localDBLock.RLock()
+	defer localDBLock.RUnlock()
+	if localDB == nil {
+		return nil, errors.Occur(errors.ErrMetricCollectionStorageFailed, "storage is not opened")
+	}
+
+	start := time.UnixMilli(int64(queryRange.StartTimestamp * 1000))
+	end := time.UnixMilli(int64(queryRange.EndTimestamp * 1000))
+	step := time.Duration(queryRange.Step) * time.Second
+	if step <= 0 {
+		step = metricconstant.DEFAULT_COLLECT_INTERVAL * time.Second
+	}
+	peers := getPeerAgents()
+	queryable := storage.QueryableFunc(func(mint, maxt int64) (storage.Querier, error) {
+		local, err := localDB.Querier(mint, maxt)
+		if err != nil {
+			return nil, err
+		}
+		secondaries := make([]storage.Querier, 0, len(peers))
+		for i := range peers {
+			secondaries = append(secondaries, &peerQuerier{agent: &peers[i], mint: mint, maxt: maxt})
+		}
+		return storage.NewMergeQuerier([]storage.Querier{local}, secondaries, storage.ChainedSeriesMerge), nil
+	})
+	query, err := queryEngine.NewRangeQuery(ctx, queryable, nil, expr, start, end, step)
+	if err != nil {
+		return nil, errors.Wrap(err, "create local range query failed")
+	}
+	defer query.Close()
+	result := query.Exec(ctx)
+	if result.Err != nil {
+		return nil, errors.Wrap(result.Err, "execute local range query failed")
+	}
+	for _, warning := range result.Warnings.AsErrors() {
+		log.WithError(warning).Warn("local range query got warning")
+	}
+	matrix, err := result.Matrix()
+	if err != nil {
+		return nil, errors.Wrap(err, "convert local query result failed")
+	}
+
+	resp := &model.PrometheusQueryRangeResponse{Status: "success"}
+	resp.Data.ResultType = "matrix"
+	resp.Data.Result = make([]model.PrometheusRangeResult, 0, len(matrix))
+	for _, series := range matrix {
+		values := make([][]interface{}, 0, len(series.Floats))
+		for _, point := range series.Floats {
+			values = append(values, []interface{}{float64(point.T) / 1000, strconv.FormatFloat(point.F, 'f', -1, 64)})
+		}
+		resp.Data.Result = append(resp.Data.Result, model.PrometheusRangeResult{
+			Metric: series.Metric.Map(),
+			Values: values,
+		})
+	}
+	return resp, nil
+}
+
+var matchTypes = map[string]labels.MatchType{
+	labels.MatchEqual.String():     labels.MatchEqual,
+	labels.MatchNotEqual.String():  labels.MatchNotEqual,
+	labels.MatchRegexp.String():    labels.MatchRegexp,
+	labels.MatchNotRegexp.String(): labels.MatchNotRegexp,
+}
+
+func getPeerAgents() []meta.AgentInfo {
+	agents, err := agentService.GetAllAgentsInfoFromOB()
+	if err != nil {
+		log.WithError(err).Warn("get all agents failed, only the local series are queried")
+		return nil
+	}
+	peers := make([]meta.AgentInfo, 0, len(agents))
+	for _, agent := range agents {
+		if !meta.OCS_AGENT.Equal(&agent) {
+			peers = append(peers, agent)
+		}
+	}
+	return peers
+}
+
+// peerQuerier selects the series from the local storage of the other agent.
+type peerQuerier struct {
+	agent      *meta.AgentInfo
+	mint, maxt int64
+}
+
+func (q *peerQuerier) Select(ctx context.Context, sortSeries bool, hints *storage.SelectHints, matchers ...*labels.Matcher) storage.SeriesSet {
+	query := model.SeriesQuery{Start: q.mint, End: q.maxt}
+	if hints != nil {
+		query.Start, query.End = hints.Start, hints.End
+	}
+	for _, m := range matchers {
+		query.Matchers = append(query.Matchers, model.LabelMatcher{Type: m.Type.String(), Name: m.Name, Value: m.Value})
+	}
+	var result []model.Series
+	if err := secure.SendPostRequest(q.agent, constant.URI_METRIC_RPC_PREFIX+constant.URI_SERIES, query, &result); err != nil {
+		return storage.ErrSeriesSet(errors.Wrapf(err, "select series from %s failed", q.agent.String()))
+	}
+
+	series := make([]storage.Series, 0, len(result))
+	for _, s := range result {
+		samples := make([]chunks.Sample, 0, len(s.Timestamps))
+		for i := range s.Timestamps {
+			if i < len(s.Values) {
+				samples = append(samples, floatSample{t: s.Timestamps[i], f: s.Values[i]})
+			}
+		}
+		series = append(series, storage.NewListSeries(labels.FromMap(s.Labels), samples))
+	}
+	if sortSeries {
+		sort.Slice(series, func(i, j int) bool { return labels.Compare(series[i].Labels(), series[j].Labels()) < 0 })
+	}
+	return &listSeriesSet{series: series, cur: -1}
+}
+
+func (q *peerQuerier) LabelValues(ctx context.Context, name string, matchers ...*labels.Matcher) ([]string, annotations.Annotations, error) {
+	return nil, nil, nil
+}
+
+func (q *peerQuerier) LabelNames(ctx context.Context, matchers ...*labels.Matcher) ([]string, annotations.Annotations, error) {
+	return nil, nil, nil
+}
+
+func (q *peerQuerier) Close() error {
+	return nil
+}
+
+type listSeriesSet struct {
+	series []storage.Series
+	cur    int
+}
+
+func (s *listSeriesSet) Next() bool {
+	s.cur++
+	return s.cur < len(s.series)
+}
+
+func (s *listSeriesSet) At() storage.Series                { return s.series[s.cur] }
+func (s *listSeriesSet) Err() error                        { return nil }
+func (s *listSeriesSet) Warnings() annotations.Annotations { return nil }
+
+type floatSample struct {
+	t int64
+	f float64
+}
+
+func (s floatSample) T() int64                      { return s.t }
+func (s floatSample) F() float64                    { return s.f }
+func (s floatSample) H() *histogram.Histogram       { return nil }
+func (s floatSample) FH() *histogram.FloatHistogram { return nil }
+func (s floatSample) Type() chunkenc.ValueType      { return chunkenc.ValFloat }
@@ -384,17 +384,19 @@ func (t *StartBackupTask) Execute() error {
 		return errors.Wrap(err, "get params")
 	}
 
-	if t.mode == constant.BACKUP_MODE_FULL {
-		for _, tenant := range t.tenants {
+	for _, tenant := range t.tenants {
+		encryption, err := t.getTenantEncryption(&tenant)
+		if err != nil {
+			return err
+		}
+		if t.mode == constant.BACKUP_MODE_FULL {
 			t.ExecuteLogf("Start full backup of %s(%d)", tenant.TenantName, tenant.TenantID)
-			if err := tenantService.StartFullBackup(tenant.TenantName, t.encryption, t.plusArchive); err != nil {
+			if err := tenantService.StartFullBackup(tenant.TenantName, encryption, t.plusArchive); err != nil {
 				return errors.Wrap(err, "start full backup")
 			}
-		}
-	} else {
-		for _, tenant := range t.tenants {
+		} else {
 			t.ExecuteLogf("Start incremental backup of %s(%d)", tenant.TenantName, tenant.TenantID)
-			if err := tenantService.StartIncrementalBackup(tenant.TenantName, t.encryption, t.plusArchive); err != nil {
+			if err := tenantService.StartIncrementalBackup(tenant.TenantName, encryption, t.plusArchive); err != nil {
 				return errors.Wrap(err, "start incremental backup")
 			}
 		}
@@ -402,6 +404,22 @@ func (t *StartBackupTask) Execute() error {
 	return nil
 }
 
+// getTenantEncryption returns the backup password of the tenant,
+// the configured one is used when no password is specified in the backup.
+func (t *StartBackupTask) getTenantEncryption(tenant *oceanbase.DbaObTenant) (string, error) {
+	if t.encryption != "" {
+		return t.encryption, nil
+	}
+	password, _, err := getTenantBackupEncryption(tenant.TenantID)
+	if err != nil {
+		return "", errors.Wrap(err, "get backup encryption")
+	}
+	if password != "" {
+		t.ExecuteLogf("Use the configured backup password of %s(%d)", tenant.TenantName, tenant.TenantID)
+	}
+	return password, nil
+}
+
 func (t *StartBackupTask) getParams() (err error) {
 	if err = t.GetContext().GetParamWithValue(PARAM_BACKUP_MODE, &t.mode); err != nil {
 		return err
@@ -450,11 +468,20 @@ func (t *WaitBackupTaskFinish) Execute() (err error) {
 	if err != nil {
 		return errors.Wrap(err, "get tenant from context")
 	}
+	var encryption string
+	if t.GetContext().GetParam(PARAM_BACKUP_ENCRYPTION) != nil {
+		if err = t.GetContext().GetParamWithValue(PARAM_BACKUP_ENCRYPTION, &encryption); err != nil {
+			return err
+		}
+	}
 
 	for _, tenant := range t.tenants {
 		if err := waitBackupFinish(t, &tenant); err != nil {
 			return err
 		}
+		if err := recordBackupSetEncryption(t, &tenant, encryption); err != nil {
+			return errors.Wrap(err, "record backup set encryption")
+		}
 	}
 	return nil
 }
@@ -0,0 +1,418 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/engine/coordinator"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/lib/system"
+	"github.com/oceanbase/obshell/ob/agent/meta"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+var (
+	complianceOnce sync.Once
+
+	// latestCompliances keeps the result of the latest check of every tenant, which is exposed as metrics.
+	latestCompliances     []bo.BackupCompliance
+	latestCompliancesLock sync.RWMutex
+)
+
+func parseBackupTargetDuration(name, value string) (int64, error) {
+	duration, err := system.ParseTime(value)
+	if err != nil || duration < time.Second {
+		return 0, errors.Occur(errors.ErrObBackupRpoTargetInvalid, name, value)
+	}
+	return int64(duration / time.Second), nil
+}
+
+func formatSeconds(seconds int64) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
+
+func newBackupRpoTargetBO(target *oceanbase.BackupRpoTarget) *bo.BackupRpoTarget {
+	return &bo.BackupRpoTarget{
+		TenantID:           target.TenantID,
+		TenantName:         target.TenantName,
+		RpoTarget:          target.RpoTarget,
+		FullBackupInterval: target.FullBackupInterval,
+		IncBackupInterval:  target.IncBackupInterval,
+		UpdateTime:         target.UpdateTime,
+	}
+}
+
+// SetTenantBackupRpoTarget sets the rpo target of the tenant,
+// the backup intervals which are not specified keep unchanged.
+func SetTenantBackupRpoTarget(tenant *oceanbase.DbaObTenant, p *param.BackupRpoTargetParam) (*bo.BackupRpoTarget, error) {
+	rpoTarget, err := parseBackupTargetDuration("rpo_target", p.RpoTarget)
+	if err != nil {
+		return nil, err
+	}
+	target, err := tenantService.GetBackupRpoTarget(tenant.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		target = &oceanbase.BackupRpoTarget{TenantID: tenant.TenantID}
+	}
+	target.TenantName = tenant.TenantName
+	target.RpoTarget = rpoTarget
+	if p.FullBackupInterval != nil {
+		target.FullBackupInterval = 0
+		if *p.FullBackupInterval != "" {
+			if target.FullBackupInterval, err = parseBackupTargetDuration("full_backup_interval", *p.FullBackupInterval); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if p.IncBackupInterval != nil {
+		target.IncBackupInterval = 0
+		if *p.IncBackupInterval != "" {
+			if target.IncBackupInterval, err = parseBackupTargetDuration("incremental_backup_interval", *p.IncBackupInterval); err != nil {
+				return nil, err
+			}
+		}
+	}
+	target.UpdateTime = time.Now()
+	if err = tenantService.SaveBackupRpoTarget(target); err != nil {
+		return nil, err
+	}
+	log.Infof("set rpo target of %s(%d) to %ds", tenant.TenantName, tenant.TenantID, rpoTarget)
+	return newBackupRpoTargetBO(target), nil
+}
+
+func GetTenantBackupRpoTarget(tenant *oceanbase.DbaObTenant) (*bo.BackupRpoTarget, error) {
+	target, err := tenantService.GetBackupRpoTarget(tenant.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, errors.Occur(errors.ErrObBackupRpoTargetNotExist, tenant.TenantName)
+	}
+	return newBackupRpoTargetBO(target), nil
+}
+
+// DeleteTenantBackupRpoTarget stops checking the tenant, the daily records are kept for the report.
+func DeleteTenantBackupRpoTarget(tenant *oceanbase.DbaObTenant) error {
+	return tenantService.DeleteBackupRpoTarget(tenant.TenantID)
+}
+
+func GetTenantBackupCompliance(tenant *oceanbase.DbaObTenant) (*bo.BackupCompliance, error) {
+	target, err := tenantService.GetBackupRpoTarget(tenant.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, errors.Occur(errors.ErrObBackupRpoTargetNotExist, tenant.TenantName)
+	}
+	return checkTenantBackupCompliance(tenant, target, time.Now())
+}
+
+// GetObclusterBackupCompliance checks all the tenants which have rpo targets.
+func GetObclusterBackupCompliance() ([]bo.BackupCompliance, error) {
+	targets, err := tenantService.ListBackupRpoTargets()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	compliances := make([]bo.BackupCompliance, 0, len(targets))
+	for i := range targets {
+		tenant, err := tenantService.GetTenantByID(targets[i].TenantID)
+		if err != nil {
+			return nil, err
+		}
+		if tenant == nil {
+			continue
+		}
+		compliance, err := checkTenantBackupCompliance(tenant, &targets[i], now)
+		if err != nil {
+			return nil, err
+		}
+		compliances = append(compliances, *compliance)
+	}
+	return compliances, nil
+}
+
+// checkTenantBackupCompliance calculates the rpo of the tenant, which is the time elapsed since the latest point
+// the tenant can be restored to: the archive checkpoint if the log archive is doing, otherwise the end of the latest backup set.
+func checkTenantBackupCompliance(tenant *oceanbase.DbaObTenant, target *oceanbase.BackupRpoTarget, now time.Time) (*bo.BackupCompliance, error) {
+	compliance := &bo.BackupCompliance{
+		TenantID:   tenant.TenantID,
+		TenantName: tenant.TenantName,
+		Target:     *newBackupRpoTargetBO(target),
+		Rpo:        -1,
+		Violations: make([]string, 0),
+		Warnings:   make([]string, 0),
+		CheckTime:  now,
+	}
+
+	sets, err := tenantService.ListBackupSetFiles(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "list backup set files")
+	}
+	for i := range sets {
+		set := &sets[i]
+		if set.Status != constant.BACKUP_SET_STATUS_SUCCESS || set.FileStatus != constant.BACKUP_FILE_STATUS_AVAILABLE || set.EndTimestamp == nil {
+			continue
+		}
+		if set.BackupType == constant.BACKUP_TYPE_FULL {
+			if compliance.LastFullBackupTime == nil || set.EndTimestamp.After(*compliance.LastFullBackupTime) {
+				compliance.LastFullBackupTime = set.EndTimestamp
+			}
+		} else if compliance.LastIncBackupTime == nil || set.EndTimestamp.After(*compliance.LastIncBackupTime) {
+			compliance.LastIncBackupTime = set.EndTimestamp
+		}
+	}
+	lastBackupTime := compliance.LastFullBackupTime
+	if lastBackupTime != nil && compliance.LastIncBackupTime != nil && compliance.LastIncBackupTime.After(*lastBackupTime) {
+		lastBackupTime = compliance.LastIncBackupTime
+	}
+
+	archive, err := tenantService.GetLatestArchiveLog(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get latest archive log")
+	}
+	if archive != nil {
+		compliance.ArchiveStatus = archive.Status
+	}
+	if parameter, err := tenantService.GetTenantParameter(tenant.TenantID, "archive_lag_target"); err != nil {
+		log.WithError(err).Warnf("get archive_lag_target of %s failed", tenant.TenantName)
+	} else if parameter != nil {
+		compliance.ArchiveLagTarget = parameter.Value
+		if lagTarget, err := system.ParseTime(parameter.Value); err == nil && int64(lagTarget/time.Second) >= target.RpoTarget {
+			compliance.Warnings = append(compliance.Warnings,
+				fmt.Sprintf("archive_lag_target %s is not less than the RPO target %s", parameter.Value, formatSeconds(target.RpoTarget)))
+		}
+	}
+
+	if compliance.LastFullBackupTime == nil {
+		compliance.Violations = append(compliance.Violations, "no available full backup set")
+	} else if archive != nil && archive.Status == constant.ARCHIVELOG_STATUS_DOING && archive.CheckpointScnDisplay.After(*lastBackupTime) {
+		compliance.ArchiveCheckpoint = &archive.CheckpointScnDisplay
+		compliance.Rpo = int64(archive.Delay)
+	} else {
+		compliance.Rpo = int64(now.Sub(*lastBackupTime) / time.Second)
+		if compliance.ArchiveStatus != constant.ARCHIVELOG_STATUS_DOING {
+			compliance.Warnings = append(compliance.Warnings, fmt.Sprintf("log archive is not doing, current status: '%s'", compliance.ArchiveStatus))
+		}
+	}
+	if compliance.Rpo > target.RpoTarget {
+		compliance.Violations = append(compliance.Violations,
+			fmt.Sprintf("RPO %s exceeds the target %s", formatSeconds(compliance.Rpo), formatSeconds(target.RpoTarget)))
+	}
+	if target.FullBackupInterval > 0 && compliance.LastFullBackupTime != nil &&
+		now.Sub(*compliance.LastFullBackupTime) > time.Duration(target.FullBackupInterval)*time.Second {
+		compliance.Violations = append(compliance.Violations, fmt.Sprintf("no full backup in the last %s", formatSeconds(target.FullBackupInterval)))
+	}
+	if target.IncBackupInterval > 0 && lastBackupTime != nil &&
+		now.Sub(*lastBackupTime) > time.Duration(target.IncBackupInterval)*time.Second {
+		compliance.Violations = append(compliance.Violations, fmt.Sprintf("no backup in the last %s", formatSeconds(target.IncBackupInterval)))
+	}
+
+	compliance.Status = constant.BACKUP_COMPLIANCE_STATUS_COMPLIANT
+	if len(compliance.Violations) != 0 {
+		compliance.Status = constant.BACKUP_COMPLIANCE_STATUS_VIOLATED
+	}
+	return compliance, nil
+}
+
+// GetBackupComplianceReport reports the daily compliance of the tenant, nil tenant means all tenants.
+// The days without any check after the first check of the tenant are reported as not compliant.
+func GetBackupComplianceReport(tenant *oceanbase.DbaObTenant, p *param.BackupComplianceReportParam) (*bo.BackupComplianceReport, error) {
+	now := time.Now()
+	endDate := now.Format(constant.BACKUP_COMPLIANCE_DATE_FORMAT)
+	if p.EndDate != "" {
+		endDate = p.EndDate
+	}
+	end, err := time.ParseInLocation(constant.BACKUP_COMPLIANCE_DATE_FORMAT, endDate, time.Local)
+	if err != nil {
+		return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "end_date", "the format should be "+constant.BACKUP_COMPLIANCE_DATE_FORMAT)
+	}
+	start := end.AddDate(0, 0, 1-constant.BACKUP_COMPLIANCE_REPORT_DEFAULT_DAYS)
+	if p.StartDate != "" {
+		if start, err = time.ParseInLocation(constant.BACKUP_COMPLIANCE_DATE_FORMAT, p.StartDate, time.Local); err != nil {
+			return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "start_date", "the format should be "+constant.BACKUP_COMPLIANCE_DATE_FORMAT)
+		}
+	}
+	if start.After(end) || end.Sub(start) >= constant.BACKUP_COMPLIANCE_REPORT_MAX_DAYS*24*time.Hour {
+		return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "start_date",
+			fmt.Sprintf("should be before end_date and within %d days", constant.BACKUP_COMPLIANCE_REPORT_MAX_DAYS))
+	}
+
+	tenantID := 0
+	if tenant != nil {
+		tenantID = tenant.TenantID
+	}
+	startDate := start.Format(constant.BACKUP_COMPLIANCE_DATE_FORMAT)
+	endDate = end.Format(constant.BACKUP_COMPLIANCE_DATE_FORMAT)
+	records, err := tenantService.ListBackupComplianceDaily(tenantID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	recordMap := make(map[int]map[string]*oceanbase.BackupComplianceDaily)
+	tenantIDs := make([]int, 0)
+	for i := range records {
+		record := &records[i]
+		if _, ok := recordMap[record.TenantID]; !ok {
+			recordMap[record.TenantID] = make(map[string]*oceanbase.BackupComplianceDaily)
+			tenantIDs = append(tenantIDs, record.TenantID)
+		}
+		recordMap[record.TenantID][record.Date] = record
+	}
+
+	report := &bo.BackupComplianceReport{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Compliant: true,
+		Days:      make([]bo.BackupComplianceDaily, 0, len(records)),
+	}
+	today := now.Format(constant.BACKUP_COMPLIANCE_DATE_FORMAT)
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		date := day.Format(constant.BACKUP_COMPLIANCE_DATE_FORMAT)
+		if date > today {
+			break
+		}
+		expected := constant.BACKUP_COMPLIANCE_CHECKS_PER_DAY
+		if date == today {
+			expected = int(now.Sub(day) / constant.BACKUP_COMPLIANCE_CHECK_INTERVAL)
+		}
+		for _, id := range tenantIDs {
+			daily := bo.BackupComplianceDaily{Date: date, TenantID: id, ExpectedCheckCount: expected}
+			if record, ok := recordMap[id][date]; ok {
+				daily.TenantName = record.TenantName
+				daily.RpoTarget = record.RpoTarget
+				daily.CheckCount = record.CheckCount
+				daily.CompliantCount = record.CompliantCount
+				daily.MaxRpo = record.MaxRpo
+				daily.FirstViolationTime = record.FirstViolationTime
+				daily.LastViolation = record.LastViolation
+				daily.Compliant = record.CheckCount > 0 && record.CompliantCount == record.CheckCount
+			} else if last := lastRecordBefore(recordMap[id], date); last != nil {
+				daily.TenantName = last.TenantName
+				daily.RpoTarget = last.RpoTarget
+				daily.LastViolation = "no compliance check"
+			} else {
+				continue
+			}
+			report.Compliant = report.Compliant && daily.Compliant
+			report.Days = append(report.Days, daily)
+		}
+	}
+	return report, nil
+}
+
+func lastRecordBefore(records map[string]*oceanbase.BackupComplianceDaily, date string) *oceanbase.BackupComplianceDaily {
+	var last *oceanbase.BackupComplianceDaily
+	for d, record := range records {
+		if d < date && (last == nil || d > last.Date) {
+			last = record
+		}
+	}
+	return last
+}
+
+// StartBackupComplianceCheck checks the compliance of the tenants which have rpo targets in background.
+// Every agent runs it, but only the maintainer of the cluster records the checks.
+func StartBackupComplianceCheck() {
+	complianceOnce.Do(func() {
+		go backupComplianceLoop()
+	})
+}
+
+// GetLatestBackupCompliances returns the result of the latest check, which is empty if the agent is not the maintainer.
+func GetLatestBackupCompliances() []bo.BackupCompliance {
+	latestCompliancesLock.RLock()
+	defer latestCompliancesLock.RUnlock()
+	return latestCompliances
+}
+
+func setLatestBackupCompliances(compliances []bo.BackupCompliance) {
+	latestCompliancesLock.Lock()
+	defer latestCompliancesLock.Unlock()
+	latestCompliances = compliances
+}
+
+func backupComplianceLoop() {
+	ticker := time.NewTicker(constant.BACKUP_COMPLIANCE_CHECK_INTERVAL)
+	defer ticker.Stop()
+	for range ticker.C {
+		if !isBackupComplianceChecker() {
+			setLatestBackupCompliances(nil)
+			continue
+		}
+		setLatestBackupCompliances(recordBackupCompliances(time.Now()))
+	}
+}
+
+func isBackupComplianceChecker() bool {
+	return meta.OCS_AGENT != nil && meta.OCS_AGENT.IsClusterAgent() &&
+		coordinator.OCS_COORDINATOR != nil && coordinator.OCS_COORDINATOR.IsMaintainer()
+}
+
+func recordBackupCompliances(now time.Time) []bo.BackupCompliance {
+	targets, err := tenantService.ListBackupRpoTargets()
+	if err != nil {
+		log.WithError(err).Debug("list rpo targets failed")
+		return nil
+	}
+	date := now.Format(constant.BACKUP_COMPLIANCE_DATE_FORMAT)
+	compliances := make([]bo.BackupCompliance, 0, len(targets))
+	for i := range targets {
+		target := &targets[i]
+		tenant, err := tenantService.GetTenantByID(target.TenantID)
+		if err != nil {
+			log.WithError(err).Warnf("get tenant %d failed", target.TenantID)
+			continue
+		}
+		if tenant == nil {
+			log.Infof("tenant %s(%d) has been dropped, remove its rpo target", target.TenantName, target.TenantID)
+			if err := tenantService.DeleteBackupRpoTarget(target.TenantID); err != nil {
+				log.WithError(err).Warnf("remove rpo target of tenant %d failed", target.TenantID)
+			}
+			continue
+		}
+
+		violation := ""
+		rpo := int64(-1)
+		compliance, err := checkTenantBackupCompliance(tenant, target, now)
+		if err != nil {
+			violation = fmt.Sprintf("check failed: %s", err.Error())
+		} else {
+			rpo = compliance.Rpo
+			violation = strings.Join(compliance.Violations, "; ")
+			compliances = append(compliances, *compliance)
+		}
+		if len(violation) > constant.BACKUP_COMPLIANCE_REASON_LENGTH {
+			violation = violation[:constant.BACKUP_COMPLIANCE_REASON_LENGTH]
+		}
+		if err := tenantService.RecordBackupCompliance(target, now, date, rpo, violation); err != nil {
+			log.WithError(err).Warnf("record backup compliance of %s failed", tenant.TenantName)
+		}
+	}
+	return compliances
+}
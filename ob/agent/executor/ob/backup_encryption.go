@@ -0,0 +1,194 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/ob/agent/engine/task"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/lib/system"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/ob/agent/secure"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+func encryptBackupSecret(secret string) (string, error) {
+	if secret == "" {
+		return "", nil
+	}
+	return secure.EncryptCredentialPassphrase(secret)
+}
+
+func decryptBackupSecret(secret string) (string, error) {
+	if secret == "" {
+		return "", nil
+	}
+	return secure.DecryptCredentialPassphrase(secret)
+}
+
+// setTenantBackupEncryption merges the encryption config into the stored one,
+// the config will be removed when both the password and the kms encrypt info are empty.
+func setTenantBackupEncryption(tenant *oceanbase.DbaObTenant, p *param.BackupEncryptionParam) (err error) {
+	encryption, err := tenantService.GetBackupEncryption(tenant.TenantID)
+	if err != nil {
+		return errors.Wrap(err, "get backup encryption")
+	}
+	if encryption == nil {
+		encryption = &oceanbase.BackupEncryption{TenantID: tenant.TenantID}
+	}
+	encryption.TenantName = tenant.TenantName
+	if p.Password != nil {
+		if encryption.Password, err = encryptBackupSecret(*p.Password); err != nil {
+			return errors.Wrap(err, "encrypt backup password")
+		}
+	}
+	if p.KmsEncryptInfo != nil {
+		if encryption.KmsEncryptInfo, err = encryptBackupSecret(*p.KmsEncryptInfo); err != nil {
+			return errors.Wrap(err, "encrypt kms encrypt info")
+		}
+	}
+
+	if encryption.Password == "" && encryption.KmsEncryptInfo == "" {
+		log.Infof("remove backup encryption of %s(%d)", tenant.TenantName, tenant.TenantID)
+		return tenantService.DeleteBackupEncryption(tenant.TenantID)
+	}
+	log.Infof("save backup encryption of %s(%d)", tenant.TenantName, tenant.TenantID)
+	return tenantService.SaveBackupEncryption(encryption)
+}
+
+// getTenantBackupEncryption returns the decrypted password and kms encrypt info of the tenant.
+func getTenantBackupEncryption(tenantID int) (password, kmsEncryptInfo string, err error) {
+	encryption, err := tenantService.GetBackupEncryption(tenantID)
+	if err != nil || encryption == nil {
+		return "", "", err
+	}
+	if password, err = decryptBackupSecret(encryption.Password); err != nil {
+		return "", "", errors.Wrap(err, "decrypt backup password")
+	}
+	if kmsEncryptInfo, err = decryptBackupSecret(encryption.KmsEncryptInfo); err != nil {
+		return "", "", errors.Wrap(err, "decrypt kms encrypt info")
+	}
+	return
+}
+
+// recordBackupSetEncryption records the keys which protect the last backup set of the tenant.
+// The password specified in the backup takes precedence over the one in the config.
+func recordBackupSetEncryption(t task.ExecutableTask, tenant *oceanbase.DbaObTenant, password string) error {
+	encryption, err := tenantService.GetBackupEncryption(tenant.TenantID)
+	if err != nil {
+		return errors.Wrap(err, "get backup encryption")
+	}
+	record := &oceanbase.BackupSetEncryption{
+		TenantID:   tenant.TenantID,
+		TenantName: tenant.TenantName,
+	}
+	if encryption != nil {
+		record.Password = encryption.Password
+		record.KmsEncryptInfo = encryption.KmsEncryptInfo
+	}
+	if password != "" {
+		if record.Password, err = encryptBackupSecret(password); err != nil {
+			return errors.Wrap(err, "encrypt backup password")
+		}
+	}
+	if record.Password == "" && record.KmsEncryptInfo == "" {
+		return nil
+	}
+
+	backupTask, err := tenantService.GetLastBackupTask(tenant.TenantID)
+	if err != nil {
+		return errors.Wrap(err, "get last backup task")
+	}
+	if backupTask == nil || backupTask.BackupSetID == 0 {
+		t.ExecuteWarnLogf("No backup set found for %s(%d), skip recording the backup keys", tenant.TenantName, tenant.TenantID)
+		return nil
+	}
+	record.BackupSetID = backupTask.BackupSetID
+	record.Path = backupDestPath(backupTask.Path)
+	t.ExecuteLogf("Record the backup keys of backup set %d of %s(%d)", record.BackupSetID, tenant.TenantName, tenant.TenantID)
+	return tenantService.SaveBackupSetEncryption(record)
+}
+
+// backupDestPath returns the backup dest without the query params,
+// so that the path recorded by observer can be compared with the one specified by user.
+func backupDestPath(uri string) string {
+	uri = system.ObStorageURI(uri)
+	if i := strings.Index(uri, "?"); i >= 0 {
+		uri = uri[:i]
+	}
+	return strings.TrimRight(uri, "/")
+}
+
+// fillRestoreDecryption supplies the recorded keys of the backup sets in the data backup dest,
+// the keys specified by user are kept and the recorded ones are appended.
+// It should be called when the restore is executed, so that the decrypted keys are never persisted.
+func fillRestoreDecryption(dataBackupUri string, decryption **[]string, kmsEncryptInfo **string) {
+	backupSets, err := system.ReadBackupSets(dataBackupUri)
+	if err != nil {
+		log.WithError(err).Warn("read backup sets failed, skip supplying the recorded backup keys")
+		return
+	}
+	setIDs := make(map[int][]int64)
+	for _, set := range backupSets {
+		setIDs[set.TenantId] = append(setIDs[set.TenantId], int64(set.BackupSetID))
+	}
+
+	var passwords []string
+	if *decryption != nil {
+		passwords = append(passwords, **decryption...)
+	}
+	userPasswords := len(passwords)
+	for tenantID, ids := range setIDs {
+		records, err := tenantService.ListBackupSetEncryptions(tenantID, backupDestPath(dataBackupUri), ids...)
+		if err != nil {
+			log.WithError(err).Warnf("list backup keys of tenant %d failed", tenantID)
+			continue
+		}
+		for _, record := range records {
+			password, err := decryptBackupSecret(record.Password)
+			if err != nil {
+				log.WithError(err).Warnf("decrypt backup password of backup set %d failed", record.BackupSetID)
+			} else if password != "" && !containsString(passwords, password) {
+				passwords = append(passwords, password)
+			}
+			if *kmsEncryptInfo == nil && record.KmsEncryptInfo != "" {
+				kms, err := decryptBackupSecret(record.KmsEncryptInfo)
+				if err != nil {
+					log.WithError(err).Warnf("decrypt kms encrypt info of backup set %d failed", record.BackupSetID)
+				} else {
+					*kmsEncryptInfo = &kms
+				}
+			}
+		}
+	}
+	if len(passwords) > userPasswords {
+		log.Infof("supply %d recorded backup passwords for restore", len(passwords)-userPasswords)
+		*decryption = &passwords
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,437 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/engine/task"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+)
+
+const (
+	waitForBackupFileDeleted = 3600 // seconds
+)
+
+// GetTenantBackupCatalog lists the backup sets and archive pieces of the tenant
+// and marks whether each of them can be deleted manually.
+func GetTenantBackupCatalog(tenant *oceanbase.DbaObTenant) (*bo.BackupCatalog, error) {
+	sets, err := tenantService.ListBackupSetFiles(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "list backup set files")
+	}
+	pieces, err := tenantService.ListArchivelogPieceFiles(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "list archivelog piece files")
+	}
+	backupFinished, err := tenantService.IsBackupFinished(tenant.TenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "check backup finished")
+	}
+
+	// Only the sets which are still restorable protect their dependencies and logs.
+	var latestFullSetID, minReplayScn int64
+	dependents := make(map[int64][]int64)
+	for _, set := range sets {
+		if !isBackupSetRestorable(&set) {
+			continue
+		}
+		if set.BackupType == constant.BACKUP_TYPE_FULL {
+			latestFullSetID = set.BackupSetID
+		}
+		if minReplayScn == 0 || set.StartReplayScn < minReplayScn {
+			minReplayScn = set.StartReplayScn
+		}
+		for _, prev := range []int64{set.PrevFullBackupSetID, set.PrevIncBackupSetID} {
+			if prev != 0 && prev != set.BackupSetID && !containsInt64(dependents[prev], set.BackupSetID) {
+				dependents[prev] = append(dependents[prev], set.BackupSetID)
+			}
+		}
+	}
+
+	records, err := tenantService.ListBackupSetEncryptions(tenant.TenantID, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "list backup set encryptions")
+	}
+	keyRecorded := make(map[string]bool, len(records))
+	for _, record := range records {
+		keyRecorded[backupSetKey(record.Path, record.BackupSetID)] = true
+	}
+
+	catalog := &bo.BackupCatalog{
+		TenantID:      int64(tenant.TenantID),
+		TenantName:    tenant.TenantName,
+		BackupSets:    make([]bo.BackupSet, 0, len(sets)),
+		ArchivePieces: make([]bo.ArchivePiece, 0, len(pieces)),
+	}
+	for _, set := range sets {
+		backupSet := set.ToBO()
+		backupSet.DependentSetIDs = dependents[set.BackupSetID]
+		backupSet.KeyRecorded = keyRecorded[backupSetKey(backupDestPath(set.Path), set.BackupSetID)]
+		switch {
+		case !backupFinished:
+			backupSet.Reason = "backup job is running"
+		case set.FileStatus != constant.BACKUP_FILE_STATUS_AVAILABLE:
+			backupSet.Reason = fmt.Sprintf("file status is %s", set.FileStatus)
+		case len(backupSet.DependentSetIDs) != 0:
+			backupSet.Reason = fmt.Sprintf("backup sets %v depend on it", backupSet.DependentSetIDs)
+		case set.BackupSetID == latestFullSetID:
+			backupSet.Reason = "it is the latest available full backup set"
+		}
+		backupSet.Deletable = backupSet.Reason == ""
+		catalog.BackupSets = append(catalog.BackupSets, *backupSet)
+	}
+	for _, piece := range pieces {
+		archivePiece := piece.ToBO()
+		switch {
+		case !backupFinished:
+			archivePiece.Reason = "backup job is running"
+		case piece.FileStatus != constant.BACKUP_FILE_STATUS_AVAILABLE:
+			archivePiece.Reason = fmt.Sprintf("file status is %s", piece.FileStatus)
+		case piece.Status == constant.BACKUP_PIECE_STATUS_ACTIVE:
+			archivePiece.Reason = "it is still being archived"
+		case minReplayScn == 0:
+			// Without a restorable backup set, it is unknown which logs are needed by the next backup.
+			archivePiece.Reason = "there is no restorable backup set to decide whether its logs are needed"
+		case piece.EndScn > minReplayScn:
+			archivePiece.Reason = "its logs are needed to restore the available backup sets"
+		}
+		archivePiece.Deletable = archivePiece.Reason == ""
+		catalog.ArchivePieces = append(catalog.ArchivePieces, *archivePiece)
+	}
+	return catalog, nil
+}
+
+// backupSetKey identifies the backup set, the ids of the backup sets in different dests may be the same.
+func backupSetKey(destPath string, backupSetID int64) string {
+	return fmt.Sprintf("%s#%d", destPath, backupSetID)
+}
+
+func isBackupSetRestorable(set *oceanbase.CdbObBackupSetFile) bool {
+	return set.Status == constant.BACKUP_SET_STATUS_SUCCESS && set.FileStatus == constant.BACKUP_FILE_STATUS_AVAILABLE
+}
+
+func containsInt64(list []int64, target int64) bool {
+	for _, v := range list {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+func findBackupSet(catalog *bo.BackupCatalog, backupSetID int64) *bo.BackupSet {
+	for i := range catalog.BackupSets {
+		if catalog.BackupSets[i].BackupSetID == backupSetID {
+			return &catalog.BackupSets[i]
+		}
+	}
+	return nil
+}
+
+func findArchivePiece(catalog *bo.BackupCatalog, pieceID int64) *bo.ArchivePiece {
+	for i := range catalog.ArchivePieces {
+		if catalog.ArchivePieces[i].PieceID == pieceID {
+			return &catalog.ArchivePieces[i]
+		}
+	}
+	return nil
+}
+
+func checkBackupSetValidatable(tenant *oceanbase.DbaObTenant, backupSetID int64) error {
+	catalog, err := GetTenantBackupCatalog(tenant)
+	if err != nil {
+		return err
+	}
+	set := findBackupSet(catalog, backupSetID)
+	if set == nil {
+		return errors.Occur(errors.ErrObBackupSetNotExist, backupSetID, tenant.TenantName)
+	}
+	if set.Status != constant.BACKUP_SET_STATUS_SUCCESS {
+		return errors.Occur(errors.ErrObBackupSetNotValidatable, backupSetID, fmt.Sprintf("status is %s", set.Status))
+	}
+	if set.FileStatus != constant.BACKUP_FILE_STATUS_AVAILABLE {
+		return errors.Occur(errors.ErrObBackupSetNotValidatable, backupSetID, fmt.Sprintf("file status is %s", set.FileStatus))
+	}
+	// An incremental set is only restorable together with the sets it is based on.
+	for _, prev := range []int64{set.PrevFullBackupSetID, set.PrevIncBackupSetID} {
+		if prev == 0 || prev == backupSetID {
+			continue
+		}
+		prevSet := findBackupSet(catalog, prev)
+		if prevSet == nil {
+			return errors.Occur(errors.ErrObBackupSetNotValidatable, backupSetID, fmt.Sprintf("dependent backup set %d does not exist", prev))
+		}
+		if prevSet.FileStatus != constant.BACKUP_FILE_STATUS_AVAILABLE {
+			return errors.Occur(errors.ErrObBackupSetNotValidatable, backupSetID, fmt.Sprintf("dependent backup set %d is %s", prev, prevSet.FileStatus))
+		}
+	}
+	return nil
+}
+
+func checkBackupSetDeletable(tenant *oceanbase.DbaObTenant, backupSetID int64) error {
+	catalog, err := GetTenantBackupCatalog(tenant)
+	if err != nil {
+		return err
+	}
+	set := findBackupSet(catalog, backupSetID)
+	if set == nil {
+		return errors.Occur(errors.ErrObBackupSetNotExist, backupSetID, tenant.TenantName)
+	}
+	if !set.Deletable {
+		return errors.Occur(errors.ErrObBackupSetNotDeletable, backupSetID, set.Reason)
+	}
+	return nil
+}
+
+func checkBackupPieceDeletable(tenant *oceanbase.DbaObTenant, pieceID int64) error {
+	catalog, err := GetTenantBackupCatalog(tenant)
+	if err != nil {
+		return err
+	}
+	piece := findArchivePiece(catalog, pieceID)
+	if piece == nil {
+		return errors.Occur(errors.ErrObBackupPieceNotExist, pieceID, tenant.TenantName)
+	}
+	if !piece.Deletable {
+		return errors.Occur(errors.ErrObBackupPieceNotDeletable, pieceID, piece.Reason)
+	}
+	return nil
+}
+
+func TenantValidateBackupSet(tenant *oceanbase.DbaObTenant, backupSetID int64) (*task.DagDetailDTO, error) {
+	if err := checkBackupSetValidatable(tenant, backupSetID); err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("%s %d for %s", DAG_VALIDATE_BACKUP_SET, backupSetID, tenant.TenantName)
+	template := task.NewTemplateBuilder(name).
+		AddTask(newValidateBackupSetTask(), false).
+		Build()
+	ctx := task.NewTaskContext().
+		SetParam(PARAM_NEED_BACKUP_TENANT, tenant.TenantName).
+		SetParam(PARAM_BACKUP_SET_ID, backupSetID)
+
+	dag, err := taskService.CreateDagInstanceByTemplate(template, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+func TenantDeleteBackupSet(tenant *oceanbase.DbaObTenant, backupSetID int64) (*task.DagDetailDTO, error) {
+	if err := checkBackupSetDeletable(tenant, backupSetID); err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("%s %d for %s", DAG_DELETE_BACKUP_SET, backupSetID, tenant.TenantName)
+	ctx := task.NewTaskContext().
+		SetParam(PARAM_NEED_BACKUP_TENANT, tenant.TenantName).
+		SetParam(PARAM_BACKUP_SET_ID, backupSetID)
+	return createDeleteBackupFileDag(name, ctx)
+}
+
+func TenantDeleteBackupPiece(tenant *oceanbase.DbaObTenant, pieceID int64) (*task.DagDetailDTO, error) {
+	if err := checkBackupPieceDeletable(tenant, pieceID); err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("%s %d for %s", DAG_DELETE_BACKUP_PIECE, pieceID, tenant.TenantName)
+	ctx := task.NewTaskContext().
+		SetParam(PARAM_NEED_BACKUP_TENANT, tenant.TenantName).
+		SetParam(PARAM_BACKUP_PIECE_ID, pieceID)
+	return createDeleteBackupFileDag(name, ctx)
+}
+
+func createDeleteBackupFileDag(name string, ctx *task.TaskContext) (*task.DagDetailDTO, error) {
+	template := task.NewTemplateBuilder(name).
+		AddTask(newCheckBackupFileDeletableTask(), false).
+		AddTask(newDeleteBackupFileTask(), false).
+		AddTask(newWaitBackupFileDeletedTask(), false).
+		Build()
+
+	dag, err := taskService.CreateDagInstanceByTemplate(template, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+// backupFile is the backup set or archive piece a delete dag works on.
+type backupFile struct {
+	tenant  *oceanbase.DbaObTenant
+	id      int64
+	isPiece bool
+}
+
+func (f *backupFile) String() string {
+	if f.isPiece {
+		return fmt.Sprintf("archive piece %d of %s", f.id, f.tenant.TenantName)
+	}
+	return fmt.Sprintf("backup set %d of %s", f.id, f.tenant.TenantName)
+}
+
+func getBackupFileFromCtx(ctx *task.TaskContext) (*backupFile, error) {
+	tenants, err := getTenantFromCtx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "get tenant from context")
+	}
+	file := &backupFile{tenant: &tenants[0]}
+	if ctx.GetParam(PARAM_BACKUP_PIECE_ID) != nil {
+		file.isPiece = true
+		err = ctx.GetParamWithValue(PARAM_BACKUP_PIECE_ID, &file.id)
+	} else {
+		err = ctx.GetParamWithValue(PARAM_BACKUP_SET_ID, &file.id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+type ValidateBackupSetTask struct {
+	task.Task
+}
+
+func newValidateBackupSetTask() *ValidateBackupSetTask {
+	t := &ValidateBackupSetTask{
+		Task: *task.NewSubTask(TASK_VALIDATE_BACKUP_SET),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanPass().SetCanCancel()
+	return t
+}
+
+func (t *ValidateBackupSetTask) Execute() error {
+	file, err := getBackupFileFromCtx(t.GetContext())
+	if err != nil {
+		return err
+	}
+	if err = checkBackupSetValidatable(file.tenant, file.id); err != nil {
+		return err
+	}
+	t.ExecuteLogf("Validate %s", file)
+	if err = tenantService.ValidateBackupSet(file.tenant.TenantName, file.id); err != nil {
+		return errors.Wrapf(err, "validate %s", file)
+	}
+	return nil
+}
+
+type CheckBackupFileDeletableTask struct {
+	task.Task
+}
+
+func newCheckBackupFileDeletableTask() *CheckBackupFileDeletableTask {
+	t := &CheckBackupFileDeletableTask{
+		Task: *task.NewSubTask(TASK_CHECK_BACKUP_FILE_DELETABLE),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanCancel()
+	return t
+}
+
+func (t *CheckBackupFileDeletableTask) Execute() error {
+	file, err := getBackupFileFromCtx(t.GetContext())
+	if err != nil {
+		return err
+	}
+	// The catalog may have changed since the dag was created, so check it again.
+	t.ExecuteLogf("Check whether %s can be deleted", file)
+	if file.isPiece {
+		return checkBackupPieceDeletable(file.tenant, file.id)
+	}
+	return checkBackupSetDeletable(file.tenant, file.id)
+}
+
+type DeleteBackupFileTask struct {
+	task.Task
+}
+
+func newDeleteBackupFileTask() *DeleteBackupFileTask {
+	t := &DeleteBackupFileTask{
+		Task: *task.NewSubTask(TASK_DELETE_BACKUP_FILE),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanCancel()
+	return t
+}
+
+func (t *DeleteBackupFileTask) Execute() error {
+	file, err := getBackupFileFromCtx(t.GetContext())
+	if err != nil {
+		return err
+	}
+	t.ExecuteLogf("Delete %s", file)
+	if file.isPiece {
+		err = tenantService.DeleteBackupPiece(file.tenant.TenantName, file.id)
+	} else {
+		err = tenantService.DeleteBackupSet(file.tenant.TenantName, file.id)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "delete %s", file)
+	}
+	return nil
+}
+
+type WaitBackupFileDeletedTask struct {
+	task.Task
+}
+
+func newWaitBackupFileDeletedTask() *WaitBackupFileDeletedTask {
+	t := &WaitBackupFileDeletedTask{
+		Task: *task.NewSubTask(TASK_WAIT_BACKUP_FILE_DELETED),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanPass().SetCanCancel()
+	return t
+}
+
+func (t *WaitBackupFileDeletedTask) Execute() error {
+	file, err := getBackupFileFromCtx(t.GetContext())
+	if err != nil {
+		return err
+	}
+	t.ExecuteLogf("Wait %s deleted", file)
+	for i := 0; i < waitForBackupFileDeleted; i++ {
+		deleted, err := isBackupFileDeleted(file)
+		if err != nil {
+			return err
+		}
+		if deleted {
+			t.ExecuteLogf("%s deleted", file)
+			return nil
+		}
+		time.Sleep(time.Second)
+		t.TimeoutCheck()
+	}
+	return errors.Occurf(errors.ErrObClusterAsyncOperationTimeout, "delete %s", file)
+}
+
+func isBackupFileDeleted(file *backupFile) (bool, error) {
+	if file.isPiece {
+		piece, err := tenantService.GetArchivelogPieceFile(file.tenant.TenantID, file.id)
+		if err != nil {
+			return false, errors.Wrap(err, "get archivelog piece file")
+		}
+		return piece == nil || piece.FileStatus == constant.BACKUP_FILE_STATUS_DELETED, nil
+	}
+	set, err := tenantService.GetBackupSetFile(file.tenant.TenantID, file.id)
+	if err != nil {
+		return false, errors.Wrap(err, "get backup set file")
+	}
+	return set == nil || set.FileStatus == constant.BACKUP_FILE_STATUS_DELETED, nil
+}
@@ -59,6 +59,16 @@ func tenantBackupConfig(tenantName string, tenantP *param.TenantBackupConfigPara
 		return nil, err
 	}
 
+	if tenantP.Encryption != nil {
+		tenant, err := tenantService.GetTenantByName(tenantName)
+		if err != nil {
+			return nil, err
+		}
+		if err = setTenantBackupEncryption(tenant, tenantP.Encryption); err != nil {
+			return nil, errors.Wrap(err, "set backup encryption")
+		}
+	}
+
 	template := buildSetBackupConfigTemplate(&tenantName)
 	ctx, err := buildSetBackupConfigTaskContext(backupConf, &tenantName)
 	if err != nil {
@@ -105,6 +115,15 @@ func GetTenantBackupConfig(tenantName string) (*bo.BackupDestInfo, error) {
 		configs.DataBaseUri = dataStorage.GenerateURIWithoutSecret()
 	}
 
+	encryption, err := tenantService.GetBackupEncryption(tenant.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if encryption != nil {
+		configs.EncryptionEnabled = encryption.Password != ""
+		configs.KmsEncryptInfoSet = encryption.KmsEncryptInfo != ""
+	}
+
 	if strings.Contains(configs.ArchiveBaseUri, "access_id") || strings.Contains(configs.ArchiveBaseUri, "access_key") ||
 		strings.Contains(configs.DataBaseUri, "access_id") || strings.Contains(configs.DataBaseUri, "access_key") {
 		return nil, nil
@@ -73,6 +73,11 @@ const (
 	PARAM_COORDINATE_AGENT           = "coordinateAgent"
 	PARAM_JOIN_MASTER_INFO           = "joinMasterInfo"
 	PARAM_ADD_SERVER_SUCCEED         = "addServerSucceed"
+	PARAM_EXPECT_FINISH_STAGE        = "expectFinishStage"
+
+	// for replace observer
+	PARAM_REPLACE_NEW_SERVER = "replaceNewServer"
+	PARAM_MIGRATED_UNITS     = "migratedUnits"
 
 	// for scale in
 	PARAM_DELETE_SERVER  = "deleteServer"
@@ -115,6 +120,8 @@ const (
 	PARAM_BACKUP_MODE         = "backupMode"
 	PARAM_BACKUP_ENCRYPTION   = "backupEncryption"
 	PARAM_BACKUP_PLUS_ARCHIVE = "backupPlusArchive"
+	PARAM_BACKUP_SET_ID       = "backupSetID"
+	PARAM_BACKUP_PIECE_ID     = "backupPieceID"
 
 	// for restore
 	PARAM_RESTORE              = "restoreParam"
@@ -127,6 +134,7 @@ const (
 	PARAM_HA_HIGH_THREAD_SCORE = "haHighThreadScore"
 	PARAM_RESTORE_SCN          = "restoreScn"
 	PARAM_NEED_DELETE_RP       = "needDeleteRp"
+	PARAM_RESTORE_TABLE        = "restoreTableParam"
 
 	PARAM_USER_NAME     = "userName"
 	PARAM_USER_PASSWORD = "userPassword"
@@ -223,6 +231,9 @@ const (
 	TASK_NAME_KILL_OBSERVER                        = "Kill observer"
 	TASK_NAME_START_OBSERVER_FOR_SCALE_IN_ROLLBACK = "Start observer for scale in rollback"
 
+	// task name for replace observer
+	TASK_NAME_MIGRATE_UNITS_TO_NEW_SERVER = "Migrate units to new observer"
+
 	TASK_NAME_STOP_ZONE   = "Stop zone %s"
 	TASK_NAME_DELETE_ZONE = "Delete zone %s"
 
@@ -234,6 +245,12 @@ const (
 	TASK_START_BACKUP        = "Start backup"
 	TASK_WAIT_BACKUP         = "Wait backup Finish"
 
+	// task name for backup set management
+	TASK_VALIDATE_BACKUP_SET         = "Validate backup set"
+	TASK_CHECK_BACKUP_FILE_DELETABLE = "Check backup file deletable"
+	TASK_DELETE_BACKUP_FILE          = "Delete backup file"
+	TASK_WAIT_BACKUP_FILE_DELETED    = "Wait backup file deleted"
+
 	// task name for restore
 	TASK_PRE_RESTORE_CHECK   = "Pre restore check"
 	TASK_CREATE_RESOURCE     = "Create resource for restore"
@@ -245,6 +262,12 @@ const (
 	TASK_CANCEL_RESTORE      = "Cancel restore"
 	TASK_DROP_RESOURCE_POOL  = "Drop resource pool"
 
+	// task name for restore table
+	TASK_PRE_RESTORE_TABLE_CHECK   = "Pre restore table check"
+	TASK_START_RESTORE_TABLE       = "Start restore table"
+	TASK_WAIT_RESTORE_TABLE_FINISH = "Wait restore table finish"
+	TASK_CLEAN_AUX_TENANT          = "Clean auxiliary tenant"
+
 	// dag name
 	DAG_EMERGENCY_START                      = "Start local observer"
 	DAG_EMERGENCY_STOP                       = "Stop local observer"
@@ -264,14 +287,19 @@ const (
 	DAG_NAME_LOCAL_SCALE_OUT                 = "Local scale out"
 	DAG_NAME_CLUSTER_SCALE_OUT               = "Cluster scale out"
 	DAG_CLUSTER_SCALE_IN                     = "Cluster scale in"
+	DAG_REPLACE_OBSERVER                     = "Replace observer"
 	DAG_DELETE_ZONE                          = "Delete zone"
 	DAG_KILL_OBSERVER                        = "Kill observer"
 	DAG_START_OBSERVER_FOR_SCALE_IN_ROLLBACK = "Start observer for scale in rollback"
 	DAG_SET_BACKUP_CONFIG                    = "Set obcluster backup config"
 	DAG_OBCLUSTER_START_FULL_BACKUP          = "Obcluster start full backup"
 	DAG_OBCLUSTER_START_INCREMENT_BACKUP     = "Obcluster start increment backup"
+	DAG_VALIDATE_BACKUP_SET                  = "Validate backup set"
+	DAG_DELETE_BACKUP_SET                    = "Delete backup set"
+	DAG_DELETE_BACKUP_PIECE                  = "Delete archive piece"
 	DAG_RESTORE_BACKUP                       = "Restore backup"
 	DAG_CANCEL_RESTORE                       = "Cancel restore"
+	DAG_RESTORE_TABLE                        = "Restore table"
 
 	// rpc retry times
 	MAX_RETRY_RPC_TIMES = 3
@@ -285,6 +313,7 @@ const (
 	ADDL_KEY_SUB_DAGS       = "sub_dags"
 	ADDL_KEY_MAIN_DAG_ID    = "main_dag_id"
 	ADDL_KEY_RESTORE_JOB_ID = "restore_job_id"
+	ADDL_KEY_RECOVER_JOB_ID = "recover_table_job_id"
 )
 
 var (
@@ -452,6 +481,10 @@ func RegisterObScaleInTask() {
 	task.RegisterTaskType(StartObserverForScaleInRollbackTask{})
 }
 
+func RegisterReplaceObserverTask() {
+	task.RegisterTaskType(MigrateUnitsToNewServerTask{})
+}
+
 func RegisterUpgradeTask() {
 	// upgrade check
 	task.RegisterTaskType(CreateUpgradeDirTask{})
@@ -489,6 +522,10 @@ func RegisterBackupTask() {
 	task.RegisterTaskType(OpenArchiveLogTask{})
 	task.RegisterTaskType(StartBackupTask{})
 	task.RegisterTaskType(WaitBackupTaskFinish{})
+	task.RegisterTaskType(ValidateBackupSetTask{})
+	task.RegisterTaskType(CheckBackupFileDeletableTask{})
+	task.RegisterTaskType(DeleteBackupFileTask{})
+	task.RegisterTaskType(WaitBackupFileDeletedTask{})
 }
 
 func RegisterRestoreTask() {
@@ -499,4 +536,8 @@ func RegisterRestoreTask() {
 	task.RegisterTaskType(UpgradeTenantTask{})
 	task.RegisterTaskType(CancelRestoreTask{})
 	task.RegisterTaskType(DropResourcePoolTask{})
+	task.RegisterTaskType(PreRestoreTableCheckTask{})
+	task.RegisterTaskType(StartRestoreTableTask{})
+	task.RegisterTaskType(WaitRestoreTableFinishTask{})
+	task.RegisterTaskType(CleanAuxTenantTask{})
 }
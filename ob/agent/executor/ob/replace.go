@@ -0,0 +1,277 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/engine/task"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/meta"
+	oceanbaseModel "github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/ob/agent/secure"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+const WAIT_MIGRATE_UNIT_FINISH_INTERVAL = 3 * time.Second
+const WAIT_MIGRATE_UNIT_FINISH_TIMES = 1200
+
+// ReplaceObserver creates a dag to replace the observer of the old agent with a new observer
+// on the host of the new agent, which is in the same zone and takes over all the units.
+func ReplaceObserver(p param.ReplaceObserverParam) (*task.DagDetailDTO, error) {
+	if !meta.OCS_AGENT.IsClusterAgent() {
+		return nil, errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, meta.OCS_AGENT.String(), meta.OCS_AGENT.GetIdentity(), meta.CLUSTER_AGENT)
+	}
+	if p.OldAgent.Equal(&p.NewAgent) {
+		return nil, errors.Occur(errors.ErrObServerReplaceSameAgent, p.OldAgent.String())
+	}
+	if meta.OCS_AGENT.Equal(&p.OldAgent) {
+		return nil, errors.Occur(errors.ErrObServerDeleteSelf)
+	}
+
+	server, err := obclusterService.GetOBServerByAgentInfo(p.OldAgent)
+	if err != nil {
+		return nil, errors.Wrap(err, "check server exist failed")
+	}
+	if server == nil {
+		return nil, errors.Occur(errors.ErrObServerNotExist, p.OldAgent.String())
+	}
+	oldServer := meta.ObserverSvrInfo{Ip: server.SvrIp, Port: server.SvrPort}
+	if server, err = obclusterService.GetOBServer(oldServer); err != nil {
+		return nil, errors.Wrapf(err, "find observer %s failed", oldServer.String())
+	} else if server == nil {
+		return nil, errors.Occur(errors.ErrObServerNotExist, oldServer.String())
+	}
+
+	// The new observer uses the ports of the old one by default.
+	if p.ObConfigs == nil {
+		p.ObConfigs = make(map[string]string)
+	}
+	if err := paramToConfig(p.ObConfigs); err != nil {
+		return nil, err
+	}
+	if _, ok := p.ObConfigs[constant.CONFIG_RPC_PORT]; !ok {
+		p.ObConfigs[constant.CONFIG_RPC_PORT] = strconv.Itoa(server.SvrPort)
+	}
+	if _, ok := p.ObConfigs[constant.CONFIG_MYSQL_PORT]; !ok {
+		p.ObConfigs[constant.CONFIG_MYSQL_PORT] = strconv.Itoa(server.SqlPort)
+	}
+
+	scaleOutParam := param.ClusterScaleOutParam{
+		ScaleOutParam: param.ScaleOutParam{
+			AgentInfo: p.NewAgent,
+			ObConfigs: p.ObConfigs,
+			Zone:      server.Zone,
+		},
+		TargetAgentPassword: p.TargetAgentPassword,
+	}
+	targetVersion, err := checkClusterScaleOut(&scaleOutParam)
+	if err != nil {
+		return nil, err
+	}
+	// The local scale out dag of a lower version agent does not know when to stop watching the replace dag.
+	if targetVersion != "" {
+		return nil, errors.Occur(errors.ErrObServerReplaceVersion, p.NewAgent.String(), meta.OCS_AGENT.String(), constant.VERSION)
+	}
+	rpcPort, err := strconv.Atoi(p.ObConfigs[constant.CONFIG_RPC_PORT])
+	if err != nil {
+		return nil, errors.Occur(errors.ErrCommonInvalidPort, p.ObConfigs[constant.CONFIG_RPC_PORT])
+	}
+	newServer := meta.ObserverSvrInfo{Ip: p.NewAgent.Ip, Port: rpcPort}
+
+	encryptAgentPassword, err := secure.EncryptForAgent(p.TargetAgentPassword, &p.NewAgent)
+	if err != nil {
+		return nil, errors.Wrap(err, "encrypt agent password failed")
+	}
+
+	builder := addClusterScaleOutTasks(task.NewTemplateBuilder(DAG_REPLACE_OBSERVER), false)
+	// The local scale out dag on the new agent finishes when the replace dag reaches the finish task.
+	finishStage := len(builder.Template.GetNodes())
+	template := builder.AddNode(newMigrateUnitsToNewServerNode(oldServer, newServer)).
+		AddNode(newDeleteObserverNode(oldServer, false)).
+		AddTask(newSetAgentToScaleInTask(), false).
+		AddNode(newWaitDeleteServerSuccessNode(oldServer)).
+		AddTask(newDeleteAgentTask(), false).
+		AddNode(newTryToInformToKillObserverNode(false, p.OldAgent)).
+		SetMaintenance(task.GlobalMaintenance()).
+		Build()
+
+	context := buildClusterScaleOutDagContext(scaleOutParam, false, targetVersion, encryptAgentPassword).
+		SetParam(PARAM_EXPECT_FINISH_STAGE, finishStage).
+		SetParam(PARAM_DELETE_SERVER, oldServer).
+		SetParam(PARAM_DELETE_AGENTS, []meta.AgentInfo{p.OldAgent})
+	dag, err := clusterTaskService.CreateDagInstanceByTemplate(template, context)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+type MigrateUnitsToNewServerTask struct {
+	task.Task
+	oldServer meta.ObserverSvrInfo
+	newServer meta.ObserverSvrInfo
+	unitIds   []int
+}
+
+func newMigrateUnitsToNewServerTask() *MigrateUnitsToNewServerTask {
+	newTask := &MigrateUnitsToNewServerTask{
+		Task: *task.NewSubTask(TASK_NAME_MIGRATE_UNITS_TO_NEW_SERVER),
+	}
+	newTask.SetCanContinue().
+		SetCanRetry().
+		SetCanCancel().
+		SetCanRollback().
+		SetCanPass()
+	return newTask
+}
+
+func newMigrateUnitsToNewServerNode(oldServer, newServer meta.ObserverSvrInfo) *task.Node {
+	context := task.NewTaskContext().
+		SetParam(PARAM_DELETE_SERVER, oldServer).
+		SetParam(PARAM_REPLACE_NEW_SERVER, newServer)
+	return task.NewNodeWithContext(newMigrateUnitsToNewServerTask(), false, context)
+}
+
+func (t *MigrateUnitsToNewServerTask) init() error {
+	ctx := t.GetContext()
+	if err := ctx.GetParamWithValue(PARAM_DELETE_SERVER, &t.oldServer); err != nil {
+		return err
+	}
+	if err := ctx.GetParamWithValue(PARAM_REPLACE_NEW_SERVER, &t.newServer); err != nil {
+		return err
+	}
+	if ctx.GetData(PARAM_MIGRATED_UNITS) != nil {
+		if err := ctx.GetDataWithValue(PARAM_MIGRATED_UNITS, &t.unitIds); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *MigrateUnitsToNewServerTask) Execute() error {
+	if err := t.init(); err != nil {
+		return err
+	}
+	for i := 0; i < WAIT_MIGRATE_UNIT_FINISH_TIMES; i++ {
+		t.TimeoutCheck()
+		units, err := obclusterService.GetUnitLocationsOfServer(t.oldServer)
+		if err != nil {
+			return errors.Wrapf(err, "get units of observer %s failed", t.oldServer.String())
+		}
+		if len(units) == 0 {
+			// Make sure that the log streams keep the majority without the old observer.
+			if alive, err := isAllLsMultiPaxosAlive(t.oldServer, t.ExecuteInfoLogf); err != nil {
+				return err
+			} else if alive {
+				t.ExecuteLogf("all units have been migrated from %s to %s", t.oldServer.String(), t.newServer.String())
+				return nil
+			}
+		}
+		// Units migrating into the old observer are migrated out after they arrive.
+		for _, unit := range units {
+			if !unit.IsMigrating() {
+				if err := t.migrate(unit); err != nil {
+					return err
+				}
+			}
+		}
+		time.Sleep(WAIT_MIGRATE_UNIT_FINISH_INTERVAL)
+	}
+	return errors.Occurf(errors.ErrObClusterAsyncOperationTimeout, "migrate units from %s", t.oldServer.String())
+}
+
+func (t *MigrateUnitsToNewServerTask) migrate(unit oceanbaseModel.ObUnitLocation) error {
+	t.ExecuteLogf("migrate unit %d of tenant %d to %s", unit.UnitId, unit.TenantId, t.newServer.String())
+	if err := obclusterService.MigrateUnit(unit.UnitId, t.newServer); err != nil {
+		return errors.Wrapf(err, "migrate unit %d failed", unit.UnitId)
+	}
+	for _, id := range t.unitIds {
+		if id == unit.UnitId {
+			return nil
+		}
+	}
+	// Record the unit in time, so that it can be migrated back when rolling back.
+	t.unitIds = append(t.unitIds, unit.UnitId)
+	t.GetContext().SetData(PARAM_MIGRATED_UNITS, t.unitIds)
+	return nil
+}
+
+func (t *MigrateUnitsToNewServerTask) Rollback() error {
+	if retry, err := clusterTaskService.IsRetryTask(t.GetID()); err != nil {
+		return err
+	} else if retry {
+		return nil
+	}
+	if err := t.init(); err != nil {
+		return err
+	}
+	if len(t.unitIds) == 0 {
+		return nil
+	}
+
+	units, err := obclusterService.GetUnitLocations(t.unitIds)
+	if err != nil {
+		return errors.Wrap(err, "get unit locations failed")
+	}
+	for _, unit := range units {
+		if err := t.migrateBack(unit); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i < WAIT_MIGRATE_UNIT_FINISH_TIMES; i++ {
+		t.TimeoutCheck()
+		if units, err = obclusterService.GetUnitLocations(t.unitIds); err != nil {
+			return errors.Wrap(err, "get unit locations failed")
+		}
+		finished := true
+		for _, unit := range units {
+			if unit.IsMigrating() || unit.SvrIp != t.oldServer.GetIp() || unit.SvrPort != t.oldServer.GetPort() {
+				finished = false
+				break
+			}
+		}
+		if finished {
+			t.ExecuteLogf("all units have been migrated back to %s", t.oldServer.String())
+			return nil
+		}
+		time.Sleep(WAIT_MIGRATE_UNIT_FINISH_INTERVAL)
+	}
+	return errors.Occurf(errors.ErrObClusterAsyncOperationTimeout, "migrate units back to %s", t.oldServer.String())
+}
+
+func (t *MigrateUnitsToNewServerTask) migrateBack(unit oceanbaseModel.ObUnitLocation) error {
+	if unit.IsMigrating() {
+		if unit.MigrateFromSvrIp == t.oldServer.GetIp() && unit.MigrateFromSvrPort == t.oldServer.GetPort() {
+			t.ExecuteLogf("cancel migrating unit %d", unit.UnitId)
+			if err := obclusterService.CancelMigrateUnit(unit.UnitId); err != nil {
+				return errors.Wrapf(err, "cancel migrate unit %d failed", unit.UnitId)
+			}
+		}
+		return nil
+	}
+	if unit.SvrIp == t.newServer.GetIp() && unit.SvrPort == t.newServer.GetPort() {
+		t.ExecuteLogf("migrate unit %d back to %s", unit.UnitId, t.oldServer.String())
+		if err := obclusterService.MigrateUnit(unit.UnitId, t.oldServer); err != nil {
+			return errors.Wrapf(err, "migrate unit %d failed", unit.UnitId)
+		}
+	}
+	return nil
+}
@@ -28,7 +28,6 @@ import (
 	"github.com/oceanbase/obshell/ob/agent/executor/common"
 	"github.com/oceanbase/obshell/ob/agent/executor/pool"
 	"github.com/oceanbase/obshell/ob/agent/executor/zone"
-	"github.com/oceanbase/obshell/ob/agent/lib/path"
 	"github.com/oceanbase/obshell/ob/agent/lib/system"
 	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
 	"github.com/oceanbase/obshell/ob/param"
@@ -38,7 +37,6 @@ func TenantRestore(p *param.RestoreParam) (*task.DagDetailDTO, error) {
 	if err := checkRestoreParam(p); err != nil {
 		return nil, err
 	}
-
 	template := buildRestoreTemplate(p)
 	ctx := buildRestoreTaskContext(p)
 	dag, err := taskService.CreateDagInstanceByTemplate(template, ctx)
@@ -139,19 +137,24 @@ func (t *PreRestoreCheckTask) Execute() (err error) {
 		}
 	}
 
-	if !system.IsFileExist(path.OBAdmin()) || (t.scn == 0 && t.param.Timestamp == nil) {
-		t.ExecuteLog("Not need to check ob_admin")
-		return
+	return checkRestoreTime(t, t.param.DataBackupUri, *t.param.ArchiveLogUri, t.param.Timestamp, t.scn)
+}
+
+// checkRestoreTime checks whether the restore point is in the restore windows of the backup.
+func checkRestoreTime(t task.ExecutableTask, dataBackupUri, archiveLogUri string, timestamp *time.Time, scn int64) error {
+	if scn == 0 && timestamp == nil {
+		t.ExecuteLog("Not need to check restore time")
+		return nil
 	}
 
-	if t.param.Timestamp != nil {
-		t.ExecuteLogf("Check restore time '%s'", t.param.Timestamp.Format("2006-01-02 15:04:05.000000"))
-		t.scn = t.param.Timestamp.UnixNano()
+	if timestamp != nil {
+		t.ExecuteLogf("Check restore time '%s'", timestamp.Format("2006-01-02 15:04:05.000000"))
+		scn = timestamp.UnixNano()
 	} else {
-		t.ExecuteLogf("Check restore time '%d'", t.scn)
+		t.ExecuteLogf("Check restore time '%d'", scn)
 	}
 
-	if err = system.CheckRestoreTime(t.param.DataBackupUri, *t.param.ArchiveLogUri, t.scn); err != nil {
+	if err := system.CheckRestoreTime(dataBackupUri, archiveLogUri, scn); err != nil {
 		return errors.Wrap(err, "check restore time")
 	}
 	return nil
@@ -297,8 +300,11 @@ func (t *StartRestoreTask) restoreTenant() (err error) {
 	}
 	locality := strings.Join(localityList, ",")
 
+	// The recorded backup keys are supplied here rather than saved in the task context.
+	restoreParam := *t.param
+	fillRestoreDecryption(restoreParam.DataBackupUri, &restoreParam.Decryption, &restoreParam.KmsEncryptInfo)
 	t.ExecuteLogf("Restore tenant '%s'", t.tenantName)
-	if err = tenantService.Restore(t.param, locality, resourcePoolList, t.restoreScn); err != nil {
+	if err = tenantService.Restore(&restoreParam, locality, resourcePoolList, t.restoreScn); err != nil {
 		// drop all created resource pool
 		if err := pool.DropFreeResourcePools(t.Task, t.createResourcePoolParam); err != nil {
 			t.ExecuteWarnLog(errors.Wrap(err, "Drop created resource pool failed"))
@@ -601,10 +607,6 @@ func GetAllRestoreTasks(p *param.QueryRestoreTasksParam) (res *bo.PaginatedResto
 }
 
 func GetRestoreWindows(param *param.RestoreWindowsParam) (res *system.RestoreWindows, e error) {
-	if !system.IsFileExist(path.OBAdmin()) {
-		return nil, errors.Occur(errors.ErrEnvironmentWithoutObAdmin)
-	}
-
 	if param.ArchiveLogUri == nil || *param.ArchiveLogUri == "" {
 		*param.ArchiveLogUri = param.DataBackupUri
 	}
@@ -616,10 +618,6 @@ func GetRestoreWindows(param *param.RestoreWindowsParam) (res *system.RestoreWin
 }
 
 func GetRestoreSourceTenantInfo(param *param.RestoreStorageParam) (res *system.RestoreTenantInfo, e error) {
-	if !system.IsFileExist(path.OBAdmin()) {
-		return nil, errors.Occur(errors.ErrEnvironmentWithoutObAdmin)
-	}
-
 	if param.ArchiveLogUri == nil || *param.ArchiveLogUri == "" {
 		*param.ArchiveLogUri = param.DataBackupUri
 	}
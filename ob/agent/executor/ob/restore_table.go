@@ -0,0 +1,429 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/engine/task"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/executor/pool"
+	"github.com/oceanbase/obshell/ob/agent/executor/zone"
+	"github.com/oceanbase/obshell/ob/agent/lib/pkg"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+const (
+	waitForRecoverTableJob = 3600 * 24 // seconds
+	waitForAuxTenantDrop   = 600       // seconds
+)
+
+// TenantRestoreTable restores tables or databases from backup into an existing tenant.
+// OceanBase restores the backup into an auxiliary tenant created on the given
+// resource pools first, and then imports the tables into the target tenant.
+func TenantRestoreTable(tenant *oceanbase.DbaObTenant, p *param.RestoreTableParam) (*task.DagDetailDTO, error) {
+	if err := checkRestoreTableParam(tenant, p); err != nil {
+		return nil, err
+	}
+
+	template := task.NewTemplateBuilder(fmt.Sprintf("%s_%s", DAG_RESTORE_TABLE, tenant.TenantName)).
+		SetMaintenance(task.TenantMaintenance(tenant.TenantName)).
+		AddTask(newPreRestoreTableCheckTask(), false).
+		AddTask(newStartRestoreTableTask(), false).
+		AddTask(newWaitRestoreTableFinishTask(), false).
+		AddTask(newCleanAuxTenantTask(), false).
+		Build()
+
+	ctx := task.NewTaskContext().
+		SetParam(task.FAILURE_EXIT_MAINTENANCE, true).
+		SetParam(PARAM_RESTORE_TABLE, *p).
+		SetParam(PARAM_TENANT_NAME, tenant.TenantName).
+		SetParam(PARAM_TASK_TIME, strconv.Itoa(int(time.Now().UnixMilli())))
+	if p.SCN != nil {
+		ctx.SetParam(PARAM_RESTORE_SCN, *p.SCN)
+	}
+
+	dag, err := taskService.CreateDagInstanceByTemplate(template, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+func checkRestoreTableParam(tenant *oceanbase.DbaObTenant, p *param.RestoreTableParam) error {
+	if err := p.Check(); err != nil {
+		return err
+	}
+
+	obVersion, err := obclusterService.GetObVersion()
+	if err != nil {
+		return errors.Wrap(err, "get ob version failed")
+	}
+	if pkg.CompareVersion(obVersion, constant.OB_VERSION_4_2_1_0) < 0 {
+		return errors.Occur(errors.ErrObRestoreTableVersionNotSupported, obVersion, constant.OB_VERSION_4_2_1_0)
+	}
+
+	job, err := tenantService.GetRunningRecoverTableJob(tenant.TenantName)
+	if err != nil {
+		return errors.Wrap(err, "get running restore table job")
+	}
+	if job != nil {
+		return errors.Occur(errors.ErrObRestoreTableJobExist, job.JobID, tenant.TenantName)
+	}
+
+	if len(p.ZoneList) == 0 {
+		return errors.Occur(errors.ErrObTenantZoneListEmpty)
+	}
+	zone.RenderZoneParams(p.ZoneList)
+	if err := zone.CheckZoneParams(p.ZoneList); err != nil {
+		return err
+	}
+	zoneList := make([]string, 0)
+	for _, zone := range p.ZoneList {
+		zoneList = append(zoneList, zone.Name)
+	}
+	return zone.CheckPrimaryZone(*p.PrimaryZone, zoneList)
+}
+
+func newAuxTenantPoolPrefix(tenantName string) string {
+	return fmt.Sprintf("%s_aux", tenantName)
+}
+
+// GetRestoreTableOverview returns the running restore table job of the tenant,
+// or the last one if there is no running job.
+func GetRestoreTableOverview(tenantName string) (*bo.RecoverTableJob, error) {
+	job, err := tenantService.GetRunningRecoverTableJob(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	if job != nil {
+		return job.ToBO(), nil
+	}
+
+	job, err = tenantService.GetRecoverTableJobHistory(tenantName, 0)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, errors.Occur(errors.ErrCommonNotFound, "restore table job")
+	}
+	res := job.ToBO()
+	res.Finished = true
+	return res, nil
+}
+
+type restoreTableParams struct {
+	tenantName string
+	param      *param.RestoreTableParam
+	scn        int64
+	timeStamp  string
+}
+
+func getRestoreTableParams(ctx *task.TaskContext) (p *restoreTableParams, err error) {
+	p = &restoreTableParams{}
+	if err = ctx.GetParamWithValue(PARAM_TENANT_NAME, &p.tenantName); err != nil {
+		return nil, err
+	}
+	if err = ctx.GetParamWithValue(PARAM_RESTORE_TABLE, &p.param); err != nil {
+		return nil, err
+	}
+	if ctx.GetParam(PARAM_RESTORE_SCN) != nil {
+		if err = ctx.GetParamWithValue(PARAM_RESTORE_SCN, &p.scn); err != nil {
+			return nil, err
+		}
+	}
+	if err = ctx.GetParamWithValue(PARAM_TASK_TIME, &p.timeStamp); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *restoreTableParams) auxPoolParams() []param.CreateResourcePoolTaskParam {
+	return buildCreateResourcePoolTaskParam(newAuxTenantPoolPrefix(p.tenantName), p.param.ZoneList, p.timeStamp)
+}
+
+type PreRestoreTableCheckTask struct {
+	task.Task
+}
+
+func newPreRestoreTableCheckTask() *PreRestoreTableCheckTask {
+	t := &PreRestoreTableCheckTask{
+		Task: *task.NewSubTask(TASK_PRE_RESTORE_TABLE_CHECK),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanPass().SetCanCancel()
+	return t
+}
+
+func (t *PreRestoreTableCheckTask) Execute() error {
+	p, err := getRestoreTableParams(t.GetContext())
+	if err != nil {
+		return err
+	}
+
+	t.ExecuteLogf("Check target tenant '%s'", p.tenantName)
+	tenant, err := tenantService.GetTenantByName(p.tenantName)
+	if err != nil {
+		return errors.Wrap(err, "get tenant")
+	}
+	if tenant == nil {
+		return errors.Occur(errors.ErrObTenantNotExist, p.tenantName)
+	}
+	return checkRestoreTime(t, p.param.DataBackupUri, *p.param.ArchiveLogUri, p.param.Timestamp, p.scn)
+}
+
+type StartRestoreTableTask struct {
+	task.Task
+}
+
+func newStartRestoreTableTask() *StartRestoreTableTask {
+	t := &StartRestoreTableTask{
+		Task: *task.NewSubTask(TASK_START_RESTORE_TABLE),
+	}
+	t.SetCanRetry().SetCanRollback().SetCanContinue().SetCanCancel()
+	return t
+}
+
+func (t *StartRestoreTableTask) Execute() error {
+	p, err := getRestoreTableParams(t.GetContext())
+	if err != nil {
+		return err
+	}
+
+	job, err := tenantService.GetRunningRecoverTableJob(p.tenantName)
+	if err != nil {
+		return errors.Wrap(err, "get running restore table job")
+	}
+	if job == nil {
+		poolParams := p.auxPoolParams()
+		if err = pool.CreatePools(t.Task, poolParams); err != nil {
+			return err
+		}
+		var poolList []string
+		for _, poolParam := range poolParams {
+			poolList = append(poolList, poolParam.PoolName)
+		}
+
+		// The recorded backup keys are supplied here rather than saved in the task context.
+		restoreParam := *p.param
+		fillRestoreDecryption(restoreParam.DataBackupUri, &restoreParam.Decryption, &restoreParam.KmsEncryptInfo)
+		t.ExecuteLogf("Restore tables into tenant '%s'", p.tenantName)
+		if err = tenantService.RecoverTable(p.tenantName, &restoreParam, strings.Join(poolList, ","), p.scn); err != nil {
+			if err := pool.DropFreeResourcePools(t.Task, poolParams); err != nil {
+				t.ExecuteWarnLog(errors.Wrap(err, "Drop created resource pool failed"))
+			}
+			return errors.Wrap(err, "restore table")
+		}
+
+		if job, err = tenantService.GetRunningRecoverTableJob(p.tenantName); err != nil {
+			return errors.Wrap(err, "get running restore table job")
+		}
+		if job == nil {
+			// The job may finish quickly, look up the history.
+			if job, err = tenantService.GetRecoverTableJobHistory(p.tenantName, 0); err != nil {
+				return errors.Wrap(err, "get restore table job history")
+			}
+		}
+	}
+	if job != nil {
+		t.ExecuteLogf("Restore table job id is %d", job.JobID)
+		t.GetContext().SetData(ADDL_KEY_RECOVER_JOB_ID, job.JobID)
+	}
+	return nil
+}
+
+func (t *StartRestoreTableTask) Rollback() error {
+	p, err := getRestoreTableParams(t.GetContext())
+	if err != nil {
+		return err
+	}
+
+	job, err := tenantService.GetRunningRecoverTableJob(p.tenantName)
+	if err != nil {
+		return errors.Wrap(err, "get running restore table job")
+	}
+	if job != nil {
+		t.ExecuteLogf("Cancel restore table job %d", job.JobID)
+		if err = tenantService.CancelRecoverTable(p.tenantName); err != nil {
+			return errors.Wrap(err, "cancel restore table")
+		}
+		for i := 0; i < waitForRestoreTaskFinish; i++ {
+			if job, err = tenantService.GetRunningRecoverTableJob(p.tenantName); err != nil {
+				return errors.Wrap(err, "get running restore table job")
+			}
+			if job == nil {
+				break
+			}
+			time.Sleep(time.Second)
+			t.TimeoutCheck()
+		}
+		if job != nil {
+			return errors.Occur(errors.ErrObClusterAsyncOperationTimeout, fmt.Sprintf("cancel restore table job %d", job.JobID))
+		}
+	}
+	return cleanAuxTenant(t, p)
+}
+
+// cleanAuxTenant drops the auxiliary tenant if OceanBase left it behind,
+// and then drops the resource pools created for it.
+// Only the job started by this dag is looked up, so the auxiliary tenant of another job is never dropped.
+func cleanAuxTenant(t task.ExecutableTask, p *restoreTableParams) error {
+	var job *oceanbase.CdbObRecoverTableJob
+	if t.GetContext().GetData(ADDL_KEY_RECOVER_JOB_ID) != nil {
+		var jobID int64
+		if err := t.GetContext().GetDataWithValue(ADDL_KEY_RECOVER_JOB_ID, &jobID); err != nil {
+			return err
+		}
+		var err error
+		if job, err = tenantService.GetRecoverTableJobHistory(p.tenantName, jobID); err != nil {
+			return errors.Wrap(err, "get restore table job history")
+		}
+	}
+	if job != nil && job.AuxTenantName != "" {
+		auxTenant, err := tenantService.GetTenantByName(job.AuxTenantName)
+		if err != nil {
+			return errors.Wrap(err, "get auxiliary tenant")
+		}
+		if auxTenant != nil {
+			t.ExecuteLogf("Drop auxiliary tenant '%s'", job.AuxTenantName)
+			if err = tenantService.DeleteTenant(job.AuxTenantName); err != nil {
+				return errors.Wrap(err, "drop auxiliary tenant")
+			}
+			for i := 0; i < waitForAuxTenantDrop && auxTenant != nil; i++ {
+				time.Sleep(time.Second)
+				t.TimeoutCheck()
+				if auxTenant, err = tenantService.GetTenantByName(job.AuxTenantName); err != nil {
+					return errors.Wrap(err, "get auxiliary tenant")
+				}
+			}
+			if auxTenant != nil {
+				return errors.Occur(errors.ErrObClusterAsyncOperationTimeout, fmt.Sprintf("drop auxiliary tenant '%s'", job.AuxTenantName))
+			}
+		}
+	}
+
+	poolParams := p.auxPoolParams()
+	for _, poolParam := range poolParams {
+		t.ExecuteLogf("Drop resource pool %s", poolParam.PoolName)
+		if exist, err := tenantService.IsResourcePoolExistAndFreed(poolParam.PoolName, poolParam.UnitConfigName, poolParam.UnitNum, poolParam.ZoneName); err != nil {
+			return err
+		} else if exist {
+			if err := tenantService.DropResourcePool(poolParam.PoolName, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type WaitRestoreTableFinishTask struct {
+	task.Task
+	jobID int64
+}
+
+func newWaitRestoreTableFinishTask() *WaitRestoreTableFinishTask {
+	t := &WaitRestoreTableFinishTask{
+		Task: *task.NewSubTask(TASK_WAIT_RESTORE_TABLE_FINISH),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanCancel()
+	return t
+}
+
+func (t *WaitRestoreTableFinishTask) Execute() error {
+	p, err := getRestoreTableParams(t.GetContext())
+	if err != nil {
+		return err
+	}
+
+	if t.GetContext().GetData(ADDL_KEY_RECOVER_JOB_ID) != nil {
+		if err = t.GetContext().GetDataWithValue(ADDL_KEY_RECOVER_JOB_ID, &t.jobID); err != nil {
+			return err
+		}
+	}
+
+	t.ExecuteLog("Wait for restore table job finish")
+	var lastStatus string
+	finished := false
+	for i := 0; i < waitForRecoverTableJob; i++ {
+		job, err := tenantService.GetRunningRecoverTableJob(p.tenantName)
+		if err != nil {
+			return errors.Wrap(err, "get running restore table job")
+		}
+		if job == nil {
+			finished = true
+			break
+		}
+		// Report the progress by the status of the job.
+		t.jobID = job.JobID
+		if job.Status != lastStatus {
+			t.ExecuteLogf("Restore table job %d is %s", job.JobID, job.Status)
+			lastStatus = job.Status
+		}
+		time.Sleep(time.Second)
+		t.TimeoutCheck()
+	}
+	if !finished {
+		return errors.Occur(errors.ErrObClusterAsyncOperationTimeout, fmt.Sprintf("restore table into tenant '%s'", p.tenantName))
+	}
+
+	job, err := tenantService.GetRecoverTableJobHistory(p.tenantName, t.jobID)
+	if err != nil {
+		return errors.Wrap(err, "get restore table job history")
+	}
+	if job == nil {
+		return errors.Occur(errors.ErrCommonNotFound, "restore table job")
+	}
+	if job.Status != constant.RECOVER_TABLE_STATUS_COMPLETED {
+		return errors.Occur(errors.ErrObRestoreTableFailed, job.JobID, job.Status, job.Comment)
+	}
+	t.ExecuteLogf("Restore table job %d completed", job.JobID)
+	return nil
+}
+
+func (t *WaitRestoreTableFinishTask) GetAdditionalData() map[string]any {
+	if err := t.GetContext().GetDataWithValue(ADDL_KEY_RECOVER_JOB_ID, &t.jobID); err != nil {
+		return nil
+	}
+	return map[string]any{
+		ADDL_KEY_RECOVER_JOB_ID: t.jobID,
+	}
+}
+
+type CleanAuxTenantTask struct {
+	task.Task
+}
+
+func newCleanAuxTenantTask() *CleanAuxTenantTask {
+	t := &CleanAuxTenantTask{
+		Task: *task.NewSubTask(TASK_CLEAN_AUX_TENANT),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanPass().SetCanCancel()
+	return t
+}
+
+func (t *CleanAuxTenantTask) Execute() error {
+	p, err := getRestoreTableParams(t.GetContext())
+	if err != nil {
+		return err
+	}
+	return cleanAuxTenant(t, p)
+}
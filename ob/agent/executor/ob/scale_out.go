@@ -62,45 +62,61 @@ type LocalScaleOutResp struct {
 }
 
 func HandleClusterScaleOut(param param.ClusterScaleOutParam) (*task.DagDetailDTO, error) {
+	targetVersion, err := checkClusterScaleOut(&param)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create Cluster Scale Out Dag
+	dag, err := CreateClusterScaleOutDag(param, targetVersion)
+	if err != nil {
+		return nil, err
+	}
+	return dag, nil
+}
+
+// checkClusterScaleOut checks the scaling agent and the configs of the observer,
+// and returns the version which the scaling agent should be upgraded to.
+func checkClusterScaleOut(param *param.ClusterScaleOutParam) (string, error) {
 	if !meta.OCS_AGENT.IsClusterAgent() {
-		return nil, errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, meta.OCS_AGENT.String(), meta.OCS_AGENT.GetIdentity(), meta.CLUSTER_AGENT)
+		return "", errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, meta.OCS_AGENT.String(), meta.OCS_AGENT.GetIdentity(), meta.CLUSTER_AGENT)
 	}
 	// Check scaling agent status.
 	var agent meta.AgentStatus
 	if err := http.SendGetRequest(&param.AgentInfo, constant.URI_API_V1+constant.URI_INFO, nil, &agent); err != nil {
-		return nil, errors.Wrapf(err, "get %s status failed", param.AgentInfo.String())
+		return "", errors.Wrapf(err, "get %s status failed", param.AgentInfo.String())
 	}
 	if !agent.IsSingleAgent() {
-		return nil, errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, agent.AgentInfo.String(), agent.Identity, meta.SINGLE)
+		return "", errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, agent.AgentInfo.String(), agent.Identity, meta.SINGLE)
 	}
 
 	// check ob version is consistent
 	if obVersion, _, err := binary.GetMyOBVersion(); err != nil {
-		return nil, errors.Wrap(err, "get ob version failed")
+		return "", errors.Wrap(err, "get ob version failed")
 	} else if obVersion != agent.OBVersion {
-		return nil, errors.Occur(errors.ErrAgentOBVersionInconsistent, param.AgentInfo.String(), agent.OBVersion, meta.OCS_AGENT.String(), obVersion)
+		return "", errors.Occur(errors.ErrAgentOBVersionInconsistent, param.AgentInfo.String(), agent.OBVersion, meta.OCS_AGENT.String(), obVersion)
 	}
 
 	var targetVersion string
 	agentVersion := strings.Split(agent.Version, "-")[0]
 	log.Infof("scale out agent %s(%s) into cluster agent %s(%s)", agent.String(), agentVersion, meta.OCS_AGENT.String(), constant.VERSION)
 	if cmp := pkg.CompareVersion(constant.VERSION, agentVersion); cmp < 0 {
-		return nil, errors.Occur(errors.ErrObClusterScaleOutHigherVersion, agent.String(), agentVersion, meta.OCS_AGENT.String(), constant.VERSION)
+		return "", errors.Occur(errors.ErrObClusterScaleOutHigherVersion, agent.String(), agentVersion, meta.OCS_AGENT.String(), constant.VERSION)
 	} else if cmp > 0 {
 		if pkg.CompareVersion(agentVersion, constant.AGENT_V4241) < 0 {
-			return nil, errors.Occur(errors.ErrObClusterScaleOutLowerVersion, agentVersion, constant.AGENT_V4241, constant.VERSION)
+			return "", errors.Occur(errors.ErrObClusterScaleOutLowerVersion, agentVersion, constant.AGENT_V4241, constant.VERSION)
 		}
 		if exist, err := agentService.TargetVersionAgentExists(constant.VERSION); err != nil {
-			return nil, errors.Wrap(err, "check target version agent exists failed")
+			return "", errors.Wrap(err, "check target version agent exists failed")
 		} else if !exist {
-			return nil, errors.Occur(errors.ErrAgentBinaryNotFound, constant.VERSION, global.Architecture, constant.DIST)
+			return "", errors.Occur(errors.ErrAgentBinaryNotFound, constant.VERSION, global.Architecture, constant.DIST)
 		}
 		targetVersion = constant.VERSION
 	}
 
 	param.ObConfigs[constant.CONFIG_HOME_PATH] = agent.HomePath
 	if err := paramToConfig(param.ObConfigs); err != nil {
-		return nil, err
+		return "", err
 	}
 
 	var rpcPort int
@@ -108,7 +124,7 @@ func HandleClusterScaleOut(param param.ClusterScaleOutParam) (*task.DagDetailDTO
 	if ok {
 		var err error
 		if rpcPort, err = strconv.Atoi(rpcPortStr); err != nil {
-			return nil, errors.Occur(errors.ErrCommonInvalidPort, rpcPortStr)
+			return "", errors.Occur(errors.ErrCommonInvalidPort, rpcPortStr)
 		}
 	} else {
 		rpcPort = constant.DEFAULT_RPC_PORT
@@ -117,17 +133,11 @@ func HandleClusterScaleOut(param param.ClusterScaleOutParam) (*task.DagDetailDTO
 
 	// Check the server is not already in the cluster.
 	if exist, err := obclusterService.IsServerExist(*srvInfo); err != nil {
-		return nil, err
+		return "", err
 	} else if exist {
-		return nil, errors.Occur(errors.ErrAgentAlreadyExists, srvInfo.String())
+		return "", errors.Occur(errors.ErrAgentAlreadyExists, srvInfo.String())
 	}
-
-	// Create Cluster Scale Out Dag
-	dag, err := CreateClusterScaleOutDag(param, targetVersion)
-	if err != nil {
-		return nil, err
-	}
-	return dag, nil
+	return targetVersion, nil
 }
 
 func HandleLocalScaleOut(params param.LocalScaleOutParam) (*LocalScaleOutResp, error) {
@@ -216,8 +226,14 @@ func CreateLocalScaleOutDag(param param.LocalScaleOutParam) (*task.Dag, error) {
 }
 
 func buildClusterScaleOutTaskTemplate(isNewZone bool) *task.Template {
-	templateBuild := task.NewTemplateBuilder(DAG_NAME_CLUSTER_SCALE_OUT).
-		AddTask(newIntegrateSingleObConfigTask(), false).
+	templateBuild := task.NewTemplateBuilder(DAG_NAME_CLUSTER_SCALE_OUT)
+	return addClusterScaleOutTasks(templateBuild, isNewZone).SetMaintenance(task.GlobalMaintenance()).Build()
+}
+
+// addClusterScaleOutTasks adds the tasks of cluster scale out to the builder.
+// The local scale out dag expects these stages, so they must be the first tasks of the dag.
+func addClusterScaleOutTasks(templateBuild *task.TemplateBuilder, isNewZone bool) *task.TemplateBuilder {
+	templateBuild.AddTask(newIntegrateSingleObConfigTask(), false).
 		AddTask(newCreateLocalScaleOutDagTask(), false).
 		AddTask(newWaitScalingReadyTask(), false).
 		AddTask(newWaitRemoteDeployTaskFinish(), false).
@@ -227,11 +243,9 @@ func buildClusterScaleOutTaskTemplate(isNewZone bool) *task.Template {
 		templateBuild.AddTask(newAddNewZoneTask(), false).
 			AddTask(newStartNewZoneTask(), false)
 	}
-	templateBuild.AddTask(newAddServerTask(), false).
+	return templateBuild.AddTask(newAddServerTask(), false).
 		AddTask(newAddAgentTask(), false).
 		AddTask(newFinishTask(), false)
-
-	return templateBuild.SetMaintenance(task.GlobalMaintenance()).Build()
 }
 
 func buildLocalScaleOutTaskTemplate(param param.LocalScaleOutParam) *task.Template {
@@ -278,6 +292,9 @@ func buildLocalScaleOutDagContext(param param.LocalScaleOutParam) *task.TaskCont
 	if param.TargetVersion != "" {
 		context.SetParam(PARAM_TARGET_AGENT_VERSION, param.TargetVersion)
 	}
+	if param.ParamExpectFinishStage != 0 {
+		context.SetParam(PARAM_EXPECT_FINISH_STAGE, param.ParamExpectFinishStage)
+	}
 	return context
 }
 
@@ -531,6 +548,13 @@ func newWatchDagTask() *WatchDagTask {
 func (t *WatchDagTask) Execute() error {
 	t.init()
 	t.ExecuteLog("Watch cluster scale out dag")
+	// The coordinate dag may have more tasks after the finish task, such as replacing observer.
+	var finishStage int
+	if t.GetContext().GetParam(PARAM_EXPECT_FINISH_STAGE) != nil {
+		if err := t.GetContext().GetParamWithValue(PARAM_EXPECT_FINISH_STAGE, &finishStage); err != nil {
+			return err
+		}
+	}
 	for {
 		t.TimeoutCheck()
 		coordinateDag, err := t.getCoordinateDag()
@@ -538,7 +562,7 @@ func (t *WatchDagTask) Execute() error {
 			t.ExecuteWarnLogf("get remote dag failed, %s", err.Error())
 			continue
 		}
-		if coordinateDag.Stage == coordinateDag.MaxStage {
+		if coordinateDag.Stage == coordinateDag.MaxStage || (finishStage != 0 && coordinateDag.Stage >= finishStage) {
 			break
 		}
 		// Failed only when remote dag failed.
@@ -825,6 +849,12 @@ func (t *CreateLocalScaleOutDagTask) buildLocalScaleOutParam() (*param.LocalScal
 	if err := ctx.GetParamWithValue(PARAM_TARGET_AGENT_VERSION, &targetVersion); err != nil {
 		return nil, err
 	}
+	var finishStage int
+	if ctx.GetParam(PARAM_EXPECT_FINISH_STAGE) != nil {
+		if err := ctx.GetParamWithValue(PARAM_EXPECT_FINISH_STAGE, &finishStage); err != nil {
+			return nil, err
+		}
+	}
 	param := param.LocalScaleOutParam{
 		ScaleOutParam: param.ScaleOutParam{
 			AgentInfo: meta.OCS_AGENT.GetAgentInfo(),
@@ -840,6 +870,7 @@ func (t *CreateLocalScaleOutDagTask) buildLocalScaleOutParam() (*param.LocalScal
 		ParamExpectStartNextStage:    paramExpectStartNextStage,
 		ParamExpectRollbackNextStage: paramExpectRollbackNextStage,
 		TargetVersion:                targetVersion,
+		ParamExpectFinishStage:       finishStage,
 	}
 	return &param, nil
 }
@@ -74,7 +74,12 @@ func SaveSharedStorageKey(ctx context.Context, params *param.SaveSharedStorageKe
 		return err
 	}
 
-	fullPath := joinPathQuery(params.Path, params.Endpoint)
+	// OceanBase accesses GCS through the S3-interoperable api.
+	path := params.Path
+	if strings.HasPrefix(path, constant.PREFIX_GCS) {
+		path = constant.PREFIX_S3 + strings.TrimPrefix(path, constant.PREFIX_GCS)
+	}
+	fullPath := joinPathQuery(path, params.Endpoint)
 	accessInfo := fmt.Sprintf("access_id=%s&access_key=%s", params.AccessKey, params.SecretKey)
 	if err := obclusterService.UpdateSharedStorageConfig(ctx, fullPath, accessInfo); err != nil {
 		return errors.Occurf(errors.ErrCommonUnexpected, "failed to execute ALTER SYSTEM statement: %v", err)
@@ -122,6 +127,14 @@ func buildStorageURI(params *param.ValidateSharedStorageKeyParam) (string, error
 		// force_path_style=true for Path-Style access (e.g. MinIO-compatible endpoints)
 		uri = fmt.Sprintf("%s%s/%s?host=%s&access_id=%s&access_key=%s&force_path_style=true",
 			constant.PREFIX_S3, pathInfo.BucketName, pathInfo.ObjectName, params.Endpoint, params.AccessKey, params.SecretKey)
+	case param.Azblob:
+		// access_id is the storage account name and access_key is the account key
+		uri = fmt.Sprintf("%s%s/%s?host=%s&access_id=%s&access_key=%s",
+			constant.PREFIX_AZBLOB, pathInfo.BucketName, pathInfo.ObjectName, params.Endpoint, params.AccessKey, params.SecretKey)
+	case param.Gcs:
+		// access_id and access_key are the HMAC keys of the service account
+		uri = fmt.Sprintf("%s%s/%s?host=%s&access_id=%s&access_key=%s",
+			constant.PREFIX_GCS, pathInfo.BucketName, pathInfo.ObjectName, params.Endpoint, params.AccessKey, params.SecretKey)
 	default:
 		return "", errors.Occur(errors.ErrCommonIllegalArgument, "unsupported storage type: %s", pathInfo.StorageType)
 	}
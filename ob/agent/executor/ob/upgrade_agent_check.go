@@ -64,6 +64,25 @@ func preCheckForAgentUpgrade(param param.UpgradeCheckParam) (err error) {
 	if err != nil {
 		return errors.Wrap(err, "failed to query all agents from ob")
 	}
+	for _, check := range agentUpgradeChecks(&param, allAgents) {
+		if err := check.check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func agentUpgradeChecks(param *param.UpgradeCheckParam, allAgents []meta.AgentInfo) []upgradeCheck {
+	return []upgradeCheck{
+		{"agents available", func() error { return checkAgentsAvailable(allAgents, false) }},
+		{"upgrade directory", func() error { return checkUpgradeDir(&param.UpgradeDir) }},
+		{"target version", func() error { return checkTargetVersionSupport(param.Version, param.Release) }},
+		{"target packages", func() error { return findTargetPkg(param.Version, param.Release) }},
+	}
+}
+
+// checkAgentsAvailable checks whether all the agents are running, and so are the observers if checkObserver is true.
+func checkAgentsAvailable(allAgents []meta.AgentInfo, checkObserver bool) error {
 	agentInfo := coordinator.OCS_COORDINATOR.Maintainer
 	agentsStatus := make(map[string]http.AgentStatus)
 	resErr := secure.SendGetRequest(agentInfo, "/api/v1/agents/status", nil, &agentsStatus)
@@ -71,10 +90,14 @@ func preCheckForAgentUpgrade(param param.UpgradeCheckParam) (err error) {
 		return errors.Wrap(resErr, "failed to query all agents status")
 	}
 	unavailableAgents := make([]string, 0)
+	unavailableObservers := make([]string, 0)
 	for agent, agentStatus := range agentsStatus {
 		if agentStatus.State != 2 {
 			unavailableAgents = append(unavailableAgents, agent)
 		}
+		if checkObserver && agentStatus.OBState != 3 {
+			unavailableObservers = append(unavailableObservers, fmt.Sprintf("%s:%d", agentStatus.Agent.GetIp(), agentStatus.SqlPort))
+		}
 	}
 	for _, agent := range allAgents {
 		if _, ok := agentsStatus[agent.String()]; !ok {
@@ -84,14 +107,8 @@ func preCheckForAgentUpgrade(param param.UpgradeCheckParam) (err error) {
 	if len(unavailableAgents) > 0 {
 		return errors.Occur(errors.ErrAgentUnavailable, strings.Join(unavailableAgents, ","))
 	}
-	if err := checkUpgradeDir(&param.UpgradeDir); err != nil {
-		return err
-	}
-	if err := checkTargetVersionSupport(param.Version, param.Release); err != nil {
-		return err
-	}
-	if err := findTargetPkg(param.Version, param.Release); err != nil {
-		return err
+	if len(unavailableObservers) > 0 {
+		return errors.Occur(errors.ErrObServerUnavailable, strings.Join(unavailableObservers, ","))
 	}
 	return nil
 }
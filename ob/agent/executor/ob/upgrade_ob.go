@@ -27,10 +27,8 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/oceanbase/obshell/ob/agent/constant"
-	"github.com/oceanbase/obshell/ob/agent/engine/coordinator"
 	"github.com/oceanbase/obshell/ob/agent/engine/task"
 	"github.com/oceanbase/obshell/ob/agent/errors"
-	"github.com/oceanbase/obshell/ob/agent/lib/http"
 	"github.com/oceanbase/obshell/ob/agent/lib/pkg"
 	"github.com/oceanbase/obshell/ob/agent/meta"
 	"github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
@@ -294,35 +292,8 @@ func preCheckForObUpgrade(param param.ObUpgradeParam, obType modelob.OBType) (p
 	if err != nil {
 		return nil, err
 	}
-	agentInfo := coordinator.OCS_COORDINATOR.Maintainer
-	agentsStatus := make(map[string]http.AgentStatus)
-	resErr := secure.SendGetRequest(agentInfo, "/api/v1/agents/status", nil, &agentsStatus)
-	if resErr != nil {
-		return nil, errors.Wrap(resErr, "failed to query all agents status")
-	}
-	unavailableAgents := make([]string, 0)
-	unavailableObservers := make([]string, 0)
-	for agent, agentStatus := range agentsStatus {
-		if agentStatus.State != 2 {
-			unavailableAgents = append(unavailableAgents, agent)
-		}
-		if agentStatus.OBState != 3 {
-			unavailableObservers = append(unavailableObservers, fmt.Sprintf("%s:%d", agentStatus.Agent.GetIp(), agentStatus.SqlPort))
-		}
-	}
-	for _, agent := range allAgents {
-		if _, ok := agentsStatus[agent.String()]; !ok {
-			unavailableAgents = append(unavailableAgents, agent.String())
-		}
-	}
-	if len(unavailableAgents) > 0 {
-		return nil, errors.Occur(errors.ErrAgentUnavailable, strings.Join(unavailableAgents, ","))
-	}
-	if len(unavailableObservers) > 0 {
-		return nil, errors.Occur(errors.ErrObServerUnavailable, strings.Join(unavailableObservers, ","))
-	}
-	currentBuildVersion, e := obclusterService.GetObBuildVersion()
-	if e != nil {
+	currentBuildVersion, err := obclusterService.GetObBuildVersion()
+	if err != nil {
 		return nil, err
 	}
 	p = &obUpgradeParams{
@@ -330,42 +301,10 @@ func preCheckForObUpgrade(param param.ObUpgradeParam, obType modelob.OBType) (p
 		currentVersion: strings.Split(currentBuildVersion, "_")[0],
 	}
 
-	if err = checkUpgradeMode(&param); err != nil {
-		return
-	}
-
-	// Check python and module dependencies on real execute agents
-	if err = checkPythonEnvOnRealExecuteAgents(allAgents); err != nil {
-		return nil, err
-	}
-
-	if err = checkUpgradeDir(&param.UpgradeDir); err != nil {
-		return nil, err
-	}
-
-	// Check if there are any tenants in the cluster with unsynchronized schema
-	if err = checkAllTenantsSchemaInSync(); err != nil {
-		return nil, err
-	}
-
-	// Check if there are any tablet is merging
-	if err = checkTabletNotInMerging(); err != nil {
-		return nil, err
-	}
-
-	// Check if there are any tenants in major compaction
-	if err = checkTenantNotInMajorCompaction(); err != nil {
-		return nil, err
-	}
-
-	// Check if data version is sync
-	if err = checkDataVersionSync(); err != nil {
-		return nil, err
-	}
-
-	// Check if there are any running backup tasks
-	if err = checkNoRunningBackupTask(); err != nil {
-		return nil, err
+	for _, check := range obUpgradeChecks(&param, allAgents) {
+		if err = check.check(); err != nil {
+			return nil, err
+		}
 	}
 
 	p.upgradeRoute, err = checkForAllRequiredPkgs(param.Version, param.Release, obType)
@@ -381,6 +320,32 @@ func preCheckForObUpgrade(param param.ObUpgradeParam, obType modelob.OBType) (p
 	return p, nil
 }
 
+type upgradeCheck struct {
+	name  string
+	check func() error
+}
+
+// obUpgradeChecks returns the checks before upgrading ob, except the check of the required packages.
+func obUpgradeChecks(param *param.ObUpgradeParam, allAgents []meta.AgentInfo) []upgradeCheck {
+	return []upgradeCheck{
+		{"agents and observers available", func() error { return checkAgentsAvailable(allAgents, true) }},
+		{"upgrade mode", func() error { return checkUpgradeMode(param) }},
+		// Check python and module dependencies on real execute agents
+		{"python environment", func() error { return checkPythonEnvOnRealExecuteAgents(allAgents) }},
+		{"upgrade directory", func() error { return checkUpgradeDir(&param.UpgradeDir) }},
+		// Check if there are any tenants in the cluster with unsynchronized schema
+		{"tenant schema in sync", checkAllTenantsSchemaInSync},
+		// Check if there are any tablet is merging
+		{"no tablet in merging", checkTabletNotInMerging},
+		// Check if there are any tenants in major compaction
+		{"no tenant in major compaction", checkTenantNotInMajorCompaction},
+		// Check if data version is sync
+		{"data version in sync", checkDataVersionSync},
+		// Check if there are any running backup tasks
+		{"no running backup task", checkNoRunningBackupTask},
+	}
+}
+
 const (
 	PARAM_ROLLING_UPGRADE      = "ROLLING"
 	PARAM_STOP_SERVICE_UPGRADE = "STOPSERVICE"
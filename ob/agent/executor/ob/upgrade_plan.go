@@ -0,0 +1,288 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ob
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/engine/task"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/lib/pkg"
+	"github.com/oceanbase/obshell/ob/agent/meta"
+	modelob "github.com/oceanbase/obshell/ob/model/oceanbase"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+const (
+	// ESTIMATED_OBSERVER_UPGRADE_TIME is a rough estimate of the time to reinstall and restart
+	// the observers of a zone for one hop of the upgrade route.
+	ESTIMATED_OBSERVER_UPGRADE_TIME = 5 * time.Minute
+	// ESTIMATED_ZONE_MINOR_FREEZE_TIME is a rough estimate of the minor freeze of a stopped zone when rolling upgrade.
+	ESTIMATED_ZONE_MINOR_FREEZE_TIME = 1 * time.Minute
+)
+
+// GetObUpgradePlan renders the plan of upgrading ob without creating any dag.
+// Unlike the upgrade, all the checks are executed and the failed ones are reported in the plan.
+func GetObUpgradePlan(p param.ObUpgradeParam) (*param.UpgradePlan, error) {
+	if !meta.OCS_AGENT.IsClusterAgent() {
+		return nil, errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, meta.OCS_AGENT.String(), meta.OCS_AGENT.GetIdentity(), meta.CLUSTER_AGENT)
+	}
+	obType, err := obclusterService.GetOBType()
+	if err != nil {
+		return nil, err
+	}
+	allAgents, err := agentService.GetAllAgentsInfoFromOB()
+	if err != nil {
+		return nil, err
+	}
+	currentBuildVersion, err := obclusterService.GetObBuildVersion()
+	if err != nil {
+		return nil, err
+	}
+	targetBuildNumber, distribution, err := pkg.SplitRelease(p.Release)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &param.UpgradePlan{
+		CurrentVersion: strings.ReplaceAll(currentBuildVersion, "_", "-"),
+		TargetVersion:  fmt.Sprintf("%s-%s", p.Version, targetBuildNumber),
+	}
+	for _, check := range obUpgradeChecks(&p, allAgents) {
+		addUpgradePlanCheck(plan, check.name, check.check())
+	}
+	plan.Mode = p.Mode
+
+	upgradeParams := &obUpgradeParams{
+		RequestParam:   &p,
+		currentVersion: strings.Split(currentBuildVersion, "_")[0],
+		agents:         allAgents,
+	}
+	addUpgradePlanCheck(plan, "target version", checkTargetOBVersionSupport(plan.TargetVersion))
+	upgradeRoute, err := getTargetObUpgradeDepYaml(p.Version, p.Release, obType)
+	addUpgradePlanCheck(plan, "upgrade route", err)
+	if err == nil {
+		upgradeParams.upgradeRoute = upgradeRoute[1:]
+		for _, node := range upgradeParams.upgradeRoute {
+			plan.Route = append(plan.Route, param.UpgradeRouteHop{
+				Version:           node.Version,
+				Release:           node.Release,
+				RequireFromBinary: node.RequireFromBinary,
+			})
+		}
+		if plan.RequiredPackages, err = getRequiredObPkgs(upgradeRoute, distribution, obType); err != nil {
+			return nil, err
+		}
+		addUpgradePlanCheck(plan, "required packages", checkForAllRequiredPkgsExist(upgradeRoute, distribution, obType))
+	}
+
+	if err = upgradeParams.initParamsForObUpgrade(); err != nil {
+		return nil, err
+	}
+	for i := range plan.Route {
+		plan.Route[i].PreScripts, plan.Route[i].PostScripts = getUpgradeHopScripts(upgradeParams, i)
+	}
+	for _, zone := range upgradeParams.dbaObZones {
+		plan.ZoneOrder = append(plan.ZoneOrder, zone.Zone)
+	}
+	plan.ZoneDowntime, plan.ClusterDowntime = estimateObUpgradeDowntime(upgradeParams)
+	plan.Stages = getTemplateStages(buildCheckAndUpgradeObTemplate(upgradeParams, obType))
+	return plan, nil
+}
+
+// getUpgradeHopScripts returns the scripts executed by the upgrade process of the hop,
+// split by the first restarting of the observers, with the zone if the script works on a zone.
+func getUpgradeHopScripts(p *obUpgradeParams, idx int) (preScripts, postScripts []string) {
+	var template *task.Template
+	if p.rollingUpgrade {
+		template = newRollingUpgradeProcessTemplate(idx, p)
+	} else {
+		template = newStopServiceUpgradeProcessTemplate(idx, p)
+	}
+	preScripts, postScripts = make([]string, 0), make([]string, 0)
+	restarted := false
+	for _, node := range template.GetNodes() {
+		if node.GetName() == TASK_REINSTALL_AND_RESTART_OBSERVER {
+			restarted = true
+			continue
+		}
+		ctx := node.GetContext()
+		if ctx == nil {
+			continue
+		}
+		script, ok := ctx.GetParam(PARAM_SCRIPT_FILE).(string)
+		if !ok {
+			continue
+		}
+		if zone, ok := ctx.GetParam(PARAM_ZONE).(string); ok && zone != "" {
+			script = fmt.Sprintf("%s (%s)", script, zone)
+		}
+		if restarted {
+			postScripts = append(postScripts, script)
+		} else {
+			preScripts = append(preScripts, script)
+		}
+	}
+	return
+}
+
+// estimateObUpgradeDowntime estimates the unavailable windows of each zone, the time of the scripts is not included.
+// When upgrading with stopping service, the observers of all the zones are restarted together in each hop,
+// so the whole cluster is unavailable in the windows. When rolling upgrade, the zones are stopped one by one
+// in each hop while the other zones keep serving, so the windows of the zones do not overlap.
+func estimateObUpgradeDowntime(p *obUpgradeParams) (zoneDowntime []param.UpgradeZoneDowntime, clusterDowntime int) {
+	window := int(ESTIMATED_OBSERVER_UPGRADE_TIME.Seconds())
+	if p.rollingUpgrade && p.freezeServer {
+		// The stopped zone is frozen before restarting.
+		window += int(ESTIMATED_ZONE_MINOR_FREEZE_TIME.Seconds())
+	}
+	hopDuration := window
+	if p.rollingUpgrade {
+		hopDuration = window * len(p.dbaObZones)
+	}
+
+	for i, zone := range p.dbaObZones {
+		downtime := param.UpgradeZoneDowntime{
+			Zone:    zone.Zone,
+			Servers: len(p.agentsInZoneMap[zone.Zone]),
+			Windows: make([]param.UpgradeDowntimeWindow, 0, len(p.upgradeRoute)),
+		}
+		for hop := range p.upgradeRoute {
+			start := hop * hopDuration
+			if p.rollingUpgrade {
+				start += i * window
+			}
+			downtime.Windows = append(downtime.Windows, param.UpgradeDowntimeWindow{Hop: hop, Start: start, Duration: window})
+			downtime.EstimatedDowntime += window
+		}
+		zoneDowntime = append(zoneDowntime, downtime)
+	}
+	if !p.rollingUpgrade {
+		clusterDowntime = len(p.upgradeRoute) * window
+	}
+	return
+}
+
+// GetAgentUpgradePlan renders the plan of upgrading agent without creating any dag.
+// The observers keep serving when upgrading agent, so there is no downtime.
+func GetAgentUpgradePlan(p param.UpgradeCheckParam) (*param.UpgradePlan, error) {
+	if !meta.OCS_AGENT.IsClusterAgent() {
+		return nil, errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, meta.OCS_AGENT.String(), meta.OCS_AGENT.GetIdentity(), meta.CLUSTER_AGENT)
+	}
+	allAgents, err := agentService.GetAllAgentsInfoFromOB()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query all agents from ob")
+	}
+	buildNumber, distribution, err := pkg.SplitRelease(p.Release)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &param.UpgradePlan{
+		CurrentVersion: constant.VERSION_RELEASE,
+		TargetVersion:  fmt.Sprintf("%s-%s", p.Version, buildNumber),
+		Route: []param.UpgradeRouteHop{
+			{Version: p.Version, Release: buildNumber},
+		},
+	}
+	for _, check := range agentUpgradeChecks(&p, allAgents) {
+		addUpgradePlanCheck(plan, check.name, check.check())
+	}
+
+	archList, err := obclusterService.GetAllArchs()
+	if err != nil {
+		return nil, err
+	}
+	for _, arch := range archList {
+		_, err := obclusterService.GetUpgradePkgInfoByVersionAndRelease(constant.PKG_OBSHELL, p.Version, buildNumber, distribution, arch)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		plan.RequiredPackages = append(plan.RequiredPackages, param.UpgradePlanPackage{
+			Name:     fmt.Sprintf("%s-%s-%s.%s.rpm", constant.PKG_OBSHELL, p.Version, p.Release, arch),
+			Arch:     arch,
+			Uploaded: err == nil,
+		})
+	}
+
+	zones, err := obclusterService.GetAllZone()
+	if err != nil {
+		return nil, err
+	}
+	for _, zone := range zones {
+		plan.ZoneOrder = append(plan.ZoneOrder, zone.Zone)
+	}
+	plan.Stages = getTemplateStages(buildAgentUpgradeTemplate(p))
+	return plan, nil
+}
+
+func addUpgradePlanCheck(plan *param.UpgradePlan, name string, err error) {
+	check := param.UpgradePlanCheck{Name: name, Passed: err == nil}
+	if err != nil {
+		check.Message = err.Error()
+		plan.FailedChecks++
+	}
+	plan.Checks = append(plan.Checks, check)
+}
+
+// getRequiredObPkgs returns the packages required by the upgrade route, the first node of which is the current version.
+func getRequiredObPkgs(upgradeRoute []RouteNode, distribution string, obType modelob.OBType) ([]param.UpgradePlanPackage, error) {
+	archList, err := obclusterService.GetAllArchs()
+	if err != nil {
+		return nil, err
+	}
+	pkgs := make([]param.UpgradePlanPackage, 0)
+	for _, node := range upgradeRoute[1:] {
+		for _, arch := range archList {
+			for _, pkgName := range constant.REQUIRE_UPGRADE_PKG_NAMES_MAP[obType] {
+				var name string
+				if node.Release == RELEASE_NULL {
+					name = fmt.Sprintf("%s-%s-${release}.%s.%s.rpm", pkgName, node.Version, distribution, arch)
+					_, err = obclusterService.GetUpgradePkgInfoByVersion(pkgName, node.Version, distribution, arch, node.DeprecatedInfo)
+				} else {
+					name = fmt.Sprintf("%s-%s.%s.%s.rpm", pkgName, node.BuildVersion, distribution, arch)
+					_, err = obclusterService.GetUpgradePkgInfoByVersionAndRelease(pkgName, node.Version, node.Release, distribution, arch)
+				}
+				if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, err
+				}
+				pkgs = append(pkgs, param.UpgradePlanPackage{Name: name, Arch: arch, Uploaded: err == nil})
+			}
+		}
+	}
+	return pkgs, nil
+}
+
+// getTemplateStages returns the names of the nodes in the template, with the zone if the node works on a zone.
+func getTemplateStages(template *task.Template) []string {
+	stages := make([]string, 0, len(template.GetNodes()))
+	for _, node := range template.GetNodes() {
+		name := node.GetName()
+		if ctx := node.GetContext(); ctx != nil {
+			if zone, ok := ctx.GetParam(PARAM_ZONE).(string); ok && zone != "" {
+				name = fmt.Sprintf("%s (%s)", name, zone)
+			}
+		}
+		stages = append(stages, name)
+	}
+	return stages
+}
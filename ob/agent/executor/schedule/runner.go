@@ -0,0 +1,204 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedule
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/engine/coordinator"
+	"github.com/oceanbase/obshell/ob/agent/engine/task"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/executor/inspection"
+	"github.com/oceanbase/obshell/ob/agent/executor/ob"
+	"github.com/oceanbase/obshell/ob/agent/executor/tenant"
+	"github.com/oceanbase/obshell/ob/agent/lib/parse"
+	"github.com/oceanbase/obshell/ob/agent/meta"
+	obmodel "github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+	taskservice "github.com/oceanbase/obshell/ob/agent/service/task"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+var (
+	clusterTaskService = taskservice.NewClusterTaskService()
+	localTaskService   = taskservice.NewLocalTaskService()
+
+	runnerOnce sync.Once
+)
+
+// Start starts the schedule runner in background.
+// Every agent runs it, but only the maintainer of the cluster triggers the schedules,
+// so the schedules keep working when the maintainer moves to another agent.
+func Start() {
+	runnerOnce.Do(func() {
+		go runLoop()
+	})
+}
+
+func runLoop() {
+	ticker := time.NewTicker(constant.SCHEDULE_CHECK_INTERVAL)
+	defer ticker.Stop()
+	for range ticker.C {
+		if !isScheduleRunner() {
+			continue
+		}
+		runDueSchedules(time.Now())
+	}
+}
+
+func isScheduleRunner() bool {
+	return meta.OCS_AGENT != nil && meta.OCS_AGENT.IsClusterAgent() &&
+		coordinator.OCS_COORDINATOR != nil && coordinator.OCS_COORDINATOR.IsMaintainer()
+}
+
+func runDueSchedules(now time.Time) {
+	jobs, err := scheduleService.ListDue(now)
+	if err != nil {
+		log.WithError(err).Debug("list due schedules failed")
+		return
+	}
+	for i := range jobs {
+		runSchedule(&jobs[i], now)
+	}
+}
+
+// runSchedule triggers the job once. If several runs were missed, for example when
+// no maintainer was active, only one run is triggered and the next one is calculated from now.
+func runSchedule(job *obmodel.ScheduleJob, now time.Time) {
+	var nextRunTime *time.Time
+	cron, cronErr := parse.ParseCron(job.Cron)
+	if cronErr == nil {
+		if next := cron.Next(now); !next.IsZero() {
+			nextRunTime = &next
+		}
+	}
+	claimed, err := scheduleService.Claim(job, now, nextRunTime)
+	if err != nil {
+		log.WithError(err).Warnf("claim schedule '%s' failed", job.Name)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	run := &obmodel.ScheduleRun{
+		JobId:       job.Id,
+		JobName:     job.Name,
+		TriggerTime: now,
+	}
+	if cronErr != nil {
+		run.Status, run.Message = constant.SCHEDULE_RUN_STATUS_FAILED, cronErr.Error()
+	} else {
+		run.Status, run.DagId, run.Message = submitSchedule(job)
+	}
+	if len(run.Message) > constant.SCHEDULE_RUN_MESSAGE_LENGTH {
+		run.Message = run.Message[:constant.SCHEDULE_RUN_MESSAGE_LENGTH]
+	}
+	log.Infof("schedule '%s' triggered: %s %s", job.Name, run.Status, run.Message)
+	if err := scheduleService.CreateRun(run, constant.SCHEDULE_RUN_HISTORY_LIMIT); err != nil {
+		log.WithError(err).Warnf("record run of schedule '%s' failed", job.Name)
+	}
+}
+
+// submitSchedule submits the task of the job, and returns the status, the generic id of the task and the message.
+func submitSchedule(job *obmodel.ScheduleJob) (string, string, string) {
+	running, err := isPreviousRunRunning(job)
+	if err != nil {
+		return constant.SCHEDULE_RUN_STATUS_FAILED, "", err.Error()
+	}
+	if running {
+		return constant.SCHEDULE_RUN_STATUS_SKIPPED, "", "the task submitted by the previous run is still running"
+	}
+
+	var dag *task.DagDetailDTO
+	switch job.JobType {
+	case constant.SCHEDULE_JOB_TYPE_BACKUP:
+		obTenant, err := tenantService.GetTenantByName(job.TenantName)
+		if err != nil {
+			return constant.SCHEDULE_RUN_STATUS_FAILED, "", err.Error()
+		}
+		if obTenant == nil {
+			return constant.SCHEDULE_RUN_STATUS_FAILED, "", fmt.Sprintf("tenant '%s' not exist", job.TenantName)
+		}
+		finished, err := tenantService.IsBackupFinished(obTenant.TenantID)
+		if err != nil {
+			return constant.SCHEDULE_RUN_STATUS_FAILED, "", err.Error()
+		}
+		if !finished {
+			return constant.SCHEDULE_RUN_STATUS_SKIPPED, "", fmt.Sprintf("backup of tenant '%s' is still running", job.TenantName)
+		}
+		mode, plusArchive := job.BackupMode, job.PlusArchive
+		dag, err = ob.TenantStartBackup(obTenant, &param.BackupParam{Mode: &mode, PlusArchive: &plusArchive})
+		if err != nil {
+			return constant.SCHEDULE_RUN_STATUS_FAILED, "", err.Error()
+		}
+	case constant.SCHEDULE_JOB_TYPE_COMPACTION:
+		compaction, err := tenant.GetTenantCompaction(job.TenantName)
+		if err != nil {
+			return constant.SCHEDULE_RUN_STATUS_FAILED, "", err.Error()
+		}
+		if compaction.Status != "IDLE" {
+			return constant.SCHEDULE_RUN_STATUS_SKIPPED, "", fmt.Sprintf("compaction of tenant '%s' is %s", job.TenantName, compaction.Status)
+		}
+		if err = tenant.TenantMajorCompaction(job.TenantName); err != nil {
+			return constant.SCHEDULE_RUN_STATUS_FAILED, "", err.Error()
+		}
+		return constant.SCHEDULE_RUN_STATUS_SUBMITTED, "", "major compaction triggered"
+	case constant.SCHEDULE_JOB_TYPE_INSPECTION:
+		dag, err = inspection.TriggerInspection(&param.InspectionParam{Scenario: job.Scenario})
+		if err != nil {
+			return constant.SCHEDULE_RUN_STATUS_FAILED, "", err.Error()
+		}
+	default:
+		return constant.SCHEDULE_RUN_STATUS_FAILED, "", fmt.Sprintf("unsupported job type '%s'", job.JobType)
+	}
+	return constant.SCHEDULE_RUN_STATUS_SUBMITTED, dag.GenericID, ""
+}
+
+// isPreviousRunRunning checks whether the task submitted by the last run is still running.
+// The local task submitted by another agent, which was the maintainer before, is regarded as finished.
+func isPreviousRunRunning(job *obmodel.ScheduleJob) (bool, error) {
+	run, err := scheduleService.GetLastRunByStatus(job.Id, constant.SCHEDULE_RUN_STATUS_SUBMITTED)
+	if err != nil || run == nil || run.DagId == "" {
+		return false, err
+	}
+	id, agent, err := task.ConvertGenericID(run.DagId)
+	if err != nil {
+		return false, err
+	}
+
+	var dag *task.Dag
+	if agent == nil {
+		dag, err = clusterTaskService.GetDagInstance(id)
+	} else if meta.OCS_AGENT.Equal(agent) {
+		dag, err = localTaskService.GetDagInstance(id)
+	} else {
+		return false, nil
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return !dag.IsFinished(), nil
+}
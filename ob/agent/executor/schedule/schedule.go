@@ -0,0 +1,228 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedule
+
+import (
+	"strings"
+	"time"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	inspectionconstant "github.com/oceanbase/obshell/ob/agent/executor/inspection/constant"
+	"github.com/oceanbase/obshell/ob/agent/lib/parse"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+	obmodel "github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+	scheduleservice "github.com/oceanbase/obshell/ob/agent/service/schedule"
+	tenantservice "github.com/oceanbase/obshell/ob/agent/service/tenant"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+var (
+	scheduleService = scheduleservice.ScheduleService{}
+	tenantService   = tenantservice.TenantService{}
+)
+
+func ListSchedules() ([]bo.Schedule, error) {
+	jobs, err := scheduleService.List()
+	if err != nil {
+		return nil, err
+	}
+	schedules := make([]bo.Schedule, 0, len(jobs))
+	for i := range jobs {
+		schedules = append(schedules, jobs[i].ToBO())
+	}
+	return schedules, nil
+}
+
+func GetSchedule(name string) (*bo.Schedule, error) {
+	job, err := getScheduleJob(name)
+	if err != nil {
+		return nil, err
+	}
+	schedule := job.ToBO()
+	return &schedule, nil
+}
+
+func CreateSchedule(p *param.CreateScheduleParam) (*bo.Schedule, error) {
+	existing, err := scheduleService.GetByName(p.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.Occur(errors.ErrScheduleAlreadyExists, p.Name)
+	}
+
+	job := &obmodel.ScheduleJob{
+		Name:        p.Name,
+		JobType:     strings.ToLower(p.JobType),
+		Cron:        strings.TrimSpace(p.Cron),
+		Enabled:     p.Enabled == nil || *p.Enabled,
+		TenantName:  p.TenantName,
+		BackupMode:  p.BackupMode,
+		PlusArchive: p.PlusArchive,
+		Scenario:    p.Scenario,
+		Description: p.Description,
+	}
+	if err := checkScheduleJob(job); err != nil {
+		return nil, err
+	}
+	if err := scheduleService.Create(job); err != nil {
+		return nil, err
+	}
+	return GetSchedule(p.Name)
+}
+
+func UpdateSchedule(name string, p *param.UpdateScheduleParam) (*bo.Schedule, error) {
+	job, err := getScheduleJob(name)
+	if err != nil {
+		return nil, err
+	}
+	if p.Cron != nil {
+		job.Cron = strings.TrimSpace(*p.Cron)
+	}
+	if p.Enabled != nil {
+		job.Enabled = *p.Enabled
+	}
+	if p.TenantName != nil {
+		job.TenantName = *p.TenantName
+	}
+	if p.BackupMode != nil {
+		job.BackupMode = *p.BackupMode
+	}
+	if p.PlusArchive != nil {
+		job.PlusArchive = *p.PlusArchive
+	}
+	if p.Scenario != nil {
+		job.Scenario = *p.Scenario
+	}
+	if p.Description != nil {
+		job.Description = *p.Description
+	}
+	if err := checkScheduleJob(job); err != nil {
+		return nil, err
+	}
+	if err := scheduleService.Update(job); err != nil {
+		return nil, err
+	}
+	return GetSchedule(name)
+}
+
+func DeleteSchedule(name string) error {
+	job, err := getScheduleJob(name)
+	if err != nil {
+		return err
+	}
+	return scheduleService.Delete(job)
+}
+
+func ListScheduleRuns(name string, p *param.ListScheduleRunsParam) ([]bo.ScheduleRun, error) {
+	job, err := getScheduleJob(name)
+	if err != nil {
+		return nil, err
+	}
+	limit := p.Limit
+	if limit <= 0 {
+		limit = constant.SCHEDULE_RUN_DEFAULT_LIMIT
+	}
+	if limit > constant.SCHEDULE_RUN_HISTORY_LIMIT {
+		limit = constant.SCHEDULE_RUN_HISTORY_LIMIT
+	}
+	runs, err := scheduleService.ListRuns(job.Id, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]bo.ScheduleRun, 0, len(runs))
+	for i := range runs {
+		res = append(res, runs[i].ToBO())
+	}
+	return res, nil
+}
+
+func getScheduleJob(name string) (*obmodel.ScheduleJob, error) {
+	job, err := scheduleService.GetByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, errors.Occur(errors.ErrScheduleNotFound, name)
+	}
+	return job, nil
+}
+
+// checkScheduleJob validates the job, clears the fields not used by the job type
+// and recalculates the next run time from now.
+func checkScheduleJob(job *obmodel.ScheduleJob) error {
+	cron, err := parse.ParseCron(job.Cron)
+	if err != nil {
+		return err
+	}
+
+	switch job.JobType {
+	case constant.SCHEDULE_JOB_TYPE_BACKUP:
+		if err := checkScheduleTenant(job); err != nil {
+			return err
+		}
+		mode := job.BackupMode
+		backupParam := param.BackupParam{Mode: &mode}
+		if err := backupParam.Check(); err != nil {
+			return err
+		}
+		job.BackupMode = *backupParam.Mode
+		job.Scenario = ""
+	case constant.SCHEDULE_JOB_TYPE_COMPACTION:
+		if err := checkScheduleTenant(job); err != nil {
+			return err
+		}
+		job.BackupMode, job.PlusArchive, job.Scenario = "", false, ""
+	case constant.SCHEDULE_JOB_TYPE_INSPECTION:
+		if job.Scenario == "" {
+			job.Scenario = strings.ToLower(inspectionconstant.SCENARIO_BASIC)
+		}
+		scenario := strings.ToUpper(job.Scenario)
+		if scenario != inspectionconstant.SCENARIO_BASIC && scenario != inspectionconstant.SCENARIO_PERFORMANCE {
+			return errors.Occur(errors.ErrObClusterInspectionScenarioNotSupported, job.Scenario, inspectionconstant.SCENARIO_BASIC, inspectionconstant.SCENARIO_PERFORMANCE)
+		}
+		job.TenantName, job.BackupMode, job.PlusArchive = "", "", false
+	default:
+		return errors.Occur(errors.ErrScheduleJobTypeInvalid, job.JobType, strings.Join([]string{
+			constant.SCHEDULE_JOB_TYPE_BACKUP, constant.SCHEDULE_JOB_TYPE_COMPACTION, constant.SCHEDULE_JOB_TYPE_INSPECTION}, ", "))
+	}
+
+	next := cron.Next(time.Now())
+	if next.IsZero() {
+		return errors.Occur(errors.ErrCommonInvalidCronExpression, job.Cron, "it never matches in the next five years")
+	}
+	job.NextRunTime = &next
+	return nil
+}
+
+func checkScheduleTenant(job *obmodel.ScheduleJob) error {
+	if job.TenantName == "" {
+		return errors.Occur(errors.ErrScheduleTenantRequired, job.JobType)
+	}
+	if job.TenantName == constant.TENANT_SYS {
+		return errors.Occur(errors.ErrObTenantSysOperationNotAllowed)
+	}
+	tenant, err := tenantService.GetTenantByName(job.TenantName)
+	if err != nil {
+		return err
+	}
+	if tenant == nil {
+		return errors.Occur(errors.ErrObTenantNotExist, job.TenantName)
+	}
+	return nil
+}
@@ -35,7 +35,13 @@ func Login(c *gin.Context) (string, error) {
 		return "", errors.Occur(errors.ErrRequestAESKeyNotFound)
 	}
 	// only support aes currently, other algorithms will be supported in the future
-	sessionID, err := secure.CreateSession()
+	var sessionID string
+	var err error
+	if account, exist := c.Get(constant.HTTP_HEADER_ACCOUNT); exist {
+		sessionID, err = secure.CreateAccountSession(account.(string))
+	} else {
+		sessionID, err = secure.CreateSession()
+	}
 	if err != nil {
 		return "", err
 	}
@@ -392,16 +392,21 @@ func CreateTenant(param *param.CreateTenantParam) (*task.DagDetailDTO, error) {
 }
 
 func buildCreateTenantDagTemplate(param *param.CreateTenantParam) (*task.Template, error) {
+	createTenantNode := newCreateTenantNode(param)
 	templateBuilder := task.NewTemplateBuilder(fmt.Sprintf(DAG_CREATE_TENANT, *param.Name)).
 		SetMaintenance(task.TenantMaintenance(*param.Name)).
-		AddNode(newCreateTenantNode(param))
+		AddNode(createTenantNode)
+
+	// The settings of the new tenant don't depend on each other,
+	// so they run concurrently once the tenant is created.
+	settingNodes := make([]*task.Node, 0)
 	if len(param.Parameters) != 0 {
-		templateBuilder.AddNode(newSetTenantParameterNode(param.Parameters))
+		settingNodes = append(settingNodes, newSetTenantParameterNode(param.Parameters))
 	}
-	templateBuilder.AddNode(newModifyTenantWhitelistNode(*param.Whitelist))
+	settingNodes = append(settingNodes, newModifyTenantWhitelistNode(*param.Whitelist))
 
 	if param.TimeZone != "" {
-		templateBuilder.AddNode(newSetTenantTimeZoneNode(param.TimeZone))
+		settingNodes = append(settingNodes, newSetTenantTimeZoneNode(param.TimeZone))
 	}
 	// Delete the read-only variables
 	variables := make(map[string]interface{})
@@ -415,7 +420,10 @@ func buildCreateTenantDagTemplate(param *param.CreateTenantParam) (*task.Templat
 		if err != nil {
 			return nil, err
 		}
-		templateBuilder.AddNode(node)
+		settingNodes = append(settingNodes, node)
+	}
+	for _, node := range settingNodes {
+		templateBuilder.AddNodeAfter(node, createTenantNode)
 	}
 
 	agents, err := agentService.GetAllAgentsInfo()
@@ -63,6 +63,13 @@ const (
 	PARAM_PRIMARY_ZONE                 = "primaryZone"
 	PARAM_ZONE_WITH_UNIT               = "zoneWithUnit"
 	PARAM_TIMESTAMP                    = "timestamp"
+	PARAM_CREATE_STANDBY_TENANT        = "createStandbyTenant"
+	PARAM_LOG_RESTORE_SOURCE           = "logRestoreSource"
+	PARAM_STANDBY_PRIMARY_INFO         = "standbyPrimaryInfo"
+	PARAM_TARGET_TENANT_ROLE           = "targetTenantRole"
+	PARAM_INHERIT_ROOT_PASSWORD        = "inheritRootPassword"
+	PARAM_CLONE_TENANT                 = "cloneTenant"
+	PARAM_SOURCE_TENANT_NAME           = "sourceTenantName"
 
 	// tenant task
 	TASK_NAME_CREATE_AND_ATTACH_RESOURCE_POOL = "Create and attach resource pools"
@@ -86,6 +93,13 @@ const (
 	TASK_NAME_ATTACH_TENANT_RESOURCE_POOL     = "Attach tenant resource pool"
 	TASK_NAME_ALTER_TENANT_LOCALITY           = "Alter tenant locality"
 	TASK_NAME_ALTER_TENANT_PRIMARY_ZONE       = "Alter tenant primary zone"
+	TASK_NAME_CREATE_STANDBY_TENANT           = "Create standby tenant"
+	TASK_NAME_WAIT_STANDBY_TENANT_NORMAL      = "Wait for standby tenant normal"
+	TASK_NAME_SET_LOG_RESTORE_SOURCE          = "Set log restore source"
+	TASK_NAME_SWITCHOVER_TENANT               = "Switchover tenant"
+	TASK_NAME_ACTIVATE_STANDBY_TENANT         = "Activate standby tenant"
+	TASK_NAME_CLONE_TENANT                    = "Clone tenant"
+	TASK_NAME_WAIT_CLONE_TENANT_FINISH        = "Wait for clone tenant finish"
 
 	// tenant dag
 	DAG_CREATE_TENANT              = "Create tenant %s"
@@ -95,6 +109,10 @@ const (
 	DAG_SCALE_IN_TENANT_REPLICA    = "Scale in tenant replicas"
 	DAG_MODIFY_TENANT_REPLICA      = "Modify tenant replicas"
 	DAG_MODIFY_TENANT_PRIMARY_ZONE = "Modify tenant primary zone"
+	DAG_CREATE_STANDBY_TENANT      = "Create standby tenant %s"
+	DAG_STANDBY_SWITCHOVER         = "Switchover standby tenant %s"
+	DAG_STANDBY_FAILOVER           = "Failover standby tenant %s"
+	DAG_CLONE_TENANT               = "Clone tenant %s from %s"
 
 	EXPRESS_OLTP = "express_oltp"
 	COMPLEX_OLTP = "complex_oltp"
@@ -141,4 +159,11 @@ func RegisterTenantTask() {
 	task.RegisterTaskType(AlterResourcePoolUnitNumTask{})
 	task.RegisterTaskType(AlterResourcePoolUnitConfTask{})
 	task.RegisterTaskType(ModifyTenantWhitelistTask{})
+	task.RegisterTaskType(CreateStandbyTenantTask{})
+	task.RegisterTaskType(WaitStandbyTenantNormalTask{})
+	task.RegisterTaskType(SetLogRestoreSourceTask{})
+	task.RegisterTaskType(SwitchoverTenantTask{})
+	task.RegisterTaskType(ActivateStandbyTenantTask{})
+	task.RegisterTaskType(CloneTenantTask{})
+	task.RegisterTaskType(WaitCloneTenantFinishTask{})
 }
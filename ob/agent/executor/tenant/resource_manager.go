@@ -0,0 +1,373 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenant
+
+import (
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+func checkResourceManagerName(name string) error {
+	if !regexp.MustCompile(constant.RESOURCE_MANAGER_NAME_PATTERN).MatchString(name) {
+		return errors.Occur(errors.ErrObResourceManagerNameInvalid, name)
+	}
+	return nil
+}
+
+// getResourceManagerConnection returns the connection of the tenant and the mode of the tenant,
+// the procedures and views of resource manager are different in mysql and oracle mode.
+func getResourceManagerConnection(name string, rootPassword *string) (*gorm.DB, string, error) {
+	tenantInfo, err := tenantService.GetTenantByName(name)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "get tenant '%s' info failed", name)
+	}
+	if tenantInfo == nil {
+		return nil, "", errors.Occur(errors.ErrObTenantNotExist, name)
+	}
+	db, err := GetConnectionWithTenantInfo(tenantInfo, rootPassword)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "Failed to get db connection of tenant %s", name)
+	}
+	return db, tenantInfo.Mode, nil
+}
+
+func checkResourcePlanExist(db *gorm.DB, mode, tenantName, plan string) error {
+	exist, err := tenantService.IsResourcePlanExist(db, mode, plan)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to check if resource plan %s exists in tenant %s", plan, tenantName)
+	}
+	if !exist {
+		return errors.Occur(errors.ErrObResourcePlanNotExist, plan, tenantName)
+	}
+	return nil
+}
+
+func checkConsumerGroupExist(db *gorm.DB, mode, tenantName, group string) error {
+	exist, err := tenantService.IsConsumerGroupExist(db, mode, group)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to check if consumer group %s exists in tenant %s", group, tenantName)
+	}
+	if !exist {
+		return errors.Occur(errors.ErrObConsumerGroupNotExist, group, tenantName)
+	}
+	return nil
+}
+
+func ListResourcePlans(tenantName string, password *string) ([]bo.ResourcePlan, error) {
+	db, mode, err := getResourceManagerConnection(tenantName, password)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return nil, err
+	}
+	plans, err := tenantService.ListResourcePlans(db, mode)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list resource plans of tenant %s", tenantName)
+	}
+	directives, err := tenantService.ListResourcePlanDirectives(db, mode)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list resource plan directives of tenant %s", tenantName)
+	}
+	activePlan, err := tenantService.GetActiveResourcePlan(tenantName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get active resource plan of tenant %s", tenantName)
+	}
+
+	directivesMap := make(map[string][]bo.ResourcePlanDirective)
+	for i := range directives {
+		directivesMap[directives[i].Plan] = append(directivesMap[directives[i].Plan], directives[i].ToBO())
+	}
+	resourcePlans := make([]bo.ResourcePlan, 0, len(plans))
+	for _, plan := range plans {
+		resourcePlan := bo.ResourcePlan{
+			PlanID:     plan.PlanID,
+			Plan:       plan.Plan,
+			Comment:    plan.Comments,
+			Active:     strings.EqualFold(plan.Plan, activePlan),
+			Directives: directivesMap[plan.Plan],
+		}
+		if resourcePlan.Directives == nil {
+			resourcePlan.Directives = make([]bo.ResourcePlanDirective, 0)
+		}
+		resourcePlans = append(resourcePlans, resourcePlan)
+	}
+	return resourcePlans, nil
+}
+
+func CreateResourcePlan(tenantName string, p *param.CreateResourcePlanParam) error {
+	if err := checkResourceManagerName(p.Plan); err != nil {
+		return err
+	}
+	db, mode, err := getResourceManagerConnection(tenantName, p.RootPassword)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return err
+	}
+	if err = tenantService.CreateResourcePlan(db, mode, p.Plan, p.Comment); err != nil {
+		return errors.Wrapf(err, "Failed to create resource plan %s of tenant %s", p.Plan, tenantName)
+	}
+	return nil
+}
+
+func DeleteResourcePlan(tenantName, plan string, password *string) error {
+	db, mode, err := getResourceManagerConnection(tenantName, password)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return err
+	}
+	exist, err := tenantService.IsResourcePlanExist(db, mode, plan)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to check if resource plan %s exists in tenant %s", plan, tenantName)
+	}
+	if !exist {
+		return nil
+	}
+	activePlan, err := tenantService.GetActiveResourcePlan(tenantName)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get active resource plan of tenant %s", tenantName)
+	}
+	if strings.EqualFold(activePlan, plan) {
+		return errors.Occur(errors.ErrObResourcePlanActive, plan, tenantName)
+	}
+	if err = tenantService.DeleteResourcePlan(db, mode, plan); err != nil {
+		return errors.Wrapf(err, "Failed to delete resource plan %s of tenant %s", plan, tenantName)
+	}
+	return nil
+}
+
+// ActivateResourcePlan makes the resource plan take effect in the tenant,
+// the current plan will be deactivated if no plan is specified.
+func ActivateResourcePlan(tenantName string, p *param.ActivateResourcePlanParam) error {
+	if p.Plan != "" {
+		db, mode, err := getResourceManagerConnection(tenantName, p.RootPassword)
+		defer CloseDbConnection(db)
+		if err != nil {
+			return err
+		}
+		if err = checkResourcePlanExist(db, mode, tenantName, p.Plan); err != nil {
+			return err
+		}
+	}
+	if err := tenantService.SetActiveResourcePlan(tenantName, p.Plan); err != nil {
+		return errors.Wrapf(err, "Failed to activate resource plan %s of tenant %s", p.Plan, tenantName)
+	}
+	return nil
+}
+
+func ListConsumerGroups(tenantName string, password *string) ([]bo.ConsumerGroup, error) {
+	db, mode, err := getResourceManagerConnection(tenantName, password)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := tenantService.ListConsumerGroups(db, mode)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list consumer groups of tenant %s", tenantName)
+	}
+	consumerGroups := make([]bo.ConsumerGroup, 0, len(groups))
+	for i := range groups {
+		consumerGroups = append(consumerGroups, groups[i].ToBO())
+	}
+	return consumerGroups, nil
+}
+
+func CreateConsumerGroup(tenantName string, p *param.CreateConsumerGroupParam) error {
+	if err := checkResourceManagerName(p.ConsumerGroup); err != nil {
+		return err
+	}
+	db, mode, err := getResourceManagerConnection(tenantName, p.RootPassword)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return err
+	}
+	if err = tenantService.CreateConsumerGroup(db, mode, p.ConsumerGroup, p.Comment); err != nil {
+		return errors.Wrapf(err, "Failed to create consumer group %s of tenant %s", p.ConsumerGroup, tenantName)
+	}
+	return nil
+}
+
+func DeleteConsumerGroup(tenantName, group string, password *string) error {
+	db, mode, err := getResourceManagerConnection(tenantName, password)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return err
+	}
+	exist, err := tenantService.IsConsumerGroupExist(db, mode, group)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to check if consumer group %s exists in tenant %s", group, tenantName)
+	}
+	if !exist {
+		return nil
+	}
+	if err = tenantService.DeleteConsumerGroup(db, mode, group); err != nil {
+		return errors.Wrapf(err, "Failed to delete consumer group %s of tenant %s", group, tenantName)
+	}
+	return nil
+}
+
+func CreateResourcePlanDirective(tenantName, plan string, p *param.CreateResourcePlanDirectiveParam) error {
+	db, mode, err := getResourceManagerConnection(tenantName, p.RootPassword)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return err
+	}
+	if err = checkResourcePlanExist(db, mode, tenantName, plan); err != nil {
+		return err
+	}
+	if err = checkConsumerGroupExist(db, mode, tenantName, p.ConsumerGroup); err != nil {
+		return err
+	}
+	if err = tenantService.CreateResourcePlanDirective(db, mode, plan, p.ConsumerGroup, &p.ResourcePlanDirective); err != nil {
+		return errors.Wrapf(err, "Failed to create directive of consumer group %s in resource plan %s of tenant %s", p.ConsumerGroup, plan, tenantName)
+	}
+	return nil
+}
+
+func ModifyResourcePlanDirective(tenantName, plan, group string, p *param.ModifyResourcePlanDirectiveParam) error {
+	db, mode, err := getResourceManagerConnection(tenantName, p.RootPassword)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return err
+	}
+	exist, err := tenantService.IsResourcePlanDirectiveExist(db, mode, plan, group)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to check if directive of consumer group %s exists in resource plan %s of tenant %s", group, plan, tenantName)
+	}
+	if !exist {
+		return errors.Occur(errors.ErrObResourcePlanDirectiveNotExist, group, plan)
+	}
+	if err = tenantService.UpdateResourcePlanDirective(db, mode, plan, group, &p.ResourcePlanDirective); err != nil {
+		return errors.Wrapf(err, "Failed to modify directive of consumer group %s in resource plan %s of tenant %s", group, plan, tenantName)
+	}
+	return nil
+}
+
+func DeleteResourcePlanDirective(tenantName, plan, group string, password *string) error {
+	db, mode, err := getResourceManagerConnection(tenantName, password)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return err
+	}
+	exist, err := tenantService.IsResourcePlanDirectiveExist(db, mode, plan, group)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to check if directive of consumer group %s exists in resource plan %s of tenant %s", group, plan, tenantName)
+	}
+	if !exist {
+		return nil
+	}
+	if err = tenantService.DeleteResourcePlanDirective(db, mode, plan, group); err != nil {
+		return errors.Wrapf(err, "Failed to delete directive of consumer group %s in resource plan %s of tenant %s", group, plan, tenantName)
+	}
+	return nil
+}
+
+func ListResourceMappings(tenantName string, password *string) ([]bo.ResourceMapping, error) {
+	db, mode, err := getResourceManagerConnection(tenantName, password)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return nil, err
+	}
+	mappings, err := tenantService.ListResourceMappings(db, mode)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list resource mappings of tenant %s", tenantName)
+	}
+	resourceMappings := make([]bo.ResourceMapping, 0, len(mappings))
+	for i := range mappings {
+		resourceMappings = append(resourceMappings, mappings[i].ToBO())
+	}
+	return resourceMappings, nil
+}
+
+// SetResourceMapping maps the user, function or column to the consumer group,
+// the mapping will be removed if no consumer group is specified.
+func SetResourceMapping(tenantName string, p *param.SetResourceMappingParam) error {
+	attribute := strings.ToUpper(p.Attribute)
+	switch attribute {
+	case constant.RESOURCE_MAPPING_ATTRIBUTE_USER, constant.RESOURCE_MAPPING_ATTRIBUTE_FUNCTION, constant.RESOURCE_MAPPING_ATTRIBUTE_COLUMN:
+	default:
+		return errors.Occur(errors.ErrObResourceMappingAttributeInvalid, p.Attribute)
+	}
+	db, mode, err := getResourceManagerConnection(tenantName, p.RootPassword)
+	defer CloseDbConnection(db)
+	if err != nil {
+		return err
+	}
+	if p.ConsumerGroup != "" {
+		if err = checkConsumerGroupExist(db, mode, tenantName, p.ConsumerGroup); err != nil {
+			return err
+		}
+	}
+	if err = tenantService.SetResourceMapping(db, mode, attribute, p.Value, p.ConsumerGroup); err != nil {
+		return errors.Wrapf(err, "Failed to set resource mapping of %s '%s' in tenant %s", attribute, p.Value, tenantName)
+	}
+	return nil
+}
+
+// GetResourceManagerIoUsage returns the active plan and the io usage of each consumer group of the tenant.
+func GetResourceManagerIoUsage(tenantName string) (*bo.ResourceManagerIoUsage, error) {
+	tenantInfo, err := tenantService.GetTenantByName(tenantName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get tenant '%s' info failed", tenantName)
+	}
+	if tenantInfo == nil {
+		return nil, errors.Occur(errors.ErrObTenantNotExist, tenantName)
+	}
+	activePlan, err := tenantService.GetActiveResourcePlan(tenantName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get active resource plan of tenant %s", tenantName)
+	}
+	stats, err := tenantService.ListGroupIoStats(tenantInfo.TenantID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get io stats of consumer groups of tenant %s", tenantName)
+	}
+
+	usage := &bo.ResourceManagerIoUsage{
+		ActivePlan: activePlan,
+		Groups:     make([]bo.ConsumerGroupIoUsage, 0),
+	}
+	groupIndex := make(map[int64]int)
+	for _, stat := range stats {
+		idx, ok := groupIndex[stat.GroupID]
+		if !ok {
+			idx = len(usage.Groups)
+			groupIndex[stat.GroupID] = idx
+			usage.Groups = append(usage.Groups, bo.ConsumerGroupIoUsage{
+				GroupID:   stat.GroupID,
+				GroupName: stat.GroupName,
+				Servers:   make([]bo.ConsumerGroupServerIoUsage, 0),
+			})
+		}
+		usage.Groups[idx].RealIops += stat.RealIops
+		usage.Groups[idx].Servers = append(usage.Groups[idx].Servers, bo.ConsumerGroupServerIoUsage{
+			SvrIp:            stat.SvrIp,
+			SvrPort:          stat.SvrPort,
+			Mode:             stat.Mode,
+			MinIops:          stat.MinIops,
+			MaxIops:          stat.MaxIops,
+			RealIops:         stat.RealIops,
+			MaxNetBandwidth:  stat.MaxNetBandwidth,
+			RealNetBandwidth: stat.RealNetBandwidth,
+		})
+	}
+	return usage, nil
+}
@@ -35,6 +35,7 @@ type SetRootPwdTask struct {
 	task.Task
 	tenantName  string
 	newPassword string
+	oldPassword string
 }
 
 func GetExecuteAgentForTenant(tenantName string) (meta.AgentInfoInterface, error) {
@@ -107,6 +108,16 @@ func newSetRootPwdNode(newPwd string) (*task.Node, error) {
 	return task.NewNodeWithContext(newSetRootPwdTask(), false, ctx), nil
 }
 
+// newSetRootPwdNodeWithInheritedPwd is used when the root password of the tenant
+// is not empty, such as a tenant cloned from another tenant. The old password is
+// read from the password map when executing, so that it is not kept in the task context.
+func newSetRootPwdNodeWithInheritedPwd(newPwd string) *task.Node {
+	ctx := task.NewTaskContext().
+		SetParam(PARAM_TENANT_NEW_PASSWORD, newPwd).
+		SetParam(PARAM_INHERIT_ROOT_PASSWORD, true)
+	return task.NewNodeWithContext(newSetRootPwdTask(), false, ctx)
+}
+
 func newSetRootPwdTask() *SetRootPwdTask {
 	newTask := &SetRootPwdTask{
 		Task: *task.NewSubTask(TASK_NAME_SET_ROOT_PWD),
@@ -125,6 +136,9 @@ func (t *SetRootPwdTask) Execute() error {
 	if err := t.GetContext().GetParamWithValue(PARAM_TENANT_NEW_PASSWORD, &t.newPassword); err != nil {
 		return err
 	}
+	if t.GetContext().GetParam(PARAM_INHERIT_ROOT_PASSWORD) != nil {
+		t.oldPassword, _ = tenant.GetPasswordMap().Get(t.tenantName)
+	}
 
 	executeAgent, err := tenantService.GetTenantActiveAgent(t.tenantName)
 	if err != nil {
@@ -135,6 +149,7 @@ func (t *SetRootPwdTask) Execute() error {
 	}
 
 	if err := secure.SendPutRequest(executeAgent, constant.URI_API_V1+constant.URI_TENANT+"/"+t.tenantName+constant.URI_ROOTPASSWORD, param.ModifyTenantRootPasswordParam{
+		OldPwd: t.oldPassword,
 		NewPwd: &t.newPassword,
 	}, nil); err != nil {
 		return errors.Wrap(err, "set root password failed")
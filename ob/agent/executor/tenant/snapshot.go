@@ -0,0 +1,371 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenant
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/engine/task"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/lib/pkg"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/ob/agent/service/tenant"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+const (
+	waitForCloneTenantFinish = 3600 // seconds
+	waitForCloneTenantNormal = 600  // seconds
+)
+
+var snapshotNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]{0,127}$`)
+
+func checkSnapshotSupported() error {
+	obVersion, err := obclusterService.GetObVersion()
+	if err != nil {
+		return errors.Wrap(err, "get ob version failed")
+	}
+	if pkg.CompareVersion(obVersion, constant.OB_VERSION_4_3_0_0) < 0 {
+		return errors.Occur(errors.ErrObTenantSnapshotVersionNotSupported, obVersion, constant.OB_VERSION_4_3_0_0)
+	}
+	return nil
+}
+
+func checkSnapshotName(name string) error {
+	if !snapshotNamePattern.MatchString(name) {
+		return errors.Occur(errors.ErrObTenantSnapshotNameInvalid, name, "should start with a letter or '_', and only contain letters, digits and '_', no more than 128 characters")
+	}
+	return nil
+}
+
+func ListTenantSnapshots(tenantName string) ([]bo.TenantSnapshot, error) {
+	t, err := checkTenantExist(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSnapshotSupported(); err != nil {
+		return nil, err
+	}
+
+	snapshots, err := tenantService.ListTenantSnapshots(t.TenantID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "list snapshots of tenant '%s' failed", tenantName)
+	}
+	res := make([]bo.TenantSnapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		res = append(res, *snapshot.ToBO())
+	}
+	return res, nil
+}
+
+// CreateTenantSnapshot creates a snapshot for the tenant, the snapshot is
+// created asynchronously and could be used to clone after it is NORMAL.
+func CreateTenantSnapshot(tenantName string, p *param.CreateTenantSnapshotParam) error {
+	t, err := checkTenantExist(tenantName)
+	if err != nil {
+		return err
+	}
+	if err := checkSnapshotSupported(); err != nil {
+		return err
+	}
+	if p.Name != "" {
+		if err := checkSnapshotName(p.Name); err != nil {
+			return err
+		}
+		if snapshot, err := tenantService.GetTenantSnapshot(t.TenantID, p.Name); err != nil {
+			return errors.Wrapf(err, "get snapshot '%s' failed", p.Name)
+		} else if snapshot != nil {
+			return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "name", fmt.Sprintf("snapshot '%s' already exists", p.Name))
+		}
+	}
+
+	if err := tenantService.CreateTenantSnapshot(tenantName, p.Name); err != nil {
+		return errors.Wrapf(err, "create snapshot for tenant '%s' failed", tenantName)
+	}
+	return nil
+}
+
+func DropTenantSnapshot(tenantName, snapshotName string) error {
+	t, err := checkTenantExist(tenantName)
+	if err != nil {
+		return err
+	}
+	if err := checkSnapshotSupported(); err != nil {
+		return err
+	}
+	if err := checkSnapshotName(snapshotName); err != nil {
+		return err
+	}
+	if snapshot, err := tenantService.GetTenantSnapshot(t.TenantID, snapshotName); err != nil {
+		return errors.Wrapf(err, "get snapshot '%s' failed", snapshotName)
+	} else if snapshot == nil {
+		return errors.Occur(errors.ErrObTenantSnapshotNotExist, snapshotName, tenantName)
+	}
+
+	if err := tenantService.DropTenantSnapshot(tenantName, snapshotName); err != nil {
+		return errors.Wrapf(err, "drop snapshot '%s' of tenant '%s' failed", snapshotName, tenantName)
+	}
+	return nil
+}
+
+func checkCloneTenantParam(sourceTenantID int, sourceTenantName string, p *param.CloneTenantParam) error {
+	if err := checkTenantName(p.Name); err != nil {
+		return err
+	}
+	if exist, err := tenantService.IsTenantExist(p.Name); err != nil {
+		return err
+	} else if exist {
+		return errors.Occur(errors.ErrObTenantExisted, p.Name)
+	}
+
+	if exist, err := unitService.IsUnitConfigExist(p.UnitConfigName); err != nil {
+		return err
+	} else if !exist {
+		return errors.Occur(errors.ErrObResourceUnitConfigNotExist, p.UnitConfigName)
+	}
+
+	if p.SnapshotName != "" {
+		if err := checkSnapshotName(p.SnapshotName); err != nil {
+			return err
+		}
+		snapshot, err := tenantService.GetTenantSnapshot(sourceTenantID, p.SnapshotName)
+		if err != nil {
+			return errors.Wrapf(err, "get snapshot '%s' failed", p.SnapshotName)
+		}
+		if snapshot == nil {
+			return errors.Occur(errors.ErrObTenantSnapshotNotExist, p.SnapshotName, sourceTenantName)
+		}
+		if snapshot.Status != constant.TENANT_SNAPSHOT_STATUS_NORMAL {
+			return errors.Occur(errors.ErrObTenantSnapshotStatusNotNormal, p.SnapshotName, snapshot.Status)
+		}
+	}
+	return nil
+}
+
+// CloneTenant clones a new tenant from the snapshot of the source tenant,
+// or from the current state of the source tenant if no snapshot specified.
+func CloneTenant(sourceTenantName string, p *param.CloneTenantParam) (*task.DagDetailDTO, error) {
+	source, err := checkTenantExist(sourceTenantName)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSnapshotSupported(); err != nil {
+		return nil, err
+	}
+	if err := checkCloneTenantParam(source.TenantID, sourceTenantName, p); err != nil {
+		return nil, err
+	}
+
+	// The source root password has been persisted by the handler, never keep it in the task context.
+	p.SourceRootPassword = nil
+
+	templateBuilder := task.NewTemplateBuilder(fmt.Sprintf(DAG_CLONE_TENANT, p.Name, sourceTenantName)).
+		SetMaintenance(task.TenantMaintenance(p.Name)).
+		AddNode(newCloneTenantNode(sourceTenantName, p)).
+		AddTask(newWaitCloneTenantFinishTask(), false)
+	if p.Whitelist != nil {
+		templateBuilder.AddNode(newModifyTenantWhitelistNode(*p.Whitelist))
+	}
+	if p.RootPassword != "" {
+		templateBuilder.AddNode(newSetRootPwdNodeWithInheritedPwd(p.RootPassword))
+	}
+
+	context := task.NewTaskContext().
+		SetParam(PARAM_TENANT_NAME, p.Name).
+		SetParam(task.FAILURE_EXIT_MAINTENANCE, true)
+	dag, err := clusterTaskService.CreateDagInstanceByTemplate(templateBuilder.Build(), context)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+// buildClonePoolName names the resource pool of the clone tenant in the same way as buildCreateResourcePoolTaskParam.
+func buildClonePoolName(tenantName string, timestamp int64) string {
+	return strings.Join([]string{tenantName, "clone", strconv.FormatInt(timestamp, 10)}, "_")
+}
+
+type CloneTenantTask struct {
+	task.Task
+	tenantName       string
+	sourceTenantName string
+	param            param.CloneTenantParam
+	timestamp        int64 // use for pool name
+	poolName         string
+}
+
+func newCloneTenantNode(sourceTenantName string, p *param.CloneTenantParam) *task.Node {
+	ctx := task.NewTaskContext().
+		SetParam(PARAM_SOURCE_TENANT_NAME, sourceTenantName).
+		SetParam(PARAM_CLONE_TENANT, p).
+		SetParam(PARAM_TIMESTAMP, time.Now().Unix())
+	return task.NewNodeWithContext(newCloneTenantTask(), false, ctx)
+}
+
+func newCloneTenantTask() *CloneTenantTask {
+	newTask := &CloneTenantTask{
+		Task: *task.NewSubTask(TASK_NAME_CLONE_TENANT),
+	}
+	newTask.SetCanRollback().SetCanRetry().SetCanCancel().SetCanContinue()
+	return newTask
+}
+
+func (t *CloneTenantTask) getParams() error {
+	ctx := t.GetContext()
+	if err := ctx.GetParamWithValue(PARAM_TENANT_NAME, &t.tenantName); err != nil {
+		return err
+	}
+	if err := ctx.GetParamWithValue(PARAM_SOURCE_TENANT_NAME, &t.sourceTenantName); err != nil {
+		return err
+	}
+	if err := ctx.GetParamWithValue(PARAM_CLONE_TENANT, &t.param); err != nil {
+		return err
+	}
+	if err := ctx.GetParamWithValue(PARAM_TIMESTAMP, &t.timestamp); err != nil {
+		return err
+	}
+	t.poolName = buildClonePoolName(t.tenantName, t.timestamp)
+	return nil
+}
+
+func (t *CloneTenantTask) Execute() error {
+	if err := t.getParams(); err != nil {
+		return err
+	}
+
+	if exist, err := tenantService.IsTenantExist(t.tenantName); err != nil {
+		return err
+	} else if exist {
+		t.ExecuteLogf("Tenant '%s' already exists", t.tenantName)
+		return nil
+	}
+
+	if t.param.SnapshotName != "" {
+		t.ExecuteLogf("Clone tenant '%s' from snapshot '%s' of tenant '%s' with resource pool '%s'", t.tenantName, t.param.SnapshotName, t.sourceTenantName, t.poolName)
+	} else {
+		t.ExecuteLogf("Clone tenant '%s' from tenant '%s' with resource pool '%s'", t.tenantName, t.sourceTenantName, t.poolName)
+	}
+	if err := tenantService.CloneTenant(t.tenantName, t.sourceTenantName, t.param.SnapshotName, t.poolName, t.param.UnitConfigName); err != nil {
+		return errors.Wrap(err, "clone tenant failed")
+	}
+	// The new tenant inherits the root password of the source tenant.
+	if sourceRootPassword, ok := tenant.GetPasswordMap().Get(t.sourceTenantName); ok {
+		tenant.GetPasswordMap().Set(t.tenantName, sourceRootPassword)
+	}
+	return nil
+}
+
+func (t *CloneTenantTask) Rollback() error {
+	if err := t.getParams(); err != nil {
+		return err
+	}
+
+	if job, err := tenantService.GetRunningCloneJob(t.tenantName); err != nil {
+		return errors.Wrap(err, "get running clone job")
+	} else if job != nil {
+		t.ExecuteLog("Cancel clone job")
+		if err := tenantService.CancelCloneTenant(t.tenantName); err != nil {
+			return errors.Wrap(err, "cancel clone job failed")
+		}
+	}
+
+	t.ExecuteLogf("Drop tenant %s if exist", t.tenantName)
+	if exist, err := tenantService.IsTenantExist(t.tenantName); err != nil {
+		return err
+	} else if exist {
+		if err := tenantService.DropTenant(t.tenantName); err != nil {
+			return errors.Wrap(err, "Drop tenant failed.")
+		}
+	}
+
+	// drop resource pool if not used
+	pool, err := tenantService.GetResourcePoolByName(t.poolName)
+	if err != nil {
+		return err
+	}
+	if pool != nil && pool.TenantId == 0 {
+		t.ExecuteLogf("Drop resource pool %s", t.poolName)
+		return tenantService.DropResourcePool(t.poolName, true)
+	}
+	return nil
+}
+
+type WaitCloneTenantFinishTask struct {
+	task.Task
+	tenantName string
+}
+
+func newWaitCloneTenantFinishTask() *WaitCloneTenantFinishTask {
+	newTask := &WaitCloneTenantFinishTask{
+		Task: *task.NewSubTask(TASK_NAME_WAIT_CLONE_TENANT_FINISH),
+	}
+	newTask.SetCanRetry().SetCanContinue().SetCanCancel()
+	return newTask
+}
+
+func (t *WaitCloneTenantFinishTask) Execute() error {
+	if err := t.GetContext().GetParamWithValue(PARAM_TENANT_NAME, &t.tenantName); err != nil {
+		return err
+	}
+
+	t.ExecuteLog("Wait for clone job finish")
+	for i := 0; ; i++ {
+		job, err := tenantService.GetRunningCloneJob(t.tenantName)
+		if err != nil {
+			return errors.Wrap(err, "get running clone job")
+		}
+		if job == nil {
+			break
+		}
+		if i%60 == 0 {
+			t.ExecuteLogf("Clone job %d is %s", job.JobID, job.Status)
+		}
+		if i >= waitForCloneTenantFinish {
+			return errors.Occur(errors.ErrObClusterAsyncOperationTimeout, fmt.Sprintf("clone tenant '%s'", t.tenantName))
+		}
+		time.Sleep(time.Second)
+		t.TimeoutCheck()
+	}
+
+	job, err := tenantService.GetLastCloneJobHistory(t.tenantName)
+	if err != nil {
+		return errors.Wrap(err, "get clone job history")
+	}
+	if job != nil && job.Status != constant.CLONE_JOB_STATUS_SUCCESS {
+		return errors.Occur(errors.ErrObTenantCloneFailed, t.tenantName, fmt.Sprintf("job %d is %s: %s", job.JobID, job.Status, job.ErrorMessage))
+	}
+
+	t.ExecuteLogf("Wait for tenant '%s' to be normal", t.tenantName)
+	for i := 0; i < waitForCloneTenantNormal; i++ {
+		status, err := tenantService.GetTenantStatus(t.tenantName)
+		if err != nil {
+			return errors.Wrapf(err, "get status of tenant '%s' failed", t.tenantName)
+		}
+		if status == constant.TENANT_STATUS_NORMAL {
+			t.ExecuteLogf("Tenant '%s' is normal", t.tenantName)
+			return nil
+		}
+		time.Sleep(time.Second)
+		t.TimeoutCheck()
+	}
+	return errors.Occur(errors.ErrObClusterAsyncOperationTimeout, fmt.Sprintf("clone tenant '%s'", t.tenantName))
+}
@@ -0,0 +1,616 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenant
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/engine/task"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/executor/pool"
+	"github.com/oceanbase/obshell/ob/agent/executor/zone"
+	"github.com/oceanbase/obshell/ob/agent/lib/pkg"
+	"github.com/oceanbase/obshell/ob/agent/lib/system"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/ob/agent/secure"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+const (
+	waitForStandbyRestoreFinish = 3600 // seconds
+	waitForStandbyTenantNormal  = 600  // seconds
+	waitForTenantRoleSwitched   = 600  // seconds
+)
+
+var logRestoreSourcePasswordPattern = regexp.MustCompile(`(?i)(PASSWORD=)\S*`)
+
+func checkCreateStandbyTenantParam(p *param.CreateStandbyTenantParam) (err error) {
+	if err = p.Check(); err != nil {
+		return
+	}
+
+	if len(p.ZoneList) == 0 {
+		return errors.Occur(errors.ErrObTenantZoneListEmpty)
+	}
+
+	if p.Type == constant.STANDBY_SOURCE_TYPE_SERVICE {
+		obVersion, err := obclusterService.GetObVersion()
+		if err != nil {
+			return errors.Wrap(err, "get ob version failed")
+		}
+		if pkg.CompareVersion(obVersion, constant.OB_VERSION_4_2_0_0) < 0 {
+			return errors.Occur(errors.ErrObStandbyVersionNotSupported, "Creating standby tenant by network", obVersion, constant.OB_VERSION_4_2_0_0)
+		}
+	}
+
+	zone.RenderZoneParams(p.ZoneList)
+	if err = zone.CheckZoneParams(p.ZoneList); err != nil {
+		return
+	}
+
+	if err = zone.CheckAtLeastOnePaxosReplica(p.ZoneList); err != nil {
+		return
+	}
+
+	zoneList := make([]string, 0)
+	locality := make(map[string]string, 0)
+	for _, zone := range p.ZoneList {
+		zoneList = append(zoneList, zone.Name)
+		locality[zone.Name] = zone.ReplicaType
+	}
+	if err = zone.CheckPrimaryZone(*p.PrimaryZone, zoneList); err != nil {
+		return
+	}
+	return zone.CheckPrimaryZoneAndLocality(*p.PrimaryZone, locality)
+}
+
+// CreateStandbyTenant creates a standby tenant which synchronizes logs from
+// the primary tenant through the network or from the archive log.
+func CreateStandbyTenant(tenantName string, p *param.CreateStandbyTenantParam) (*task.DagDetailDTO, error) {
+	if err := checkTenantName(tenantName); err != nil {
+		return nil, err
+	}
+
+	if exist, err := tenantService.IsTenantExist(tenantName); err != nil {
+		return nil, err
+	} else if exist {
+		return nil, errors.Occur(errors.ErrObTenantExisted, tenantName)
+	}
+
+	if err := checkCreateStandbyTenantParam(p); err != nil {
+		return nil, err
+	}
+
+	isSharedStorage, err := obclusterService.IsSharedStorageMode()
+	if err != nil {
+		return nil, err
+	}
+	if !isSharedStorage {
+		if err := CheckResourceEnough(p.ZoneList); err != nil {
+			return nil, err
+		}
+	}
+
+	createStandbyTenantNode, err := newCreateStandbyTenantNode(p)
+	if err != nil {
+		return nil, err
+	}
+	templateBuilder := task.NewTemplateBuilder(fmt.Sprintf(DAG_CREATE_STANDBY_TENANT, tenantName)).
+		SetMaintenance(task.TenantMaintenance(tenantName)).
+		AddNode(createStandbyTenantNode).
+		AddTask(newWaitStandbyTenantNormalTask(), false)
+	if p.Type == constant.STANDBY_SOURCE_TYPE_LOCATION {
+		templateBuilder.AddNode(newSetLogRestoreSourceNode(constant.STANDBY_SOURCE_TYPE_LOCATION+"="+system.ObStorageURI(p.ArchiveLogUri), nil))
+	}
+
+	context := task.NewTaskContext().
+		SetParam(PARAM_TENANT_NAME, tenantName).
+		SetParam(task.FAILURE_EXIT_MAINTENANCE, true)
+	dag, err := clusterTaskService.CreateDagInstanceByTemplate(templateBuilder.Build(), context)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+// StandbySwitchover switches the role of the tenant between primary and standby.
+// A primary tenant will be switched to standby, and a standby tenant will be switched to primary.
+func StandbySwitchover(tenantName string, p *param.StandbySwitchoverParam) (*task.DagDetailDTO, error) {
+	roleInfo, err := getTenantRoleInfo(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	if roleInfo.SwitchoverStatus != constant.TENANT_SWITCHOVER_STATUS_NORMAL {
+		return nil, errors.Occur(errors.ErrObStandbySwitchoverStatusInvalid, tenantName, roleInfo.SwitchoverStatus)
+	}
+
+	var targetRole string
+	switch roleInfo.TenantRole {
+	case constant.TENANT_ROLE_PRIMARY:
+		targetRole = constant.TENANT_ROLE_STANDBY
+	case constant.TENANT_ROLE_STANDBY:
+		targetRole = constant.TENANT_ROLE_PRIMARY
+		if p.PrimaryInfo != nil {
+			return nil, errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "primary_info", "only available when switching a primary tenant to standby")
+		}
+	default:
+		return nil, errors.Occur(errors.ErrObStandbyTenantRoleUnexpected, tenantName, roleInfo.TenantRole, constant.TENANT_ROLE_PRIMARY+" or "+constant.TENANT_ROLE_STANDBY)
+	}
+	if p.PrimaryInfo != nil {
+		if err := p.PrimaryInfo.Check(); err != nil {
+			return nil, err
+		}
+	}
+
+	templateBuilder := task.NewTemplateBuilder(fmt.Sprintf(DAG_STANDBY_SWITCHOVER, tenantName)).
+		SetMaintenance(task.TenantMaintenance(tenantName)).
+		AddTask(newSwitchoverTenantTask(), false)
+	if p.PrimaryInfo != nil {
+		primaryInfo, err := encryptStandbyPrimaryInfo(p.PrimaryInfo)
+		if err != nil {
+			return nil, err
+		}
+		templateBuilder.AddNode(newSetLogRestoreSourceNode("", primaryInfo))
+	}
+
+	context := task.NewTaskContext().
+		SetParam(PARAM_TENANT_NAME, tenantName).
+		SetParam(PARAM_TARGET_TENANT_ROLE, targetRole).
+		SetParam(task.FAILURE_EXIT_MAINTENANCE, true)
+	dag, err := clusterTaskService.CreateDagInstanceByTemplate(templateBuilder.Build(), context)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+// StandbyFailover activates the standby tenant to be a primary tenant,
+// which is used when the primary tenant is unavailable.
+func StandbyFailover(tenantName string) (*task.DagDetailDTO, error) {
+	roleInfo, err := getTenantRoleInfo(tenantName)
+	if err != nil {
+		return nil, err
+	}
+	if roleInfo.TenantRole != constant.TENANT_ROLE_STANDBY {
+		return nil, errors.Occur(errors.ErrObStandbyTenantRoleUnexpected, tenantName, roleInfo.TenantRole, constant.TENANT_ROLE_STANDBY)
+	}
+
+	template := task.NewTemplateBuilder(fmt.Sprintf(DAG_STANDBY_FAILOVER, tenantName)).
+		SetMaintenance(task.TenantMaintenance(tenantName)).
+		AddTask(newActivateStandbyTenantTask(), false).Build()
+	context := task.NewTaskContext().
+		SetParam(PARAM_TENANT_NAME, tenantName).
+		SetParam(task.FAILURE_EXIT_MAINTENANCE, true)
+	dag, err := clusterTaskService.CreateDagInstanceByTemplate(template, context)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+func GetStandbyTenantStatus(tenantName string) (*param.StandbyTenantStatus, error) {
+	roleInfo, err := getTenantRoleInfo(tenantName)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &param.StandbyTenantStatus{
+		TenantName:       roleInfo.TenantName,
+		TenantRole:       roleInfo.TenantRole,
+		Status:           roleInfo.Status,
+		SwitchoverStatus: roleInfo.SwitchoverStatus,
+		SwitchoverEpoch:  roleInfo.SwitchoverEpoch,
+		SyncScn:          roleInfo.SyncScn,
+		ReplayableScn:    roleInfo.ReplayableScn,
+		ReadableScn:      roleInfo.ReadableScn,
+		RecoveryUntilScn: roleInfo.RecoveryUntilScn,
+		LogMode:          roleInfo.LogMode,
+	}
+	if roleInfo.TenantRole == constant.TENANT_ROLE_STANDBY && roleInfo.ReadableScn > 0 {
+		// The scn is a timestamp in nanoseconds.
+		lag := (time.Now().UnixNano() - roleInfo.ReadableScn) / int64(time.Second)
+		if lag < 0 {
+			lag = 0
+		}
+		status.LagSeconds = &lag
+	}
+
+	source, err := tenantService.GetLogRestoreSource(roleInfo.TenantID)
+	if err != nil {
+		return nil, errors.Wrap(err, "get log restore source failed")
+	}
+	if source != nil {
+		status.LogRestoreSource = maskLogRestoreSource(source.Value)
+	}
+	return status, nil
+}
+
+func getTenantRoleInfo(tenantName string) (*oceanbase.DbaObTenantRoleInfo, error) {
+	if _, err := checkTenantExist(tenantName); err != nil {
+		return nil, err
+	}
+	roleInfo, err := tenantService.GetTenantRoleInfo(tenantName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get role of tenant '%s' failed", tenantName)
+	}
+	if roleInfo == nil {
+		return nil, errors.Occur(errors.ErrObTenantNotExist, tenantName)
+	}
+	return roleInfo, nil
+}
+
+// encryptStandbyPrimaryInfo returns a copy of the primary info whose password is
+// encrypted by the credential key, so that it can be saved in the task context.
+func encryptStandbyPrimaryInfo(info *param.StandbyPrimaryInfo) (*param.StandbyPrimaryInfo, error) {
+	encrypted := *info
+	if info.Password != "" {
+		password, err := secure.EncryptCredentialPassphrase(info.Password)
+		if err != nil {
+			return nil, errors.Wrap(err, "encrypt password of primary tenant")
+		}
+		encrypted.Password = password
+	}
+	return &encrypted, nil
+}
+
+// buildLogRestoreSource renders the log restore source from the primary info
+// saved in the task context, whose password is encrypted.
+func buildLogRestoreSource(info *param.StandbyPrimaryInfo) (string, error) {
+	decrypted := *info
+	if info.Password != "" {
+		password, err := secure.DecryptCredentialPassphrase(info.Password)
+		if err != nil {
+			return "", errors.Wrap(err, "decrypt password of primary tenant")
+		}
+		decrypted.Password = password
+	}
+	return decrypted.LogRestoreSource(), nil
+}
+
+func maskLogRestoreSource(source string) string {
+	return logRestoreSourcePasswordPattern.ReplaceAllString(source, "${1}******")
+}
+
+func waitTenantRole(t task.Task, tenantName, targetRole string) error {
+	for i := 0; i < waitForTenantRoleSwitched; i++ {
+		roleInfo, err := tenantService.GetTenantRoleInfo(tenantName)
+		if err != nil {
+			return errors.Wrapf(err, "get role of tenant '%s' failed", tenantName)
+		}
+		if roleInfo != nil && roleInfo.TenantRole == targetRole && roleInfo.SwitchoverStatus == constant.TENANT_SWITCHOVER_STATUS_NORMAL {
+			return nil
+		}
+		time.Sleep(time.Second)
+		t.TimeoutCheck()
+	}
+	return errors.Occur(errors.ErrObClusterAsyncOperationTimeout, fmt.Sprintf("switch tenant '%s' to %s", tenantName, targetRole))
+}
+
+type CreateStandbyTenantTask struct {
+	task.Task
+	param                   param.CreateStandbyTenantParam
+	tenantName              string
+	timestamp               int64 // use for pool name
+	createResourcePoolParam []param.CreateResourcePoolTaskParam
+}
+
+func newCreateStandbyTenantNode(p *param.CreateStandbyTenantParam) (*task.Node, error) {
+	createParam := *p
+	if p.PrimaryInfo != nil {
+		primaryInfo, err := encryptStandbyPrimaryInfo(p.PrimaryInfo)
+		if err != nil {
+			return nil, err
+		}
+		createParam.PrimaryInfo = primaryInfo
+	}
+	ctx := task.NewTaskContext().
+		SetParam(PARAM_CREATE_STANDBY_TENANT, &createParam).
+		SetParam(PARAM_TIMESTAMP, time.Now().Unix())
+	return task.NewNodeWithContext(newCreateStandbyTenantTask(), false, ctx), nil
+}
+
+func newCreateStandbyTenantTask() *CreateStandbyTenantTask {
+	newTask := &CreateStandbyTenantTask{
+		Task: *task.NewSubTask(TASK_NAME_CREATE_STANDBY_TENANT),
+	}
+	newTask.SetCanRollback().SetCanRetry().SetCanCancel().SetCanContinue()
+	return newTask
+}
+
+func (t *CreateStandbyTenantTask) getParams() error {
+	if err := t.GetContext().GetParamWithValue(PARAM_TENANT_NAME, &t.tenantName); err != nil {
+		return err
+	}
+	if err := t.GetContext().GetParamWithValue(PARAM_CREATE_STANDBY_TENANT, &t.param); err != nil {
+		return err
+	}
+	if err := t.GetContext().GetParamWithValue(PARAM_TIMESTAMP, &t.timestamp); err != nil {
+		return err
+	}
+	t.createResourcePoolParam = buildCreateResourcePoolTaskParam(t.tenantName, t.param.ZoneList, t.timestamp)
+	return nil
+}
+
+func (t *CreateStandbyTenantTask) Execute() error {
+	if err := t.getParams(); err != nil {
+		return err
+	}
+
+	if exist, err := tenantService.IsTenantExist(t.tenantName); err != nil {
+		return err
+	} else if exist {
+		t.ExecuteLogf("Tenant '%s' already exists", t.tenantName)
+		return nil
+	}
+
+	if err := pool.CreatePools(t.Task, t.createResourcePoolParam); err != nil {
+		return err
+	}
+
+	var poolList []string
+	for _, poolParam := range t.createResourcePoolParam {
+		poolList = append(poolList, poolParam.PoolName)
+	}
+	var localityList []string
+	for _, zone := range t.param.ZoneList {
+		if zone.ReplicaType == "" {
+			localityList = append(localityList, strings.Join([]string{constant.REPLICA_TYPE_FULL, zone.Name}, "@"))
+		} else {
+			localityList = append(localityList, strings.Join([]string{zone.ReplicaType, zone.Name}, "@"))
+		}
+	}
+	locality := strings.Join(localityList, ",")
+
+	var err error
+	if t.param.Type == constant.STANDBY_SOURCE_TYPE_SERVICE {
+		var logRestoreSource string
+		if logRestoreSource, err = buildLogRestoreSource(t.param.PrimaryInfo); err != nil {
+			return err
+		}
+		t.ExecuteLogf("Create standby tenant '%s' from primary tenant '%s'", t.tenantName, t.param.PrimaryInfo.TenantName)
+		resourcePoolList := "\"" + strings.Join(poolList, "\",\"") + "\""
+		err = tenantService.CreateStandbyTenant(t.tenantName, logRestoreSource, resourcePoolList, locality, *t.param.PrimaryZone)
+	} else {
+		t.ExecuteLogf("Restore standby tenant '%s' from backup", t.tenantName)
+		err = tenantService.RestoreStandbyTenant(t.tenantName, &t.param, locality, strings.Join(poolList, ","))
+	}
+	if err != nil {
+		// drop all created resource pool
+		if err := pool.DropFreeResourcePools(t.Task, t.createResourcePoolParam); err != nil {
+			t.ExecuteWarnLog(errors.Wrap(err, "Drop created resource pool failed."))
+		}
+		return errors.Wrap(err, "create standby tenant failed")
+	}
+	return nil
+}
+
+func (t *CreateStandbyTenantTask) Rollback() error {
+	if err := t.getParams(); err != nil {
+		return err
+	}
+
+	if t.param.Type == constant.STANDBY_SOURCE_TYPE_LOCATION {
+		if job, err := tenantService.GetRunningRestoreTask(t.tenantName); err != nil {
+			return errors.Wrap(err, "get running restore task")
+		} else if job != nil {
+			t.ExecuteLog("Cancel restore job")
+			if err := tenantService.CancelRestore(t.tenantName); err != nil {
+				return errors.Wrap(err, "cancel restore job failed")
+			}
+		}
+	}
+
+	t.ExecuteLogf("Drop tenant %s if exist", t.tenantName)
+	if exist, err := tenantService.IsTenantExist(t.tenantName); err != nil {
+		return err
+	} else if exist {
+		if err := tenantService.DropTenant(t.tenantName); err != nil {
+			return errors.Wrap(err, "Drop tenant failed.")
+		}
+	}
+	// drop resource if not used
+	return pool.DropFreeResourcePools(t.Task, t.createResourcePoolParam)
+}
+
+type WaitStandbyTenantNormalTask struct {
+	task.Task
+	tenantName string
+}
+
+func newWaitStandbyTenantNormalTask() *WaitStandbyTenantNormalTask {
+	newTask := &WaitStandbyTenantNormalTask{
+		Task: *task.NewSubTask(TASK_NAME_WAIT_STANDBY_TENANT_NORMAL),
+	}
+	newTask.SetCanRetry().SetCanContinue().SetCanCancel()
+	return newTask
+}
+
+func (t *WaitStandbyTenantNormalTask) Execute() error {
+	if err := t.GetContext().GetParamWithValue(PARAM_TENANT_NAME, &t.tenantName); err != nil {
+		return err
+	}
+
+	t.ExecuteLog("Wait for restore task finish if exists")
+	for i := 0; ; i++ {
+		job, err := tenantService.GetRunningRestoreTask(t.tenantName)
+		if err != nil {
+			return errors.Wrap(err, "get running restore task")
+		}
+		if job == nil {
+			break
+		}
+		if i >= waitForStandbyRestoreFinish {
+			return errors.Occur(errors.ErrObClusterAsyncOperationTimeout, fmt.Sprintf("restore standby tenant '%s'", t.tenantName))
+		}
+		time.Sleep(time.Second)
+		t.TimeoutCheck()
+	}
+
+	t.ExecuteLogf("Wait for standby tenant '%s' to be normal", t.tenantName)
+	for i := 0; i < waitForStandbyTenantNormal; i++ {
+		roleInfo, err := tenantService.GetTenantRoleInfo(t.tenantName)
+		if err != nil {
+			return errors.Wrapf(err, "get role of tenant '%s' failed", t.tenantName)
+		}
+		if roleInfo == nil {
+			return errors.Occur(errors.ErrObTenantNotExist, t.tenantName)
+		}
+		if roleInfo.Status == constant.TENANT_STATUS_NORMAL {
+			if roleInfo.TenantRole != constant.TENANT_ROLE_STANDBY {
+				return errors.Occur(errors.ErrObStandbyTenantRoleUnexpected, t.tenantName, roleInfo.TenantRole, constant.TENANT_ROLE_STANDBY)
+			}
+			t.ExecuteLogf("Standby tenant '%s' is normal", t.tenantName)
+			return nil
+		}
+		time.Sleep(time.Second)
+		t.TimeoutCheck()
+	}
+	return errors.Occur(errors.ErrObClusterAsyncOperationTimeout, fmt.Sprintf("create standby tenant '%s'", t.tenantName))
+}
+
+type SetLogRestoreSourceTask struct {
+	task.Task
+	tenantName       string
+	logRestoreSource string
+}
+
+// newSetLogRestoreSourceNode sets the log restore source to the location, or to
+// the primary tenant when the primary info(with encrypted password) is given.
+func newSetLogRestoreSourceNode(location string, primaryInfo *param.StandbyPrimaryInfo) *task.Node {
+	ctx := task.NewTaskContext()
+	if primaryInfo != nil {
+		ctx.SetParam(PARAM_STANDBY_PRIMARY_INFO, primaryInfo)
+	} else {
+		ctx.SetParam(PARAM_LOG_RESTORE_SOURCE, location)
+	}
+	return task.NewNodeWithContext(newSetLogRestoreSourceTask(), false, ctx)
+}
+
+func newSetLogRestoreSourceTask() *SetLogRestoreSourceTask {
+	newTask := &SetLogRestoreSourceTask{
+		Task: *task.NewSubTask(TASK_NAME_SET_LOG_RESTORE_SOURCE),
+	}
+	newTask.SetCanRetry().SetCanContinue().SetCanCancel().SetCanPass()
+	return newTask
+}
+
+func (t *SetLogRestoreSourceTask) Execute() error {
+	if err := t.GetContext().GetParamWithValue(PARAM_TENANT_NAME, &t.tenantName); err != nil {
+		return err
+	}
+	if t.GetContext().GetParam(PARAM_STANDBY_PRIMARY_INFO) != nil {
+		var primaryInfo param.StandbyPrimaryInfo
+		if err := t.GetContext().GetParamWithValue(PARAM_STANDBY_PRIMARY_INFO, &primaryInfo); err != nil {
+			return err
+		}
+		logRestoreSource, err := buildLogRestoreSource(&primaryInfo)
+		if err != nil {
+			return err
+		}
+		t.logRestoreSource = logRestoreSource
+	} else if err := t.GetContext().GetParamWithValue(PARAM_LOG_RESTORE_SOURCE, &t.logRestoreSource); err != nil {
+		return err
+	}
+
+	t.ExecuteLogf("Set log restore source of tenant '%s' to '%s'", t.tenantName, maskLogRestoreSource(t.logRestoreSource))
+	if err := tenantService.SetLogRestoreSource(t.tenantName, t.logRestoreSource); err != nil {
+		return errors.Wrap(err, "set log restore source failed")
+	}
+	return nil
+}
+
+type SwitchoverTenantTask struct {
+	task.Task
+	tenantName string
+	targetRole string
+}
+
+func newSwitchoverTenantTask() *SwitchoverTenantTask {
+	newTask := &SwitchoverTenantTask{
+		Task: *task.NewSubTask(TASK_NAME_SWITCHOVER_TENANT),
+	}
+	newTask.SetCanRetry().SetCanContinue().SetCanCancel()
+	return newTask
+}
+
+func (t *SwitchoverTenantTask) Execute() error {
+	if err := t.GetContext().GetParamWithValue(PARAM_TENANT_NAME, &t.tenantName); err != nil {
+		return err
+	}
+	if err := t.GetContext().GetParamWithValue(PARAM_TARGET_TENANT_ROLE, &t.targetRole); err != nil {
+		return err
+	}
+
+	roleInfo, err := getTenantRoleInfo(t.tenantName)
+	if err != nil {
+		return err
+	}
+	if roleInfo.TenantRole == t.targetRole && roleInfo.SwitchoverStatus == constant.TENANT_SWITCHOVER_STATUS_NORMAL {
+		t.ExecuteLogf("Tenant '%s' is already %s", t.tenantName, t.targetRole)
+		return nil
+	}
+
+	t.ExecuteLogf("Switchover tenant '%s' to %s", t.tenantName, t.targetRole)
+	if t.targetRole == constant.TENANT_ROLE_PRIMARY {
+		err = tenantService.SwitchoverToPrimary(t.tenantName)
+	} else {
+		err = tenantService.SwitchoverToStandby(t.tenantName)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "switchover tenant '%s' to %s failed", t.tenantName, t.targetRole)
+	}
+
+	t.ExecuteLogf("Wait for tenant '%s' to be %s", t.tenantName, t.targetRole)
+	return waitTenantRole(t.Task, t.tenantName, t.targetRole)
+}
+
+type ActivateStandbyTenantTask struct {
+	task.Task
+	tenantName string
+}
+
+func newActivateStandbyTenantTask() *ActivateStandbyTenantTask {
+	newTask := &ActivateStandbyTenantTask{
+		Task: *task.NewSubTask(TASK_NAME_ACTIVATE_STANDBY_TENANT),
+	}
+	newTask.SetCanRetry().SetCanContinue().SetCanCancel()
+	return newTask
+}
+
+func (t *ActivateStandbyTenantTask) Execute() error {
+	if err := t.GetContext().GetParamWithValue(PARAM_TENANT_NAME, &t.tenantName); err != nil {
+		return err
+	}
+
+	role, err := tenantService.GetTenantRole(t.tenantName)
+	if err != nil {
+		return errors.Wrapf(err, "get role of tenant '%s' failed", t.tenantName)
+	}
+	if role != constant.TENANT_ROLE_PRIMARY {
+		t.ExecuteLogf("Activate standby tenant '%s'", t.tenantName)
+		if err := tenantService.ActiveTenant(t.tenantName); err != nil {
+			return errors.Wrapf(err, "activate standby tenant '%s' failed", t.tenantName)
+		}
+	}
+
+	t.ExecuteLogf("Wait for tenant '%s' to be %s", t.tenantName, constant.TENANT_ROLE_PRIMARY)
+	return waitTenantRole(t.Task, t.tenantName, constant.TENANT_ROLE_PRIMARY)
+}
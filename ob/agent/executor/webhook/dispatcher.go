@@ -0,0 +1,235 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/engine/event"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/meta"
+	obmodel "github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/ob/agent/secure"
+)
+
+var (
+	dispatcherOnce sync.Once
+
+	targetsLock sync.RWMutex
+	targets     = make(map[string]*webhookTarget)
+)
+
+// webhookTarget delivers the events through its queue one by one,
+// so that the webhook receives the events in the order of publishing.
+type webhookTarget struct {
+	name   string
+	url    string
+	events map[string]bool // empty means all the event types
+	secret string
+	client *resty.Client
+	queue  chan *event.Event
+	done   chan struct{} // closed after all the queued events are delivered
+}
+
+func newWebhookTarget(webhook *obmodel.TaskWebhook) (*webhookTarget, error) {
+	target := &webhookTarget{
+		name:   webhook.Name,
+		url:    webhook.Url,
+		events: make(map[string]bool),
+		client: resty.New().SetTimeout(constant.WEBHOOK_DELIVERY_TIMEOUT),
+		queue:  make(chan *event.Event, constant.WEBHOOK_QUEUE_SIZE),
+		done:   make(chan struct{}),
+	}
+	for _, t := range strings.Split(webhook.Events, ",") {
+		if t != "" {
+			target.events[t] = true
+		}
+	}
+	if webhook.Secret != "" {
+		secret, err := secure.DecryptCredentialPassphrase(webhook.Secret)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decrypt secret of task webhook '%s' failed", webhook.Name)
+		}
+		target.secret = secret
+	}
+	return target, nil
+}
+
+func (t *webhookTarget) accept(evt *event.Event) bool {
+	return len(t.events) == 0 || t.events[evt.Type]
+}
+
+// sameAs returns whether the target is built from the same webhook config.
+func (t *webhookTarget) sameAs(other *webhookTarget) bool {
+	if t.url != other.url || t.secret != other.secret || len(t.events) != len(other.events) {
+		return false
+	}
+	for eventType := range t.events {
+		if !other.events[eventType] {
+			return false
+		}
+	}
+	return true
+}
+
+// enqueue adds the event to the queue, the event is dropped if the queue is full.
+func (t *webhookTarget) enqueue(evt *event.Event) {
+	select {
+	case t.queue <- evt:
+	default:
+		log.Warnf("the queue of task webhook '%s' is full, drop event %d (%s)", t.name, evt.Id, evt.Type)
+	}
+}
+
+// run delivers the queued events in order until the queue is closed.
+// The target replacing a previous one of the same webhook waits for it, so the order is kept across the reloads.
+func (t *webhookTarget) run(previous *webhookTarget) {
+	defer close(t.done)
+	if previous != nil {
+		<-previous.done
+	}
+	for evt := range t.queue {
+		t.deliver(evt)
+	}
+}
+
+// send posts the event to the webhook once.
+func (t *webhookTarget) send(ctx context.Context, evt *event.Event) error {
+	body, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	req := t.client.R().SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetHeader(constant.WEBHOOK_HEADER_EVENT, evt.Type).
+		SetHeader(constant.WEBHOOK_HEADER_DELIVERY, fmt.Sprintf("%s-%d", evt.Agent, evt.Id)).
+		SetBody(body)
+	if t.secret != "" {
+		mac := hmac.New(sha256.New, []byte(t.secret))
+		mac.Write(body)
+		req.SetHeader(constant.WEBHOOK_HEADER_SIGNATURE, constant.WEBHOOK_SIGNATURE_PREFIX+hex.EncodeToString(mac.Sum(nil)))
+	}
+	resp, err := req.Post(t.url)
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode(), resp.String())
+	}
+	return nil
+}
+
+// deliver sends the event to the webhook, and retries with backoff if failed.
+func (t *webhookTarget) deliver(evt *event.Event) {
+	interval := constant.WEBHOOK_RETRY_INTERVAL
+	for attempt := 0; ; attempt++ {
+		err := t.send(context.Background(), evt)
+		if err == nil {
+			return
+		}
+		if attempt >= constant.WEBHOOK_MAX_RETRIES {
+			log.WithError(err).Warnf("deliver event %d (%s) to task webhook '%s' failed", evt.Id, evt.Type, t.name)
+			return
+		}
+		time.Sleep(interval)
+		interval *= 2
+	}
+}
+
+// Start starts the dispatcher which delivers the task events published by this agent to the webhooks.
+// The webhooks are stored in the cluster, so only the cluster agents deliver the events.
+func Start() {
+	dispatcherOnce.Do(func() {
+		go dispatch()
+	})
+}
+
+func dispatch() {
+	events, _ := event.Subscribe(constant.WEBHOOK_EVENT_BUFFER_SIZE)
+	ticker := time.NewTicker(constant.WEBHOOK_RELOAD_INTERVAL)
+	defer ticker.Stop()
+	reloadWebhooks()
+	for {
+		select {
+		case <-ticker.C:
+			reloadWebhooks()
+		case evt := <-events:
+			targetsLock.RLock()
+			for _, target := range targets {
+				if target.accept(evt) {
+					target.enqueue(evt)
+				}
+			}
+			targetsLock.RUnlock()
+		}
+	}
+}
+
+// reloadWebhooks only replaces the targets whose webhook is changed,
+// the queue of the removed or replaced target is closed after the queued events are delivered.
+func reloadWebhooks() {
+	loaded := make(map[string]*webhookTarget)
+	if meta.OCS_AGENT != nil && meta.OCS_AGENT.IsClusterAgent() {
+		webhooks, err := webhookService.ListEnabled()
+		if err != nil {
+			log.WithError(err).Debug("list task webhooks failed")
+			return
+		}
+		for i := range webhooks {
+			target, err := newWebhookTarget(&webhooks[i])
+			if err != nil {
+				log.WithError(err).Warn("load task webhook failed")
+				continue
+			}
+			loaded[target.name] = target
+		}
+	}
+
+	targetsLock.Lock()
+	defer targetsLock.Unlock()
+	newTargets := make(map[string]*webhookTarget, len(loaded))
+	for name, target := range loaded {
+		previous := targets[name]
+		if previous != nil && previous.sameAs(target) {
+			newTargets[name] = previous
+			continue
+		}
+		if previous != nil {
+			close(previous.queue)
+		}
+		go target.run(previous)
+		newTargets[name] = target
+	}
+	for name, previous := range targets {
+		if _, ok := newTargets[name]; !ok {
+			close(previous.queue)
+		}
+	}
+	targets = newTargets
+}
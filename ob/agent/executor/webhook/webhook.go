@@ -0,0 +1,222 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/engine/event"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/meta"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+	obmodel "github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/ob/agent/secure"
+	webhookservice "github.com/oceanbase/obshell/ob/agent/service/webhook"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+var webhookService = webhookservice.WebhookService{}
+
+func ListWebhooks() ([]bo.TaskWebhook, error) {
+	webhooks, err := webhookService.List()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]bo.TaskWebhook, 0, len(webhooks))
+	for i := range webhooks {
+		res = append(res, toMaskedBO(&webhooks[i]))
+	}
+	return res, nil
+}
+
+func GetWebhook(name string) (*bo.TaskWebhook, error) {
+	webhook, err := getWebhook(name)
+	if err != nil {
+		return nil, err
+	}
+	res := toMaskedBO(webhook)
+	return &res, nil
+}
+
+func CreateWebhook(p *param.CreateTaskWebhookParam) (*bo.TaskWebhook, error) {
+	existing, err := webhookService.GetByName(p.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.Occur(errors.ErrTaskWebhookAlreadyExists, p.Name)
+	}
+
+	webhook := &obmodel.TaskWebhook{
+		Name:        p.Name,
+		Url:         strings.TrimSpace(p.Url),
+		Enabled:     p.Enabled == nil || *p.Enabled,
+		Description: p.Description,
+	}
+	if err := checkWebhookUrl(webhook.Url); err != nil {
+		return nil, err
+	}
+	if webhook.Events, err = formatEventTypes(p.Events); err != nil {
+		return nil, err
+	}
+	if webhook.Secret, err = encryptSecret(p.Secret); err != nil {
+		return nil, err
+	}
+	if err := webhookService.Create(webhook); err != nil {
+		return nil, err
+	}
+	go reloadWebhooks()
+	return GetWebhook(p.Name)
+}
+
+func UpdateWebhook(name string, p *param.UpdateTaskWebhookParam) (*bo.TaskWebhook, error) {
+	webhook, err := getWebhook(name)
+	if err != nil {
+		return nil, err
+	}
+	if p.Url != nil {
+		webhook.Url = strings.TrimSpace(*p.Url)
+		if err := checkWebhookUrl(webhook.Url); err != nil {
+			return nil, err
+		}
+	}
+	if p.Events != nil {
+		if webhook.Events, err = formatEventTypes(*p.Events); err != nil {
+			return nil, err
+		}
+	}
+	// The masked secret returned by the query keeps the stored one.
+	if p.Secret != nil && *p.Secret != constant.WEBHOOK_MASKED_SECRET {
+		if webhook.Secret, err = encryptSecret(*p.Secret); err != nil {
+			return nil, err
+		}
+	}
+	if p.Enabled != nil {
+		webhook.Enabled = *p.Enabled
+	}
+	if p.Description != nil {
+		webhook.Description = *p.Description
+	}
+	if err := webhookService.Update(webhook); err != nil {
+		return nil, err
+	}
+	go reloadWebhooks()
+	return GetWebhook(name)
+}
+
+func DeleteWebhook(name string) error {
+	webhook, err := getWebhook(name)
+	if err != nil {
+		return err
+	}
+	if err := webhookService.Delete(webhook); err != nil {
+		return err
+	}
+	go reloadWebhooks()
+	return nil
+}
+
+// TestWebhook sends a test event to the webhook once, even if the webhook is disabled.
+func TestWebhook(ctx context.Context, name string) error {
+	webhook, err := getWebhook(name)
+	if err != nil {
+		return err
+	}
+	target, err := newWebhookTarget(webhook)
+	if err != nil {
+		return err
+	}
+	evt := &event.Event{
+		Type:  "test",
+		Time:  time.Now(),
+		State: "test",
+	}
+	if meta.OCS_AGENT != nil {
+		evt.Agent = meta.OCS_AGENT.String()
+	}
+	if err := target.send(ctx, evt); err != nil {
+		return errors.Occur(errors.ErrTaskWebhookSendFailed, name, err.Error())
+	}
+	return nil
+}
+
+func getWebhook(name string) (*obmodel.TaskWebhook, error) {
+	webhook, err := webhookService.GetByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if webhook == nil {
+		return nil, errors.Occur(errors.ErrTaskWebhookNotFound, name)
+	}
+	return webhook, nil
+}
+
+func toMaskedBO(webhook *obmodel.TaskWebhook) bo.TaskWebhook {
+	res := webhook.ToBO()
+	if webhook.Secret != "" {
+		res.Secret = constant.WEBHOOK_MASKED_SECRET
+	}
+	return res
+}
+
+func checkWebhookUrl(rawUrl string) error {
+	u, err := url.Parse(rawUrl)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Occur(errors.ErrTaskWebhookUrlInvalid, rawUrl)
+	}
+	return nil
+}
+
+// formatEventTypes checks the event types and joins them with comma.
+func formatEventTypes(types []string) (string, error) {
+	res := make([]string, 0, len(types))
+	for _, t := range types {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" {
+			continue
+		}
+		if err := CheckEventType(t); err != nil {
+			return "", err
+		}
+		res = append(res, t)
+	}
+	return strings.Join(res, ","), nil
+}
+
+func CheckEventType(eventType string) error {
+	for _, t := range event.ALL_TYPES {
+		if t == eventType {
+			return nil
+		}
+	}
+	return errors.Occur(errors.ErrTaskEventTypeInvalid, eventType, strings.Join(event.ALL_TYPES, ", "))
+}
+
+func encryptSecret(secret string) (string, error) {
+	if secret == "" {
+		return "", nil
+	}
+	encrypted, err := secure.EncryptCredentialPassphrase(secret)
+	if err != nil {
+		return "", errors.Wrap(err, "encrypt task webhook secret failed")
+	}
+	return encrypted, nil
+}
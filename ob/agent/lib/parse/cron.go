@@ -0,0 +1,179 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parse
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oceanbase/obshell/ob/agent/errors"
+)
+
+// CronSchedule is a parsed standard cron expression with five fields:
+// minute, hour, day of month, month and day of week.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+type cronField struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronMinute = cronField{0, 59, nil}
+	cronHour   = cronField{0, 23, nil}
+	cronDom    = cronField{1, 31, nil}
+	cronMonth  = cronField{1, 12, map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}}
+	// 7 is accepted as sunday as well.
+	cronDow = cronField{0, 7, map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}}
+
+	cronMacros = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// ParseCron parses the cron expression, such as "0 2 * * *" or "@hourly".
+func ParseCron(expr string) (*CronSchedule, error) {
+	spec := strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, errors.Occur(errors.ErrCommonInvalidCronExpression, expr, "expected 5 fields")
+	}
+
+	schedule := &CronSchedule{
+		domStar: fields[2] == "*" || fields[2] == "?",
+		dowStar: fields[4] == "*" || fields[4] == "?",
+	}
+	var err error
+	for i, target := range []*uint64{&schedule.minute, &schedule.hour, &schedule.dom, &schedule.month, &schedule.dow} {
+		field := []cronField{cronMinute, cronHour, cronDom, cronMonth, cronDow}[i]
+		if *target, err = parseCronField(fields[i], field); err != nil {
+			return nil, errors.Occur(errors.ErrCommonInvalidCronExpression, expr, err.Error())
+		}
+	}
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+	return schedule, nil
+}
+
+func parseCronField(expr string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, step := part, 1
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			var err error
+			rangeExpr = part[:idx]
+			if step, err = strconv.Atoi(part[idx+1:]); err != nil || step <= 0 {
+				return 0, errors.Errorf("invalid step in '%s'", part)
+			}
+		}
+
+		start, end := field.min, field.max
+		if rangeExpr != "*" && rangeExpr != "?" {
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+			var err error
+			if start, err = parseCronValue(bounds[0], field); err != nil {
+				return 0, err
+			}
+			end = start
+			if len(bounds) == 2 {
+				if end, err = parseCronValue(bounds[1], field); err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				end = field.max
+			}
+			if start > end {
+				return 0, errors.Errorf("invalid range '%s'", rangeExpr)
+			}
+		}
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(value string, field cronField) (int, error) {
+	if num, ok := field.names[strings.ToUpper(value)]; ok {
+		return num, nil
+	}
+	num, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.Errorf("invalid value '%s'", value)
+	}
+	if num < field.min || num > field.max {
+		return 0, errors.Errorf("value %d out of range [%d, %d]", num, field.min, field.max)
+	}
+	return num, nil
+}
+
+// Next returns the first time matching the schedule after t, with minute precision.
+// The zero time is returned if there is no matching time in the next five years.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// matchDay follows the cron convention: when both day of month and day of week
+// are restricted, the day matches if either of them matches.
+func (s *CronSchedule) matchDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
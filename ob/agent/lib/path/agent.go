@@ -107,6 +107,11 @@ func ObshellDefaultVariablePath() string {
 	return filepath.Join(EtcDir(), "default_system_variable.json")
 }
 
+// ObshellAlertRulePath returns the default path of the Prometheus rule file rendered by obshell.
+func ObshellAlertRulePath() string {
+	return filepath.Join(EtcDir(), "obshell_alert_rules.yml")
+}
+
 func ObshellCertificateAndKeyPaths() (key string, cert string) {
 	keys := scanFiles(filepath.Join(CertificateDir(), "*.key"))
 	for _, key := range keys {
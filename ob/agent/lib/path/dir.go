@@ -80,6 +80,10 @@ func LogDir() string {
 	return filepath.Join(AgentDir(), constant.DIR_LOG_OBSHELL)
 }
 
+func MetricDir() string {
+	return filepath.Join(AgentDir(), constant.DIR_METRIC)
+}
+
 func EtcDir() string {
 	return filepath.Join(AgentDir(), constant.OB_DIR_ETC)
 }
@@ -115,45 +115,68 @@ func formateBackupInfo(context string) []string {
 	return infos
 }
 
-func getLogPointAndDataSet(clogCtx, dataCtx string) ([][]int64, map[int]*BackupSet, error) {
-	clogSet := formateBackupInfo(clogCtx)
+func getLogPointSet(clogCtx string) ([][]int64, error) {
 	var logPointSet [][]int64
-
-	for _, logPoint := range clogSet {
+	for _, logPoint := range formateBackupInfo(clogCtx) {
 		var logPointData ArchiveInfo
 		if err := json.Unmarshal([]byte(logPoint), &logPointData); err != nil {
-			return nil, nil, errors.Wrap(err, "Failed to parse logPoint data")
+			return nil, errors.Wrap(err, "Failed to parse logPoint data")
 		}
 		logPointSet = append(logPointSet, []int64{logPointData.StartSCN.Val, logPointData.CheckPointSCN.Val})
 	}
+	return logPointSet, nil
+}
 
+func getDataSet(dataCtx string) (map[int]*BackupSet, error) {
 	dataSet := make(map[int]*BackupSet)
 	for _, data := range formateBackupInfo(dataCtx) {
 		var backupSet BackupSet
 		if err := json.Unmarshal([]byte(data), &backupSet); err != nil {
-			return nil, nil, errors.Wrap(err, "Failed to parse backupSet data")
+			return nil, errors.Wrap(err, "Failed to parse backupSet data")
 		}
 		dataSet[backupSet.BackupSetID] = &backupSet
 	}
+	return dataSet, nil
+}
+
+func getLogPointAndDataSet(clogCtx, dataCtx string) ([][]int64, map[int]*BackupSet, error) {
+	logPointSet, err := getLogPointSet(clogCtx)
+	if err != nil {
+		return nil, nil, err
+	}
+	dataSet, err := getDataSet(dataCtx)
+	if err != nil {
+		return nil, nil, err
+	}
 	return logPointSet, dataSet, nil
 }
 
-func getRestoreWindows(dataURI, logURI string) ([][2]int64, error) {
+func getLogPointAndDataSetByOBAdmin(dataURI, logURI string) ([][]int64, map[int]*BackupSet, error) {
 	log.Info("Get archive log context")
 	archiveLogCtx, err := getOBAdminCtxByURI(logURI)
 	if err != nil {
-		return nil, errors.Wrap(err, "execute archive log command failed")
+		return nil, nil, errors.Wrap(err, "execute archive log command failed")
 	}
 
 	log.Info("Get data backup context")
 	dataCtx, err := getOBAdminCtxByURI(dataURI)
 	if err != nil {
-		return nil, errors.Wrap(err, "execute data backup command failed")
+		return nil, nil, errors.Wrap(err, "execute data backup command failed")
 	}
 
-	logPointSet, dataSet, err := getLogPointAndDataSet(archiveLogCtx, dataCtx)
+	return getLogPointAndDataSet(archiveLogCtx, dataCtx)
+}
+
+func getRestoreWindows(dataURI, logURI string) ([][2]int64, error) {
+	logPointSet, dataSet, err := readLogPointAndDataSet(dataURI, logURI)
 	if err != nil {
-		return nil, err
+		if !IsFileExist(path.OBAdmin()) {
+			return nil, err
+		}
+		log.WithError(err).Warn("Read backup meta failed, fallback to ob_admin")
+		if logPointSet, dataSet, err = getLogPointAndDataSetByOBAdmin(dataURI, logURI); err != nil {
+			return nil, err
+		}
 	}
 
 	// sort logPointSet by start scn
@@ -296,10 +319,10 @@ func getClusterNameForLocalityInfo(res string) string {
 	return strings.TrimSpace(clusterName)
 }
 
-func GetRestoreSourceTenantInfo(dataURI, logURI string) (*RestoreTenantInfo, error) {
+func getSourceTenantNameByOBAdmin(dataURI string) (clusterName, tenantName string, err error) {
 	storage, err := GetStorageInterfaceByURI(strings.Join([]string{dataURI, BACKUP_FORMAT_PATH}, "/"))
 	if err != nil {
-		return nil, err
+		return "", "", err
 	}
 	cmd := fmt.Sprintf("export LD_LIBRARY_PATH='%s/lib'; %s dump_backup -q -d '%s'", global.HomePath, path.OBAdmin(), storage.GenerateURI())
 	if storage.GenerateQueryParams() != "" {
@@ -308,7 +331,31 @@ func GetRestoreSourceTenantInfo(dataURI, logURI string) (*RestoreTenantInfo, err
 
 	res, err := ExecCommand(cmd)
 	if err != nil {
-		return nil, errors.Wrap(err, "execute dump_backup command failed")
+		return "", "", errors.Wrap(err, "execute dump_backup command failed")
+	}
+	return getClusterNameForLocalityInfo(res), getTenantNameForLocalityInfo(res), nil
+}
+
+func getSourceTenantName(dataURI string) (clusterName, tenantName string, err error) {
+	storage, err := GetStorageInterfaceByURI(dataURI)
+	if err != nil {
+		return "", "", err
+	}
+	clusterName, tenantName, err = readBackupFormat(storage)
+	if err != nil {
+		if !IsFileExist(path.OBAdmin()) {
+			return "", "", errors.Wrap(err, "read backup format")
+		}
+		log.WithError(err).Warn("Read backup format failed, fallback to ob_admin")
+		return getSourceTenantNameByOBAdmin(dataURI)
+	}
+	return
+}
+
+func GetRestoreSourceTenantInfo(dataURI, logURI string) (*RestoreTenantInfo, error) {
+	clusterName, tenantName, err := getSourceTenantName(dataURI)
+	if err != nil {
+		return nil, err
 	}
 
 	restoreWindows, err := GetRestoreWindows(dataURI, logURI)
@@ -317,8 +364,34 @@ func GetRestoreSourceTenantInfo(dataURI, logURI string) (*RestoreTenantInfo, err
 	}
 
 	return &RestoreTenantInfo{
-		TenantName:     getTenantNameForLocalityInfo(res),
-		ClusterName:    getClusterNameForLocalityInfo(res),
+		TenantName:     tenantName,
+		ClusterName:    clusterName,
 		RestoreWindows: restoreWindows,
 	}, nil
 }
+
+func getDataSetByOBAdmin(dataURI string) (map[int]*BackupSet, error) {
+	dataCtx, err := getOBAdminCtxByURI(dataURI)
+	if err != nil {
+		return nil, errors.Wrap(err, "execute data backup command failed")
+	}
+	return getDataSet(dataCtx)
+}
+
+// ReadBackupSets reads the successful backup sets in the data backup dest natively,
+// ob_admin is used only when the meta files can't be read.
+func ReadBackupSets(dataURI string) (map[int]*BackupSet, error) {
+	dataStorage, err := GetStorageInterfaceByURI(dataURI)
+	if err != nil {
+		return nil, errors.Wrap(err, "get storage interface failed")
+	}
+	dataSet, err := readBackupSets(dataStorage)
+	if err != nil {
+		if !IsFileExist(path.OBAdmin()) {
+			return nil, errors.Wrap(err, "read backup sets failed and there is no ob_admin to fallback")
+		}
+		log.WithError(err).Warn("Read backup sets failed, fallback to ob_admin")
+		return getDataSetByOBAdmin(dataURI)
+	}
+	return dataSet, nil
+}
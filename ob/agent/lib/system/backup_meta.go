@@ -0,0 +1,439 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package system
+
+import (
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
+
+	"github.com/oceanbase/obshell/ob/agent/errors"
+)
+
+// The backup and archive destinations are laid out by observer as follows:
+//
+//	data backup dest:
+//	  format.obbak
+//	  backup_sets/backup_set_{id}_{full|inc}_end_success_{time}.obbak
+//	  backup_set_{id}_{full|inc}/backup_set_info.obbak
+//	archive log dest:
+//	  piece_d{dest_id}r{round_id}p{piece_id}/single_piece_info.obarc
+//	  piece_d{dest_id}r{round_id}p{piece_id}/checkpoint/checkpoint_info.{id}.obarc
+//
+// Every meta file starts with an ObBackupCommonHeader, followed by the
+// OB_UNIS serialization of the desc.
+const (
+	BACKUP_SETS_DIR           = "backup_sets"
+	BACKUP_SET_INFO_FILE      = "backup_set_info.obbak"
+	ARCHIVE_SINGLE_PIECE_FILE = "single_piece_info.obarc"
+	ARCHIVE_CHECKPOINT_DIR    = "checkpoint"
+	ARCHIVE_CHECKPOINT_PREFIX = "checkpoint_info."
+	ARCHIVE_FILE_SUFFIX       = ".obarc"
+)
+
+// Layout of ObBackupCommonHeader, which is written in little endian.
+const (
+	backupCommonHeaderLength      = 48
+	backupCommonHeaderVersion     = 1
+	backupCommonHeaderVersionOff  = 2
+	backupCommonHeaderLenOff      = 8
+	backupCommonHeaderChecksumOff = 10
+	backupCommonHeaderDataOff     = 16
+	backupCommonHeaderZDataOff    = 24
+)
+
+var (
+	backupSetPlaceholderReg = regexp.MustCompile(`^backup_set_(\d+)_(full|inc)_end_success_`)
+	archivePieceDirReg      = regexp.MustCompile(`^piece_d(\d+)r(\d+)p(\d+)$`)
+)
+
+// unisDecoder decodes the OB_UNIS serialization used by observer.
+type unisDecoder struct {
+	buf []byte
+	pos int
+}
+
+func (d *unisDecoder) varint() (int64, error) {
+	v, n := binary.Uvarint(d.buf[d.pos:])
+	if n <= 0 {
+		return 0, errors.Errorf("decode varint at offset %d failed", d.pos)
+	}
+	d.pos += n
+	return int64(v), nil
+}
+
+func (d *unisDecoder) bool() (bool, error) {
+	if d.pos >= len(d.buf) {
+		return false, errors.Errorf("decode bool at offset %d failed", d.pos)
+	}
+	v := d.buf[d.pos]
+	d.pos++
+	return v != 0, nil
+}
+
+// str decodes a vstr, which is the length, the bytes and a trailing '\0'.
+func (d *unisDecoder) str() (string, error) {
+	l, err := d.varint()
+	if err != nil {
+		return "", err
+	}
+	if l < 0 || int64(d.pos)+l+1 > int64(len(d.buf)) {
+		return "", errors.Errorf("decode string of length %d at offset %d failed", l, d.pos)
+	}
+	s := string(d.buf[d.pos : d.pos+int(l)])
+	d.pos += int(l) + 1
+	return s, nil
+}
+
+// unis enters a nested OB_UNIS struct, which is the version, the length and the body.
+func (d *unisDecoder) unis() (*unisDecoder, error) {
+	if _, err := d.varint(); err != nil {
+		return nil, err
+	}
+	l, err := d.varint()
+	if err != nil {
+		return nil, err
+	}
+	if l < 0 || int64(d.pos)+l > int64(len(d.buf)) {
+		return nil, errors.Errorf("decode struct of length %d at offset %d failed", l, d.pos)
+	}
+	sub := &unisDecoder{buf: d.buf[d.pos : d.pos+int(l)]}
+	d.pos += int(l)
+	return sub, nil
+}
+
+func (d *unisDecoder) varints(vals ...*int64) (err error) {
+	for _, v := range vals {
+		if *v, err = d.varint(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *unisDecoder) skipVarints(n int) error {
+	for i := 0; i < n; i++ {
+		if _, err := d.varint(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// calcBackupHeaderChecksum returns the checksum of ObBackupCommonHeader, which is the xor of
+// the 16-bit words of all the fields but the checksum itself, the padding is not included.
+func calcBackupHeaderChecksum(header []byte) int16 {
+	var checksum uint16
+	for off := 0; off < backupCommonHeaderLength; off += 2 {
+		if off >= backupCommonHeaderChecksumOff && off < backupCommonHeaderDataOff {
+			continue
+		}
+		checksum ^= binary.LittleEndian.Uint16(header[off:])
+	}
+	return int16(checksum)
+}
+
+// newBackupFileDecoder checks the common header of the meta file and returns the decoder of its desc.
+// The header is verified before decoding, so that a file which is not a backup meta file
+// is reported as an error rather than decoded into garbage.
+func newBackupFileDecoder(content []byte) (*unisDecoder, error) {
+	if len(content) < backupCommonHeaderLength {
+		return nil, errors.Errorf("file length %d is less than the common header", len(content))
+	}
+	if version := binary.LittleEndian.Uint16(content[backupCommonHeaderVersionOff:]); version != backupCommonHeaderVersion {
+		return nil, errors.Errorf("unsupported common header version %d", version)
+	}
+	if checksum := int16(binary.LittleEndian.Uint16(content[backupCommonHeaderChecksumOff:])); checksum != calcBackupHeaderChecksum(content) {
+		return nil, errors.Errorf("common header checksum mismatch: expect %d, got %d", calcBackupHeaderChecksum(content), checksum)
+	}
+	headerLen := int64(int16(binary.LittleEndian.Uint16(content[backupCommonHeaderLenOff:])))
+	dataLen := int64(binary.LittleEndian.Uint64(content[backupCommonHeaderDataOff:]))
+	dataZLen := int64(binary.LittleEndian.Uint64(content[backupCommonHeaderZDataOff:]))
+	if headerLen < backupCommonHeaderLength || dataLen <= 0 || headerLen+dataLen > int64(len(content)) {
+		return nil, errors.Errorf("invalid common header: header length %d, data length %d, file length %d", headerLen, dataLen, len(content))
+	}
+	if dataZLen != dataLen {
+		return nil, errors.Errorf("compressed meta file is not supported")
+	}
+	d := &unisDecoder{buf: content[headerLen : headerLen+dataLen]}
+	return d.unis()
+}
+
+func readBackupFile(storage StorageInterface, subpath string) (*unisDecoder, error) {
+	content, err := storage.ReadFile(subpath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read %s", subpath)
+	}
+	d, err := newBackupFileDecoder(content)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decode %s", subpath)
+	}
+	return d, nil
+}
+
+// decodeBackupSetFileDesc decodes ObBackupSetFileDesc until min_restore_scn.
+func decodeBackupSetFileDesc(d *unisDecoder) (*BackupSet, error) {
+	var setID, tenantID, prevFullID, prevIncID, startReplaySCN, minRestoreSCN int64
+	// backup_set_id, incarnation, tenant_id, dest_id
+	if err := d.varints(&setID, new(int64), &tenantID, new(int64)); err != nil {
+		return nil, err
+	}
+	// backup_type
+	if _, err := d.unis(); err != nil {
+		return nil, err
+	}
+	plusArchivelog, err := d.bool()
+	if err != nil {
+		return nil, err
+	}
+	// date, prev_full_backup_set_id, prev_inc_backup_set_id
+	if err := d.varints(new(int64), &prevFullID, &prevIncID); err != nil {
+		return nil, err
+	}
+	// stats
+	if _, err := d.unis(); err != nil {
+		return nil, err
+	}
+	// start_time, end_time, status, result, encryption_mode
+	if err := d.skipVarints(5); err != nil {
+		return nil, err
+	}
+	// passwd
+	if _, err := d.str(); err != nil {
+		return nil, err
+	}
+	// file_status
+	if err := d.skipVarints(1); err != nil {
+		return nil, err
+	}
+	// backup_path
+	if _, err := d.str(); err != nil {
+		return nil, err
+	}
+	if err := d.varints(&startReplaySCN, &minRestoreSCN); err != nil {
+		return nil, err
+	}
+	return &BackupSet{
+		TenantKey:           TenantKey{TenantId: int(tenantID)},
+		BackupSetID:         int(setID),
+		PlusArchivelog:      plusArchivelog,
+		PrevFullBackupSetID: int(prevFullID),
+		PrevIncBackupSetID:  int(prevIncID),
+		StartReplaySCN:      SCN{Val: startReplaySCN},
+		MinRestoreSCN:       SCN{Val: minRestoreSCN},
+	}, nil
+}
+
+// readBackupSets reads all the successful backup sets in the data backup dest.
+func readBackupSets(storage StorageInterface) (map[int]*BackupSet, error) {
+	names, err := storage.ListFiles(BACKUP_SETS_DIR)
+	if err != nil {
+		return nil, errors.Wrap(err, "list backup sets")
+	}
+
+	dataSet := make(map[int]*BackupSet)
+	for _, name := range names {
+		matches := backupSetPlaceholderReg.FindStringSubmatch(name)
+		if matches == nil {
+			continue
+		}
+		id, _ := strconv.Atoi(matches[1])
+		if _, ok := dataSet[id]; ok {
+			continue
+		}
+		infoPath := fmt.Sprintf("backup_set_%d_%s/%s", id, matches[2], BACKUP_SET_INFO_FILE)
+		d, err := readBackupFile(storage, infoPath)
+		if err != nil {
+			return nil, err
+		}
+		// ObExternBackupSetInfoDesc wraps the ObBackupSetFileDesc.
+		if d, err = d.unis(); err != nil {
+			return nil, errors.Wrapf(err, "decode %s", infoPath)
+		}
+		backupSet, err := decodeBackupSetFileDesc(d)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decode %s", infoPath)
+		}
+		if backupSet.BackupSetID != id || backupSet.StartReplaySCN.Val <= 0 || backupSet.MinRestoreSCN.Val < backupSet.StartReplaySCN.Val {
+			return nil, errors.Errorf("unexpected backup set info in %s: %+v", infoPath, backupSet)
+		}
+		dataSet[id] = backupSet
+	}
+	return dataSet, nil
+}
+
+// decodeSinglePieceDesc decodes the start scn and checkpoint scn of ObSinglePieceDesc.
+func decodeSinglePieceDesc(d *unisDecoder) (startSCN, checkpointSCN int64, err error) {
+	// ObTenantArchivePieceAttr
+	if d, err = d.unis(); err != nil {
+		return
+	}
+	// key
+	if _, err = d.unis(); err != nil {
+		return
+	}
+	// incarnation, dest_no, file_count
+	if err = d.skipVarints(3); err != nil {
+		return
+	}
+	err = d.varints(&startSCN, &checkpointSCN)
+	return
+}
+
+// decodePieceCheckpointDesc decodes the start scn and checkpoint scn of ObPieceCheckpointDesc.
+func decodePieceCheckpointDesc(d *unisDecoder) (startSCN, checkpointSCN int64, err error) {
+	// dest_id, round_id, piece_id, incarnation
+	if err = d.skipVarints(4); err != nil {
+		return
+	}
+	// compatible
+	if _, err = d.unis(); err != nil {
+		return
+	}
+	err = d.varints(&startSCN, &checkpointSCN)
+	return
+}
+
+// latestCheckpointFile returns the checkpoint file with the largest id.
+func latestCheckpointFile(names []string) string {
+	latest, latestID := "", int64(-1)
+	for _, name := range names {
+		if !strings.HasPrefix(name, ARCHIVE_CHECKPOINT_PREFIX) || !strings.HasSuffix(name, ARCHIVE_FILE_SUFFIX) {
+			continue
+		}
+		id, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(name, ARCHIVE_CHECKPOINT_PREFIX), ARCHIVE_FILE_SUFFIX), 10, 64)
+		if err != nil {
+			continue
+		}
+		if id > latestID {
+			latest, latestID = name, id
+		}
+	}
+	return latest
+}
+
+// readArchivePiece reads the start scn and checkpoint scn of the piece,
+// from the single piece info if the piece is frozen, otherwise from the latest checkpoint info.
+func readArchivePiece(storage StorageInterface, pieceDir string) ([]int64, error) {
+	names, err := storage.ListFiles(pieceDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "list %s", pieceDir)
+	}
+
+	var startSCN, checkpointSCN int64
+	var filePath string
+	if slices.Contains(names, ARCHIVE_SINGLE_PIECE_FILE) {
+		filePath = fmt.Sprintf("%s/%s", pieceDir, ARCHIVE_SINGLE_PIECE_FILE)
+		d, err := readBackupFile(storage, filePath)
+		if err != nil {
+			return nil, err
+		}
+		if startSCN, checkpointSCN, err = decodeSinglePieceDesc(d); err != nil {
+			return nil, errors.Wrapf(err, "decode %s", filePath)
+		}
+	} else {
+		checkpointDir := fmt.Sprintf("%s/%s", pieceDir, ARCHIVE_CHECKPOINT_DIR)
+		names, err := storage.ListFiles(checkpointDir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "list %s", checkpointDir)
+		}
+		latest := latestCheckpointFile(names)
+		if latest == "" {
+			// The piece has not done any checkpoint yet.
+			return nil, nil
+		}
+		filePath = fmt.Sprintf("%s/%s", checkpointDir, latest)
+		d, err := readBackupFile(storage, filePath)
+		if err != nil {
+			return nil, err
+		}
+		if startSCN, checkpointSCN, err = decodePieceCheckpointDesc(d); err != nil {
+			return nil, errors.Wrapf(err, "decode %s", filePath)
+		}
+	}
+	if startSCN <= 0 || checkpointSCN < startSCN {
+		return nil, errors.Errorf("unexpected piece info in %s: start scn %d, checkpoint scn %d", filePath, startSCN, checkpointSCN)
+	}
+	return []int64{startSCN, checkpointSCN}, nil
+}
+
+// readArchivePieces reads the start scn and checkpoint scn of all the pieces in the archive log dest.
+func readArchivePieces(storage StorageInterface) ([][]int64, error) {
+	names, err := storage.ListFiles("")
+	if err != nil {
+		return nil, errors.Wrap(err, "list archive pieces")
+	}
+	sort.Strings(names)
+
+	var logPointSet [][]int64
+	for _, name := range names {
+		if !archivePieceDirReg.MatchString(name) {
+			continue
+		}
+		logPoint, err := readArchivePiece(storage, name)
+		if err != nil {
+			return nil, err
+		}
+		if logPoint != nil {
+			logPointSet = append(logPointSet, logPoint)
+		}
+	}
+	return logPointSet, nil
+}
+
+// readBackupFormat reads the cluster name and tenant name from the format file of the data backup dest.
+func readBackupFormat(storage StorageInterface) (clusterName, tenantName string, err error) {
+	d, err := readBackupFile(storage, BACKUP_FORMAT_PATH)
+	if err != nil {
+		return
+	}
+	if clusterName, err = d.str(); err != nil {
+		return
+	}
+	tenantName, err = d.str()
+	return
+}
+
+// readLogPointAndDataSet reads the archive pieces and backup sets from the meta files natively.
+func readLogPointAndDataSet(dataURI, logURI string) ([][]int64, map[int]*BackupSet, error) {
+	logStorage, err := GetStorageInterfaceByURI(logURI)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "get storage interface failed")
+	}
+	dataStorage, err := GetStorageInterfaceByURI(dataURI)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "get storage interface failed")
+	}
+
+	log.Info("Read archive log meta")
+	logPointSet, err := readArchivePieces(logStorage)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "read archive log meta")
+	}
+	log.Info("Read data backup meta")
+	dataSet, err := readBackupSets(dataStorage)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "read data backup meta")
+	}
+	return logPointSet, dataSet, nil
+}
@@ -0,0 +1,168 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// The fixtures in testdata/backup_meta follow the layout of the backup dests:
+//
+//	data:    backup set 1 (full) and 2 (inc), backup set 3 is not finished
+//	archive: piece 1 is frozen, piece 2 is active with two checkpoints
+const (
+	fixtureDataDir    = "testdata/backup_meta/data"
+	fixtureArchiveDir = "testdata/backup_meta/archive"
+	fixtureTime       = int64(1704067200) * int64(time.Second)
+)
+
+func fixtureSCN(seconds int64) int64 {
+	return fixtureTime + seconds*int64(time.Second)
+}
+
+func fixtureURI(t *testing.T, dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		t.Fatalf("get abs path of %s failed: %v", dir, err)
+	}
+	return "file://" + abs
+}
+
+func fixtureStorage(t *testing.T, dir string) StorageInterface {
+	storage, err := GetStorageInterfaceByURI(fixtureURI(t, dir))
+	if err != nil {
+		t.Fatalf("get storage of %s failed: %v", dir, err)
+	}
+	return storage
+}
+
+func TestReadBackupFormat(t *testing.T) {
+	clusterName, tenantName, err := readBackupFormat(fixtureStorage(t, fixtureDataDir))
+	if err != nil {
+		t.Fatalf("read backup format failed: %v", err)
+	}
+	if clusterName != "obcluster" || tenantName != "tenant1" {
+		t.Errorf("unexpected cluster name %q and tenant name %q", clusterName, tenantName)
+	}
+}
+
+func TestReadBackupSets(t *testing.T) {
+	dataSet, err := readBackupSets(fixtureStorage(t, fixtureDataDir))
+	if err != nil {
+		t.Fatalf("read backup sets failed: %v", err)
+	}
+	expected := map[int]*BackupSet{
+		1: {
+			TenantKey:      TenantKey{TenantId: 1002},
+			BackupSetID:    1,
+			StartReplaySCN: SCN{Val: fixtureSCN(10)},
+			MinRestoreSCN:  SCN{Val: fixtureSCN(20)},
+		},
+		2: {
+			TenantKey:           TenantKey{TenantId: 1002},
+			BackupSetID:         2,
+			PrevFullBackupSetID: 1,
+			PrevIncBackupSetID:  1,
+			StartReplaySCN:      SCN{Val: fixtureSCN(60)},
+			MinRestoreSCN:       SCN{Val: fixtureSCN(70)},
+		},
+	}
+	if !reflect.DeepEqual(dataSet, expected) {
+		t.Errorf("unexpected backup sets: %+v", dataSet)
+	}
+}
+
+func TestReadArchivePieces(t *testing.T) {
+	logPointSet, err := readArchivePieces(fixtureStorage(t, fixtureArchiveDir))
+	if err != nil {
+		t.Fatalf("read archive pieces failed: %v", err)
+	}
+	// The active piece is read from its latest checkpoint.
+	expected := [][]int64{
+		{fixtureSCN(0), fixtureSCN(100)},
+		{fixtureSCN(100), fixtureSCN(300)},
+	}
+	if !reflect.DeepEqual(logPointSet, expected) {
+		t.Errorf("unexpected archive pieces: %v", logPointSet)
+	}
+}
+
+func TestGetRestoreWindows(t *testing.T) {
+	windows, err := GetRestoreWindows(fixtureURI(t, fixtureDataDir), fixtureURI(t, fixtureArchiveDir))
+	if err != nil {
+		t.Fatalf("get restore windows failed: %v", err)
+	}
+	expected := []RestoreWindow{{
+		StartTime: time.Unix(0, fixtureSCN(20)),
+		EndTime:   time.Unix(0, fixtureSCN(300)),
+	}}
+	if !reflect.DeepEqual(windows.Windows, expected) {
+		t.Errorf("unexpected restore windows: %+v", windows.Windows)
+	}
+}
+
+func TestNewBackupFileDecoderWithInvalidHeader(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join(fixtureDataDir, BACKUP_FORMAT_PATH))
+	if err != nil {
+		t.Fatalf("read format file failed: %v", err)
+	}
+	if _, err := newBackupFileDecoder(content); err != nil {
+		t.Fatalf("decode format file failed: %v", err)
+	}
+
+	cases := map[string]func([]byte) []byte{
+		"truncated header": func(c []byte) []byte {
+			return c[:backupCommonHeaderLength-1]
+		},
+		"unsupported version": func(c []byte) []byte {
+			c[backupCommonHeaderVersionOff] = 2
+			return c
+		},
+		"checksum mismatch": func(c []byte) []byte {
+			c[backupCommonHeaderDataOff]++
+			return c
+		},
+		"truncated data": func(c []byte) []byte {
+			return c[:len(c)-1]
+		},
+	}
+	for name, corrupt := range cases {
+		broken := corrupt(append([]byte(nil), content...))
+		if _, err := newBackupFileDecoder(broken); err == nil {
+			t.Errorf("expect error with %s", name)
+		}
+	}
+}
+
+func TestReadBackupSetsByURI(t *testing.T) {
+	dataSet, err := ReadBackupSets(fixtureURI(t, fixtureDataDir))
+	if err != nil {
+		t.Fatalf("read backup sets failed: %v", err)
+	}
+	if len(dataSet) != 2 || dataSet[1] == nil || dataSet[2] == nil {
+		t.Errorf("unexpected backup sets: %+v", dataSet)
+	}
+
+	// Without ob_admin, the error of the native reader is reported.
+	if _, err := ReadBackupSets(fixtureURI(t, fixtureArchiveDir+"/none")); err == nil {
+		t.Errorf("expect error when reading the dest not exist")
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"path"
 	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/aliyun/aliyun-oss-go-sdk/oss"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -57,6 +58,10 @@ type StorageInterface interface {
 	GetResourceType() string
 	CheckWritePermission() error
 	NewWithObjectKey(string) StorageInterface
+	// ListFiles returns the names of the files and directories right under the subpath.
+	ListFiles(subpath string) ([]string, error)
+	// ReadFile returns the content of the file at the subpath.
+	ReadFile(subpath string) ([]byte, error)
 }
 
 type OSSConfig struct {
@@ -104,18 +109,26 @@ func (c *OSSConfig) GetResourceType() string {
 	return constant.PROTOCOL_OSS
 }
 
-func (c *OSSConfig) CheckWritePermission() error {
+func (c *OSSConfig) newBucket() (*oss.Bucket, error) {
 	client, err := oss.New(c.Host, c.AccessID, c.AccessKey)
 	if err != nil {
-		return errors.Wrap(err, "create oss client")
+		return nil, errors.Wrap(err, "create oss client")
 	}
 	log.Info("OSS client created")
 
 	ossBucket, err := client.Bucket(c.BucketName)
 	if err != nil {
-		return errors.Wrap(err, "get oss bucket")
+		return nil, errors.Wrap(err, "get oss bucket")
+	}
+	log.Infof("OSS bucket %s created", c.BucketName)
+	return ossBucket, nil
+}
+
+func (c *OSSConfig) CheckWritePermission() error {
+	ossBucket, err := c.newBucket()
+	if err != nil {
+		return err
 	}
-	log.Infof("OSS bucket %s created: %#+v", c.BucketName, ossBucket)
 
 	emptyContent := bytes.NewReader([]byte(""))
 	testFile := path.Join(c.ObjectKey, meta.OCS_AGENT.GetIp(), fmt.Sprint(meta.OCS_AGENT.GetPort()))
@@ -171,11 +184,11 @@ func (c *COSConfig) GenerateURIWithoutSecret() (res string) {
 	return
 }
 
-func (c *COSConfig) CheckWritePermission() error {
+func (c *COSConfig) newClient() (*cos.Client, error) {
 	cosURL := fmt.Sprintf("https://%s.%s", c.BucketName, c.Host)
 	u, err := url.Parse(cosURL)
 	if err != nil {
-		return errors.Wrap(err, "parse cos uri")
+		return nil, errors.Wrap(err, "parse cos uri")
 	}
 
 	b := &cos.BaseURL{
@@ -188,6 +201,14 @@ func (c *COSConfig) CheckWritePermission() error {
 		},
 	})
 	log.Info("COS client created")
+	return client, nil
+}
+
+func (c *COSConfig) CheckWritePermission() error {
+	client, err := c.newClient()
+	if err != nil {
+		return err
+	}
 
 	emptyContent := bytes.NewReader([]byte(""))
 	testFile := path.Join(c.ObjectKey, meta.OCS_AGENT.GetIp(), fmt.Sprint(meta.OCS_AGENT.GetPort()))
@@ -245,7 +266,7 @@ func (c *S3Config) GenerateQueryParams() string {
 	return fmt.Sprintf("%s=%s&%s=%s&%s=%s", host, c.Host, accessID, c.AccessID, accessKey, c.AccessKey)
 }
 
-func (c *S3Config) CheckWritePermission() (err error) {
+func (c *S3Config) newClient() (svc *s3.S3, err error) {
 	var sess *session.Session
 	if c.S3Region != "" {
 		sess, err = session.NewSession(&aws.Config{
@@ -264,11 +285,19 @@ func (c *S3Config) CheckWritePermission() (err error) {
 		sess, err = session.NewSession(awsConfig)
 	}
 	if err != nil {
-		return errors.Wrap(err, "create s3 session")
+		return nil, errors.Wrap(err, "create s3 session")
 	}
 
-	svc := s3.New(sess)
+	svc = s3.New(sess)
 	log.Info("S3 client created")
+	return svc, nil
+}
+
+func (c *S3Config) CheckWritePermission() (err error) {
+	svc, err := c.newClient()
+	if err != nil {
+		return err
+	}
 
 	emptyContent := bytes.NewReader([]byte(""))
 	testFile := path.Join(c.ObjectKey, meta.OCS_AGENT.GetIp(), fmt.Sprint(meta.OCS_AGENT.GetPort()))
@@ -297,6 +326,103 @@ func (c *S3Config) CheckWritePermission() (err error) {
 	return nil
 }
 
+// AzBlobConfig is the config of Azure Blob Storage, the bucket name is the container,
+// the host is the blob service endpoint and the access id is the storage account name.
+type AzBlobConfig struct {
+	BaseConf
+}
+
+func (c *AzBlobConfig) NewWithObjectKey(subpath string) StorageInterface {
+	copy := new(AzBlobConfig)
+	*copy = *c
+	copy.ObjectKey = fmt.Sprintf("%s/%s", c.ObjectKey, subpath)
+	return copy
+}
+
+func (c *AzBlobConfig) GetResourceType() string {
+	return constant.PROTOCOL_AZBLOB
+}
+
+func (c *AzBlobConfig) GenerateURI() (res string) {
+	res = fmt.Sprintf("%s&%s=%s&%s=%s", c.GenerateURIWithoutSecret(), accessID, c.AccessID, accessKey, c.AccessKey)
+	return
+}
+
+func (c *AzBlobConfig) GenerateURIWithoutSecret() (res string) {
+	res = fmt.Sprintf("%s%s/%s?%s=%s", constant.PREFIX_AZBLOB, c.BucketName, c.ObjectKey, host, c.Host)
+	if c.DeleteMode != "" {
+		res += fmt.Sprintf("&%s=%s", deleteMode, c.DeleteMode)
+	}
+	return
+}
+
+func (c *AzBlobConfig) GenerateURIWhitoutParams() string {
+	return fmt.Sprintf("%s%s/%s", constant.PREFIX_AZBLOB, c.BucketName, c.ObjectKey)
+}
+
+func (c *AzBlobConfig) GenerateQueryParams() string {
+	return fmt.Sprintf("%s=%s&%s=%s&%s=%s", host, c.Host, accessID, c.AccessID, accessKey, c.AccessKey)
+}
+
+// serviceURL returns the blob service url, https is used when the host has no scheme.
+// Emulators such as Azurite use a path-style url like http://127.0.0.1:10000/devstoreaccount1.
+func (c *AzBlobConfig) serviceURL() string {
+	if strings.Contains(c.Host, "://") {
+		return c.Host
+	}
+	return "https://" + c.Host
+}
+
+func (c *AzBlobConfig) newClient() (*azblob.Client, error) {
+	cred, err := azblob.NewSharedKeyCredential(c.AccessID, c.AccessKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "create azblob credential")
+	}
+	client, err := azblob.NewClientWithSharedKeyCredential(c.serviceURL(), cred, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "create azblob client")
+	}
+	log.Info("Azure Blob client created")
+	return client, nil
+}
+
+func (c *AzBlobConfig) CheckWritePermission() error {
+	client, err := c.newClient()
+	if err != nil {
+		return err
+	}
+
+	testFile := path.Join(c.ObjectKey, meta.OCS_AGENT.GetIp(), fmt.Sprint(meta.OCS_AGENT.GetPort()))
+	log.Infof("test file: %s", testFile)
+	if _, err = client.UploadBuffer(context.Background(), c.BucketName, testFile, []byte(""), nil); err != nil {
+		return errors.Wrap(err, "put azblob object")
+	}
+
+	if _, err = client.DeleteBlob(context.Background(), c.BucketName, testFile, nil); err != nil {
+		return errors.Wrap(err, "delete azblob object")
+	}
+
+	return nil
+}
+
+// GCSConfig is the config of Google Cloud Storage accessed with HMAC keys.
+// OceanBase reaches GCS through its S3-interoperable XML API, so the generated
+// uri is in the s3:// form, while the gs:// form is accepted as input.
+type GCSConfig struct {
+	S3Config
+}
+
+func (c *GCSConfig) NewWithObjectKey(subpath string) StorageInterface {
+	copy := new(GCSConfig)
+	*copy = *c
+	copy.ObjectKey = fmt.Sprintf("%s/%s", c.ObjectKey, subpath)
+	return copy
+}
+
+func (c *GCSConfig) GetResourceType() string {
+	return constant.PROTOCOL_GCS
+}
+
 type NFSConfig struct {
 	Path string
 }
@@ -358,11 +484,29 @@ func GetStorageInterfaceByURI(uri string) (StorageInterface, error) {
 		return GetS3Storage(uri)
 	} else if strings.HasPrefix(uri, constant.PREFIX_FILE) {
 		return GetNFSStorage(uri)
+	} else if strings.HasPrefix(uri, constant.PREFIX_AZBLOB) {
+		return GetAzBlobStorage(uri)
+	} else if strings.HasPrefix(uri, constant.PREFIX_GCS) {
+		return GetGCSStorage(uri)
 	} else {
 		return nil, errors.Occur(errors.ErrObStorageURIInvalid, "invalid uri protocol")
 	}
 }
 
+// ObStorageURI returns the uri in the form accepted by OceanBase.
+// The gs:// uri is converted into the s3:// form of the S3-interoperable api of GCS.
+func ObStorageURI(uri string) string {
+	if !strings.HasPrefix(uri, constant.PREFIX_GCS) {
+		return uri
+	}
+	storage, err := GetGCSStorage(uri)
+	if err != nil {
+		log.WithError(err).Warn("parse gcs uri failed")
+		return uri
+	}
+	return storage.GenerateURI()
+}
+
 func GetResourceType(uri string) (t string, err error) {
 	if strings.HasPrefix(uri, constant.PREFIX_OSS) {
 		t = constant.PROTOCOL_OSS
@@ -372,6 +516,10 @@ func GetResourceType(uri string) (t string, err error) {
 		t = constant.PROTOCOL_S3
 	} else if strings.HasPrefix(uri, constant.PREFIX_FILE) {
 		t = constant.PROTOCOL_FILE
+	} else if strings.HasPrefix(uri, constant.PREFIX_AZBLOB) {
+		t = constant.PROTOCOL_AZBLOB
+	} else if strings.HasPrefix(uri, constant.PREFIX_GCS) {
+		t = constant.PROTOCOL_GCS
 	} else {
 		err = errors.Occur(errors.ErrObStorageURIInvalid, "invalid path type")
 	}
@@ -415,6 +563,37 @@ func GetS3Storage(url string) (StorageInterface, error) {
 	return conf, nil
 }
 
+// GetAzBlobStorage parses the azblob uri, the host defaults to the public endpoint of the account.
+func GetAzBlobStorage(url string) (StorageInterface, error) {
+	conf := &AzBlobConfig{}
+	urlWithoutScheme := strings.TrimPrefix(url, constant.PREFIX_AZBLOB)
+	if _, err := conf.parseParams(urlWithoutScheme); err != nil {
+		return nil, errors.Wrap(err, "parse azblob config")
+	}
+	if conf.AccessID == "" {
+		return nil, errors.Occur(errors.ErrObStorageURIInvalid, "azblob access_id(storage account name) is required")
+	}
+	if conf.Host == "" {
+		conf.Host = fmt.Sprintf("https://%s.blob.core.windows.net", conf.AccessID)
+	}
+	return conf, nil
+}
+
+// GetGCSStorage parses the gs uri, the host defaults to the public endpoint of GCS.
+// Path-style access is always used, which is also required by emulators such as fake-gcs-server.
+func GetGCSStorage(url string) (StorageInterface, error) {
+	conf := &GCSConfig{}
+	urlWithoutScheme := strings.TrimPrefix(url, constant.PREFIX_GCS)
+	if _, err := conf.parseParams(urlWithoutScheme); err != nil {
+		return nil, errors.Wrap(err, "parse gcs config")
+	}
+	if conf.Host == "" {
+		conf.Host = constant.GCS_DEFAULT_HOST
+	}
+	conf.ForcePathStyle = true
+	return conf, nil
+}
+
 func GetNFSStorage(url string) (StorageInterface, error) {
 	conf := &NFSConfig{}
 	conf.Path = strings.TrimPrefix(url, constant.PREFIX_FILE)
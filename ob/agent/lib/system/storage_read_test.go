@@ -0,0 +1,195 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package system
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oceanbase/obshell/ob/agent/meta"
+)
+
+// The storage tests run against the emulators only when their endpoints are given, such as
+//
+//	docker run -p 10000:10000 mcr.microsoft.com/azure-storage/azurite azurite-blob --blobHost 0.0.0.0
+//	OBSHELL_TEST_AZURITE_ENDPOINT=http://127.0.0.1:10000/devstoreaccount1
+//
+//	docker run -p 4443:4443 fsouza/fake-gcs-server -scheme http -public-host 127.0.0.1:4443
+//	OBSHELL_TEST_FAKE_GCS_ENDPOINT=http://127.0.0.1:4443
+const (
+	ENV_AZURITE_ENDPOINT  = "OBSHELL_TEST_AZURITE_ENDPOINT"
+	ENV_FAKE_GCS_ENDPOINT = "OBSHELL_TEST_FAKE_GCS_ENDPOINT"
+
+	// The well-known account of Azurite.
+	AZURITE_ACCOUNT = "devstoreaccount1"
+	AZURITE_KEY     = "Eby8vdM02xNOcqFlqUwJPLlmEtlymEdXUu/qJVYqvbD8Omeo5jMGD+XLI9rN3rTULoSTpuw9Pm2agTdvaalLnLLOLA=="
+)
+
+// storageSeeder puts the object with the key into the bucket of the emulator.
+type storageSeeder func(key string, content []byte) error
+
+func getEmulatorEndpoint(t *testing.T, env string) string {
+	endpoint := os.Getenv(env)
+	if endpoint == "" {
+		t.Skipf("%s is not set", env)
+	}
+	if meta.OCS_AGENT == nil {
+		meta.OCS_AGENT = meta.NewAgentInstance("127.0.0.1", 2886, "zone1", meta.SINGLE, "")
+	}
+	return strings.TrimRight(endpoint, "/")
+}
+
+func newTestBucketName() string {
+	return fmt.Sprintf("obshell-test-%d", time.Now().UnixNano())
+}
+
+// testStorageReadWrite checks the storage with the layout
+//
+//	<object key>/file
+//	<object key>/dir/a
+//	<object key>/dir/sub/b
+func testStorageReadWrite(t *testing.T, storage StorageInterface, objectKey string, seed storageSeeder) {
+	if err := storage.CheckWritePermission(); err != nil {
+		t.Fatalf("check write permission failed: %v", err)
+	}
+
+	files := map[string]string{
+		"file":      "file content",
+		"dir/a":     "a content",
+		"dir/sub/b": "b content",
+	}
+	for name, content := range files {
+		if err := seed(path.Join(objectKey, name), []byte(content)); err != nil {
+			t.Fatalf("seed %s failed: %v", name, err)
+		}
+	}
+
+	listCases := map[string][]string{
+		"":        {"dir", "file"},
+		"dir":     {"a", "sub"},
+		"dir/sub": {"b"},
+		"none":    {},
+	}
+	for subpath, expected := range listCases {
+		names, err := storage.ListFiles(subpath)
+		if err != nil {
+			t.Fatalf("list files of '%s' failed: %v", subpath, err)
+		}
+		sort.Strings(names)
+		if !reflect.DeepEqual(names, expected) {
+			t.Errorf("list files of '%s' = %v, expected %v", subpath, names, expected)
+		}
+	}
+
+	for name, content := range files {
+		data, err := storage.ReadFile(name)
+		if err != nil {
+			t.Fatalf("read file %s failed: %v", name, err)
+		}
+		if string(data) != content {
+			t.Errorf("read file %s = %q, expected %q", name, data, content)
+		}
+	}
+	if _, err := storage.ReadFile("none"); err == nil {
+		t.Errorf("expect error when reading the file not exist")
+	}
+
+	// The storage with the object key reads the files under it.
+	data, err := storage.NewWithObjectKey("dir").ReadFile("sub/b")
+	if err != nil {
+		t.Fatalf("read file sub/b of dir failed: %v", err)
+	}
+	if string(data) != files["dir/sub/b"] {
+		t.Errorf("read file sub/b of dir = %q, expected %q", data, files["dir/sub/b"])
+	}
+}
+
+func TestAzBlobStorageWithAzurite(t *testing.T) {
+	endpoint := getEmulatorEndpoint(t, ENV_AZURITE_ENDPOINT)
+	containerName := newTestBucketName()
+	objectKey := "backup/data"
+	uri := fmt.Sprintf("azblob://%s/%s?host=%s&access_id=%s&access_key=%s", containerName, objectKey, endpoint, AZURITE_ACCOUNT, AZURITE_KEY)
+	storage, err := GetStorageInterfaceByURI(uri)
+	if err != nil {
+		t.Fatalf("parse azblob uri failed: %v", err)
+	}
+	conf, ok := storage.(*AzBlobConfig)
+	if !ok {
+		t.Fatalf("expect *AzBlobConfig, got %T", storage)
+	}
+
+	client, err := conf.newClient()
+	if err != nil {
+		t.Fatalf("create azblob client failed: %v", err)
+	}
+	if _, err = client.CreateContainer(context.Background(), containerName, nil); err != nil {
+		t.Fatalf("create container failed: %v", err)
+	}
+	defer client.DeleteContainer(context.Background(), containerName, nil)
+
+	testStorageReadWrite(t, storage, objectKey, func(key string, content []byte) error {
+		_, err := client.UploadBuffer(context.Background(), containerName, key, content, nil)
+		return err
+	})
+}
+
+func TestGCSStorageWithFakeGCSServer(t *testing.T) {
+	endpoint := getEmulatorEndpoint(t, ENV_FAKE_GCS_ENDPOINT)
+	bucketName := newTestBucketName()
+	objectKey := "backup/data"
+	uri := fmt.Sprintf("gs://%s/%s?host=%s&access_id=id&access_key=key", bucketName, objectKey, endpoint)
+	storage, err := GetStorageInterfaceByURI(uri)
+	if err != nil {
+		t.Fatalf("parse gs uri failed: %v", err)
+	}
+	if _, ok := storage.(*GCSConfig); !ok {
+		t.Fatalf("expect *GCSConfig, got %T", storage)
+	}
+
+	// The bucket and the objects are created by the JSON API of fake-gcs-server.
+	body := fmt.Sprintf(`{"name": %q}`, bucketName)
+	if err := postFakeGCS(endpoint+"/storage/v1/b", "application/json", []byte(body)); err != nil {
+		t.Fatalf("create bucket failed: %v", err)
+	}
+
+	testStorageReadWrite(t, storage, objectKey, func(key string, content []byte) error {
+		uploadURL := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=media&name=%s", endpoint, bucketName, url.QueryEscape(key))
+		return postFakeGCS(uploadURL, "application/octet-stream", content)
+	})
+}
+
+func postFakeGCS(target string, contentType string, body []byte) error {
+	resp, err := http.Post(target, contentType, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("post %s: %s", target, resp.Status)
+	}
+	return nil
+}
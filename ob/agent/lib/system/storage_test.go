@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package system
+
+import "testing"
+
+func TestObStorageURI(t *testing.T) {
+	cases := []struct {
+		uri      string
+		expected string
+	}{
+		{
+			uri:      "gs://bucket/backup/data?access_id=id&access_key=key",
+			expected: "s3://bucket/backup/data?host=https://storage.googleapis.com&access_id=id&access_key=key",
+		},
+		{
+			uri:      "gs://bucket/backup/data/?host=http://127.0.0.1:4443&access_id=id&access_key=key&delete_mode=tagging",
+			expected: "s3://bucket/backup/data?host=http://127.0.0.1:4443&delete_mode=tagging&access_id=id&access_key=key",
+		},
+		{
+			uri:      "s3://bucket/backup/data?host=s3.amazonaws.com&access_id=id&access_key=key",
+			expected: "s3://bucket/backup/data?host=s3.amazonaws.com&access_id=id&access_key=key",
+		},
+		{
+			uri:      "file:///data/backup",
+			expected: "file:///data/backup",
+		},
+		{
+			// The invalid gs uri is returned as it is, and will be rejected by OceanBase.
+			uri:      "gs://bucket",
+			expected: "gs://bucket",
+		},
+	}
+	for _, c := range cases {
+		if res := ObStorageURI(c.uri); res != c.expected {
+			t.Errorf("ObStorageURI(%q) = %q, expected %q", c.uri, res, c.expected)
+		}
+	}
+}
+
+func TestGetGCSStorage(t *testing.T) {
+	storage, err := GetStorageInterfaceByURI("gs://bucket/backup?access_id=id&access_key=key")
+	if err != nil {
+		t.Fatalf("parse gs uri failed: %v", err)
+	}
+	conf, ok := storage.(*GCSConfig)
+	if !ok {
+		t.Fatalf("expect *GCSConfig, got %T", storage)
+	}
+	if !conf.ForcePathStyle {
+		t.Errorf("expect path-style access for gcs")
+	}
+	if conf.GetResourceType() != "gs" {
+		t.Errorf("expect resource type gs, got %s", conf.GetResourceType())
+	}
+	sub := conf.NewWithObjectKey("tenant")
+	if _, ok := sub.(*GCSConfig); !ok {
+		t.Errorf("expect *GCSConfig with the object key, got %T", sub)
+	}
+	if uri := sub.GenerateURIWhitoutParams(); uri != "s3://bucket/backup/tenant" {
+		t.Errorf("unexpected uri %s", uri)
+	}
+}
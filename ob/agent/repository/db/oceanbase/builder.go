@@ -54,6 +54,18 @@ var tableList = []interface{}{
 	oceanbase.OcsConfig{},
 	oceanbase.InspectionReport{},
 	oceanbase.ProfileCredential{},
+	oceanbase.AlarmChannel{},
+	oceanbase.AlarmDelivery{},
+	oceanbase.AlarmRule{},
+	oceanbase.AgentAccount{},
+	oceanbase.AuditLog{},
+	oceanbase.ScheduleJob{},
+	oceanbase.ScheduleRun{},
+	oceanbase.TaskWebhook{},
+	oceanbase.BackupEncryption{},
+	oceanbase.BackupSetEncryption{},
+	oceanbase.BackupRpoTarget{},
+	oceanbase.BackupComplianceDaily{},
 }
 
 // createGormDbByConfig will create an ob db instance according to the configuration and
@@ -180,6 +180,7 @@ var SqliteTables = []interface{}{
 	sqlite.NodeInstance{},
 	sqlite.UpgradePkgInfo{},
 	sqlite.UpgradePkgChunk{},
+	sqlite.AuditLog{},
 }
 
 // MigrateSqliteTables will check if the sqlite tables exist, if not, it will create them.
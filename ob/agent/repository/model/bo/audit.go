@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bo
+
+import "time"
+
+type AuditLog struct {
+	Id           int64     `json:"id"`
+	Time         time.Time `json:"time"`
+	ClientIp     string    `json:"client_ip"`
+	User         string    `json:"user"`
+	Session      string    `json:"session"` // fingerprint of the session id, never the session id itself
+	Method       string    `json:"method"`
+	Route        string    `json:"route"`
+	Uri          string    `json:"uri"`
+	Params       string    `json:"params"` // request body with the sensitive fields masked
+	DagId        string    `json:"dag_id"`
+	Status       int       `json:"status"`
+	Successful   bool      `json:"successful"`
+	ErrorCode    string    `json:"error_code"`
+	ErrorMessage string    `json:"error_message"`
+	TraceId      string    `json:"trace_id"`
+}
+
+type PaginatedAuditLogResponse struct {
+	Contents []AuditLog `json:"contents"`
+	Page     CustomPage `json:"page"`
+}
+
+type AuditRetention struct {
+	RetentionDays int `json:"retention_days"`
+}
@@ -42,6 +42,9 @@ type BackupDestInfo struct {
 	TenantID       int    `json:"tenant_id"`
 	ArchiveBaseUri string `json:"archive_base_uri"`
 	DataBaseUri    string `json:"data_base_uri"`
+
+	EncryptionEnabled bool `json:"encryption_enabled"`
+	KmsEncryptInfoSet bool `json:"kms_encrypt_info_set"`
 }
 
 type BackupJob struct {
@@ -59,6 +62,60 @@ type BackupJob struct {
 	Path           string     `json:"path"`
 }
 
+type BackupSet struct {
+	TenantID              int64      `json:"tenant_id"`
+	BackupSetID           int64      `json:"backup_set_id"`
+	Incarnation           int64      `json:"incarnation"`
+	BackupType            string     `json:"backup_type"`
+	PrevFullBackupSetID   int64      `json:"prev_full_backup_set_id"`
+	PrevIncBackupSetID    int64      `json:"prev_inc_backup_set_id"`
+	StartTimestamp        *time.Time `json:"start_timestamp"`
+	EndTimestamp          *time.Time `json:"end_timestamp,omitempty"`
+	Status                string     `json:"status"`
+	FileStatus            string     `json:"file_status"`
+	PlusArchivelog        string     `json:"plus_archivelog"`
+	StartReplayScn        int64      `json:"start_replay_scn"`
+	StartReplayScnDisplay *time.Time `json:"start_replay_scn_display,omitempty"`
+	MinRestoreScn         int64      `json:"min_restore_scn"`
+	MinRestoreScnDisplay  *time.Time `json:"min_restore_scn_display,omitempty"`
+	InputBytes            int64      `json:"input_bytes"`
+	OutputBytes           int64      `json:"output_bytes"`
+	EncryptionMode        string     `json:"encryption_mode"`
+	KeyRecorded           bool       `json:"key_recorded"` // whether obshell records the key of the set
+	Path                  string     `json:"path"`
+	DependentSetIDs       []int64    `json:"dependent_set_ids"`
+	Deletable             bool       `json:"deletable"`
+	Reason                string     `json:"reason,omitempty"` // why the set cannot be deleted
+}
+
+type ArchivePiece struct {
+	TenantID             int64      `json:"tenant_id"`
+	DestID               int64      `json:"dest_id"`
+	RoundID              int64      `json:"round_id"`
+	PieceID              int64      `json:"piece_id"`
+	Incarnation          int64      `json:"incarnation"`
+	Status               string     `json:"status"`
+	FileStatus           string     `json:"file_status"`
+	StartScn             int64      `json:"start_scn"`
+	StartScnDisplay      *time.Time `json:"start_scn_display,omitempty"`
+	CheckpointScn        int64      `json:"checkpoint_scn"`
+	CheckpointScnDisplay *time.Time `json:"checkpoint_scn_display,omitempty"`
+	EndScn               int64      `json:"end_scn"`
+	EndScnDisplay        *time.Time `json:"end_scn_display,omitempty"`
+	InputBytes           int64      `json:"input_bytes"`
+	OutputBytes          int64      `json:"output_bytes"`
+	Path                 string     `json:"path"`
+	Deletable            bool       `json:"deletable"`
+	Reason               string     `json:"reason,omitempty"` // why the piece cannot be deleted
+}
+
+type BackupCatalog struct {
+	TenantID      int64          `json:"tenant_id"`
+	TenantName    string         `json:"tenant_name"`
+	BackupSets    []BackupSet    `json:"backup_sets"`
+	ArchivePieces []ArchivePiece `json:"archive_pieces"`
+}
+
 type CustomPage struct {
 	TotalElements uint64 `json:"total_elements"`
 	TotalPages    uint64 `json:"total_pages"`
@@ -71,3 +128,49 @@ type PaginatedBackupJobResponse struct {
 	Contents []BackupJob `json:"contents"`
 	Page     CustomPage  `json:"page"`
 }
+
+type BackupRpoTarget struct {
+	TenantID           int       `json:"tenant_id"`
+	TenantName         string    `json:"tenant_name"`
+	RpoTarget          int64     `json:"rpo_target"`           // seconds
+	FullBackupInterval int64     `json:"full_backup_interval"` // seconds, 0 means not checked
+	IncBackupInterval  int64     `json:"incremental_backup_interval"`
+	UpdateTime         time.Time `json:"update_time"`
+}
+
+type BackupCompliance struct {
+	TenantID           int             `json:"tenant_id"`
+	TenantName         string          `json:"tenant_name"`
+	Target             BackupRpoTarget `json:"target"`
+	Status             string          `json:"status"`
+	Rpo                int64           `json:"rpo"` // seconds since the latest recoverable point, -1 means not recoverable
+	ArchiveStatus      string          `json:"archive_status"`
+	ArchiveCheckpoint  *time.Time      `json:"archive_checkpoint,omitempty"`
+	ArchiveLagTarget   string          `json:"archive_lag_target"`
+	LastFullBackupTime *time.Time      `json:"last_full_backup_time,omitempty"`
+	LastIncBackupTime  *time.Time      `json:"last_incremental_backup_time,omitempty"`
+	Violations         []string        `json:"violations"`
+	Warnings           []string        `json:"warnings"`
+	CheckTime          time.Time       `json:"check_time"`
+}
+
+type BackupComplianceDaily struct {
+	Date               string     `json:"date"`
+	TenantID           int        `json:"tenant_id"`
+	TenantName         string     `json:"tenant_name"`
+	RpoTarget          int64      `json:"rpo_target"`
+	CheckCount         int        `json:"check_count"`
+	ExpectedCheckCount int        `json:"expected_check_count"`
+	CompliantCount     int        `json:"compliant_count"`
+	MaxRpo             int64      `json:"max_rpo"`
+	FirstViolationTime *time.Time `json:"first_violation_time,omitempty"`
+	LastViolation      string     `json:"last_violation,omitempty"`
+	Compliant          bool       `json:"compliant"` // all the checks of the day passed
+}
+
+type BackupComplianceReport struct {
+	StartDate string                  `json:"start_date"`
+	EndDate   string                  `json:"end_date"`
+	Compliant bool                    `json:"compliant"` // every tenant complied with its target in every day
+	Days      []BackupComplianceDaily `json:"days"`
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bo
+
+type ResourcePlan struct {
+	PlanID     int64                   `json:"plan_id"`
+	Plan       string                  `json:"plan"`
+	Comment    string                  `json:"comment"`
+	Active     bool                    `json:"active"`
+	Directives []ResourcePlanDirective `json:"directives"`
+}
+
+type ResourcePlanDirective struct {
+	ConsumerGroup    string `json:"consumer_group"`
+	Comment          string `json:"comment"`
+	MgmtP1           int    `json:"mgmt_p1"`
+	UtilizationLimit int    `json:"utilization_limit"`
+	MinIops          int    `json:"min_iops"`
+	MaxIops          int    `json:"max_iops"`
+	WeightIops       int    `json:"weight_iops"`
+}
+
+type ConsumerGroup struct {
+	ConsumerGroupID int64  `json:"consumer_group_id"`
+	ConsumerGroup   string `json:"consumer_group"`
+	Comment         string `json:"comment"`
+}
+
+type ResourceMapping struct {
+	Attribute     string `json:"attribute"`
+	Value         string `json:"value"`
+	ConsumerGroup string `json:"consumer_group"`
+	Status        string `json:"status"`
+}
+
+type ConsumerGroupServerIoUsage struct {
+	SvrIp            string `json:"svr_ip"`
+	SvrPort          int    `json:"svr_port"`
+	Mode             string `json:"mode"`
+	MinIops          int64  `json:"min_iops"`
+	MaxIops          int64  `json:"max_iops"`
+	RealIops         int64  `json:"real_iops"`
+	MaxNetBandwidth  int64  `json:"max_net_bandwidth"`
+	RealNetBandwidth int64  `json:"real_net_bandwidth"`
+}
+
+// ConsumerGroupIoUsage is the io usage of the consumer group, the cpu usage is not included.
+type ConsumerGroupIoUsage struct {
+	GroupID   int64                        `json:"group_id"`
+	GroupName string                       `json:"group_name"`
+	RealIops  int64                        `json:"real_iops"` // the sum of real iops of all servers
+	Servers   []ConsumerGroupServerIoUsage `json:"servers"`
+}
+
+type ResourceManagerIoUsage struct {
+	ActivePlan string                 `json:"active_plan"`
+	Groups     []ConsumerGroupIoUsage `json:"groups"`
+}
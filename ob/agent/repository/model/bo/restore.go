@@ -85,3 +85,24 @@ type PaginatedRestoreTaskResponse struct {
 	Contents []RestoreTask `json:"contents"`
 	Page     CustomPage    `json:"page"`
 }
+
+type RecoverTableJob struct {
+	JobID             int64  `json:"job_id"`
+	StartTimestamp    string `json:"start_timestamp"`
+	EndTimestamp      string `json:"end_timestamp,omitempty"`
+	Status            string `json:"status"`
+	AuxTenantName     string `json:"aux_tenant_name"`
+	TargetTenantName  string `json:"target_tenant_name"`
+	ImportAll         string `json:"import_all"`
+	DbList            string `json:"db_list"`
+	TableList         string `json:"table_list"`
+	RemapDbList       string `json:"remap_db_list"`
+	RemapTableList    string `json:"remap_table_list"`
+	RestoreScn        int64  `json:"restore_scn"`
+	RestoreScnDisplay string `json:"restore_scn_display"`
+	RestoreOption     string `json:"restore_option"`
+	Result            string `json:"result"`
+	Comment           string `json:"comment"`
+	Description       string `json:"description"`
+	Finished          bool   `json:"finished"`
+}
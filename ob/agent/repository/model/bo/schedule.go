@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bo
+
+import "time"
+
+type Schedule struct {
+	Name        string     `json:"name"`
+	JobType     string     `json:"job_type"`
+	Cron        string     `json:"cron"`
+	Enabled     bool       `json:"enabled"`
+	TenantName  string     `json:"tenant_name,omitempty"`  // required by the backup and compaction jobs
+	BackupMode  string     `json:"backup_mode,omitempty"`  // full or incremental, only for the backup jobs
+	PlusArchive bool       `json:"plus_archive,omitempty"` // only for the backup jobs
+	Scenario    string     `json:"scenario,omitempty"`     // only for the inspection jobs
+	Description string     `json:"description"`
+	LastRunTime *time.Time `json:"last_run_time"`
+	NextRunTime *time.Time `json:"next_run_time"`
+	CreateTime  time.Time  `json:"create_time"`
+	UpdateTime  time.Time  `json:"update_time"`
+}
+
+type ScheduleRun struct {
+	Id           int64     `json:"id"`
+	ScheduleName string    `json:"schedule_name"`
+	TriggerTime  time.Time `json:"trigger_time"`
+	Status       string    `json:"status"`
+	DagId        string    `json:"dag_id"` // generic id of the submitted task
+	Message      string    `json:"message"`
+}
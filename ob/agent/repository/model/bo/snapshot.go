@@ -0,0 +1,30 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bo
+
+import "time"
+
+type TenantSnapshot struct {
+	SnapshotID   int64     `json:"snapshot_id"`
+	SnapshotName string    `json:"snapshot_name"`
+	Status       string    `json:"status"`
+	SnapshotScn  int64     `json:"snapshot_scn"`
+	ClogStartScn int64     `json:"clog_start_scn"`
+	Type         string    `json:"type"`
+	CreateTime   time.Time `json:"create_time"`
+	DataVersion  string    `json:"data_version"`
+}
@@ -31,6 +31,7 @@ type DagInstance struct {
 	MaintenanceType   int
 	MaintenanceKey    string
 	IsFinished        bool
+	IsGraph           bool
 	Context           []byte
 	Operator          int
 	StartTime         time.Time
@@ -48,6 +49,7 @@ type NodeInstance struct {
 	Type              string
 	State             int
 	MaxStage          int
+	Upstreams         string // comma separated stages of the upstream nodes
 	ExecuterAgentIp   string
 	ExecuterAgentPort int
 	Context           []byte
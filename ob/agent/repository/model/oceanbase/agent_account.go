@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oceanbase
+
+import "time"
+
+type AgentAccount struct {
+	ID          int64     `gorm:"primaryKey;autoIncrement;column:id;type:bigint(20);not null"`
+	Name        string    `gorm:"column:name;type:varchar(64);not null;uniqueIndex"`
+	Password    string    `gorm:"column:password;type:varchar(128);not null"` // bcrypt hash of the password
+	Role        string    `gorm:"column:role;type:varchar(32);not null"`
+	Tenants     string    `gorm:"column:tenants;type:text"` // json array of the tenant names
+	Description string    `gorm:"column:description;type:varchar(256)"`
+	CreateTime  time.Time `gorm:"column:create_time;type:datetime;default:CURRENT_TIMESTAMP"`
+	UpdateTime  time.Time `gorm:"column:update_time;type:datetime;default:CURRENT_TIMESTAMP;autoUpdateTime"`
+}
+
+func (AgentAccount) TableName() string {
+	return "agent_account"
+}
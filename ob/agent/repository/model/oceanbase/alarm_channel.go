@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oceanbase
+
+import "time"
+
+type AlarmChannel struct {
+	Id          int64     `gorm:"primaryKey;autoIncrement;column:id;type:bigint(20);not null"`
+	Name        string    `gorm:"column:name;type:varchar(128);not null;uniqueIndex"`
+	Type        string    `gorm:"column:type;type:varchar(32);not null"`
+	Description string    `gorm:"column:description;type:varchar(256)"`
+	Enabled     bool      `gorm:"column:enabled;type:tinyint(1);not null;default:1"`
+	Route       string    `gorm:"column:route;type:text"`
+	Retry       string    `gorm:"column:retry;type:varchar(256)"`
+	Config      string    `gorm:"column:config;type:text;not null"` // encrypted, may contain secrets
+	CreateTime  time.Time `gorm:"column:create_time;type:datetime;default:CURRENT_TIMESTAMP"`
+	UpdateTime  time.Time `gorm:"column:update_time;type:datetime;default:CURRENT_TIMESTAMP;autoUpdateTime"`
+}
+
+func (AlarmChannel) TableName() string {
+	return "alarm_channel"
+}
+
+type AlarmDelivery struct {
+	Id           int64     `gorm:"primaryKey;autoIncrement;column:id;type:bigint(20);not null"`
+	ChannelName  string    `gorm:"column:channel_name;type:varchar(128);not null;index"`
+	ChannelType  string    `gorm:"column:channel_type;type:varchar(32);not null"`
+	RuleName     string    `gorm:"column:rule_name;type:varchar(128)"`
+	Severity     string    `gorm:"column:severity;type:varchar(32)"`
+	AlertStatus  string    `gorm:"column:alert_status;type:varchar(32)"`
+	Summary      string    `gorm:"column:summary;type:varchar(1024)"`
+	Status       string    `gorm:"column:status;type:varchar(32);not null"`
+	Attempts     int       `gorm:"column:attempts;type:int;not null"`
+	ErrorMessage string    `gorm:"column:error_message;type:text"`
+	CreateTime   time.Time `gorm:"column:create_time;type:datetime;default:CURRENT_TIMESTAMP;index"`
+}
+
+func (AlarmDelivery) TableName() string {
+	return "alarm_delivery"
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oceanbase
+
+import "time"
+
+type AlarmRule struct {
+	Id           int64     `gorm:"primaryKey;autoIncrement;column:id;type:bigint(20);not null"`
+	Name         string    `gorm:"column:name;type:varchar(128);not null;uniqueIndex"`
+	InstanceType string    `gorm:"column:instance_type;type:varchar(32);not null"`
+	Type         string    `gorm:"column:type;type:varchar(32);not null"`
+	Template     string    `gorm:"column:template;type:text"`
+	Query        string    `gorm:"column:query;type:text"`
+	Duration     int       `gorm:"column:duration;type:int;not null;default:0"`
+	Labels       string    `gorm:"column:labels;type:text"`
+	Severity     string    `gorm:"column:severity;type:varchar(32);not null"`
+	Summary      string    `gorm:"column:summary;type:varchar(1024)"`
+	Description  string    `gorm:"column:description;type:text"`
+	Enabled      bool      `gorm:"column:enabled;type:tinyint(1);not null;default:1"`
+	CreateTime   time.Time `gorm:"column:create_time;type:datetime;default:CURRENT_TIMESTAMP"`
+	UpdateTime   time.Time `gorm:"column:update_time;type:datetime;default:CURRENT_TIMESTAMP;autoUpdateTime"`
+}
+
+func (AlarmRule) TableName() string {
+	return "alarm_rule"
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oceanbase
+
+import (
+	"time"
+
+	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+)
+
+type AuditLog struct {
+	Id           int64     `gorm:"primaryKey;autoIncrement;not null"`
+	Time         time.Time `gorm:"column:record_time;type:TIMESTAMP(6);default:CURRENT_TIMESTAMP(6);index"`
+	ClientIp     string    `gorm:"type:varchar(64)"`
+	User         string    `gorm:"column:user_name;type:varchar(64);index"`
+	Session      string    `gorm:"type:varchar(64)"`
+	Method       string    `gorm:"type:varchar(16)"`
+	Route        string    `gorm:"type:varchar(256)"`
+	Uri          string    `gorm:"type:varchar(1024)"`
+	Params       string    `gorm:"type:text"`
+	DagId        string    `gorm:"type:varchar(64)"`
+	Status       int       `gorm:"not null"`
+	Successful   bool      `gorm:"not null"`
+	ErrorCode    string    `gorm:"type:varchar(128)"`
+	ErrorMessage string    `gorm:"type:text"`
+	TraceId      string    `gorm:"type:varchar(64)"`
+}
+
+func (a *AuditLog) ToBO() *bo.AuditLog {
+	return &bo.AuditLog{
+		Id:           a.Id,
+		Time:         a.Time,
+		ClientIp:     a.ClientIp,
+		User:         a.User,
+		Session:      a.Session,
+		Method:       a.Method,
+		Route:        a.Route,
+		Uri:          a.Uri,
+		Params:       a.Params,
+		DagId:        a.DagId,
+		Status:       a.Status,
+		Successful:   a.Successful,
+		ErrorCode:    a.ErrorCode,
+		ErrorMessage: a.ErrorMessage,
+		TraceId:      a.TraceId,
+	}
+}
+
+func ConvertAuditLogBOToDO(a *bo.AuditLog) *AuditLog {
+	return &AuditLog{
+		Id:           a.Id,
+		Time:         a.Time,
+		ClientIp:     a.ClientIp,
+		User:         a.User,
+		Session:      a.Session,
+		Method:       a.Method,
+		Route:        a.Route,
+		Uri:          a.Uri,
+		Params:       a.Params,
+		DagId:        a.DagId,
+		Status:       a.Status,
+		Successful:   a.Successful,
+		ErrorCode:    a.ErrorCode,
+		ErrorMessage: a.ErrorMessage,
+		TraceId:      a.TraceId,
+	}
+}
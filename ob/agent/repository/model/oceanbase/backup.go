@@ -126,3 +126,89 @@ func (t *CdbObBackupJob) ToBO() *bo.BackupJob {
 		Path:           t.Path,
 	}
 }
+
+type CdbObBackupSetFile struct {
+	TenantID              int64      `gorm:"column:TENANT_ID"`
+	BackupSetID           int64      `gorm:"column:BACKUP_SET_ID"`
+	Incarnation           int64      `gorm:"column:INCARNATION"`
+	BackupType            string     `gorm:"column:BACKUP_TYPE"`
+	PrevFullBackupSetID   int64      `gorm:"column:PREV_FULL_BACKUP_SET_ID"`
+	PrevIncBackupSetID    int64      `gorm:"column:PREV_INC_BACKUP_SET_ID"`
+	StartTimestamp        *time.Time `gorm:"column:START_TIMESTAMP"`
+	EndTimestamp          *time.Time `gorm:"column:END_TIMESTAMP"`
+	Status                string     `gorm:"column:STATUS"`
+	FileStatus            string     `gorm:"column:FILE_STATUS"`
+	PlusArchivelog        string     `gorm:"column:PLUS_ARCHIVELOG"`
+	StartReplayScn        int64      `gorm:"column:START_REPLAY_SCN"`
+	StartReplayScnDisplay *time.Time `gorm:"column:START_REPLAY_SCN_DISPLAY"`
+	MinRestoreScn         int64      `gorm:"column:MIN_RESTORE_SCN"`
+	MinRestoreScnDisplay  *time.Time `gorm:"column:MIN_RESTORE_SCN_DISPLAY"`
+	InputBytes            int64      `gorm:"column:INPUT_BYTES"`
+	OutputBytes           int64      `gorm:"column:OUTPUT_BYTES"`
+	EncryptionMode        string     `gorm:"column:ENCRYPTION_MODE"`
+	Path                  string     `gorm:"column:PATH"`
+}
+
+func (t *CdbObBackupSetFile) ToBO() *bo.BackupSet {
+	return &bo.BackupSet{
+		TenantID:              t.TenantID,
+		BackupSetID:           t.BackupSetID,
+		Incarnation:           t.Incarnation,
+		BackupType:            t.BackupType,
+		PrevFullBackupSetID:   t.PrevFullBackupSetID,
+		PrevIncBackupSetID:    t.PrevIncBackupSetID,
+		StartTimestamp:        t.StartTimestamp,
+		EndTimestamp:          t.EndTimestamp,
+		Status:                t.Status,
+		FileStatus:            t.FileStatus,
+		PlusArchivelog:        t.PlusArchivelog,
+		StartReplayScn:        t.StartReplayScn,
+		StartReplayScnDisplay: t.StartReplayScnDisplay,
+		MinRestoreScn:         t.MinRestoreScn,
+		MinRestoreScnDisplay:  t.MinRestoreScnDisplay,
+		InputBytes:            t.InputBytes,
+		OutputBytes:           t.OutputBytes,
+		EncryptionMode:        t.EncryptionMode,
+		Path:                  t.Path,
+	}
+}
+
+type CdbObArchivelogPieceFile struct {
+	TenantID             int64      `gorm:"column:TENANT_ID"`
+	DestID               int64      `gorm:"column:DEST_ID"`
+	RoundID              int64      `gorm:"column:ROUND_ID"`
+	PieceID              int64      `gorm:"column:PIECE_ID"`
+	Incarnation          int64      `gorm:"column:INCARNATION"`
+	Status               string     `gorm:"column:STATUS"`
+	FileStatus           string     `gorm:"column:FILE_STATUS"`
+	StartScn             int64      `gorm:"column:START_SCN"`
+	StartScnDisplay      *time.Time `gorm:"column:START_SCN_DISPLAY"`
+	CheckpointScn        int64      `gorm:"column:CHECKPOINT_SCN"`
+	CheckpointScnDisplay *time.Time `gorm:"column:CHECKPOINT_SCN_DISPLAY"`
+	EndScn               int64      `gorm:"column:END_SCN"`
+	EndScnDisplay        *time.Time `gorm:"column:END_SCN_DISPLAY"`
+	InputBytes           int64      `gorm:"column:INPUT_BYTES"`
+	OutputBytes          int64      `gorm:"column:OUTPUT_BYTES"`
+	Path                 string     `gorm:"column:PATH"`
+}
+
+func (t *CdbObArchivelogPieceFile) ToBO() *bo.ArchivePiece {
+	return &bo.ArchivePiece{
+		TenantID:             t.TenantID,
+		DestID:               t.DestID,
+		RoundID:              t.RoundID,
+		PieceID:              t.PieceID,
+		Incarnation:          t.Incarnation,
+		Status:               t.Status,
+		FileStatus:           t.FileStatus,
+		StartScn:             t.StartScn,
+		StartScnDisplay:      t.StartScnDisplay,
+		CheckpointScn:        t.CheckpointScn,
+		CheckpointScnDisplay: t.CheckpointScnDisplay,
+		EndScn:               t.EndScn,
+		EndScnDisplay:        t.EndScnDisplay,
+		InputBytes:           t.InputBytes,
+		OutputBytes:          t.OutputBytes,
+		Path:                 t.Path,
+	}
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oceanbase
+
+import "time"
+
+// BackupRpoTarget is the recovery point objective and the backup frequency targets of the tenant,
+// all of them are in seconds, and 0 means not checked.
+type BackupRpoTarget struct {
+	TenantID           int       `gorm:"primaryKey;autoIncrement:false;column:tenant_id;not null"`
+	TenantName         string    `gorm:"column:tenant_name;type:varchar(128);not null"`
+	RpoTarget          int64     `gorm:"column:rpo_target;not null"`
+	FullBackupInterval int64     `gorm:"column:full_backup_interval;default:0"`
+	IncBackupInterval  int64     `gorm:"column:inc_backup_interval;default:0"`
+	UpdateTime         time.Time `gorm:"column:update_time;type:datetime;default:CURRENT_TIMESTAMP;autoUpdateTime"`
+}
+
+func (BackupRpoTarget) TableName() string {
+	return "backup_rpo_target"
+}
+
+// BackupComplianceDaily aggregates the compliance checks of the tenant in one day.
+type BackupComplianceDaily struct {
+	Id                 int64      `gorm:"primaryKey;autoIncrement;column:id;type:bigint(20);not null"`
+	TenantID           int        `gorm:"column:tenant_id;not null;uniqueIndex:uk_tenant_date"`
+	Date               string     `gorm:"column:date;type:varchar(10);not null;uniqueIndex:uk_tenant_date"`
+	TenantName         string     `gorm:"column:tenant_name;type:varchar(128);not null"`
+	RpoTarget          int64      `gorm:"column:rpo_target;not null"`
+	CheckCount         int        `gorm:"column:check_count;default:0"`
+	CompliantCount     int        `gorm:"column:compliant_count;default:0"`
+	MaxRpo             int64      `gorm:"column:max_rpo;default:0"`
+	FirstViolationTime *time.Time `gorm:"column:first_violation_time;type:datetime"`
+	LastViolation      string     `gorm:"column:last_violation;type:varchar(512);default:''"`
+	UpdateTime         time.Time  `gorm:"column:update_time;type:datetime;default:CURRENT_TIMESTAMP;autoUpdateTime"`
+}
+
+func (BackupComplianceDaily) TableName() string {
+	return "backup_compliance_daily"
+}
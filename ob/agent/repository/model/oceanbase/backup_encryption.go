@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oceanbase
+
+import "time"
+
+// BackupEncryption is the backup encryption config of the tenant, the secrets are encrypted.
+type BackupEncryption struct {
+	TenantID       int       `gorm:"primaryKey;autoIncrement:false;column:tenant_id;not null"`
+	TenantName     string    `gorm:"column:tenant_name;type:varchar(128);not null"`
+	Password       string    `gorm:"column:password;type:varchar(1024);default:''"`
+	KmsEncryptInfo string    `gorm:"column:kms_encrypt_info;type:text"`
+	UpdateTime     time.Time `gorm:"column:update_time;type:datetime;default:CURRENT_TIMESTAMP;autoUpdateTime"`
+}
+
+func (BackupEncryption) TableName() string {
+	return "backup_encryption"
+}
+
+// BackupSetEncryption records the keys which protect the backup set, the secrets are encrypted.
+type BackupSetEncryption struct {
+	Id             int64     `gorm:"primaryKey;autoIncrement;column:id;type:bigint(20);not null"`
+	TenantID       int       `gorm:"column:tenant_id;not null;uniqueIndex:uk_tenant_backup_set"`
+	BackupSetID    int64     `gorm:"column:backup_set_id;not null;uniqueIndex:uk_tenant_backup_set"`
+	TenantName     string    `gorm:"column:tenant_name;type:varchar(128);not null"`
+	Path           string    `gorm:"column:path;type:varchar(1024);default:'';uniqueIndex:uk_tenant_backup_set"`
+	Password       string    `gorm:"column:password;type:varchar(1024);default:''"`
+	KmsEncryptInfo string    `gorm:"column:kms_encrypt_info;type:text"`
+	CreateTime     time.Time `gorm:"column:create_time;type:datetime;default:CURRENT_TIMESTAMP"`
+}
+
+func (BackupSetEncryption) TableName() string {
+	return "backup_set_encryption"
+}
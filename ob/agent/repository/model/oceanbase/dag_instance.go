@@ -36,6 +36,7 @@ type DagInstance struct {
 	MaintenanceType   int       `gorm:"not null;default:1"`
 	MaintenanceKey    string    `gorm:"type:varchar(128);default:''"`
 	IsFinished        bool      `gorm:"not null"`
+	IsGraph           bool      `gorm:"not null;default:false"`
 	Context           []byte    `gorm:"type:text"`
 	Operator          int       `gorm:"not null"`
 	StartTime         time.Time `gorm:"type:TIMESTAMP(6);default:CURRENT_TIMESTAMP(6)"`
@@ -62,6 +63,7 @@ func (d *DagInstance) ToBO() *bo.DagInstance {
 		MaintenanceType:   MaintenanceType,
 		MaintenanceKey:    d.MaintenanceKey,
 		IsFinished:        d.IsFinished,
+		IsGraph:           d.IsGraph,
 		Context:           d.Context,
 		Operator:          d.Operator,
 		StartTime:         d.StartTime,
@@ -85,6 +87,7 @@ func ConvertDagInstanceBOToDO(d *bo.DagInstance) *DagInstance {
 		MaintenanceType:   d.MaintenanceType,
 		MaintenanceKey:    d.MaintenanceKey,
 		IsFinished:        d.IsFinished,
+		IsGraph:           d.IsGraph,
 		Context:           d.Context,
 		Operator:          d.Operator,
 		StartTime:         d.StartTime,
@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oceanbase
+
+type ServerSysStat struct {
+	Zone       string  `gorm:"column:ZONE"`
+	SvrIp      string  `gorm:"column:SVR_IP"`
+	SvrPort    int     `gorm:"column:SVR_PORT"`
+	TenantId   int64   `gorm:"column:TENANT_ID"`
+	TenantName string  `gorm:"column:TENANT_NAME"`
+	StatId     int64   `gorm:"column:STAT_ID"`
+	Value      float64 `gorm:"column:VALUE"`
+}
+
+type TenantSessionCount struct {
+	Zone        string `gorm:"column:ZONE"`
+	SvrIp       string `gorm:"column:SVR_IP"`
+	SvrPort     int    `gorm:"column:SVR_PORT"`
+	TenantName  string `gorm:"column:TENANT_NAME"`
+	ActiveCount int64  `gorm:"column:ACTIVE_COUNT"`
+	TotalCount  int64  `gorm:"column:TOTAL_COUNT"`
+}
+
+type KvCacheSize struct {
+	Zone       string `gorm:"column:ZONE"`
+	SvrIp      string `gorm:"column:SVR_IP"`
+	SvrPort    int    `gorm:"column:SVR_PORT"`
+	TenantId   int64  `gorm:"column:TENANT_ID"`
+	TenantName string `gorm:"column:TENANT_NAME"`
+	CacheName  string `gorm:"column:CACHE_NAME"`
+	CacheSize  int64  `gorm:"column:CACHE_SIZE"`
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oceanbase
+
+import "github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+
+type DbaRsrcPlan struct {
+	PlanID   int64  `gorm:"column:PLAN_ID"`
+	Plan     string `gorm:"column:PLAN"`
+	Comments string `gorm:"column:COMMENTS"`
+}
+
+type DbaRsrcConsumerGroup struct {
+	ConsumerGroupID int64  `gorm:"column:CONSUMER_GROUP_ID"`
+	ConsumerGroup   string `gorm:"column:CONSUMER_GROUP"`
+	Comments        string `gorm:"column:COMMENTS"`
+}
+
+func (g *DbaRsrcConsumerGroup) ToBO() bo.ConsumerGroup {
+	return bo.ConsumerGroup{
+		ConsumerGroupID: g.ConsumerGroupID,
+		ConsumerGroup:   g.ConsumerGroup,
+		Comment:         g.Comments,
+	}
+}
+
+type DbaRsrcPlanDirective struct {
+	Plan             string `gorm:"column:PLAN"`
+	GroupOrSubplan   string `gorm:"column:GROUP_OR_SUBPLAN"`
+	Comments         string `gorm:"column:COMMENTS"`
+	MgmtP1           int    `gorm:"column:MGMT_P1"`
+	UtilizationLimit int    `gorm:"column:UTILIZATION_LIMIT"`
+	MinIops          int    `gorm:"column:MIN_IOPS"`
+	MaxIops          int    `gorm:"column:MAX_IOPS"`
+	WeightIops       int    `gorm:"column:WEIGHT_IOPS"`
+}
+
+func (d *DbaRsrcPlanDirective) ToBO() bo.ResourcePlanDirective {
+	return bo.ResourcePlanDirective{
+		ConsumerGroup:    d.GroupOrSubplan,
+		Comment:          d.Comments,
+		MgmtP1:           d.MgmtP1,
+		UtilizationLimit: d.UtilizationLimit,
+		MinIops:          d.MinIops,
+		MaxIops:          d.MaxIops,
+		WeightIops:       d.WeightIops,
+	}
+}
+
+type DbaRsrcGroupMapping struct {
+	Attribute     string `gorm:"column:ATTRIBUTE"`
+	Value         string `gorm:"column:VALUE"`
+	ConsumerGroup string `gorm:"column:CONSUMER_GROUP"`
+	Status        string `gorm:"column:STATUS"`
+}
+
+func (m *DbaRsrcGroupMapping) ToBO() bo.ResourceMapping {
+	return bo.ResourceMapping{
+		Attribute:     m.Attribute,
+		Value:         m.Value,
+		ConsumerGroup: m.ConsumerGroup,
+		Status:        m.Status,
+	}
+}
+
+// GvObGroupIoStat is the io usage of a consumer group on an observer.
+type GvObGroupIoStat struct {
+	SvrIp            string `gorm:"column:SVR_IP"`
+	SvrPort          int    `gorm:"column:SVR_PORT"`
+	GroupID          int64  `gorm:"column:GROUP_ID"`
+	GroupName        string `gorm:"column:GROUP_NAME"`
+	Mode             string `gorm:"column:MODE"`
+	MinIops          int64  `gorm:"column:MIN_IOPS"`
+	MaxIops          int64  `gorm:"column:MAX_IOPS"`
+	RealIops         int64  `gorm:"column:REAL_IOPS"`
+	MaxNetBandwidth  int64  `gorm:"column:MAX_NET_BANDWIDTH"`
+	RealNetBandwidth int64  `gorm:"column:REAL_NET_BANDWIDTH"`
+}
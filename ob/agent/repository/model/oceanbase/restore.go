@@ -124,3 +124,46 @@ func (i *CdbObRestoreHistory) ToBO() *bo.CdbObRestoreHistory {
 	}
 	return res
 }
+
+type CdbObRecoverTableJob struct {
+	TenantID          int64  `gorm:"column:TENANT_ID"`
+	JobID             int64  `gorm:"column:JOB_ID"`
+	StartTimestamp    string `gorm:"column:START_TIMESTAMP"`
+	EndTimestamp      string `gorm:"column:END_TIMESTAMP"`
+	Status            string `gorm:"column:STATUS"`
+	AuxTenantName     string `gorm:"column:AUX_TENANT_NAME"`
+	TargetTenantName  string `gorm:"column:TARGET_TENANT_NAME"`
+	ImportAll         string `gorm:"column:IMPORT_ALL"`
+	DbList            string `gorm:"column:DB_LIST"`
+	TableList         string `gorm:"column:TABLE_LIST"`
+	RemapDbList       string `gorm:"column:REMAP_DB_LIST"`
+	RemapTableList    string `gorm:"column:REMAP_TABLE_LIST"`
+	RestoreScn        int64  `gorm:"column:RESTORE_SCN"`
+	RestoreScnDisplay string `gorm:"column:RESTORE_SCN_DISPLAY"`
+	RestoreOption     string `gorm:"column:RESTORE_OPTION"`
+	Result            string `gorm:"column:RESULT"`
+	Comment           string `gorm:"column:COMMENT"`
+	Description       string `gorm:"column:DESCRIPTION"`
+}
+
+func (j *CdbObRecoverTableJob) ToBO() *bo.RecoverTableJob {
+	return &bo.RecoverTableJob{
+		JobID:             j.JobID,
+		StartTimestamp:    j.StartTimestamp,
+		EndTimestamp:      j.EndTimestamp,
+		Status:            j.Status,
+		AuxTenantName:     j.AuxTenantName,
+		TargetTenantName:  j.TargetTenantName,
+		ImportAll:         j.ImportAll,
+		DbList:            j.DbList,
+		TableList:         j.TableList,
+		RemapDbList:       j.RemapDbList,
+		RemapTableList:    j.RemapTableList,
+		RestoreScn:        j.RestoreScn,
+		RestoreScnDisplay: j.RestoreScnDisplay,
+		RestoreOption:     j.RestoreOption,
+		Result:            j.Result,
+		Comment:           j.Comment,
+		Description:       j.Description,
+	}
+}
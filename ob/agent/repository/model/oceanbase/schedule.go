@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oceanbase
+
+import (
+	"time"
+
+	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+)
+
+type ScheduleJob struct {
+	Id          int64      `gorm:"primaryKey;autoIncrement;column:id;type:bigint(20);not null"`
+	Name        string     `gorm:"column:name;type:varchar(128);not null;uniqueIndex"`
+	JobType     string     `gorm:"column:job_type;type:varchar(32);not null"`
+	Cron        string     `gorm:"column:cron;type:varchar(128);not null"`
+	Enabled     bool       `gorm:"column:enabled;not null;default:true"`
+	TenantName  string     `gorm:"column:tenant_name;type:varchar(128);default:''"`
+	BackupMode  string     `gorm:"column:backup_mode;type:varchar(32);default:''"`
+	PlusArchive bool       `gorm:"column:plus_archive;not null;default:false"`
+	Scenario    string     `gorm:"column:scenario;type:varchar(32);default:''"`
+	Description string     `gorm:"column:description;type:varchar(256)"`
+	LastRunTime *time.Time `gorm:"column:last_run_time;type:datetime"`
+	NextRunTime *time.Time `gorm:"column:next_run_time;type:datetime;index"`
+	CreateTime  time.Time  `gorm:"column:create_time;type:datetime;default:CURRENT_TIMESTAMP"`
+	UpdateTime  time.Time  `gorm:"column:update_time;type:datetime;default:CURRENT_TIMESTAMP;autoUpdateTime"`
+}
+
+func (ScheduleJob) TableName() string {
+	return "schedule_job"
+}
+
+func (s *ScheduleJob) ToBO() bo.Schedule {
+	return bo.Schedule{
+		Name:        s.Name,
+		JobType:     s.JobType,
+		Cron:        s.Cron,
+		Enabled:     s.Enabled,
+		TenantName:  s.TenantName,
+		BackupMode:  s.BackupMode,
+		PlusArchive: s.PlusArchive,
+		Scenario:    s.Scenario,
+		Description: s.Description,
+		LastRunTime: s.LastRunTime,
+		NextRunTime: s.NextRunTime,
+		CreateTime:  s.CreateTime,
+		UpdateTime:  s.UpdateTime,
+	}
+}
+
+type ScheduleRun struct {
+	Id          int64     `gorm:"primaryKey;autoIncrement;column:id;type:bigint(20);not null"`
+	JobId       int64     `gorm:"column:job_id;type:bigint(20);not null;index"`
+	JobName     string    `gorm:"column:job_name;type:varchar(128);not null"`
+	TriggerTime time.Time `gorm:"column:trigger_time;type:datetime;not null"`
+	Status      string    `gorm:"column:status;type:varchar(32);not null"`
+	DagId       string    `gorm:"column:dag_id;type:varchar(128);default:''"`
+	Message     string    `gorm:"column:message;type:varchar(1024);default:''"`
+}
+
+func (ScheduleRun) TableName() string {
+	return "schedule_run"
+}
+
+func (r *ScheduleRun) ToBO() bo.ScheduleRun {
+	return bo.ScheduleRun{
+		Id:           r.Id,
+		ScheduleName: r.JobName,
+		TriggerTime:  r.TriggerTime,
+		Status:       r.Status,
+		DagId:        r.DagId,
+		Message:      r.Message,
+	}
+}
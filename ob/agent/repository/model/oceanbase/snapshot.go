@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oceanbase
+
+import (
+	"time"
+
+	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+)
+
+type CdbObTenantSnapshot struct {
+	TenantID     int       `gorm:"column:TENANT_ID"`
+	SnapshotID   int64     `gorm:"column:SNAPSHOT_ID"`
+	SnapshotName string    `gorm:"column:SNAPSHOT_NAME"`
+	Status       string    `gorm:"column:STATUS"`
+	SnapshotScn  int64     `gorm:"column:SNAPSHOT_SCN"`
+	ClogStartScn int64     `gorm:"column:CLOG_START_SCN"`
+	Type         string    `gorm:"column:TYPE"`
+	CreateTime   time.Time `gorm:"column:CREATE_TIME"`
+	DataVersion  string    `gorm:"column:DATA_VERSION"`
+	OwnerJobID   int64     `gorm:"column:OWNER_JOB_ID"`
+}
+
+func (s *CdbObTenantSnapshot) ToBO() *bo.TenantSnapshot {
+	return &bo.TenantSnapshot{
+		SnapshotID:   s.SnapshotID,
+		SnapshotName: s.SnapshotName,
+		Status:       s.Status,
+		SnapshotScn:  s.SnapshotScn,
+		ClogStartScn: s.ClogStartScn,
+		Type:         s.Type,
+		CreateTime:   s.CreateTime,
+		DataVersion:  s.DataVersion,
+	}
+}
+
+type DbaObCloneJob struct {
+	JobID              int64  `gorm:"column:JOB_ID"`
+	SourceTenantName   string `gorm:"column:SOURCE_TENANT_NAME"`
+	CloneTenantName    string `gorm:"column:CLONE_TENANT_NAME"`
+	TenantSnapshotName string `gorm:"column:TENANT_SNAPSHOT_NAME"`
+	ResourcePoolName   string `gorm:"column:RESOURCE_POOL_NAME"`
+	UnitConfigName     string `gorm:"column:UNIT_CONFIG_NAME"`
+	Status             string `gorm:"column:STATUS"`
+	ErrorMessage       string `gorm:"column:ERROR_MESSAGE"`
+}
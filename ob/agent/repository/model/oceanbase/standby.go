@@ -0,0 +1,38 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oceanbase
+
+type DbaObTenantRoleInfo struct {
+	TenantID         int    `gorm:"column:TENANT_ID"`
+	TenantName       string `gorm:"column:TENANT_NAME"`
+	TenantRole       string `gorm:"column:TENANT_ROLE"`
+	Status           string `gorm:"column:STATUS"`
+	SwitchoverStatus string `gorm:"column:SWITCHOVER_STATUS"`
+	SwitchoverEpoch  int64  `gorm:"column:SWITCHOVER_EPOCH"`
+	SyncScn          int64  `gorm:"column:SYNC_SCN"`
+	ReplayableScn    int64  `gorm:"column:REPLAYABLE_SCN"`
+	ReadableScn      int64  `gorm:"column:READABLE_SCN"`
+	RecoveryUntilScn int64  `gorm:"column:RECOVERY_UNTIL_SCN"`
+	LogMode          string `gorm:"column:LOG_MODE"`
+}
+
+type CdbObLogRestoreSource struct {
+	TenantID         int    `gorm:"column:TENANT_ID"`
+	Type             string `gorm:"column:TYPE"`
+	Value            string `gorm:"column:VALUE"` // NOTICE: this field may contain password, don't return it!!!
+	RecoveryUntilScn int64  `gorm:"column:RECOVERY_UNTIL_SCN"`
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oceanbase
+
+import (
+	"strings"
+	"time"
+
+	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+)
+
+type TaskWebhook struct {
+	Id          int64     `gorm:"primaryKey;autoIncrement;column:id;type:bigint(20);not null"`
+	Name        string    `gorm:"column:name;type:varchar(128);not null;uniqueIndex"`
+	Url         string    `gorm:"column:url;type:varchar(1024);not null"`
+	Events      string    `gorm:"column:events;type:varchar(512);default:''"`  // comma separated event types
+	Secret      string    `gorm:"column:secret;type:varchar(1024);default:''"` // encrypted
+	Enabled     bool      `gorm:"column:enabled;not null;default:true"`
+	Description string    `gorm:"column:description;type:varchar(256)"`
+	CreateTime  time.Time `gorm:"column:create_time;type:datetime;default:CURRENT_TIMESTAMP"`
+	UpdateTime  time.Time `gorm:"column:update_time;type:datetime;default:CURRENT_TIMESTAMP;autoUpdateTime"`
+}
+
+func (TaskWebhook) TableName() string {
+	return "task_webhook"
+}
+
+// ToBO converts the webhook without the secret.
+func (w *TaskWebhook) ToBO() bo.TaskWebhook {
+	events := make([]string, 0)
+	if w.Events != "" {
+		events = strings.Split(w.Events, ",")
+	}
+	return bo.TaskWebhook{
+		Name:        w.Name,
+		Url:         w.Url,
+		Events:      events,
+		Enabled:     w.Enabled,
+		Description: w.Description,
+		CreateTime:  w.CreateTime,
+		UpdateTime:  w.UpdateTime,
+	}
+}
@@ -51,3 +51,17 @@ func ConvertDbaObUnitConfigToObUnit(unit *DbaObUnitConfig) *bo.ObUnitConfig {
 		MinIops:      unit.MinIops,
 	}
 }
+
+// ObUnitLocation is the server of the unit, and the server it is migrating from if it is migrating.
+type ObUnitLocation struct {
+	UnitId             int    `gorm:"column:UNIT_ID"`
+	TenantId           int    `gorm:"column:TENANT_ID"`
+	SvrIp              string `gorm:"column:SVR_IP"`
+	SvrPort            int    `gorm:"column:SVR_PORT"`
+	MigrateFromSvrIp   string `gorm:"column:MIGRATE_FROM_SVR_IP"`
+	MigrateFromSvrPort int    `gorm:"column:MIGRATE_FROM_SVR_PORT"`
+}
+
+func (u *ObUnitLocation) IsMigrating() bool {
+	return u.MigrateFromSvrIp != ""
+}
@@ -34,6 +34,7 @@ type DagInstance struct {
 	ExecuterAgentPort int       `gorm:"type:int;not null"`
 	IsMaintenance     bool      `gorm:"not null"`
 	IsFinished        bool      `gorm:"not null"`
+	IsGraph           bool      `gorm:"not null;default:false"`
 	Context           []byte    `gorm:"type:text"`
 	Operator          int       `gorm:"not null"`
 	StartTime         time.Time `gorm:"autoCreateTime"`
@@ -63,6 +64,7 @@ func (d *DagInstance) ToBO() *bo.DagInstance {
 		IsMaintenance:     d.IsMaintenance,
 		MaintenanceType:   MaintenanceType,
 		IsFinished:        d.IsFinished,
+		IsGraph:           d.IsGraph,
 		Context:           d.Context,
 		Operator:          d.Operator,
 		StartTime:         d.StartTime,
@@ -84,6 +86,7 @@ func ConvertDagInstanceBOToDO(d *bo.DagInstance) *DagInstance {
 		ExecuterAgentPort: d.ExecuterAgentPort,
 		IsMaintenance:     d.IsMaintenance,
 		IsFinished:        d.IsFinished,
+		IsGraph:           d.IsGraph,
 		Context:           d.Context,
 		Operator:          d.Operator,
 		StartTime:         d.StartTime,
@@ -31,6 +31,7 @@ type NodeInstance struct {
 	Type              string    `gorm:"type:varchar(128);not null"`
 	State             int       `gorm:"not null"`
 	MaxStage          int       `gorm:"not null"`
+	Upstreams         string    `gorm:"type:varchar(1024);default:''"`
 	ExecuterAgentIp   string    `gorm:"type:varchar(64);not null"`
 	ExecuterAgentPort int       `gorm:"type:int;not null"`
 	Context           []byte    `gorm:"type:text"`
@@ -51,6 +52,7 @@ func (n *NodeInstance) ToBO() *bo.NodeInstance {
 		Type:              n.Type,
 		State:             n.State,
 		MaxStage:          n.MaxStage,
+		Upstreams:         n.Upstreams,
 		ExecuterAgentIp:   n.ExecuterAgentIp,
 		ExecuterAgentPort: n.ExecuterAgentPort,
 		Context:           n.Context,
@@ -72,6 +74,7 @@ func ConvertNodeInstanceBOToDO(n *bo.NodeInstance) *NodeInstance {
 		Type:              n.Type,
 		State:             n.State,
 		MaxStage:          n.MaxStage,
+		Upstreams:         n.Upstreams,
 		ExecuterAgentIp:   n.ExecuterAgentIp,
 		ExecuterAgentPort: n.ExecuterAgentPort,
 		Context:           n.Context,
@@ -55,6 +55,9 @@ func InitOcsAgentRpcRoutes(s *http2.State, r *gin.Engine, isLocalRoute bool) {
 	observer.DELETE("", killObserverHandler)
 	observer.POST("", startObserverHandler)
 
+	metric := v1.Group(constant.URI_METRIC_GROUP)
+	metric.POST(constant.URI_SERIES, selectSeriesHandler)
+
 	maintainer := v1.Group(constant.URI_MAINTAINER)
 	maintainer.GET("", getMaintainerHandler)
 	maintainer.POST(constant.URI_UPDATE, updateAllAgentsHandler)
@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rpc
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/obshell/ob/agent/api/common"
+	metricexecutor "github.com/oceanbase/obshell/ob/agent/executor/metric"
+	model "github.com/oceanbase/obshell/ob/model/metric"
+)
+
+func selectSeriesHandler(c *gin.Context) {
+	var query model.SeriesQuery
+	if err := c.BindJSON(&query); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	series, err := metricexecutor.SelectLocalSeries(&query)
+	common.SendResponse(c, series, err)
+}
@@ -37,6 +37,7 @@ const (
 
 type HttpHeader struct {
 	SessionID    string `json:"session_id"`
+	Account      string `json:"account"` // Account name when logging in with an account password
 	Auth         string
 	Ts           string
 	Token        string
@@ -46,10 +47,18 @@ type HttpHeader struct {
 	Sha256       string
 	ForwardType  int
 	ForwardAgent meta.AgentInfo
+	OriginUser   string `json:"origin_user"` // the user who sent the forwarded request, used by the audit log
+	OriginIp     string `json:"origin_ip"`   // the client ip of the forwarded request, used by the audit log
+}
+
+// ForwardOrigin is the original requester of a forwarded request.
+type ForwardOrigin struct {
+	User     string
+	ClientIp string
 }
 
 func BuildAgentHeader(agentInfo meta.AgentInfoInterface, password string, uri string, isForword bool, keys ...[]byte) map[string]string {
-	auth := buildHeader(agentInfo, password, uri, isForword, keys...)
+	auth := buildHeader(agentInfo, password, uri, isForword, nil, keys...)
 	header := map[string]string{
 		constant.OCS_AGENT_HEADER: auth,
 	}
@@ -57,14 +66,14 @@ func BuildAgentHeader(agentInfo meta.AgentInfoInterface, password string, uri st
 }
 
 func BuildHeader(agentInfo meta.AgentInfoInterface, uri string, isForword bool, keys ...[]byte) map[string]string {
-	auth := buildHeader(agentInfo, meta.OCEANBASE_PWD, uri, isForword, keys...)
+	auth := buildHeader(agentInfo, meta.OCEANBASE_PWD, uri, isForword, nil, keys...)
 	header := map[string]string{
 		constant.OCS_HEADER: auth,
 	}
 	return header
 }
 
-func buildHeader(agentInfo meta.AgentInfoInterface, password string, uri string, isForword bool, keys ...[]byte) string {
+func buildHeader(agentInfo meta.AgentInfoInterface, password string, uri string, isForword bool, origin *ForwardOrigin, keys ...[]byte) string {
 	pk := GetAgentPublicKey(agentInfo)
 	if pk == "" {
 		log.Warnf("no key for agent '%s'", agentInfo.String())
@@ -96,6 +105,10 @@ func buildHeader(agentInfo meta.AgentInfoInterface, password string, uri string,
 		header.ForwardType = ManualForward
 		header.ForwardAgent = meta.OCS_AGENT.GetAgentInfo()
 	}
+	if origin != nil {
+		header.OriginUser = origin.User
+		header.OriginIp = origin.ClientIp
+	}
 
 	mAuth, err := json.Marshal(header)
 	if err != nil {
@@ -119,11 +132,13 @@ func DecryptHeader(ciphertext string) (HttpHeader, error) {
 	return headers, err
 }
 
-func RepackageHeaderForAutoForward(header *HttpHeader, agentInfo meta.AgentInfoInterface) (headers map[string]string, err error) {
+func RepackageHeaderForAutoForward(header *HttpHeader, agentInfo meta.AgentInfoInterface, origin ForwardOrigin) (headers map[string]string, err error) {
 	err = errors.Occur(errors.ErrSecurityAuthenticationUnauthorized)
 
 	header.ForwardType = AutoForward
 	header.ForwardAgent = meta.OCS_AGENT.GetAgentInfo()
+	header.OriginUser = origin.User
+	header.OriginIp = origin.ClientIp
 	// encrypt for master
 	pk := GetAgentPublicKey(agentInfo)
 	if pk == "" {
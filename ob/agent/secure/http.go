@@ -20,6 +20,7 @@ import (
 	"github.com/go-resty/resty/v2"
 
 	"github.com/oceanbase/obshell/ob/agent/config"
+	"github.com/oceanbase/obshell/ob/agent/constant"
 	"github.com/oceanbase/obshell/ob/agent/errors"
 	"github.com/oceanbase/obshell/ob/agent/lib/http"
 	"github.com/oceanbase/obshell/ob/agent/meta"
@@ -146,8 +147,11 @@ func BuildBody(agentInfo meta.AgentInfoInterface, param interface{}) (encryptedB
 	return
 }
 
-func BuildHeaderForForward(agentInfo meta.AgentInfoInterface, uri string, keys ...[]byte) map[string]string {
-	return BuildHeader(agentInfo, uri, true, keys...)
+func BuildHeaderForForward(agentInfo meta.AgentInfoInterface, uri string, origin ForwardOrigin, keys ...[]byte) map[string]string {
+	auth := buildHeader(agentInfo, meta.OCEANBASE_PWD, uri, true, &origin, keys...)
+	return map[string]string{
+		constant.OCS_HEADER: auth,
+	}
 }
 
 func SendRequestWithPassword(agentInfo meta.AgentInfoInterface, uri string, method string, agentPassword string, param interface{}, ret interface{}) error {
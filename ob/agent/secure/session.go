@@ -27,6 +27,10 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+const (
+	sessionKeyAccount = "account"
+)
+
 var (
 	sessionMgr *SessionManager
 )
@@ -228,6 +232,36 @@ func CreateSession() (string, error) {
 	return session.ID, nil
 }
 
+// CreateAccountSession creates a new session bound to the account.
+func CreateAccountSession(accountName string) (string, error) {
+	session, err := sessionMgr.createSession()
+	if err != nil {
+		return "", err
+	}
+	sessionMgr.mutex.Lock()
+	session.Data[sessionKeyAccount] = accountName
+	sessionMgr.mutex.Unlock()
+	return session.ID, nil
+}
+
+// GetSessionAccount returns the account bound to the session.
+// Empty string means the session is created by the root password.
+func GetSessionAccount(sessionID string) string {
+	if sessionMgr == nil {
+		return ""
+	}
+	sessionMgr.mutex.RLock()
+	defer sessionMgr.mutex.RUnlock()
+	session, exists := sessionMgr.sessions[sessionID]
+	if !exists {
+		return ""
+	}
+	if name, ok := session.Data[sessionKeyAccount].(string); ok {
+		return name
+	}
+	return ""
+}
+
 func InvalidateSession(sessionID string) {
 	if sessionMgr == nil {
 		return
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package account
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	oceanbasedb "github.com/oceanbase/obshell/ob/agent/repository/db/oceanbase"
+	obmodel "github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+)
+
+type AccountService struct{}
+
+func (s *AccountService) List() ([]obmodel.AgentAccount, error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ocs instance failed")
+	}
+	var accounts []obmodel.AgentAccount
+	if err := db.Order("id ASC").Find(&accounts).Error; err != nil {
+		return nil, errors.Wrap(err, "list accounts failed")
+	}
+	return accounts, nil
+}
+
+// GetByName returns nil if the account does not exist.
+func (s *AccountService) GetByName(name string) (*obmodel.AgentAccount, error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ocs instance failed")
+	}
+	var account obmodel.AgentAccount
+	err = db.Where("name = ?", name).First(&account).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "get account failed")
+	}
+	return &account, nil
+}
+
+func (s *AccountService) Create(account *obmodel.AgentAccount) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return errors.Wrap(err, "get ocs instance failed")
+	}
+	if err := db.Create(account).Error; err != nil {
+		return errors.Wrap(err, "create account failed")
+	}
+	return nil
+}
+
+func (s *AccountService) Update(account *obmodel.AgentAccount) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return errors.Wrap(err, "get ocs instance failed")
+	}
+	err = db.Model(account).Updates(map[string]interface{}{
+		"role":        account.Role,
+		"tenants":     account.Tenants,
+		"description": account.Description,
+	}).Error
+	if err != nil {
+		return errors.Wrap(err, "update account failed")
+	}
+	return nil
+}
+
+func (s *AccountService) UpdatePassword(name, password string) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return errors.Wrap(err, "get ocs instance failed")
+	}
+	if err := db.Model(&obmodel.AgentAccount{}).Where("name = ?", name).Update("password", password).Error; err != nil {
+		return errors.Wrap(err, "update account password failed")
+	}
+	return nil
+}
+
+func (s *AccountService) Delete(name string) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return errors.Wrap(err, "get ocs instance failed")
+	}
+	if err := db.Where("name = ?", name).Delete(&obmodel.AgentAccount{}).Error; err != nil {
+		return errors.Wrap(err, "delete account failed")
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alarm
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	oceanbasedb "github.com/oceanbase/obshell/ob/agent/repository/db/oceanbase"
+	obmodel "github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+)
+
+type AlarmService struct{}
+
+func (s *AlarmService) ListChannels(channelType, keyword string) ([]obmodel.AlarmChannel, error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ocs instance failed")
+	}
+	query := db.Model(&obmodel.AlarmChannel{})
+	if channelType != "" {
+		query = query.Where("type = ?", channelType)
+	}
+	if keyword != "" {
+		keyword = "%" + keyword + "%"
+		query = query.Where("name LIKE ? OR description LIKE ?", keyword, keyword)
+	}
+	var channels []obmodel.AlarmChannel
+	if err := query.Order("id ASC").Find(&channels).Error; err != nil {
+		return nil, errors.Wrap(err, "list alarm channels failed")
+	}
+	return channels, nil
+}
+
+func (s *AlarmService) ListEnabledChannels() ([]obmodel.AlarmChannel, error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ocs instance failed")
+	}
+	var channels []obmodel.AlarmChannel
+	if err := db.Where("enabled = ?", true).Find(&channels).Error; err != nil {
+		return nil, errors.Wrap(err, "list enabled alarm channels failed")
+	}
+	return channels, nil
+}
+
+func (s *AlarmService) GetChannelByName(name string) (*obmodel.AlarmChannel, error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ocs instance failed")
+	}
+	var channel obmodel.AlarmChannel
+	err = db.Where("name = ?", name).First(&channel).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "get alarm channel failed")
+	}
+	return &channel, nil
+}
+
+// SaveChannel creates the channel if the id is 0, otherwise updates it.
+func (s *AlarmService) SaveChannel(channel *obmodel.AlarmChannel) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return errors.Wrap(err, "get ocs instance failed")
+	}
+	if channel.Id == 0 {
+		return db.Create(channel).Error
+	}
+	return db.Model(channel).Updates(map[string]interface{}{
+		"type":        channel.Type,
+		"description": channel.Description,
+		"enabled":     channel.Enabled,
+		"route":       channel.Route,
+		"retry":       channel.Retry,
+		"config":      channel.Config,
+	}).Error
+}
+
+func (s *AlarmService) DeleteChannel(name string) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return errors.Wrap(err, "get ocs instance failed")
+	}
+	return db.Where("name = ?", name).Delete(&obmodel.AlarmChannel{}).Error
+}
+
+func (s *AlarmService) ListAllChannelsTx(tx *gorm.DB) ([]obmodel.AlarmChannel, error) {
+	if tx == nil {
+		return nil, errors.Occur(errors.ErrCommonIllegalArgument, "tx is required")
+	}
+	var channels []obmodel.AlarmChannel
+	if err := tx.Find(&channels).Error; err != nil {
+		return nil, errors.Wrap(err, "query alarm channels failed")
+	}
+	return channels, nil
+}
+
+func (s *AlarmService) UpdateChannelConfigTx(tx *gorm.DB, id int64, config string) error {
+	if tx == nil {
+		return errors.Occur(errors.ErrCommonIllegalArgument, "tx is required")
+	}
+	return tx.Model(&obmodel.AlarmChannel{}).
+		Where("id = ?", id).
+		Update("config", config).Error
+}
+
+func (s *AlarmService) CreateDelivery(delivery *obmodel.AlarmDelivery) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return errors.Wrap(err, "get ocs instance failed")
+	}
+	return db.Create(delivery).Error
+}
+
+func (s *AlarmService) ListDeliveries(channelName, status string, startTime, endTime time.Time, limit int) ([]obmodel.AlarmDelivery, error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ocs instance failed")
+	}
+	query := db.Model(&obmodel.AlarmDelivery{})
+	if channelName != "" {
+		query = query.Where("channel_name = ?", channelName)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if !startTime.IsZero() {
+		query = query.Where("create_time >= ?", startTime)
+	}
+	if !endTime.IsZero() {
+		query = query.Where("create_time <= ?", endTime)
+	}
+	var deliveries []obmodel.AlarmDelivery
+	if err := query.Order("id DESC").Limit(limit).Find(&deliveries).Error; err != nil {
+		return nil, errors.Wrap(err, "list alarm deliveries failed")
+	}
+	return deliveries, nil
+}
+
+// PurgeDeliveries deletes the delivery history created before the given time.
+func (s *AlarmService) PurgeDeliveries(before time.Time) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return errors.Wrap(err, "get ocs instance failed")
+	}
+	return db.Where("create_time < ?", before).Delete(&obmodel.AlarmDelivery{}).Error
+}
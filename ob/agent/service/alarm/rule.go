@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alarm
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	oceanbasedb "github.com/oceanbase/obshell/ob/agent/repository/db/oceanbase"
+	obmodel "github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+)
+
+func (s *AlarmService) ListStoredRules() ([]obmodel.AlarmRule, error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ocs instance failed")
+	}
+	var rules []obmodel.AlarmRule
+	if err := db.Order("id ASC").Find(&rules).Error; err != nil {
+		return nil, errors.Wrap(err, "list alarm rules failed")
+	}
+	return rules, nil
+}
+
+func (s *AlarmService) GetStoredRuleByName(name string) (*obmodel.AlarmRule, error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ocs instance failed")
+	}
+	var rule obmodel.AlarmRule
+	err = db.Where("name = ?", name).First(&rule).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "get alarm rule failed")
+	}
+	return &rule, nil
+}
+
+// SaveRule creates the rule if the id is 0, otherwise updates it.
+func (s *AlarmService) SaveRule(rule *obmodel.AlarmRule) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return errors.Wrap(err, "get ocs instance failed")
+	}
+	if rule.Id == 0 {
+		return db.Create(rule).Error
+	}
+	return db.Model(rule).Updates(map[string]interface{}{
+		"instance_type": rule.InstanceType,
+		"type":          rule.Type,
+		"template":      rule.Template,
+		"query":         rule.Query,
+		"duration":      rule.Duration,
+		"labels":        rule.Labels,
+		"severity":      rule.Severity,
+		"summary":       rule.Summary,
+		"description":   rule.Description,
+		"enabled":       rule.Enabled,
+	}).Error
+}
+
+func (s *AlarmService) DeleteRule(name string) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return errors.Wrap(err, "get ocs instance failed")
+	}
+	return db.Where("name = ?", name).Delete(&obmodel.AlarmRule{}).Error
+}
@@ -0,0 +1,198 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audit
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/meta"
+	oceanbasedb "github.com/oceanbase/obshell/ob/agent/repository/db/oceanbase"
+	sqlitedb "github.com/oceanbase/obshell/ob/agent/repository/db/sqlite"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+	obmodel "github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+	sqlitemodel "github.com/oceanbase/obshell/ob/agent/repository/model/sqlite"
+)
+
+// AuditService stores the audit logs in the meta tables of OceanBase,
+// or in sqlite before the cluster is bootstrapped.
+type AuditService struct{}
+
+type ListQuery struct {
+	User       string
+	Method     string
+	KeyWord    string // matches the route or uri
+	DagId      string
+	Successful *bool
+	StartTime  *time.Time
+	EndTime    *time.Time
+	Page       int
+	PageSize   int
+}
+
+func (s *AuditService) isLocal() bool {
+	return meta.OCS_AGENT == nil || !meta.OCS_AGENT.IsClusterAgent()
+}
+
+func (s *AuditService) getDbInstance() (*gorm.DB, error) {
+	if s.isLocal() {
+		return sqlitedb.GetSqliteInstance()
+	}
+	return oceanbasedb.GetOcsInstance()
+}
+
+func (s *AuditService) model() interface{} {
+	if s.isLocal() {
+		return &sqlitemodel.AuditLog{}
+	}
+	return &obmodel.AuditLog{}
+}
+
+func (s *AuditService) Create(log *bo.AuditLog) error {
+	db, err := s.getDbInstance()
+	if err != nil {
+		return errors.Wrap(err, "get db instance failed")
+	}
+	if s.isLocal() {
+		err = db.Create(sqlitemodel.ConvertAuditLogBOToDO(log)).Error
+	} else {
+		err = db.Create(obmodel.ConvertAuditLogBOToDO(log)).Error
+	}
+	if err != nil {
+		return errors.Wrap(err, "create audit log failed")
+	}
+	return nil
+}
+
+func (s *AuditService) List(query *ListQuery) ([]bo.AuditLog, int64, error) {
+	db, err := s.getDbInstance()
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "get db instance failed")
+	}
+
+	queryBuilder := db.Model(s.model())
+	if query.User != "" {
+		queryBuilder = queryBuilder.Where("user_name = ?", query.User)
+	}
+	if query.Method != "" {
+		queryBuilder = queryBuilder.Where("method = ?", query.Method)
+	}
+	if query.KeyWord != "" {
+		keyWord := "%" + query.KeyWord + "%"
+		queryBuilder = queryBuilder.Where("route LIKE ? OR uri LIKE ?", keyWord, keyWord)
+	}
+	if query.DagId != "" {
+		queryBuilder = queryBuilder.Where("dag_id = ?", query.DagId)
+	}
+	if query.Successful != nil {
+		queryBuilder = queryBuilder.Where("successful = ?", *query.Successful)
+	}
+	if query.StartTime != nil {
+		queryBuilder = queryBuilder.Where("record_time >= ?", *query.StartTime)
+	}
+	if query.EndTime != nil {
+		queryBuilder = queryBuilder.Where("record_time <= ?", *query.EndTime)
+	}
+
+	var total int64
+	if err := queryBuilder.Count(&total).Error; err != nil {
+		return nil, 0, errors.Wrap(err, "count audit logs failed")
+	}
+
+	queryBuilder = queryBuilder.Order("id DESC")
+	if query.Page > 0 && query.PageSize > 0 {
+		queryBuilder = queryBuilder.Offset((query.Page - 1) * query.PageSize).Limit(query.PageSize)
+	}
+
+	logs := make([]bo.AuditLog, 0)
+	if s.isLocal() {
+		var records []sqlitemodel.AuditLog
+		if err := queryBuilder.Find(&records).Error; err != nil {
+			return nil, 0, errors.Wrap(err, "list audit logs failed")
+		}
+		for i := range records {
+			logs = append(logs, *records[i].ToBO())
+		}
+	} else {
+		var records []obmodel.AuditLog
+		if err := queryBuilder.Find(&records).Error; err != nil {
+			return nil, 0, errors.Wrap(err, "list audit logs failed")
+		}
+		for i := range records {
+			logs = append(logs, *records[i].ToBO())
+		}
+	}
+	return logs, total, nil
+}
+
+// DeleteBefore deletes the audit logs recorded before the time, returns the count of the deleted logs.
+func (s *AuditService) DeleteBefore(t time.Time) (int64, error) {
+	db, err := s.getDbInstance()
+	if err != nil {
+		return 0, errors.Wrap(err, "get db instance failed")
+	}
+	result := db.Where("record_time < ?", t).Delete(s.model())
+	if result.Error != nil {
+		return 0, errors.Wrap(result.Error, "delete audit logs failed")
+	}
+	return result.RowsAffected, nil
+}
+
+// MigrateLocalLogs moves the audit logs recorded in sqlite before the cluster is bootstrapped
+// or taken over into the meta table, returns the count of the migrated logs.
+// A failure between the insert and the delete may duplicate a batch, which is preferred to losing it.
+func (s *AuditService) MigrateLocalLogs(batchSize int) (int64, error) {
+	if s.isLocal() {
+		return 0, nil
+	}
+	sqliteDb, err := sqlitedb.GetSqliteInstance()
+	if err != nil {
+		return 0, errors.Wrap(err, "get sqlite instance failed")
+	}
+	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return 0, errors.Wrap(err, "get oceanbase instance failed")
+	}
+
+	var count int64
+	for {
+		var records []sqlitemodel.AuditLog
+		if err := sqliteDb.Order("id").Limit(batchSize).Find(&records).Error; err != nil {
+			return count, errors.Wrap(err, "list local audit logs failed")
+		}
+		if len(records) == 0 {
+			return count, nil
+		}
+		ids := make([]int64, 0, len(records))
+		obRecords := make([]*obmodel.AuditLog, 0, len(records))
+		for i := range records {
+			ids = append(ids, records[i].Id)
+			obRecord := obmodel.ConvertAuditLogBOToDO(records[i].ToBO())
+			obRecord.Id = 0
+			obRecords = append(obRecords, obRecord)
+		}
+		if err := oceanbaseDb.Create(obRecords).Error; err != nil {
+			return count, errors.Wrap(err, "migrate audit logs failed")
+		}
+		if err := sqliteDb.Delete(&sqlitemodel.AuditLog{}, ids).Error; err != nil {
+			return count, errors.Wrap(err, "delete migrated audit logs failed")
+		}
+		count += int64(len(records))
+	}
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metric
+
+import (
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/meta"
+	oceanbasedb "github.com/oceanbase/obshell/ob/agent/repository/db/oceanbase"
+	obmodel "github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+)
+
+type MetricService struct{}
+
+// filterServer appends the condition of the server to the where clause if the server is specified.
+func filterServer(where string, alias string, server *meta.ObserverSvrInfo, args []interface{}) (string, []interface{}) {
+	if server != nil {
+		where += " AND " + alias + ".SVR_IP = ? AND " + alias + ".SVR_PORT = ?"
+		args = append(args, server.GetIp(), server.GetPort())
+	}
+	return where, args
+}
+
+// GetSysStats returns the statistics of all tenants filtered by stat ids,
+// only the ones on the server will be returned if it is specified.
+func (s *MetricService) GetSysStats(statIds []int64, server *meta.ObserverSvrInfo) ([]obmodel.ServerSysStat, error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ob instance failed")
+	}
+	var stats []obmodel.ServerSysStat
+	if len(statIds) == 0 {
+		return stats, nil
+	}
+	sql := "SELECT s.ZONE, st.SVR_IP, st.SVR_PORT, st.CON_ID AS TENANT_ID, t.TENANT_NAME, st.STAT_ID, st.VALUE " +
+		"FROM oceanbase.GV$SYSSTAT st " +
+		"JOIN oceanbase.DBA_OB_TENANTS t ON st.CON_ID = t.TENANT_ID " +
+		"JOIN oceanbase.DBA_OB_SERVERS s ON st.SVR_IP = s.SVR_IP AND st.SVR_PORT = s.SVR_PORT " +
+		"WHERE st.STAT_ID IN ?"
+	sql, args := filterServer(sql, "st", server, []interface{}{statIds})
+	if err := db.Raw(sql, args...).Scan(&stats).Error; err != nil {
+		return nil, errors.Wrap(err, "query sysstat failed")
+	}
+	return stats, nil
+}
+
+// GetTenantSessionCounts returns the active and total session count of each tenant on each server,
+// only the ones on the server will be returned if it is specified.
+func (s *MetricService) GetTenantSessionCounts(sleepCommand string, server *meta.ObserverSvrInfo) ([]obmodel.TenantSessionCount, error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ob instance failed")
+	}
+	var counts []obmodel.TenantSessionCount
+	sql := "SELECT s.ZONE, p.SVR_IP, p.SVR_PORT, p.TENANT AS TENANT_NAME, " +
+		"SUM(CASE WHEN p.COMMAND <> ? THEN 1 ELSE 0 END) AS ACTIVE_COUNT, COUNT(*) AS TOTAL_COUNT " +
+		"FROM oceanbase.GV$OB_PROCESSLIST p " +
+		"JOIN oceanbase.DBA_OB_SERVERS s ON p.SVR_IP = s.SVR_IP AND p.SVR_PORT = s.SVR_PORT " +
+		"WHERE 1 = 1"
+	sql, args := filterServer(sql, "p", server, []interface{}{sleepCommand})
+	sql += " GROUP BY s.ZONE, p.SVR_IP, p.SVR_PORT, p.TENANT"
+	if err := db.Raw(sql, args...).Scan(&counts).Error; err != nil {
+		return nil, errors.Wrap(err, "query session count failed")
+	}
+	return counts, nil
+}
+
+// GetKvCacheSizes returns the size of each kv cache of each tenant on each server,
+// only the ones on the server will be returned if it is specified.
+func (s *MetricService) GetKvCacheSizes(server *meta.ObserverSvrInfo) ([]obmodel.KvCacheSize, error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ob instance failed")
+	}
+	var sizes []obmodel.KvCacheSize
+	sql := "SELECT s.ZONE, c.SVR_IP, c.SVR_PORT, c.TENANT_ID, t.TENANT_NAME, c.CACHE_NAME, c.CACHE_SIZE " +
+		"FROM oceanbase.GV$OB_KVCACHE c " +
+		"JOIN oceanbase.DBA_OB_TENANTS t ON c.TENANT_ID = t.TENANT_ID " +
+		"JOIN oceanbase.DBA_OB_SERVERS s ON c.SVR_IP = s.SVR_IP AND c.SVR_PORT = s.SVR_PORT " +
+		"WHERE 1 = 1"
+	sql, args := filterServer(sql, "c", server, nil)
+	if err := db.Raw(sql, args...).Scan(&sizes).Error; err != nil {
+		return nil, errors.Wrap(err, "query kv cache size failed")
+	}
+	return sizes, nil
+}
+
+// GetServerCapacities returns the resource capacity and assignment of all servers,
+// only the one of the server will be returned if it is specified.
+func (s *MetricService) GetServerCapacities(server *meta.ObserverSvrInfo) ([]obmodel.ObServerCapacity, error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ob instance failed")
+	}
+	var capacities []obmodel.ObServerCapacity
+	query := db.Model(&obmodel.ObServerCapacity{})
+	if server != nil {
+		query = query.Where("SVR_IP = ? AND SVR_PORT = ?", server.GetIp(), server.GetPort())
+	}
+	if err := query.Find(&capacities).Error; err != nil {
+		return nil, errors.Wrap(err, "query server capacity failed")
+	}
+	return capacities, nil
+}
+
+// GetServerParameters returns the parameters of the server by names.
+func (s *MetricService) GetServerParameters(svrIp string, svrPort int, names []string) (map[string]string, error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ob instance failed")
+	}
+	var params []struct {
+		Name  string `gorm:"column:NAME"`
+		Value string `gorm:"column:VALUE"`
+	}
+	sql := "SELECT NAME, VALUE FROM oceanbase.GV$OB_PARAMETERS WHERE SVR_IP = ? AND SVR_PORT = ? AND NAME IN ?"
+	if err := db.Raw(sql, svrIp, svrPort, names).Scan(&params).Error; err != nil {
+		return nil, errors.Wrap(err, "query server parameters failed")
+	}
+	res := make(map[string]string, len(params))
+	for _, param := range params {
+		res[param.Name] = param.Value
+	}
+	return res, nil
+}
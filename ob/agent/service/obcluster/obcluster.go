@@ -1187,6 +1187,45 @@ func (ObclusterService *ObclusterService) IsLsMultiPaxosAliveAfterStopZone(lsId
 	}
 }
 
+// GetUnitLocationsOfServer returns the units on the server or migrating from the server.
+func (*ObclusterService) GetUnitLocationsOfServer(svrInfo meta.ObserverSvrInfo) (units []oceanbase.ObUnitLocation, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Table(DBA_OB_UNITS).
+		Where("(SVR_IP = ? AND SVR_PORT = ?) OR (MIGRATE_FROM_SVR_IP = ? AND MIGRATE_FROM_SVR_PORT = ?)", svrInfo.GetIp(), svrInfo.GetPort(), svrInfo.GetIp(), svrInfo.GetPort()).
+		Scan(&units).Error
+	return
+}
+
+func (*ObclusterService) GetUnitLocations(unitIds []int) (units []oceanbase.ObUnitLocation, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Table(DBA_OB_UNITS).Where("UNIT_ID IN ?", unitIds).Scan(&units).Error
+	return
+}
+
+func (*ObclusterService) MigrateUnit(unitId int, destination meta.ObserverSvrInfo) error {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	alterSql := fmt.Sprintf("ALTER SYSTEM MIGRATE UNIT = %d DESTINATION = '%s'", unitId, destination.String())
+	return oceanbaseDb.Exec(alterSql).Error
+}
+
+func (*ObclusterService) CancelMigrateUnit(unitId int) error {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	alterSql := fmt.Sprintf("ALTER SYSTEM CANCEL MIGRATE UNIT %d", unitId)
+	return oceanbaseDb.Exec(alterSql).Error
+}
+
 func (*ObclusterService) HasUnitInZone(zone string) (exist bool, err error) {
 	oceanbaseDb, err := oceanbasedb.GetInstance()
 	if err != nil {
@@ -0,0 +1,191 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedule
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	oceanbasedb "github.com/oceanbase/obshell/ob/agent/repository/db/oceanbase"
+	obmodel "github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+)
+
+type ScheduleService struct{}
+
+func (s *ScheduleService) List() ([]obmodel.ScheduleJob, error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ocs instance failed")
+	}
+	var jobs []obmodel.ScheduleJob
+	if err := db.Order("id ASC").Find(&jobs).Error; err != nil {
+		return nil, errors.Wrap(err, "list schedules failed")
+	}
+	return jobs, nil
+}
+
+// ListDue returns the enabled schedules whose next run time is not after now.
+func (s *ScheduleService) ListDue(now time.Time) ([]obmodel.ScheduleJob, error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ocs instance failed")
+	}
+	var jobs []obmodel.ScheduleJob
+	if err := db.Where("enabled = ? AND next_run_time <= ?", true, now).Order("next_run_time ASC").Find(&jobs).Error; err != nil {
+		return nil, errors.Wrap(err, "list due schedules failed")
+	}
+	return jobs, nil
+}
+
+// GetByName returns nil if the schedule does not exist.
+func (s *ScheduleService) GetByName(name string) (*obmodel.ScheduleJob, error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ocs instance failed")
+	}
+	var job obmodel.ScheduleJob
+	err = db.Where("name = ?", name).First(&job).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "get schedule failed")
+	}
+	return &job, nil
+}
+
+func (s *ScheduleService) Create(job *obmodel.ScheduleJob) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return errors.Wrap(err, "get ocs instance failed")
+	}
+	if err := db.Create(job).Error; err != nil {
+		return errors.Wrap(err, "create schedule failed")
+	}
+	return nil
+}
+
+func (s *ScheduleService) Update(job *obmodel.ScheduleJob) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return errors.Wrap(err, "get ocs instance failed")
+	}
+	err = db.Model(job).Updates(map[string]interface{}{
+		"cron":          job.Cron,
+		"enabled":       job.Enabled,
+		"tenant_name":   job.TenantName,
+		"backup_mode":   job.BackupMode,
+		"plus_archive":  job.PlusArchive,
+		"scenario":      job.Scenario,
+		"description":   job.Description,
+		"next_run_time": job.NextRunTime,
+	}).Error
+	if err != nil {
+		return errors.Wrap(err, "update schedule failed")
+	}
+	return nil
+}
+
+// Claim moves the next run time of the schedule forward only if it has not been changed since it was read,
+// so that a run is never triggered twice even if the maintainer changes meanwhile.
+func (s *ScheduleService) Claim(job *obmodel.ScheduleJob, runTime time.Time, nextRunTime *time.Time) (bool, error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return false, errors.Wrap(err, "get ocs instance failed")
+	}
+	resp := db.Model(&obmodel.ScheduleJob{}).
+		Where("id = ? AND next_run_time = ?", job.Id, job.NextRunTime).
+		Updates(map[string]interface{}{
+			"last_run_time": runTime,
+			"next_run_time": nextRunTime,
+		})
+	if resp.Error != nil {
+		return false, errors.Wrap(resp.Error, "claim schedule failed")
+	}
+	return resp.RowsAffected == 1, nil
+}
+
+func (s *ScheduleService) Delete(job *obmodel.ScheduleJob) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return errors.Wrap(err, "get ocs instance failed")
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("job_id = ?", job.Id).Delete(&obmodel.ScheduleRun{}).Error; err != nil {
+			return errors.Wrap(err, "delete schedule runs failed")
+		}
+		if err := tx.Delete(job).Error; err != nil {
+			return errors.Wrap(err, "delete schedule failed")
+		}
+		return nil
+	})
+}
+
+// CreateRun records the run and only keeps the latest historyLimit runs of the schedule.
+func (s *ScheduleService) CreateRun(run *obmodel.ScheduleRun, historyLimit int) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return errors.Wrap(err, "get ocs instance failed")
+	}
+	if err := db.Create(run).Error; err != nil {
+		return errors.Wrap(err, "create schedule run failed")
+	}
+
+	var ids []int64
+	err = db.Model(&obmodel.ScheduleRun{}).Where("job_id = ?", run.JobId).
+		Order("id DESC").Offset(historyLimit-1).Limit(1).Pluck("id", &ids).Error
+	if err != nil {
+		return errors.Wrap(err, "get expired schedule runs failed")
+	}
+	if len(ids) > 0 {
+		if err := db.Where("job_id = ? AND id < ?", run.JobId, ids[0]).Delete(&obmodel.ScheduleRun{}).Error; err != nil {
+			return errors.Wrap(err, "delete expired schedule runs failed")
+		}
+	}
+	return nil
+}
+
+func (s *ScheduleService) ListRuns(jobId int64, limit int) ([]obmodel.ScheduleRun, error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ocs instance failed")
+	}
+	var runs []obmodel.ScheduleRun
+	if err := db.Where("job_id = ?", jobId).Order("id DESC").Limit(limit).Find(&runs).Error; err != nil {
+		return nil, errors.Wrap(err, "list schedule runs failed")
+	}
+	return runs, nil
+}
+
+// GetLastRunByStatus returns nil if the schedule has no run in the status.
+func (s *ScheduleService) GetLastRunByStatus(jobId int64, status string) (*obmodel.ScheduleRun, error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ocs instance failed")
+	}
+	var run obmodel.ScheduleRun
+	err = db.Where("job_id = ? AND status = ?", jobId, status).Order("id DESC").First(&run).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "get last schedule run failed")
+	}
+	return &run, nil
+}
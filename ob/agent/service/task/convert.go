@@ -61,7 +61,9 @@ func (s *taskService) convertDagInstance(bo *bo.DagInstance) (*task.Dag, error)
 	}
 
 	maintenance := task.NewMaintenance(bo.MaintenanceType, bo.MaintenanceKey)
-	return task.NewDag(bo.Id, bo.Name, bo.Type, bo.State, bo.Stage, bo.MaxStage, bo.Operator, maintenance, ctx, s.isLocal, bo.StartTime, bo.EndTime), nil
+	dag := task.NewDag(bo.Id, bo.Name, bo.Type, bo.State, bo.Stage, bo.MaxStage, bo.Operator, maintenance, ctx, s.isLocal, bo.StartTime, bo.EndTime)
+	dag.SetGraph(bo.IsGraph)
+	return dag, nil
 }
 
 // convertNodeInstance converts NodeInstance to task.TaskNode.
@@ -71,7 +73,13 @@ func (s *taskService) convertNodeInstance(bo *bo.NodeInstance) (*task.Node, erro
 		return nil, err
 	}
 
-	return task.NewNodeWithId(bo.Id, bo.Name, int(bo.DagId), bo.Type, bo.State, bo.Operator, bo.StructName, ctx, s.isLocal, bo.StartTime, bo.EndTime), nil
+	upstreamStages, err := parseUpstreamStages(bo.Upstreams)
+	if err != nil {
+		return nil, err
+	}
+	node := task.NewNodeWithId(bo.Id, bo.Name, int(bo.DagId), bo.Type, bo.State, bo.Operator, bo.StructName, ctx, s.isLocal, bo.StartTime, bo.EndTime)
+	node.SetUpstreamStages(upstreamStages)
+	return node, nil
 }
 
 // convertSubTaskInstance convert SubTaskInstance to task.ExecutableTask.
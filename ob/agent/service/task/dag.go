@@ -21,6 +21,7 @@ import (
 
 	"gorm.io/gorm"
 
+	"github.com/oceanbase/obshell/ob/agent/engine/event"
 	"github.com/oceanbase/obshell/ob/agent/engine/task"
 	"github.com/oceanbase/obshell/ob/agent/errors"
 	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
@@ -248,6 +249,7 @@ func (s *taskService) CreateDagInstanceByTemplate(template *task.Template, ctx *
 	if err != nil {
 		return nil, errors.WrapRetain(errors.ErrTaskCreateFailed, err, template.Name)
 	}
+	s.publishDagEvent(event.DAG_CREATED, dag, task.READY)
 	return dag, nil
 }
 
@@ -276,6 +278,7 @@ func (s *taskService) newDagInstanceBO(template *task.Template, ctx *task.TaskCo
 		IsMaintenance:   template.IsMaintenance(),
 		MaintenanceType: template.GetMaintenanceType(),
 		MaintenanceKey:  template.GetMaintenanceKey(),
+		IsGraph:         template.IsGraph(),
 		Context:         []byte(ctxJsonStr),
 	}, nil
 }
@@ -289,19 +292,33 @@ func (s *taskService) SetDagRollback(dag *task.Dag) error {
 }
 
 func (s *taskService) SetDagRetryAndReady(dag *task.Dag) error {
+	if dag.IsGraph() {
+		nodes, err := s.getGraphNodesCanRetry(dag)
+		if err != nil {
+			return err
+		}
+		return s.txForRetryAndReadyDag(dag, nodes)
+	}
 	node, err := s.getNodeCanRetry(dag)
 	if err != nil {
 		return err
 	}
-	return s.txForRetryAndReadyDag(dag, node)
+	return s.txForRetryAndReadyDag(dag, []*task.Node{node})
 }
 
 func (s *taskService) CancelDag(dag *task.Dag) error {
+	if dag.IsGraph() {
+		nodes, err := s.getGraphNodesCanCancel(dag)
+		if err != nil {
+			return err
+		}
+		return s.txForCancelDag(dag, nodes)
+	}
 	node, err := s.getNodeCanCancel(dag)
 	if err != nil {
 		return err
 	}
-	return s.txForCancelDag(dag, node)
+	return s.txForCancelDag(dag, []*task.Node{node})
 }
 
 func (s *taskService) PassDag(dag *task.Dag) error {
@@ -309,7 +326,11 @@ func (s *taskService) PassDag(dag *task.Dag) error {
 	if err != nil {
 		return err
 	}
-	return s.txForPassDag(dag, nodes)
+	if err := s.txForPassDag(dag, nodes); err != nil {
+		return err
+	}
+	s.publishDagEvent(event.DAG_SUCCEEDED, dag, task.SUCCEED)
+	return nil
 }
 
 func (s *taskService) getNodesCanRollback(dag *task.Dag) ([]*task.Node, error) {
@@ -321,6 +342,9 @@ func (s *taskService) getNodesCanRollback(dag *task.Dag) ([]*task.Node, error) {
 	if err != nil {
 		return nil, err
 	}
+	if dag.IsGraph() {
+		return s.getGraphNodesCanRollback(nodes)
+	}
 
 	var _idx int
 	for idx, node := range nodes {
@@ -383,6 +407,9 @@ func (s *taskService) getNodesCanPass(dag *task.Dag) ([]*task.Node, error) {
 	if err != nil {
 		return nil, err
 	}
+	if dag.IsGraph() {
+		return s.getGraphNodesCanPass(nodes)
+	}
 
 	idx := len(nodes)
 	for _idx, node := range nodes {
@@ -425,8 +452,11 @@ func (s *taskService) txForRollbackDag(dag *task.Dag, rollbackNodes []*task.Node
 		return err
 	}
 	return db.Transaction(func(tx *gorm.DB) error {
-		lastNode := rollbackNodes[len(rollbackNodes)-1]
-		if dag.IsMaintenance() && lastNode.GetContext().GetParam(task.FAILURE_EXIT_MAINTENANCE) != nil {
+		exitNodes := rollbackNodes[len(rollbackNodes)-1:]
+		if dag.IsGraph() {
+			exitNodes = getFailedNodes(rollbackNodes)
+		}
+		if dag.IsMaintenance() && hasFailureExitMaintenance(exitNodes) {
 			if err := s.StartMaintenance(tx, dag); err != nil {
 				return err
 			}
@@ -453,13 +483,13 @@ func (s *taskService) txForRollbackDag(dag *task.Dag, rollbackNodes []*task.Node
 	})
 }
 
-func (s *taskService) txForRetryAndReadyDag(dag *task.Dag, node *task.Node) error {
+func (s *taskService) txForRetryAndReadyDag(dag *task.Dag, nodes []*task.Node) error {
 	db, err := s.getDbInstance()
 	if err != nil {
 		return err
 	}
 	return db.Transaction(func(tx *gorm.DB) error {
-		if dag.IsMaintenance() && node.GetContext().GetParam(task.FAILURE_EXIT_MAINTENANCE) != nil {
+		if dag.IsMaintenance() && hasFailureExitMaintenance(nodes) {
 			if err := s.StartMaintenance(tx, dag); err != nil {
 				return err
 			}
@@ -471,8 +501,10 @@ func (s *taskService) txForRetryAndReadyDag(dag *task.Dag, node *task.Node) erro
 		if err := s.updateDagOperator(tx, dag, task.RUN); err != nil {
 			return errors.Wrap(err, "failed to rerun dag")
 		}
-		if err := s.updateNodeOperator(tx, node, task.RETRY); err != nil {
-			return errors.Wrap(err, "failed to retry node")
+		for _, node := range nodes {
+			if err := s.updateNodeOperator(tx, node, task.RETRY); err != nil {
+				return errors.Wrap(err, "failed to retry node")
+			}
 		}
 
 		return nil
@@ -480,7 +512,7 @@ func (s *taskService) txForRetryAndReadyDag(dag *task.Dag, node *task.Node) erro
 
 }
 
-func (s *taskService) txForCancelDag(dag *task.Dag, node *task.Node) error {
+func (s *taskService) txForCancelDag(dag *task.Dag, nodes []*task.Node) error {
 	db, err := s.getDbInstance()
 	if err != nil {
 		return err
@@ -489,8 +521,10 @@ func (s *taskService) txForCancelDag(dag *task.Dag, node *task.Node) error {
 		if err := s.updateDagOperator(tx, dag, task.CANCEL); err != nil {
 			return errors.Wrap(err, "failed to cancel dag")
 		}
-		if err := s.updateNodeOperator(tx, node, task.CANCEL); err != nil {
-			return errors.Wrap(err, "failed to cancel node")
+		for _, node := range nodes {
+			if err := s.updateNodeOperator(tx, node, task.CANCEL); err != nil {
+				return errors.Wrap(err, "failed to cancel node")
+			}
 		}
 		return nil
 	})
@@ -539,6 +573,7 @@ func (s *taskService) StartDag(dag *task.Dag) error {
 	if err := s.updateDagState(db, dag, task.RUNNING); err != nil {
 		return err
 	}
+	s.publishDagEvent(event.DAG_RUNNING, dag, task.RUNNING)
 	return nil
 }
 
@@ -567,7 +602,7 @@ func (s *taskService) FinishDagAsFailed(dag *task.Dag) error {
 	if err != nil {
 		return err
 	}
-	return db.Transaction(func(tx *gorm.DB) error {
+	err = db.Transaction(func(tx *gorm.DB) error {
 		if err := s.updateDagState(tx, dag, task.FAILED); err != nil {
 			return err
 		}
@@ -582,6 +617,15 @@ func (s *taskService) FinishDagAsFailed(dag *task.Dag) error {
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	if dag.IsCancel() {
+		s.publishDagEvent(event.DAG_CANCELLED, dag, task.FAILED)
+	} else {
+		s.publishDagEvent(event.DAG_FAILED, dag, task.FAILED)
+	}
+	return nil
 }
 
 func (s *taskService) FinishDagAsSucceed(dag *task.Dag) error {
@@ -589,7 +633,7 @@ func (s *taskService) FinishDagAsSucceed(dag *task.Dag) error {
 	if err != nil {
 		return err
 	}
-	return db.Transaction(func(tx *gorm.DB) error {
+	err = db.Transaction(func(tx *gorm.DB) error {
 		if err := s.updateDagState(tx, dag, task.SUCCEED); err != nil {
 			return err
 		}
@@ -598,6 +642,15 @@ func (s *taskService) FinishDagAsSucceed(dag *task.Dag) error {
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	if dag.IsRollback() {
+		s.publishDagEvent(event.DAG_ROLLED_BACK, dag, task.SUCCEED)
+	} else {
+		s.publishDagEvent(event.DAG_SUCCEEDED, dag, task.SUCCEED)
+	}
+	return nil
 }
 
 func (s *taskService) updateDagOperator(tx *gorm.DB, dag *task.Dag, operator int) error {
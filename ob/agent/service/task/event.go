@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package task
+
+import (
+	"github.com/oceanbase/obshell/ob/agent/engine/event"
+	"github.com/oceanbase/obshell/ob/agent/engine/task"
+	"github.com/oceanbase/obshell/ob/agent/meta"
+)
+
+func (s *taskService) publishDagEvent(eventType string, dag *task.Dag, state int) {
+	evt := &event.Event{
+		Type:     eventType,
+		DagId:    task.ConvertToGenericID(dag, dag.GetDagType()),
+		DagName:  dag.GetName(),
+		DagType:  dag.GetDagType(),
+		Stage:    dag.GetStage(),
+		MaxStage: dag.GetMaxStage(),
+		State:    task.STATE_MAP[state],
+		Operator: task.OPERATOR_MAP[dag.GetOperator()],
+	}
+	if meta.OCS_AGENT != nil {
+		evt.Agent = meta.OCS_AGENT.String()
+	}
+	event.Publish(evt)
+}
+
+func (s *taskService) publishSubTaskEvent(eventType string, subtask task.ExecutableTask, state int) {
+	evt := &event.Event{
+		Type:     eventType,
+		TaskName: subtask.GetName(),
+		State:    task.STATE_MAP[state],
+		Operator: task.OPERATOR_MAP[subtask.GetOperator()],
+		Agent:    subtask.GetExecuteAgent().String(),
+	}
+	// The dag of the remote sub task executed locally is not stored in this agent.
+	if dag, err := s.GetDagBySubTaskId(subtask.GetID()); err == nil {
+		evt.DagId = task.ConvertToGenericID(dag, dag.GetDagType())
+		evt.DagName = dag.GetName()
+		evt.DagType = dag.GetDagType()
+		evt.Stage = dag.GetStage()
+		evt.MaxStage = dag.GetMaxStage()
+		evt.TaskId = task.ConvertToGenericID(subtask, dag.GetDagType())
+	} else {
+		evt.TaskId = task.ConvertToGenericID(subtask, "")
+	}
+	event.Publish(evt)
+}
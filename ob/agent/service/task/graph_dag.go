@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package task
+
+import (
+	"github.com/oceanbase/obshell/ob/agent/engine/task"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+)
+
+// getGraphNodesCanRollback returns all the finished nodes of a graph dag which have not been rolled back,
+// so that each branch could be rolled back independently.
+func (s *taskService) getGraphNodesCanRollback(nodes []*task.Node) ([]*task.Node, error) {
+	rollbackNodes := make([]*task.Node, 0)
+	hasFailed := false
+	for _, node := range nodes {
+		if !node.IsFinished() || (node.IsRollback() && node.IsSuccess()) {
+			continue
+		}
+		if _, err := s.GetSubTasks(node); err != nil {
+			return nil, err
+		}
+		if !node.CanRollback() {
+			return nil, errors.Occur(errors.ErrTaskDagOperatorRollbackNotAllowed, node.GetName())
+		}
+		if node.IsFail() {
+			hasFailed = true
+		}
+		rollbackNodes = append(rollbackNodes, node)
+	}
+	if !hasFailed {
+		return nil, errors.Occur(errors.ErrCommonUnexpected, "failed to set dag rollback: no node failed")
+	}
+	return rollbackNodes, nil
+}
+
+// getGraphNodesCanRetry returns all the failed nodes of a graph dag.
+func (s *taskService) getGraphNodesCanRetry(dag *task.Dag) ([]*task.Node, error) {
+	if !dag.IsFail() {
+		return nil, errors.Occur(errors.ErrTaskDagOperatorRetryNotFailedDag)
+	}
+	nodes, err := s.GetNodes(dag)
+	if err != nil {
+		return nil, err
+	}
+	failedNodes := getFailedNodes(nodes)
+	if len(failedNodes) == 0 {
+		return nil, errors.Occur(errors.ErrCommonUnexpected, "failed to retry dag: no node failed")
+	}
+	for _, node := range failedNodes {
+		if _, err := s.GetSubTasks(node); err != nil {
+			return nil, err
+		}
+		if !node.CanRetry() {
+			return nil, errors.Occur(errors.ErrTaskDagOperatorRetryNotAllowed, node.GetName())
+		}
+	}
+	return failedNodes, nil
+}
+
+// getGraphNodesCanCancel returns all the running nodes of a graph dag.
+// If no node is running, the first unfinished node will be canceled.
+func (s *taskService) getGraphNodesCanCancel(dag *task.Dag) ([]*task.Node, error) {
+	if dag.IsFinished() {
+		return nil, errors.Occur(errors.ErrTaskDagOperatorCancelFinishedDag)
+	}
+	nodes, err := s.GetNodes(dag)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelNodes := make([]*task.Node, 0)
+	var firstUnfinished *task.Node
+	for _, node := range nodes {
+		if node.IsFinished() {
+			continue
+		}
+		if firstUnfinished == nil {
+			firstUnfinished = node
+		}
+		if node.IsRunning() {
+			cancelNodes = append(cancelNodes, node)
+		}
+	}
+	if len(cancelNodes) == 0 && firstUnfinished != nil {
+		cancelNodes = append(cancelNodes, firstUnfinished)
+	}
+	if len(cancelNodes) == 0 {
+		return nil, errors.Occur(errors.ErrCommonUnexpected, "failed to cancel dag: no node found")
+	}
+	for _, node := range cancelNodes {
+		if _, err := s.GetSubTasks(node); err != nil {
+			return nil, err
+		}
+		if !node.CanCancel() {
+			return nil, errors.Occur(errors.ErrTaskDagOperatorCancelNotAllowed, node.GetName())
+		}
+	}
+	return cancelNodes, nil
+}
+
+// getGraphNodesCanPass returns all the nodes of a graph dag which have not succeeded.
+func (s *taskService) getGraphNodesCanPass(nodes []*task.Node) ([]*task.Node, error) {
+	passNodes := make([]*task.Node, 0)
+	for _, node := range nodes {
+		if node.IsSuccess() {
+			continue
+		}
+		if _, err := s.GetSubTasks(node); err != nil {
+			return nil, err
+		}
+		if !node.CanPass() {
+			return nil, errors.Occur(errors.ErrTaskDagOperatorPassNotAllowed, node.GetName())
+		}
+		passNodes = append(passNodes, node)
+	}
+	return passNodes, nil
+}
+
+func getFailedNodes(nodes []*task.Node) []*task.Node {
+	failedNodes := make([]*task.Node, 0)
+	for _, node := range nodes {
+		if node.IsFail() {
+			failedNodes = append(failedNodes, node)
+		}
+	}
+	return failedNodes
+}
+
+func hasFailureExitMaintenance(nodes []*task.Node) bool {
+	for _, node := range nodes {
+		if node.GetContext().GetParam(task.FAILURE_EXIT_MAINTENANCE) != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -17,6 +17,9 @@
 package task
 
 import (
+	"strconv"
+	"strings"
+
 	"gorm.io/gorm"
 
 	"github.com/oceanbase/obshell/ob/agent/engine/task"
@@ -29,6 +32,10 @@ import (
 func (s *taskService) newNodes(template *task.Template, ctx *task.TaskContext) ([]*bo.NodeInstance, error) {
 	nodes := template.GetNodes()
 	nodeInstancesBO := make([]*bo.NodeInstance, 0, len(nodes))
+	stages := make(map[*task.Node]int, len(nodes))
+	for idx, node := range nodes {
+		stages[node] = idx + 1
+	}
 	for idx, node := range nodes {
 		maxStage := 1
 		s.mergeNodeContext(node, ctx)
@@ -43,6 +50,14 @@ func (s *taskService) newNodes(template *task.Template, ctx *task.TaskContext) (
 				return nil, errors.Occurf(errors.ErrCommonUnexpected, "serial node %s has more than one execute agents", node.GetName())
 			}
 		}
+		upstreams := ""
+		if template.IsGraph() {
+			upstreamStages := make([]int, 0, len(node.GetUpstreams()))
+			for _, upstream := range node.GetUpstreams() {
+				upstreamStages = append(upstreamStages, stages[upstream])
+			}
+			upstreams = formatUpstreamStages(upstreamStages)
+		}
 		nodeInstancesBO = append(nodeInstancesBO, &bo.NodeInstance{
 			DagStage:   idx + 1,
 			Name:       node.GetName(),
@@ -50,6 +65,7 @@ func (s *taskService) newNodes(template *task.Template, ctx *task.TaskContext) (
 			Operator:   task.RUN,
 			State:      task.PENDING,
 			MaxStage:   maxStage,
+			Upstreams:  upstreams,
 			StructName: node.GetTaskType().Name(),
 		})
 	}
@@ -105,14 +121,60 @@ func (s *taskService) GetNodes(dag *task.Dag) ([]*task.Node, error) {
 			return nil, err
 		}
 		nodes = append(nodes, node)
+		if dag.IsGraph() {
+			continue
+		}
 		if idx > 0 {
 			nodes[idx-1].AddDownstream(node)
 			node.AddUpstream(nodes[idx-1])
 		}
 	}
+	if dag.IsGraph() {
+		if err = connectGraphNodes(nodes); err != nil {
+			return nil, err
+		}
+	}
 	return nodes, nil
 }
 
+// connectGraphNodes connects the nodes of a graph dag by their upstream stages.
+// The nodes must be ordered by stage.
+func connectGraphNodes(nodes []*task.Node) error {
+	for _, node := range nodes {
+		for _, stage := range node.GetUpstreamStages() {
+			if stage < 1 || stage > len(nodes) {
+				return errors.Occurf(errors.ErrCommonUnexpected, "node %s has invalid upstream stage %d", node.GetName(), stage)
+			}
+			nodes[stage-1].Connect(node)
+		}
+	}
+	return nil
+}
+
+func formatUpstreamStages(stages []int) string {
+	strs := make([]string, 0, len(stages))
+	for _, stage := range stages {
+		strs = append(strs, strconv.Itoa(stage))
+	}
+	return strings.Join(strs, ",")
+}
+
+func parseUpstreamStages(upstreams string) ([]int, error) {
+	if upstreams == "" {
+		return nil, nil
+	}
+	strs := strings.Split(upstreams, ",")
+	stages := make([]int, 0, len(strs))
+	for _, str := range strs {
+		stage, err := strconv.Atoi(strings.TrimSpace(str))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid upstreams '%s'", upstreams)
+		}
+		stages = append(stages, stage)
+	}
+	return stages, nil
+}
+
 func (s *taskService) PassNode(node *task.Node, dag *task.Dag) error {
 	if !dag.IsFail() {
 		return errors.Occur(errors.ErrTaskNodeOperatorPassNotFailedDag)
@@ -21,6 +21,7 @@ import (
 
 	"gorm.io/gorm"
 
+	"github.com/oceanbase/obshell/ob/agent/engine/event"
 	"github.com/oceanbase/obshell/ob/agent/engine/task"
 	"github.com/oceanbase/obshell/ob/agent/errors"
 	"github.com/oceanbase/obshell/ob/agent/lib/json"
@@ -186,6 +187,7 @@ func (s *taskService) StartSubTask(subtask task.ExecutableTask) error {
 	}
 	subtask.SetState(taskInstanceBO.State)
 	subtask.SetStartTime(taskInstanceBO.StartTime)
+	s.publishSubTaskEvent(event.TASK_RUNNING, subtask, task.RUNNING)
 	return nil
 }
 
@@ -209,7 +211,7 @@ func (s *taskService) FinishSubTask(subtask task.ExecutableTask, state int) erro
 	}
 	taskInstance := s.convertSubTaskInstanceBOToDO(taskInstanceBO)
 
-	return db.Transaction(func(tx *gorm.DB) error {
+	err = db.Transaction(func(tx *gorm.DB) error {
 		resp := tx.Model(s.getSubTaskModel()).Where("id=? and execute_times=? and state=?", subtask.GetID(), subtask.GetExecuteTimes(), task.RUNNING).Updates(taskInstance)
 		if resp.Error != nil {
 			return resp.Error
@@ -227,6 +229,15 @@ func (s *taskService) FinishSubTask(subtask task.ExecutableTask, state int) erro
 		subtask.SetEndTime(taskInstanceBO.EndTime)
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	if state == task.SUCCEED {
+		s.publishSubTaskEvent(event.TASK_SUCCEEDED, subtask, state)
+	} else {
+		s.publishSubTaskEvent(event.TASK_FAILED, subtask, state)
+	}
+	return nil
 }
 
 func (s *taskService) SetSubTaskFailed(subtask task.ExecutableTask, logContent string) error {
@@ -422,3 +422,66 @@ func (s *TenantService) GetTenantBackupJobCount(tenantID int, p *param.QueryBack
 
 	return uint64(historyCount + runningCount), nil
 }
+
+func (s *TenantService) ListBackupSetFiles(tenantID int) (sets []oceanbase.CdbObBackupSetFile, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return
+	}
+	err = oceanbaseDb.Table(CDB_OB_BACKUP_SET_FILES).Where("TENANT_ID = ?", tenantID).Order("BACKUP_SET_ID").Scan(&sets).Error
+	return
+}
+
+func (s *TenantService) GetBackupSetFile(tenantID int, backupSetID int64) (set *oceanbase.CdbObBackupSetFile, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return
+	}
+	err = oceanbaseDb.Table(CDB_OB_BACKUP_SET_FILES).Where("TENANT_ID = ? and BACKUP_SET_ID = ?", tenantID, backupSetID).Scan(&set).Error
+	return
+}
+
+func (s *TenantService) ListArchivelogPieceFiles(tenantID int) (pieces []oceanbase.CdbObArchivelogPieceFile, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return
+	}
+	err = oceanbaseDb.Table(CDB_OB_ARCHIVELOG_PIECE_FILES).Where("TENANT_ID = ?", tenantID).Order("PIECE_ID").Scan(&pieces).Error
+	return
+}
+
+func (s *TenantService) GetArchivelogPieceFile(tenantID int, pieceID int64) (piece *oceanbase.CdbObArchivelogPieceFile, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return
+	}
+	err = oceanbaseDb.Table(CDB_OB_ARCHIVELOG_PIECE_FILES).Where("TENANT_ID = ? and PIECE_ID = ?", tenantID, pieceID).Scan(&piece).Error
+	return
+}
+
+func (s *TenantService) ValidateBackupSet(tenantName string, backupSetID int64) error {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	sql := fmt.Sprintf("ALTER SYSTEM VALIDATE BACKUPSET %d TENANT = %s", backupSetID, tenantName)
+	return oceanbaseDb.Exec(sql).Error
+}
+
+func (s *TenantService) DeleteBackupSet(tenantName string, backupSetID int64) error {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	sql := fmt.Sprintf("ALTER SYSTEM DELETE BACKUPSET %d TENANT = %s", backupSetID, tenantName)
+	return oceanbaseDb.Exec(sql).Error
+}
+
+func (s *TenantService) DeleteBackupPiece(tenantName string, pieceID int64) error {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	sql := fmt.Sprintf("ALTER SYSTEM DELETE BACKUPPIECE %d TENANT = %s", pieceID, tenantName)
+	return oceanbaseDb.Exec(sql).Error
+}
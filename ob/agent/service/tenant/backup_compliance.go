@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenant
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	oceanbasedb "github.com/oceanbase/obshell/ob/agent/repository/db/oceanbase"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+)
+
+// GetBackupRpoTarget returns nil if the tenant has no rpo target.
+func (s *TenantService) GetBackupRpoTarget(tenantID int) (*oceanbase.BackupRpoTarget, error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, err
+	}
+	var target oceanbase.BackupRpoTarget
+	if err = db.Where("tenant_id = ?", tenantID).First(&target).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &target, nil
+}
+
+func (s *TenantService) ListBackupRpoTargets() (targets []oceanbase.BackupRpoTarget, err error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = db.Order("tenant_id").Find(&targets).Error
+	return
+}
+
+func (s *TenantService) SaveBackupRpoTarget(target *oceanbase.BackupRpoTarget) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return err
+	}
+	return db.Save(target).Error
+}
+
+func (s *TenantService) DeleteBackupRpoTarget(tenantID int) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return err
+	}
+	return db.Where("tenant_id = ?", tenantID).Delete(&oceanbase.BackupRpoTarget{}).Error
+}
+
+// RecordBackupCompliance accumulates one compliance check into the daily record of the tenant.
+// The violation is empty if the check passed.
+func (s *TenantService) RecordBackupCompliance(target *oceanbase.BackupRpoTarget, checkTime time.Time, date string, rpo int64, violation string) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return err
+	}
+	record := &oceanbase.BackupComplianceDaily{
+		TenantID:   target.TenantID,
+		Date:       date,
+		TenantName: target.TenantName,
+		RpoTarget:  target.RpoTarget,
+		CheckCount: 1,
+		MaxRpo:     rpo,
+	}
+	assignments := map[string]interface{}{
+		"tenant_name": target.TenantName,
+		"rpo_target":  gorm.Expr("GREATEST(rpo_target, ?)", target.RpoTarget),
+		"check_count": gorm.Expr("check_count + 1"),
+		"max_rpo":     gorm.Expr("GREATEST(max_rpo, ?)", rpo),
+		"update_time": checkTime,
+	}
+	if violation == "" {
+		record.CompliantCount = 1
+		assignments["compliant_count"] = gorm.Expr("compliant_count + 1")
+	} else {
+		record.FirstViolationTime = &checkTime
+		record.LastViolation = violation
+		assignments["first_violation_time"] = gorm.Expr("IFNULL(first_violation_time, ?)", checkTime)
+		assignments["last_violation"] = violation
+	}
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "date"}},
+		DoUpdates: clause.Assignments(assignments),
+	}).Create(record).Error
+}
+
+// ListBackupComplianceDaily lists the daily records between the dates, tenantID 0 means all tenants.
+func (s *TenantService) ListBackupComplianceDaily(tenantID int, startDate, endDate string) (records []oceanbase.BackupComplianceDaily, err error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, err
+	}
+	query := db.Where("date >= ? AND date <= ?", startDate, endDate)
+	if tenantID != 0 {
+		query = query.Where("tenant_id = ?", tenantID)
+	}
+	err = query.Order("date, tenant_id").Find(&records).Error
+	return
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenant
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	oceanbasedb "github.com/oceanbase/obshell/ob/agent/repository/db/oceanbase"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+)
+
+// GetBackupEncryption returns nil if the tenant has no backup encryption config.
+func (s *TenantService) GetBackupEncryption(tenantID int) (*oceanbase.BackupEncryption, error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, err
+	}
+	var encryption oceanbase.BackupEncryption
+	if err = db.Where("tenant_id = ?", tenantID).First(&encryption).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &encryption, nil
+}
+
+func (s *TenantService) SaveBackupEncryption(encryption *oceanbase.BackupEncryption) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return err
+	}
+	return db.Save(encryption).Error
+}
+
+func (s *TenantService) DeleteBackupEncryption(tenantID int) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return err
+	}
+	return db.Where("tenant_id = ?", tenantID).Delete(&oceanbase.BackupEncryption{}).Error
+}
+
+// SaveBackupSetEncryption records the keys of the backup set, the record of the same backup set in the same dest will be overwritten.
+func (s *TenantService) SaveBackupSetEncryption(record *oceanbase.BackupSetEncryption) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return err
+	}
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "path"}, {Name: "backup_set_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"tenant_name", "password", "kms_encrypt_info"}),
+	}).Create(record).Error
+}
+
+// ListBackupSetEncryptions lists the keys of the backup sets in the backup dest of the path,
+// the records of all the dests are listed if the path is empty.
+func (s *TenantService) ListBackupSetEncryptions(tenantID int, path string, backupSetIDs ...int64) (records []oceanbase.BackupSetEncryption, err error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, err
+	}
+	query := db.Where("tenant_id = ?", tenantID)
+	if path != "" {
+		query = query.Where("path = ?", path)
+	}
+	if len(backupSetIDs) != 0 {
+		query = query.Where("backup_set_id IN ?", backupSetIDs)
+	}
+	err = query.Order("backup_set_id").Find(&records).Error
+	return
+}
@@ -32,18 +32,22 @@ const (
 	DBA_OB_DATABASES             = "oceanbase.DBA_OB_DATABASES"
 	DBA_OBJECTS                  = "oceanbase.DBA_OBJECTS"
 
-	CDB_OB_SYS_VARIABLES        = "oceanbase.CDB_OB_SYS_VARIABLES"
-	CDB_OB_ARCHIVELOG           = "oceanbase.CDB_OB_ARCHIVELOG"
-	CDB_OB_ARCHIVELOG_SUMMARY   = "oceanbase.CDB_OB_ARCHIVELOG_SUMMARY"
-	CDB_OB_BACKUP_DELETE_POLICY = "oceanbase.CDB_OB_BACKUP_DELETE_POLICY"
-	CDB_OB_BACKUP_JOBS          = "oceanbase.CDB_OB_BACKUP_JOBS"
-	CDB_OB_BACKUP_JOB_HISTORY   = "oceanbase.CDB_OB_BACKUP_JOB_HISTORY"
-	CDB_OB_ARCHIVE_DEST         = "oceanbase.CDB_OB_ARCHIVE_DEST"
-	CDB_OB_BACKUP_PARAMETER     = "oceanbase.CDB_OB_BACKUP_PARAMETER"
-	CDB_OB_BACKUP_TASKS         = "oceanbase.CDB_OB_BACKUP_TASKS"
-	CDB_OB_BACKUP_TASK_HISTORY  = "oceanbase.CDB_OB_BACKUP_TASK_HISTORY"
-	CDB_OB_RESTORE_PROGRESS     = "oceanbase.CDB_OB_RESTORE_PROGRESS"
-	CDB_OB_RESTORE_HISTORY      = "oceanbase.CDB_OB_RESTORE_HISTORY"
+	CDB_OB_SYS_VARIABLES             = "oceanbase.CDB_OB_SYS_VARIABLES"
+	CDB_OB_ARCHIVELOG                = "oceanbase.CDB_OB_ARCHIVELOG"
+	CDB_OB_ARCHIVELOG_SUMMARY        = "oceanbase.CDB_OB_ARCHIVELOG_SUMMARY"
+	CDB_OB_BACKUP_DELETE_POLICY      = "oceanbase.CDB_OB_BACKUP_DELETE_POLICY"
+	CDB_OB_BACKUP_JOBS               = "oceanbase.CDB_OB_BACKUP_JOBS"
+	CDB_OB_BACKUP_JOB_HISTORY        = "oceanbase.CDB_OB_BACKUP_JOB_HISTORY"
+	CDB_OB_ARCHIVE_DEST              = "oceanbase.CDB_OB_ARCHIVE_DEST"
+	CDB_OB_BACKUP_PARAMETER          = "oceanbase.CDB_OB_BACKUP_PARAMETER"
+	CDB_OB_BACKUP_TASKS              = "oceanbase.CDB_OB_BACKUP_TASKS"
+	CDB_OB_BACKUP_TASK_HISTORY       = "oceanbase.CDB_OB_BACKUP_TASK_HISTORY"
+	CDB_OB_BACKUP_SET_FILES          = "oceanbase.CDB_OB_BACKUP_SET_FILES"
+	CDB_OB_ARCHIVELOG_PIECE_FILES    = "oceanbase.CDB_OB_ARCHIVELOG_PIECE_FILES"
+	CDB_OB_RESTORE_PROGRESS          = "oceanbase.CDB_OB_RESTORE_PROGRESS"
+	CDB_OB_RESTORE_HISTORY           = "oceanbase.CDB_OB_RESTORE_HISTORY"
+	CDB_OB_RECOVER_TABLE_JOBS        = "oceanbase.CDB_OB_RECOVER_TABLE_JOBS"
+	CDB_OB_RECOVER_TABLE_JOB_HISTORY = "oceanbase.CDB_OB_RECOVER_TABLE_JOB_HISTORY"
 
 	GV_OB_PARAMETERS = "oceanbase.GV$OB_PARAMETERS"
 	GV_OB_SERVERS    = "oceanbase.GV$OB_SERVERS"
@@ -0,0 +1,263 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenant
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	oceanbasedb "github.com/oceanbase/obshell/ob/agent/repository/db/oceanbase"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+const (
+	GV_OB_GROUP_IO_STAT = "oceanbase.GV$OB_GROUP_IO_STAT"
+
+	// the views of resource manager are in different schemas in mysql and oracle tenant
+	SQL_SELECT_RESOURCE_PLANS              = "SELECT PLAN_ID, PLAN, COMMENTS FROM %s.DBA_RSRC_PLANS ORDER BY PLAN_ID"
+	SQL_COUNT_RESOURCE_PLAN                = "SELECT COUNT(*) FROM %s.DBA_RSRC_PLANS WHERE PLAN = ?"
+	SQL_SELECT_CONSUMER_GROUPS             = "SELECT CONSUMER_GROUP_ID, CONSUMER_GROUP, COMMENTS FROM %s.DBA_RSRC_CONSUMER_GROUPS ORDER BY CONSUMER_GROUP_ID"
+	SQL_COUNT_CONSUMER_GROUP               = "SELECT COUNT(*) FROM %s.DBA_RSRC_CONSUMER_GROUPS WHERE CONSUMER_GROUP = ?"
+	SQL_SELECT_RESOURCE_PLAN_DIRECTIVES    = "SELECT PLAN, GROUP_OR_SUBPLAN, COMMENTS, MGMT_P1, UTILIZATION_LIMIT, MIN_IOPS, MAX_IOPS, WEIGHT_IOPS FROM %s.DBA_RSRC_PLAN_DIRECTIVES ORDER BY PLAN, GROUP_OR_SUBPLAN"
+	SQL_COUNT_RESOURCE_PLAN_DIRECTIVE      = "SELECT COUNT(*) FROM %s.DBA_RSRC_PLAN_DIRECTIVES WHERE PLAN = ? AND GROUP_OR_SUBPLAN = ?"
+	SQL_SELECT_RESOURCE_MAPPINGS           = "SELECT ATTRIBUTE, VALUE, CONSUMER_GROUP, STATUS FROM %s.DBA_RSRC_GROUP_MAPPINGS ORDER BY ATTRIBUTE, VALUE"
+	SQL_CALL_RESOURCE_MANAGER_MYSQL        = "CALL DBMS_RESOURCE_MANAGER.%s(%s)"
+	SQL_CALL_RESOURCE_MANAGER_ORACLE       = "BEGIN DBMS_RESOURCE_MANAGER.%s(%s); END;"
+	SQL_SET_TENANT_RESOURCE_MANAGER_PLAN   = "ALTER TENANT `%s` SET VARIABLES resource_manager_plan = %s"
+	SQL_SELECT_GROUP_IO_STATS              = "SELECT SVR_IP, SVR_PORT, GROUP_ID, GROUP_NAME, MODE, MIN_IOPS, MAX_IOPS, REAL_IOPS, MAX_NET_BANDWIDTH, REAL_NET_BANDWIDTH FROM " + GV_OB_GROUP_IO_STAT + " WHERE TENANT_ID = ? ORDER BY GROUP_ID, SVR_IP, SVR_PORT, MODE"
+	RESOURCE_MANAGER_PLAN_VARIABLE         = "resource_manager_plan"
+	RESOURCE_MANAGER_MYSQL_SCHEMA          = "oceanbase"
+	RESOURCE_MANAGER_ORACLE_SCHEMA         = "SYS"
+	RESOURCE_MANAGER_PROC_CREATE_PLAN      = "CREATE_PLAN"
+	RESOURCE_MANAGER_PROC_DELETE_PLAN      = "DELETE_PLAN"
+	RESOURCE_MANAGER_PROC_CREATE_GROUP     = "CREATE_CONSUMER_GROUP"
+	RESOURCE_MANAGER_PROC_DELETE_GROUP     = "DELETE_CONSUMER_GROUP"
+	RESOURCE_MANAGER_PROC_CREATE_DIRECTIVE = "CREATE_PLAN_DIRECTIVE"
+	RESOURCE_MANAGER_PROC_UPDATE_DIRECTIVE = "UPDATE_PLAN_DIRECTIVE"
+	RESOURCE_MANAGER_PROC_DELETE_DIRECTIVE = "DELETE_PLAN_DIRECTIVE"
+	RESOURCE_MANAGER_PROC_SET_MAPPING      = "SET_CONSUMER_GROUP_MAPPING"
+)
+
+func resourceManagerSchema(mode string) string {
+	if mode == constant.ORACLE_MODE {
+		return RESOURCE_MANAGER_ORACLE_SCHEMA
+	}
+	return RESOURCE_MANAGER_MYSQL_SCHEMA
+}
+
+// rmArg is a named argument of the procedure of DBMS_RESOURCE_MANAGER, nil value means NULL.
+type rmArg struct {
+	name  string
+	value interface{}
+}
+
+// quoteOracleLiteral quotes the string literal in oracle mode, where the backslash is not an escape character.
+func quoteOracleLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// quoteMysqlLiteral quotes the string literal in mysql mode, where the backslash is an escape character.
+func quoteMysqlLiteral(value string) string {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// callResourceManager calls the procedure of DBMS_RESOURCE_MANAGER with named arguments.
+// The arguments are bound in mysql mode, and inlined as literals in the anonymous block of oracle mode.
+func callResourceManager(db *gorm.DB, mode string, procedure string, args []rmArg) error {
+	pairs := make([]string, 0, len(args))
+	if mode != constant.ORACLE_MODE {
+		values := make([]interface{}, 0, len(args))
+		for _, arg := range args {
+			pairs = append(pairs, arg.name+" => ?")
+			values = append(values, arg.value)
+		}
+		return db.Exec(fmt.Sprintf(SQL_CALL_RESOURCE_MANAGER_MYSQL, procedure, strings.Join(pairs, ", ")), values...).Error
+	}
+	for _, arg := range args {
+		switch v := arg.value.(type) {
+		case nil:
+			pairs = append(pairs, arg.name+" => NULL")
+		case string:
+			pairs = append(pairs, arg.name+" => "+quoteOracleLiteral(v))
+		default:
+			pairs = append(pairs, fmt.Sprintf("%s => %d", arg.name, v))
+		}
+	}
+	return db.Exec(fmt.Sprintf(SQL_CALL_RESOURCE_MANAGER_ORACLE, procedure, strings.Join(pairs, ", "))).Error
+}
+
+func (t *TenantService) ListResourcePlans(db *gorm.DB, mode string) (plans []oceanbase.DbaRsrcPlan, err error) {
+	err = db.Raw(fmt.Sprintf(SQL_SELECT_RESOURCE_PLANS, resourceManagerSchema(mode))).Scan(&plans).Error
+	return
+}
+
+func (t *TenantService) IsResourcePlanExist(db *gorm.DB, mode string, plan string) (bool, error) {
+	var count int64
+	err := db.Raw(fmt.Sprintf(SQL_COUNT_RESOURCE_PLAN, resourceManagerSchema(mode)), plan).Scan(&count).Error
+	return count > 0, err
+}
+
+func (t *TenantService) CreateResourcePlan(db *gorm.DB, mode string, plan string, comment string) error {
+	return callResourceManager(db, mode, RESOURCE_MANAGER_PROC_CREATE_PLAN, []rmArg{
+		{"PLAN", plan},
+		{"COMMENT", comment},
+	})
+}
+
+func (t *TenantService) DeleteResourcePlan(db *gorm.DB, mode string, plan string) error {
+	return callResourceManager(db, mode, RESOURCE_MANAGER_PROC_DELETE_PLAN, []rmArg{
+		{"PLAN", plan},
+	})
+}
+
+func (t *TenantService) ListConsumerGroups(db *gorm.DB, mode string) (groups []oceanbase.DbaRsrcConsumerGroup, err error) {
+	err = db.Raw(fmt.Sprintf(SQL_SELECT_CONSUMER_GROUPS, resourceManagerSchema(mode))).Scan(&groups).Error
+	return
+}
+
+func (t *TenantService) IsConsumerGroupExist(db *gorm.DB, mode string, group string) (bool, error) {
+	var count int64
+	err := db.Raw(fmt.Sprintf(SQL_COUNT_CONSUMER_GROUP, resourceManagerSchema(mode)), group).Scan(&count).Error
+	return count > 0, err
+}
+
+func (t *TenantService) CreateConsumerGroup(db *gorm.DB, mode string, group string, comment string) error {
+	return callResourceManager(db, mode, RESOURCE_MANAGER_PROC_CREATE_GROUP, []rmArg{
+		{"CONSUMER_GROUP", group},
+		{"COMMENT", comment},
+	})
+}
+
+func (t *TenantService) DeleteConsumerGroup(db *gorm.DB, mode string, group string) error {
+	return callResourceManager(db, mode, RESOURCE_MANAGER_PROC_DELETE_GROUP, []rmArg{
+		{"CONSUMER_GROUP", group},
+	})
+}
+
+func (t *TenantService) ListResourcePlanDirectives(db *gorm.DB, mode string) (directives []oceanbase.DbaRsrcPlanDirective, err error) {
+	err = db.Raw(fmt.Sprintf(SQL_SELECT_RESOURCE_PLAN_DIRECTIVES, resourceManagerSchema(mode))).Scan(&directives).Error
+	return
+}
+
+func (t *TenantService) IsResourcePlanDirectiveExist(db *gorm.DB, mode string, plan string, group string) (bool, error) {
+	var count int64
+	err := db.Raw(fmt.Sprintf(SQL_COUNT_RESOURCE_PLAN_DIRECTIVE, resourceManagerSchema(mode)), plan, group).Scan(&count).Error
+	return count > 0, err
+}
+
+// buildDirectiveArgs builds the named arguments of the directive,
+// prefix is "NEW_" when updating the directive.
+func buildDirectiveArgs(directive *param.ResourcePlanDirective, prefix string) []rmArg {
+	args := make([]rmArg, 0)
+	if directive.Comment != nil {
+		args = append(args, rmArg{prefix + "COMMENT", *directive.Comment})
+	}
+	if directive.MgmtP1 != nil {
+		args = append(args, rmArg{prefix + "MGMT_P1", *directive.MgmtP1})
+	}
+	if directive.UtilizationLimit != nil {
+		args = append(args, rmArg{prefix + "UTILIZATION_LIMIT", *directive.UtilizationLimit})
+	}
+	if directive.MinIops != nil {
+		args = append(args, rmArg{prefix + "MIN_IOPS", *directive.MinIops})
+	}
+	if directive.MaxIops != nil {
+		args = append(args, rmArg{prefix + "MAX_IOPS", *directive.MaxIops})
+	}
+	if directive.WeightIops != nil {
+		args = append(args, rmArg{prefix + "WEIGHT_IOPS", *directive.WeightIops})
+	}
+	return args
+}
+
+func (t *TenantService) CreateResourcePlanDirective(db *gorm.DB, mode string, plan string, group string, directive *param.ResourcePlanDirective) error {
+	args := []rmArg{
+		{"PLAN", plan},
+		{"GROUP_OR_SUBPLAN", group},
+	}
+	return callResourceManager(db, mode, RESOURCE_MANAGER_PROC_CREATE_DIRECTIVE, append(args, buildDirectiveArgs(directive, "")...))
+}
+
+func (t *TenantService) UpdateResourcePlanDirective(db *gorm.DB, mode string, plan string, group string, directive *param.ResourcePlanDirective) error {
+	newArgs := buildDirectiveArgs(directive, "NEW_")
+	if len(newArgs) == 0 {
+		return nil
+	}
+	args := []rmArg{
+		{"PLAN", plan},
+		{"GROUP_OR_SUBPLAN", group},
+	}
+	return callResourceManager(db, mode, RESOURCE_MANAGER_PROC_UPDATE_DIRECTIVE, append(args, newArgs...))
+}
+
+func (t *TenantService) DeleteResourcePlanDirective(db *gorm.DB, mode string, plan string, group string) error {
+	return callResourceManager(db, mode, RESOURCE_MANAGER_PROC_DELETE_DIRECTIVE, []rmArg{
+		{"PLAN", plan},
+		{"GROUP_OR_SUBPLAN", group},
+	})
+}
+
+func (t *TenantService) ListResourceMappings(db *gorm.DB, mode string) (mappings []oceanbase.DbaRsrcGroupMapping, err error) {
+	err = db.Raw(fmt.Sprintf(SQL_SELECT_RESOURCE_MAPPINGS, resourceManagerSchema(mode))).Scan(&mappings).Error
+	return
+}
+
+// SetResourceMapping maps the user, function or column to the consumer group,
+// the mapping will be removed if group is empty.
+func (t *TenantService) SetResourceMapping(db *gorm.DB, mode string, attribute string, value string, group string) error {
+	var consumerGroup interface{}
+	if group != "" {
+		consumerGroup = group
+	}
+	return callResourceManager(db, mode, RESOURCE_MANAGER_PROC_SET_MAPPING, []rmArg{
+		{"ATTRIBUTE", attribute},
+		{"VALUE", value},
+		{"CONSUMER_GROUP", consumerGroup},
+	})
+}
+
+func (t *TenantService) GetActiveResourcePlan(tenantName string) (string, error) {
+	variable, err := t.GetTenantVariable(tenantName, RESOURCE_MANAGER_PLAN_VARIABLE)
+	if err != nil || variable == nil {
+		return "", err
+	}
+	return variable.Value, nil
+}
+
+// SetActiveResourcePlan activates the resource plan of the tenant,
+// the current plan will be deactivated if plan is empty.
+func (t *TenantService) SetActiveResourcePlan(tenantName string, plan string) error {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	return db.Exec(fmt.Sprintf(SQL_SET_TENANT_RESOURCE_MANAGER_PLAN, tenantName, quoteMysqlLiteral(plan))).Error
+}
+
+func (t *TenantService) ListGroupIoStats(tenantId int) (stats []oceanbase.GvObGroupIoStat, err error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = db.Raw(SQL_SELECT_GROUP_IO_STATS, tenantId).Scan(&stats).Error
+	return
+}
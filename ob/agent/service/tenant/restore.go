@@ -165,7 +165,7 @@ func (s *TenantService) Restore(c *param.RestoreParam, locality, poolList string
 		sql = fmt.Sprintf("%s SET @kms_encrypt_info =\"%s\";", sql, *c.KmsEncryptInfo)
 	}
 
-	restoreSql := fmt.Sprintf("ALTER SYSTEM RESTORE %s FROM \"%s, %s\"", c.TenantName, c.DataBackupUri, *c.ArchiveLogUri)
+	restoreSql := fmt.Sprintf("ALTER SYSTEM RESTORE %s FROM \"%s, %s\"", c.TenantName, system.ObStorageURI(c.DataBackupUri), system.ObStorageURI(*c.ArchiveLogUri))
 	if c.Timestamp != nil {
 		restoreSql = fmt.Sprintf("%s UNTIL TIME= \"%s\"", restoreSql, c.Timestamp.Format("2006-01-02 15:04:05.000000"))
 	}
@@ -189,6 +189,92 @@ func (s *TenantService) Restore(c *param.RestoreParam, locality, poolList string
 	return oceanbaseDb.Exec(sql).Error
 }
 
+func (s *TenantService) GetRunningRecoverTableJob(targetTenantName string) (*oceanbase.CdbObRecoverTableJob, error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	var job *oceanbase.CdbObRecoverTableJob
+	err = oceanbaseDb.Table(CDB_OB_RECOVER_TABLE_JOBS).Where("TENANT_ID = 1 AND TARGET_TENANT_NAME = ?", targetTenantName).Scan(&job).Error
+	return job, err
+}
+
+func (s *TenantService) GetRecoverTableJobHistory(targetTenantName string, jobID int64) (*oceanbase.CdbObRecoverTableJob, error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	var job *oceanbase.CdbObRecoverTableJob
+	query := oceanbaseDb.Table(CDB_OB_RECOVER_TABLE_JOB_HISTORY).Where("TENANT_ID = 1 AND TARGET_TENANT_NAME = ?", targetTenantName)
+	if jobID != 0 {
+		query = query.Where("JOB_ID = ?", jobID)
+	}
+	err = query.Order("START_TIMESTAMP desc").Limit(1).Scan(&job).Error
+	return job, err
+}
+
+func (s *TenantService) RecoverTable(targetTenantName string, c *param.RestoreTableParam, poolList string, scn int64) (err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return
+	}
+
+	var sql string
+	if c.Decryption != nil && len(*c.Decryption) > 0 {
+		decryption := make([]string, 0, len(*c.Decryption))
+		for _, pwd := range *c.Decryption {
+			decryption = append(decryption, fmt.Sprintf("'%s'", strings.ReplaceAll(pwd, "\"", "\\\"")))
+		}
+		sql = fmt.Sprintf("SET DECRYPTION IDENTIFIED BY %s;", strings.Join(decryption, ","))
+	}
+
+	if c.KmsEncryptInfo != nil {
+		sql = fmt.Sprintf("%s SET @kms_encrypt_info =\"%s\";", sql, *c.KmsEncryptInfo)
+	}
+
+	recoverList := append([]string{}, c.Tables...)
+	for _, db := range c.Databases {
+		recoverList = append(recoverList, fmt.Sprintf("%s.*", db))
+	}
+	recoverSql := fmt.Sprintf("ALTER SYSTEM RECOVER TABLE %s TO TENANT %s FROM \"%s, %s\"", strings.Join(recoverList, ", "), targetTenantName, system.ObStorageURI(c.DataBackupUri), system.ObStorageURI(*c.ArchiveLogUri))
+	if c.Timestamp != nil {
+		recoverSql = fmt.Sprintf("%s UNTIL TIME= \"%s\"", recoverSql, c.Timestamp.Format("2006-01-02 15:04:05.000000"))
+	}
+	if scn != 0 {
+		recoverSql = fmt.Sprintf("%s UNTIL SCN=%d", recoverSql, scn)
+	}
+
+	recoverOption := fmt.Sprintf("pool_list=%s", poolList)
+	if c.PrimaryZone != nil {
+		recoverOption = fmt.Sprintf("%s&primary_zone=%s", recoverOption, *c.PrimaryZone)
+	}
+	if c.Concurrency != nil {
+		recoverOption = fmt.Sprintf("%s&concurrency=%d", recoverOption, *c.Concurrency)
+	}
+	recoverSql = fmt.Sprintf("%s WITH '%s'", recoverSql, recoverOption)
+
+	remapList := append([]string{}, c.RemapTables...)
+	for _, rule := range c.RemapDatabases {
+		parts := strings.SplitN(rule, ":", 2)
+		remapList = append(remapList, fmt.Sprintf("%s.*:%s", parts[0], parts[1]))
+	}
+	if len(remapList) > 0 {
+		recoverSql = fmt.Sprintf("%s REMAP TABLE %s", recoverSql, strings.Join(remapList, ", "))
+	}
+
+	sql = fmt.Sprintf("%s %s;", sql, recoverSql)
+	return oceanbaseDb.Exec(sql).Error
+}
+
+func (s *TenantService) CancelRecoverTable(targetTenantName string) (err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return
+	}
+	sql := fmt.Sprintf("ALTER SYSTEM CANCEL RECOVER TABLE %s", targetTenantName)
+	return oceanbaseDb.Exec(sql).Error
+}
+
 func (s *TenantService) GetTenantLevelDagIDByTenantName(name string) (id *int64, err error) {
 	oceanbaseDb, err := oceanbasedb.GetOcsInstance()
 	if err != nil {
@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenant
+
+import (
+	"fmt"
+
+	oceanbasedb "github.com/oceanbase/obshell/ob/agent/repository/db/oceanbase"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+)
+
+const (
+	CDB_OB_TENANT_SNAPSHOTS = "oceanbase.CDB_OB_TENANT_SNAPSHOTS"
+	DBA_OB_CLONE_PROGRESS   = "oceanbase.DBA_OB_CLONE_PROGRESS"
+	DBA_OB_CLONE_HISTORY    = "oceanbase.DBA_OB_CLONE_HISTORY"
+
+	SQL_CREATE_TENANT_SNAPSHOT      = "ALTER SYSTEM CREATE SNAPSHOT %s FOR TENANT = `%s`"
+	SQL_CREATE_TENANT_SNAPSHOT_AUTO = "ALTER SYSTEM CREATE SNAPSHOT FOR TENANT = `%s`"
+	SQL_DROP_TENANT_SNAPSHOT        = "ALTER SYSTEM DROP SNAPSHOT %s FOR TENANT = `%s`"
+	SQL_CLONE_TENANT                = "CREATE TENANT `%s` FROM `%s` WITH RESOURCE_POOL = %s, UNIT = %s"
+	SQL_CLONE_TENANT_USING_SNAPSHOT = "CREATE TENANT `%s` FROM `%s` USING SNAPSHOT %s WITH RESOURCE_POOL = %s, UNIT = %s"
+	SQL_CANCEL_CLONE_TENANT         = "ALTER SYSTEM CANCEL CLONE `%s`"
+)
+
+func (s *TenantService) ListTenantSnapshots(tenantID int) (snapshots []oceanbase.CdbObTenantSnapshot, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Table(CDB_OB_TENANT_SNAPSHOTS).Where("TENANT_ID = ?", tenantID).Order("SNAPSHOT_ID").Scan(&snapshots).Error
+	return
+}
+
+func (s *TenantService) GetTenantSnapshot(tenantID int, snapshotName string) (snapshot *oceanbase.CdbObTenantSnapshot, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Table(CDB_OB_TENANT_SNAPSHOTS).Where("TENANT_ID = ? AND SNAPSHOT_NAME = ?", tenantID, snapshotName).Scan(&snapshot).Error
+	return
+}
+
+// CreateTenantSnapshot creates a snapshot for the tenant, the name of the
+// snapshot will be generated by OceanBase if snapshotName is empty.
+func (s *TenantService) CreateTenantSnapshot(tenantName, snapshotName string) error {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	if snapshotName == "" {
+		return oceanbaseDb.Exec(fmt.Sprintf(SQL_CREATE_TENANT_SNAPSHOT_AUTO, tenantName)).Error
+	}
+	return oceanbaseDb.Exec(fmt.Sprintf(SQL_CREATE_TENANT_SNAPSHOT, snapshotName, tenantName)).Error
+}
+
+func (s *TenantService) DropTenantSnapshot(tenantName, snapshotName string) error {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	return oceanbaseDb.Exec(fmt.Sprintf(SQL_DROP_TENANT_SNAPSHOT, snapshotName, tenantName)).Error
+}
+
+// CloneTenant clones the source tenant to a new tenant, OceanBase will create
+// the resource pool with the unit config for the new tenant.
+func (s *TenantService) CloneTenant(tenantName, sourceTenantName, snapshotName, poolName, unitConfigName string) error {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	if snapshotName == "" {
+		return oceanbaseDb.Exec(fmt.Sprintf(SQL_CLONE_TENANT, tenantName, sourceTenantName, poolName, unitConfigName)).Error
+	}
+	return oceanbaseDb.Exec(fmt.Sprintf(SQL_CLONE_TENANT_USING_SNAPSHOT, tenantName, sourceTenantName, snapshotName, poolName, unitConfigName)).Error
+}
+
+func (s *TenantService) CancelCloneTenant(tenantName string) error {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	return oceanbaseDb.Exec(fmt.Sprintf(SQL_CANCEL_CLONE_TENANT, tenantName)).Error
+}
+
+func (s *TenantService) GetRunningCloneJob(tenantName string) (job *oceanbase.DbaObCloneJob, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Table(DBA_OB_CLONE_PROGRESS).Where("CLONE_TENANT_NAME = ?", tenantName).Scan(&job).Error
+	return
+}
+
+func (s *TenantService) GetLastCloneJobHistory(tenantName string) (job *oceanbase.DbaObCloneJob, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Table(DBA_OB_CLONE_HISTORY).Where("CLONE_TENANT_NAME = ?", tenantName).Order("JOB_ID DESC").Limit(1).Scan(&job).Error
+	return
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenant
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/lib/system"
+	oceanbasedb "github.com/oceanbase/obshell/ob/agent/repository/db/oceanbase"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+const (
+	CDB_OB_LOG_RESTORE_SOURCE = "oceanbase.CDB_OB_LOG_RESTORE_SOURCE"
+
+	SQL_CREATE_STANDBY_TENANT       = "CREATE STANDBY TENANT `%s` LOG_RESTORE_SOURCE = \"%s\" RESOURCE_POOL_LIST=(%s), LOCALITY = \"%s\", PRIMARY_ZONE = `%s`"
+	SQL_SET_LOG_RESTORE_SOURCE      = "ALTER SYSTEM SET LOG_RESTORE_SOURCE = \"%s\" TENANT = `%s`"
+	SQL_SWITCHOVER_TO_PRIMARY       = "ALTER SYSTEM SWITCHOVER TO PRIMARY TENANT = `%s`"
+	SQL_SWITCHOVER_TO_STANDBY       = "ALTER SYSTEM SWITCHOVER TO STANDBY TENANT = `%s`"
+	SQL_RESTORE_STANDBY_TENANT_BASE = "ALTER SYSTEM RESTORE `%s` FROM \"%s,%s\" UNTIL UNLIMITED WITH '%s'"
+)
+
+func (s *TenantService) GetTenantRoleInfo(tenantName string) (res *oceanbase.DbaObTenantRoleInfo, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Table(DBA_OB_TENANTS).Where("TENANT_NAME = ? and TENANT_TYPE = ?", tenantName, constant.TENANT_TYPE_USER).Scan(&res).Error
+	return
+}
+
+func (s *TenantService) GetLogRestoreSource(tenantID int) (res *oceanbase.CdbObLogRestoreSource, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Table(CDB_OB_LOG_RESTORE_SOURCE).Where("TENANT_ID = ?", tenantID).Scan(&res).Error
+	return
+}
+
+func (s *TenantService) CreateStandbyTenant(tenantName, logRestoreSource, poolList, locality, primaryZone string) error {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	sql := fmt.Sprintf(SQL_CREATE_STANDBY_TENANT, tenantName, transfer(logRestoreSource), poolList, locality, primaryZone)
+	return oceanbaseDb.Exec(sql).Error
+}
+
+// RestoreStandbyTenant restores a tenant from the backup without activating it,
+// so the tenant stays as a standby tenant after the restore finished.
+func (s *TenantService) RestoreStandbyTenant(tenantName string, p *param.CreateStandbyTenantParam, locality, poolList string) error {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+
+	var sql string
+	if p.Decryption != nil && len(*p.Decryption) > 0 {
+		pwds := make([]string, 0, len(*p.Decryption))
+		for _, pwd := range *p.Decryption {
+			pwds = append(pwds, quoteMysqlLiteral(pwd))
+		}
+		sql = fmt.Sprintf("SET DECRYPTION IDENTIFIED BY %s;", strings.Join(pwds, ","))
+	}
+
+	restoreOption := fmt.Sprintf("pool_list=%s&locality=%s&primary_zone=%s", poolList, locality, *p.PrimaryZone)
+	sql = fmt.Sprintf("%s %s;", sql, fmt.Sprintf(SQL_RESTORE_STANDBY_TENANT_BASE, tenantName, system.ObStorageURI(p.DataBackupUri), system.ObStorageURI(p.ArchiveLogUri), restoreOption))
+	return oceanbaseDb.Exec(sql).Error
+}
+
+func (s *TenantService) SetLogRestoreSource(tenantName, logRestoreSource string) error {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	return oceanbaseDb.Exec(fmt.Sprintf(SQL_SET_LOG_RESTORE_SOURCE, transfer(logRestoreSource), tenantName)).Error
+}
+
+func (s *TenantService) SwitchoverToPrimary(tenantName string) error {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	return oceanbaseDb.Exec(fmt.Sprintf(SQL_SWITCHOVER_TO_PRIMARY, tenantName)).Error
+}
+
+func (s *TenantService) SwitchoverToStandby(tenantName string) error {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	return oceanbaseDb.Exec(fmt.Sprintf(SQL_SWITCHOVER_TO_STANDBY, tenantName)).Error
+}
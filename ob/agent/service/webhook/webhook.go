@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhook
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	oceanbasedb "github.com/oceanbase/obshell/ob/agent/repository/db/oceanbase"
+	obmodel "github.com/oceanbase/obshell/ob/agent/repository/model/oceanbase"
+)
+
+type WebhookService struct{}
+
+func (s *WebhookService) List() ([]obmodel.TaskWebhook, error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ocs instance failed")
+	}
+	var webhooks []obmodel.TaskWebhook
+	if err := db.Order("id ASC").Find(&webhooks).Error; err != nil {
+		return nil, errors.Wrap(err, "list task webhooks failed")
+	}
+	return webhooks, nil
+}
+
+func (s *WebhookService) ListEnabled() ([]obmodel.TaskWebhook, error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ocs instance failed")
+	}
+	var webhooks []obmodel.TaskWebhook
+	if err := db.Where("enabled = ?", true).Order("id ASC").Find(&webhooks).Error; err != nil {
+		return nil, errors.Wrap(err, "list enabled task webhooks failed")
+	}
+	return webhooks, nil
+}
+
+// GetByName returns nil if the webhook does not exist.
+func (s *WebhookService) GetByName(name string) (*obmodel.TaskWebhook, error) {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return nil, errors.Wrap(err, "get ocs instance failed")
+	}
+	var webhook obmodel.TaskWebhook
+	err = db.Where("name = ?", name).First(&webhook).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "get task webhook failed")
+	}
+	return &webhook, nil
+}
+
+func (s *WebhookService) Create(webhook *obmodel.TaskWebhook) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return errors.Wrap(err, "get ocs instance failed")
+	}
+	if err := db.Create(webhook).Error; err != nil {
+		return errors.Wrap(err, "create task webhook failed")
+	}
+	return nil
+}
+
+func (s *WebhookService) Update(webhook *obmodel.TaskWebhook) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return errors.Wrap(err, "get ocs instance failed")
+	}
+	err = db.Model(webhook).Updates(map[string]interface{}{
+		"url":         webhook.Url,
+		"events":      webhook.Events,
+		"secret":      webhook.Secret,
+		"enabled":     webhook.Enabled,
+		"description": webhook.Description,
+	}).Error
+	if err != nil {
+		return errors.Wrap(err, "update task webhook failed")
+	}
+	return nil
+}
+
+func (s *WebhookService) Delete(webhook *obmodel.TaskWebhook) error {
+	db, err := oceanbasedb.GetOcsInstance()
+	if err != nil {
+		return errors.Wrap(err, "get ocs instance failed")
+	}
+	if err := db.Delete(webhook).Error; err != nil {
+		return errors.Wrap(err, "delete task webhook failed")
+	}
+	return nil
+}
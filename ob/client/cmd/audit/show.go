@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audit
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/lib/http"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/ob/client/command"
+	clientconst "github.com/oceanbase/obshell/ob/client/constant"
+	"github.com/oceanbase/obshell/ob/client/lib/stdio"
+	"github.com/oceanbase/obshell/ob/client/utils/api"
+)
+
+var showHeader = []string{"Time", "User", "Client", "Method", "Uri", "Task", "Result"}
+
+type showFlags struct {
+	user    string
+	method  string
+	keyword string
+	taskId  string
+	since   string
+	failed  bool
+	page    int
+	size    int
+	verbose bool
+}
+
+func newShowCmd() *cobra.Command {
+	opts := &showFlags{}
+	showCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_SHOW,
+		Short: "Show the audit log of the mutating API requests, the latest first.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return auditShow(opts)
+		}),
+		Example: showCmdExample(),
+	})
+
+	showCmd.Flags().SortFlags = false
+	showCmd.VarsPs(&opts.user, []string{FLAG_USER, FLAG_USER_SH}, "", "Only show the requests of the user, the account name, 'root', 'agent' or 'local'.", false)
+	showCmd.VarsPs(&opts.method, []string{FLAG_METHOD}, "", "Only show the requests of the HTTP method.", false)
+	showCmd.VarsPs(&opts.keyword, []string{FLAG_KEYWORD, FLAG_KEYWORD_SH}, "", "Only show the requests whose uri contains the keyword.", false)
+	showCmd.VarsPs(&opts.taskId, []string{FLAG_TASK_ID}, "", "Only show the request which created the task.", false)
+	showCmd.VarsPs(&opts.since, []string{FLAG_SINCE}, "", "Only show the requests in the recent duration, such as '30m' or '24h'.", false)
+	showCmd.VarsPs(&opts.failed, []string{FLAG_FAILED}, false, "Only show the failed requests.", false)
+	showCmd.VarsPs(&opts.page, []string{FLAG_PAGE, FLAG_PAGE_SH}, 1, "Page number.", false)
+	showCmd.VarsPs(&opts.size, []string{FLAG_SIZE, FLAG_SIZE_SH}, constant.AUDIT_DEFAULT_PAGE_SIZE, "Page size.", false)
+	showCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+
+	return showCmd.Command
+}
+
+func auditShow(opts *showFlags) error {
+	query := map[string]string{
+		"page":      strconv.Itoa(opts.page),
+		"page_size": strconv.Itoa(opts.size),
+	}
+	if opts.user != "" {
+		query["user"] = opts.user
+	}
+	if opts.method != "" {
+		query["method"] = opts.method
+	}
+	if opts.keyword != "" {
+		query["key_word"] = opts.keyword
+	}
+	if opts.taskId != "" {
+		query["dag_id"] = opts.taskId
+	}
+	if opts.failed {
+		query["successful"] = "false"
+	}
+	if opts.since != "" {
+		duration, err := time.ParseDuration(opts.since)
+		if err != nil {
+			return errors.Occur(errors.ErrCliUsageError, fmt.Sprintf("invalid duration '%s'", opts.since))
+		}
+		query["start_time"] = time.Now().Add(-duration).Format(time.RFC3339)
+	}
+
+	var resp bo.PaginatedAuditLogResponse
+	if err := api.CallApiWithMethod(http.GET, constant.URI_API_V1+constant.URI_AUDIT_GROUP, query, &resp); err != nil {
+		return err
+	}
+	if len(resp.Contents) == 0 {
+		stdio.Info("No audit log found.")
+		return nil
+	}
+
+	data := make([][]string, 0, len(resp.Contents))
+	for _, log := range resp.Contents {
+		result := "OK"
+		if !log.Successful {
+			result = log.ErrorCode
+			if result == "" {
+				result = strconv.Itoa(log.Status)
+			}
+		}
+		data = append(data, []string{log.Time.Local().Format(time.DateTime), log.User, log.ClientIp, log.Method, log.Uri, log.DagId, result})
+	}
+	stdio.PrintTable(showHeader, data)
+	stdio.Printf("Page %d/%d, %d logs in total.", resp.Page.Number, resp.Page.TotalPages, resp.Page.TotalElements)
+	return nil
+}
+
+func showCmdExample() string {
+	return `  obshell audit show
+  obshell audit show --since 24h -k tenant
+  obshell audit show -u admin --failed -p 2`
+}
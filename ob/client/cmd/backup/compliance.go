@@ -0,0 +1,295 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/lib/http"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/ob/client/cmd/tenant"
+	"github.com/oceanbase/obshell/ob/client/command"
+	clientconst "github.com/oceanbase/obshell/ob/client/constant"
+	cmdlib "github.com/oceanbase/obshell/ob/client/lib/cmd"
+	"github.com/oceanbase/obshell/ob/client/lib/stdio"
+	"github.com/oceanbase/obshell/ob/client/utils/api"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+const (
+	CMD_COMPLIANCE  = "compliance"
+	CMD_SET_TARGET  = "set-target"
+	CMD_DROP_TARGET = "drop-target"
+	CMD_REPORT      = "report"
+
+	FLAG_RPO_TARGET           = "rpo"
+	FLAG_FULL_BACKUP_INTERVAL = "full-interval"
+	FLAG_INC_BACKUP_INTERVAL  = "inc-interval"
+	FLAG_START_DATE           = "start-date"
+	FLAG_END_DATE             = "end-date"
+)
+
+var (
+	complianceHeader = []string{"Tenant", "Status", "RPO", "RPO Target", "Archive", "Last Full Backup", "Last Backup", "Violations"}
+	reportHeader     = []string{"Date", "Tenant", "RPO Target", "Checks", "Compliant Checks", "Max RPO", "First Violation", "Last Violation", "Result"}
+)
+
+type complianceFlags struct {
+	tenantName         string
+	rpoTarget          string
+	fullBackupInterval string
+	incBackupInterval  string
+	startDate          string
+	endDate            string
+	verbose            bool
+}
+
+func newComplianceCmd() *cobra.Command {
+	complianceCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_COMPLIANCE,
+		Short: "Manage the RPO targets of the tenants and check whether the backups comply with them.",
+	})
+	complianceCmd.AddCommand(newComplianceShowCmd())
+	complianceCmd.AddCommand(newSetTargetCmd())
+	complianceCmd.AddCommand(newDropTargetCmd())
+	complianceCmd.AddCommand(newReportCmd())
+	return complianceCmd.Command
+}
+
+func newComplianceShowCmd() *cobra.Command {
+	opts := &complianceFlags{}
+	showCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_SHOW,
+		Short:   "Check the backup compliance of all the tenants with RPO targets or a specific tenant.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return complianceShow(opts)
+		}),
+		Example: `  obshell backup compliance show
+  obshell backup compliance show -t tenant1`,
+	})
+	showCmd.Flags().SortFlags = false
+	showCmd.VarsPs(&opts.tenantName, []string{tenant.FLAG_TENANT_NAME, tenant.FLAG_TENANT_NAME_SH}, "", "The name of the tenant.", false)
+	showCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return showCmd.Command
+}
+
+func newSetTargetCmd() *cobra.Command {
+	opts := &complianceFlags{}
+	setTargetCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_SET_TARGET,
+		Short:   "Set the RPO and backup frequency targets of the tenant.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			p := &param.BackupRpoTargetParam{RpoTarget: opts.rpoTarget}
+			if cmd.Flags().Changed(FLAG_FULL_BACKUP_INTERVAL) {
+				p.FullBackupInterval = &opts.fullBackupInterval
+			}
+			if cmd.Flags().Changed(FLAG_INC_BACKUP_INTERVAL) {
+				p.IncBackupInterval = &opts.incBackupInterval
+			}
+			return setTarget(opts.tenantName, p)
+		}),
+		Example: `  obshell backup compliance set-target -t tenant1 --rpo 5m
+  obshell backup compliance set-target -t tenant1 --rpo 5m --full-interval 7d --inc-interval 1d`,
+	})
+	setTargetCmd.Flags().SortFlags = false
+	setTargetCmd.VarsPs(&opts.tenantName, []string{tenant.FLAG_TENANT_NAME, tenant.FLAG_TENANT_NAME_SH}, "", "The name of the tenant.", true)
+	setTargetCmd.VarsPs(&opts.rpoTarget, []string{FLAG_RPO_TARGET}, "", "The recovery point objective, such as '5m'.", true)
+	setTargetCmd.VarsPs(&opts.fullBackupInterval, []string{FLAG_FULL_BACKUP_INTERVAL}, "", "The max interval between two successful full backups, such as '7d'. Empty means not checked.", false)
+	setTargetCmd.VarsPs(&opts.incBackupInterval, []string{FLAG_INC_BACKUP_INTERVAL}, "", "The max interval between two successful backups of any type, such as '1d'. Empty means not checked.", false)
+	setTargetCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return setTargetCmd.Command
+}
+
+func newDropTargetCmd() *cobra.Command {
+	opts := &complianceFlags{}
+	dropTargetCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_DROP_TARGET,
+		Short:   "Stop checking the backup compliance of the tenant, the daily records are kept.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			uri := constant.URI_TENANT_API_PREFIX + "/" + opts.tenantName + constant.URI_BACKUP + constant.URI_RPO_TARGET
+			if err := api.CallApiWithMethod(http.DELETE, uri, nil, nil); err != nil {
+				return err
+			}
+			stdio.Infof("The RPO target of tenant %s has been dropped.", opts.tenantName)
+			return nil
+		}),
+		Example: `  obshell backup compliance drop-target -t tenant1`,
+	})
+	dropTargetCmd.Flags().SortFlags = false
+	dropTargetCmd.VarsPs(&opts.tenantName, []string{tenant.FLAG_TENANT_NAME, tenant.FLAG_TENANT_NAME_SH}, "", "The name of the tenant.", true)
+	dropTargetCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return dropTargetCmd.Command
+}
+
+func newReportCmd() *cobra.Command {
+	opts := &complianceFlags{}
+	reportCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_REPORT,
+		Short:   "Show the daily backup compliance report.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return complianceReport(opts)
+		}),
+		Example: `  obshell backup compliance report
+  obshell backup compliance report -t tenant1 --start-date 2024-01-01 --end-date 2024-01-31`,
+	})
+	reportCmd.Flags().SortFlags = false
+	reportCmd.VarsPs(&opts.tenantName, []string{tenant.FLAG_TENANT_NAME, tenant.FLAG_TENANT_NAME_SH}, "", "The name of the tenant, all the tenants if not specified.", false)
+	reportCmd.VarsPs(&opts.startDate, []string{FLAG_START_DATE}, "", "The start date, such as '2024-01-01'. Defaults to 6 days before the end date.", false)
+	reportCmd.VarsPs(&opts.endDate, []string{FLAG_END_DATE}, "", "The end date, such as '2024-01-07'. Defaults to today.", false)
+	reportCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return reportCmd.Command
+}
+
+func complianceShow(opts *complianceFlags) error {
+	compliances := make([]bo.BackupCompliance, 0)
+	if opts.tenantName != "" {
+		var compliance bo.BackupCompliance
+		uri := constant.URI_TENANT_API_PREFIX + "/" + opts.tenantName + constant.URI_BACKUP + constant.URI_COMPLIANCE
+		if err := api.CallApiWithMethod(http.GET, uri, nil, &compliance); err != nil {
+			return err
+		}
+		compliances = append(compliances, compliance)
+	} else if err := api.CallApiWithMethod(http.GET, constant.URI_OBCLUSTER_API_PREFIX+constant.URI_BACKUP+constant.URI_COMPLIANCE, nil, &compliances); err != nil {
+		return err
+	}
+	if len(compliances) == 0 {
+		stdio.Info("No tenant has RPO target.")
+		return nil
+	}
+
+	data := make([][]string, 0, len(compliances))
+	for _, compliance := range compliances {
+		rpo := "-"
+		if compliance.Rpo >= 0 {
+			rpo = formatSeconds(compliance.Rpo)
+		}
+		data = append(data, []string{
+			compliance.TenantName,
+			compliance.Status,
+			rpo,
+			formatSeconds(compliance.Target.RpoTarget),
+			compliance.ArchiveStatus,
+			formatTime(compliance.LastFullBackupTime),
+			formatTime(latestTime(compliance.LastFullBackupTime, compliance.LastIncBackupTime)),
+			strings.Join(compliance.Violations, "; "),
+		})
+	}
+	stdio.PrintTable(complianceHeader, data)
+	for _, compliance := range compliances {
+		for _, warning := range compliance.Warnings {
+			stdio.Warnf("%s: %s", compliance.TenantName, warning)
+		}
+	}
+	return nil
+}
+
+func setTarget(tenantName string, p *param.BackupRpoTargetParam) error {
+	var target bo.BackupRpoTarget
+	uri := constant.URI_TENANT_API_PREFIX + "/" + tenantName + constant.URI_BACKUP + constant.URI_RPO_TARGET
+	if err := api.CallApiWithMethod(http.PUT, uri, p, &target); err != nil {
+		return err
+	}
+	stdio.Infof("The RPO target of tenant %s has been set to %s.", tenantName, formatSeconds(target.RpoTarget))
+	return nil
+}
+
+func complianceReport(opts *complianceFlags) error {
+	uri := constant.URI_OBCLUSTER_API_PREFIX + constant.URI_BACKUP + constant.URI_COMPLIANCE + constant.URI_REPORT
+	if opts.tenantName != "" {
+		uri = constant.URI_TENANT_API_PREFIX + "/" + opts.tenantName + constant.URI_BACKUP + constant.URI_COMPLIANCE + constant.URI_REPORT
+	}
+	query := map[string]string{}
+	if opts.startDate != "" {
+		query["start_date"] = opts.startDate
+	}
+	if opts.endDate != "" {
+		query["end_date"] = opts.endDate
+	}
+
+	var report bo.BackupComplianceReport
+	if err := api.CallApiWithMethod(http.GET, uri, query, &report); err != nil {
+		return err
+	}
+	if len(report.Days) == 0 {
+		stdio.Infof("No compliance check from %s to %s.", report.StartDate, report.EndDate)
+		return nil
+	}
+
+	data := make([][]string, 0, len(report.Days))
+	for _, day := range report.Days {
+		result := "PASS"
+		if !day.Compliant {
+			result = "FAIL"
+		}
+		data = append(data, []string{
+			day.Date,
+			day.TenantName,
+			formatSeconds(day.RpoTarget),
+			fmt.Sprintf("%d/%d", day.CheckCount, day.ExpectedCheckCount),
+			fmt.Sprint(day.CompliantCount),
+			formatSeconds(day.MaxRpo),
+			formatTime(day.FirstViolationTime),
+			day.LastViolation,
+			result,
+		})
+	}
+	stdio.PrintTable(reportHeader, data)
+	if report.Compliant {
+		stdio.Infof("All the tenants complied with their RPO targets from %s to %s.", report.StartDate, report.EndDate)
+	} else {
+		stdio.Warnf("Some tenants violated their RPO targets from %s to %s.", report.StartDate, report.EndDate)
+	}
+	return nil
+}
+
+func formatSeconds(seconds int64) string {
+	if seconds < 0 {
+		return "-"
+	}
+	return (time.Duration(seconds) * time.Second).String()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format(time.DateTime)
+}
+
+func latestTime(a, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.After(*a)) {
+		return b
+	}
+	return a
+}
@@ -43,5 +43,6 @@ func NewBackupCmd() *cobra.Command {
 	})
 	taskCmd.AddCommand(newShowCmd())
 	taskCmd.AddCommand(NewSetConfigCmd())
+	taskCmd.AddCommand(newComplianceCmd())
 	return taskCmd.Command
 }
@@ -97,6 +97,13 @@ const (
 	// CMD_SCALE_IN represents the "scale-in" command.
 	CMD_SCALE_IN = "scale-in"
 
+	// CMD_REPLACE represents the "replace" command used to replace an observer with a new one.
+	CMD_REPLACE = "replace"
+
+	FLAG_NEW_SERVER     = "new_server"
+	FLAG_NEW_SERVER_SH  = "n"
+	FLAG_AGENT_PASSWORD = "agent_password"
+
 	// CMD_UPGRADE represents the "upgrade" command for upgrading the cluster.
 	CMD_UPGRADE = "upgrade"
 	// Flags for the "upgrade" command.
@@ -154,7 +161,7 @@ func NewClusterCmd() *cobra.Command {
 			case CMD_START:
 				AsyncCheckAndStartDaemon()
 				fmt.Println("Starting the OceanBase cluster, please wait...")
-			case CMD_STOP, CMD_SHOW, CMD_SCALE_OUT, CMD_UPGRADE, CMD_SCALE_IN:
+			case CMD_STOP, CMD_SHOW, CMD_SCALE_OUT, CMD_UPGRADE, CMD_SCALE_IN, CMD_REPLACE:
 				return CheckAndStartDaemon(true)
 			default:
 				return CheckAndStartDaemon()
@@ -169,6 +176,7 @@ func NewClusterCmd() *cobra.Command {
 	clusterCmd.AddCommand(newUpgradeCmd())
 	clusterCmd.AddCommand(NewScaleOutCmd())
 	clusterCmd.AddCommand(NewScaleInCmd())
+	clusterCmd.AddCommand(newReplaceCmd())
 	clusterCmd.AddCommand(newShowCmd())
 	clusterCmd.AddCommand(newStopCmd())
 	clusterCmd.AddCommand(newBackupCmd())
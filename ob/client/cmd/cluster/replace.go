@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/ob/agent/config"
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/engine/task"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/lib/http"
+	ocsagentlog "github.com/oceanbase/obshell/ob/agent/log"
+	"github.com/oceanbase/obshell/ob/agent/meta"
+	"github.com/oceanbase/obshell/ob/client/command"
+	clientconst "github.com/oceanbase/obshell/ob/client/constant"
+	"github.com/oceanbase/obshell/ob/client/global"
+	"github.com/oceanbase/obshell/ob/client/lib/stdio"
+	"github.com/oceanbase/obshell/ob/client/utils/api"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+type ClusterReplaceFlags struct {
+	server        string // the server holding the observer to be replaced
+	newServer     string // the server to hold the new observer
+	agentPassword string // the password of the agent on the new server
+	global.DropFlags
+	ObserverConfigFlags
+}
+
+func newReplaceCmd() *cobra.Command {
+	opts := &ClusterReplaceFlags{}
+	replaceCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_REPLACE,
+		Short: "Replace a observer with a new one on another server in the same zone.",
+		Long:  "Replace a observer with a new one on another server in the same zone. The units on the old observer will be migrated to the new one, and then the old observer will be deleted from the cluster.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			ocsagentlog.InitLogger(config.DefaultClientLoggerConifg())
+			ocsagentlog.SetDBLoggerLevel(ocsagentlog.Silent)
+			stdio.SetSkipConfirmMode(opts.SkipConfirm)
+			stdio.SetVerboseMode(opts.Verbose)
+			return clusterReplace(cmd, opts)
+		}),
+		Example: `  obshell cluster replace -s 192.168.1.1:2886 -n 192.168.1.2:2886`,
+	})
+
+	replaceCmd.Flags().SortFlags = false
+	// Setup of required flags for 'replace' command.
+	replaceCmd.VarsPs(&opts.server, []string{FLAG_SERVER_SH, FLAG_SERVER}, "", "The address of the server holding the observer to be replaced. If the port is unspecified, it will be 2886.", true)
+	replaceCmd.VarsPs(&opts.newServer, []string{FLAG_NEW_SERVER_SH, FLAG_NEW_SERVER}, "", "The address of the server to hold the new observer, whose agent must be single. If the port is unspecified, it will be 2886.", true)
+
+	// Configuration of optional flags for the new observer, the ports default to the ones of the old observer.
+	replaceCmd.VarsPs(&opts.mysqlPort, []string{FLAG_MYSQL_PORT_SH, FLAG_MYSQL_PORT}, 0, "The SQL service port for the new observer.", false)
+	replaceCmd.VarsPs(&opts.rpcPort, []string{FLAG_RPC_PORT_SH, FLAG_RPC_PORT}, 0, "The remote access port for the new observer.", false)
+	replaceCmd.VarsPs(&opts.dataDir, []string{FLAG_DATA_DIR_SH, FLAG_DATA_DIR}, "", "The directory for storing the new observer's data.", false)
+	replaceCmd.VarsPs(&opts.redoDir, []string{FLAG_REDO_DIR_SH, FLAG_REDO_DIR}, "", "The directory for storing the new observer's clogs.", false)
+	replaceCmd.VarsPs(&opts.logLevel, []string{FLAG_LOG_LEVEL_SH, FLAG_LOG_LEVEL}, "", "The log print level for the new observer.", false)
+	replaceCmd.VarsPs(&opts.optStr, []string{FLAG_OPT_STR_SH, FLAG_OPT_STR}, "", "Additional parameters for the new observer, use the format key=value for each configuration, separated by commas.", false)
+	replaceCmd.VarsPs(&opts.agentPassword, []string{FLAG_AGENT_PASSWORD}, "", "The password of the agent on the new server.", false)
+
+	replaceCmd.VarsPs(&opts.SkipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt", false)
+	replaceCmd.VarsPs(&opts.Verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+
+	return replaceCmd.Command
+}
+
+func clusterReplace(cmd *cobra.Command, flags *ClusterReplaceFlags) error {
+	if err := parseObserverConfigFlags(cmd, &flags.ObserverConfigFlags); err != nil {
+		return err
+	}
+	oldAgent, err := meta.ConvertAddressToAgentInfo(flags.server)
+	if err != nil {
+		return err
+	}
+	newAgent, err := meta.ConvertAddressToAgentInfo(flags.newServer)
+	if err != nil {
+		return err
+	}
+
+	pass, err := stdio.Confirm(fmt.Sprintf("Please confirm if you need to replace the observer on '%s' with a new one on '%s'. The observer on '%s' will be deleted from cluster.", flags.server, flags.newServer, flags.server))
+	if err != nil {
+		return errors.Wrap(err, "ask for replace confirmation failed")
+	}
+	if !pass {
+		return errors.Occur(errors.ErrCliOperationCancelled)
+	}
+
+	replaceParam := param.ReplaceObserverParam{
+		OldAgent:            *oldAgent,
+		NewAgent:            *newAgent,
+		ObConfigs:           flags.parsedConfig,
+		TargetAgentPassword: flags.agentPassword,
+	}
+	stdio.StartLoading("Calling API to replace observer")
+	var dag task.DagDetailDTO
+	if err := api.CallApiWithMethod(http.POST, constant.URI_OBSERVER_API_PREFIX+constant.URI_REPLACE, replaceParam, &dag); err != nil {
+		return err
+	}
+	stdio.StopLoading()
+	return api.NewDagHandler(&dag).PrintDagStage()
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedule
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/lib/http"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/ob/client/command"
+	clientconst "github.com/oceanbase/obshell/ob/client/constant"
+	"github.com/oceanbase/obshell/ob/client/lib/stdio"
+	"github.com/oceanbase/obshell/ob/client/utils/api"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+type createFlags struct {
+	param.CreateScheduleParam
+	disabled bool
+	verbose  bool
+}
+
+func newCreateCmd() *cobra.Command {
+	opts := &createFlags{}
+	createCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_CREATE,
+		Short: "Create a scheduled job triggered by a cron expression.",
+		Long: "Create a scheduled job triggered by a cron expression. The job is triggered by the maintainer of the cluster, " +
+			"and a run is skipped if the task submitted by the previous run is still running.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) <= 0 {
+				return errors.Occur(errors.ErrCliUsageError, "schedule name is required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			opts.Name = args[0]
+			return scheduleCreate(opts)
+		}),
+		Example: createCmdExample(),
+	})
+
+	createCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: scheduleArgsAnnotate}
+	createCmd.Flags().SortFlags = false
+	createCmd.VarsPs(&opts.JobType, []string{FLAG_TYPE, FLAG_TYPE_SH}, "", "The job type, 'backup', 'compaction' or 'inspection'.", true)
+	createCmd.VarsPs(&opts.Cron, []string{FLAG_CRON, FLAG_CRON_SH}, "", "The cron expression with five fields, such as '0 2 * * *', or a macro such as '@hourly'.", true)
+	createCmd.VarsPs(&opts.TenantName, []string{FLAG_TENANT, FLAG_TENANT_SH}, "", "The tenant name, required by the backup and compaction jobs.", false)
+	createCmd.VarsPs(&opts.BackupMode, []string{FLAG_MODE}, "", "The backup mode, 'full' or 'incremental'. Default 'full'.", false)
+	createCmd.VarsPs(&opts.PlusArchive, []string{FLAG_PLUS_ARCHIVE}, false, "Backup plus archive log.", false)
+	createCmd.VarsPs(&opts.Scenario, []string{FLAG_SCENARIO}, "", "The inspection scenario, 'basic' or 'performance'. Default 'basic'.", false)
+	createCmd.VarsPs(&opts.Description, []string{FLAG_DESCRIPTION, FLAG_DESCRIPTION_SH}, "", "The description of the schedule.", false)
+	createCmd.VarsPs(&opts.disabled, []string{FLAG_DISABLED}, false, "Create the schedule disabled.", false)
+	createCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return createCmd.Command
+}
+
+func scheduleCreate(opts *createFlags) error {
+	enabled := !opts.disabled
+	opts.Enabled = &enabled
+
+	var schedule bo.Schedule
+	stdio.StartLoadingf("create schedule %s", opts.Name)
+	if err := api.CallApiWithMethod(http.POST, constant.URI_API_V1+constant.URI_SCHEDULES, &opts.CreateScheduleParam, &schedule); err != nil {
+		return err
+	}
+	stdio.LoadSuccessf("create schedule %s", opts.Name)
+	printSchedules([]bo.Schedule{schedule})
+	return nil
+}
+
+func createCmdExample() string {
+	return `  obshell schedule create nightly-full -t backup -c '0 2 * * *' -n t1
+  obshell schedule create hourly-inc -t backup -c '@hourly' -n t1 --mode incremental
+  obshell schedule create weekly-inspection -t inspection -c '0 3 * * SUN' --scenario performance`
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedule
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/ob/agent/global"
+	"github.com/oceanbase/obshell/ob/client/cmd/cluster"
+	"github.com/oceanbase/obshell/ob/client/command"
+	clientconst "github.com/oceanbase/obshell/ob/client/constant"
+	"github.com/oceanbase/obshell/ob/client/lib/stdio"
+)
+
+const (
+	CMD_CREATE  = "create"
+	CMD_SHOW    = "show"
+	CMD_UPDATE  = "update"
+	CMD_DROP    = "drop"
+	CMD_HISTORY = "history"
+
+	FLAG_TYPE            = "type"
+	FLAG_TYPE_SH         = "t"
+	FLAG_CRON            = "cron"
+	FLAG_CRON_SH         = "c"
+	FLAG_TENANT          = "tenant"
+	FLAG_TENANT_SH       = "n"
+	FLAG_MODE            = "mode"
+	FLAG_PLUS_ARCHIVE    = "plus_archive"
+	FLAG_SCENARIO        = "scenario"
+	FLAG_DESCRIPTION     = "description"
+	FLAG_DESCRIPTION_SH  = "d"
+	FLAG_DISABLED        = "disabled"
+	FLAG_ENABLE          = "enable"
+	FLAG_DISABLE         = "disable"
+	FLAG_LIMIT           = "limit"
+	FLAG_LIMIT_SH        = "l"
+	scheduleArgsAnnotate = "<schedule-name>"
+)
+
+func NewScheduleCmd() *cobra.Command {
+	scheduleCmd := command.NewCommand(&cobra.Command{
+		Use:   clientconst.CMD_SCHEDULE,
+		Short: "Manage the scheduled backup, compaction and inspection jobs.",
+		PersistentPreRunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			defer stdio.StopLoading()
+			global.InitGlobalVariable()
+			return cluster.CheckAndStartDaemon()
+		}),
+	})
+	scheduleCmd.AddCommand(newCreateCmd())
+	scheduleCmd.AddCommand(newShowCmd())
+	scheduleCmd.AddCommand(newUpdateCmd())
+	scheduleCmd.AddCommand(newDropCmd())
+	scheduleCmd.AddCommand(newHistoryCmd())
+	return scheduleCmd.Command
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedule
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/lib/http"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/ob/client/command"
+	clientconst "github.com/oceanbase/obshell/ob/client/constant"
+	"github.com/oceanbase/obshell/ob/client/lib/stdio"
+	"github.com/oceanbase/obshell/ob/client/utils/api"
+)
+
+var historyHeader = []string{"Trigger Time", "Status", "Task", "Message"}
+
+func newHistoryCmd() *cobra.Command {
+	var limit int
+	var verbose bool
+	historyCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_HISTORY,
+		Short: "Show the latest runs of a schedule.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) <= 0 {
+				return errors.Occur(errors.ErrCliUsageError, "schedule name is required")
+			}
+			stdio.SetVerboseMode(verbose)
+			stdio.SetSilenceMode(false)
+			return scheduleHistory(args[0], limit)
+		}),
+		Example: `  obshell schedule history nightly-full
+  obshell schedule history nightly-full -l 50`,
+	})
+
+	historyCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: scheduleArgsAnnotate}
+	historyCmd.Flags().SortFlags = false
+	historyCmd.VarsPs(&limit, []string{FLAG_LIMIT, FLAG_LIMIT_SH}, constant.SCHEDULE_RUN_DEFAULT_LIMIT, "Max number of the runs to show.", false)
+	historyCmd.VarsPs(&verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return historyCmd.Command
+}
+
+func scheduleHistory(name string, limit int) error {
+	var runs []bo.ScheduleRun
+	query := map[string]string{"limit": strconv.Itoa(limit)}
+	if err := api.CallApiWithMethod(http.GET, constant.URI_API_V1+constant.URI_SCHEDULES+"/"+name+constant.URI_RUNS, query, &runs); err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		stdio.Infof("Schedule %s has not been triggered yet.", name)
+		return nil
+	}
+	data := make([][]string, 0, len(runs))
+	for _, run := range runs {
+		data = append(data, []string{run.TriggerTime.Local().Format(time.DateTime), run.Status, run.DagId, run.Message})
+	}
+	stdio.PrintTable(historyHeader, data)
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedule
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/lib/http"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/ob/client/command"
+	clientconst "github.com/oceanbase/obshell/ob/client/constant"
+	"github.com/oceanbase/obshell/ob/client/lib/stdio"
+	"github.com/oceanbase/obshell/ob/client/utils/api"
+)
+
+var showHeader = []string{"Name", "Type", "Cron", "Enabled", "Target", "Last Run", "Next Run"}
+
+func newShowCmd() *cobra.Command {
+	var verbose bool
+	showCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_SHOW,
+		Short: "Show all the schedules or the specified one.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(verbose)
+			stdio.SetSilenceMode(false)
+			if len(args) > 0 {
+				return scheduleShow(args[0])
+			}
+			return scheduleList()
+		}),
+		Example: `  obshell schedule show
+  obshell schedule show nightly-full`,
+	})
+
+	showCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "[schedule-name]"}
+	showCmd.Flags().SortFlags = false
+	showCmd.VarsPs(&verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return showCmd.Command
+}
+
+func scheduleList() error {
+	var schedules []bo.Schedule
+	if err := api.CallApiWithMethod(http.GET, constant.URI_API_V1+constant.URI_SCHEDULES, nil, &schedules); err != nil {
+		return err
+	}
+	if len(schedules) == 0 {
+		stdio.Info("No schedule found.")
+		return nil
+	}
+	printSchedules(schedules)
+	return nil
+}
+
+func scheduleShow(name string) error {
+	var schedule bo.Schedule
+	if err := api.CallApiWithMethod(http.GET, constant.URI_API_V1+constant.URI_SCHEDULES+"/"+name, nil, &schedule); err != nil {
+		return err
+	}
+	printSchedules([]bo.Schedule{schedule})
+	if schedule.Description != "" {
+		stdio.Printf("Description: %s", schedule.Description)
+	}
+	return nil
+}
+
+func printSchedules(schedules []bo.Schedule) {
+	data := make([][]string, 0, len(schedules))
+	for _, schedule := range schedules {
+		data = append(data, []string{schedule.Name, schedule.JobType, schedule.Cron, formatEnabled(schedule.Enabled),
+			scheduleTarget(&schedule), formatTime(schedule.LastRunTime), formatTime(schedule.NextRunTime)})
+	}
+	stdio.PrintTable(showHeader, data)
+}
+
+func scheduleTarget(schedule *bo.Schedule) string {
+	switch schedule.JobType {
+	case constant.SCHEDULE_JOB_TYPE_BACKUP:
+		target := []string{schedule.TenantName, schedule.BackupMode}
+		if schedule.PlusArchive {
+			target = append(target, "plus archive")
+		}
+		return strings.Join(target, ", ")
+	case constant.SCHEDULE_JOB_TYPE_INSPECTION:
+		return schedule.Scenario
+	default:
+		return schedule.TenantName
+	}
+}
+
+func formatEnabled(enabled bool) string {
+	if enabled {
+		return "yes"
+	}
+	return "no"
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format(time.DateTime)
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schedule
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/lib/http"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/ob/client/command"
+	clientconst "github.com/oceanbase/obshell/ob/client/constant"
+	"github.com/oceanbase/obshell/ob/client/lib/stdio"
+	"github.com/oceanbase/obshell/ob/client/utils/api"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+type updateFlags struct {
+	cron        string
+	tenant      string
+	mode        string
+	plusArchive bool
+	scenario    string
+	description string
+	enable      bool
+	disable     bool
+	verbose     bool
+}
+
+func newUpdateCmd() *cobra.Command {
+	opts := &updateFlags{}
+	updateCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_UPDATE,
+		Short: "Update the cron expression or the parameters of a schedule, or enable/disable it.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) <= 0 {
+				return errors.Occur(errors.ErrCliUsageError, "schedule name is required")
+			}
+			if opts.enable && opts.disable {
+				return errors.Occur(errors.ErrCliUsageError, "--enable and --disable are mutually exclusive")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return scheduleUpdate(cmd, args[0], opts)
+		}),
+		Example: `  obshell schedule update nightly-full -c '30 1 * * *'
+  obshell schedule update hourly-inc --disable`,
+	})
+
+	updateCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: scheduleArgsAnnotate}
+	updateCmd.Flags().SortFlags = false
+	updateCmd.VarsPs(&opts.cron, []string{FLAG_CRON, FLAG_CRON_SH}, "", "The new cron expression.", false)
+	updateCmd.VarsPs(&opts.tenant, []string{FLAG_TENANT, FLAG_TENANT_SH}, "", "The new tenant name.", false)
+	updateCmd.VarsPs(&opts.mode, []string{FLAG_MODE}, "", "The new backup mode, 'full' or 'incremental'.", false)
+	updateCmd.VarsPs(&opts.plusArchive, []string{FLAG_PLUS_ARCHIVE}, false, "Whether to backup plus archive log.", false)
+	updateCmd.VarsPs(&opts.scenario, []string{FLAG_SCENARIO}, "", "The new inspection scenario, 'basic' or 'performance'.", false)
+	updateCmd.VarsPs(&opts.description, []string{FLAG_DESCRIPTION, FLAG_DESCRIPTION_SH}, "", "The new description.", false)
+	updateCmd.VarsPs(&opts.enable, []string{FLAG_ENABLE}, false, "Enable the schedule.", false)
+	updateCmd.VarsPs(&opts.disable, []string{FLAG_DISABLE}, false, "Disable the schedule.", false)
+	updateCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return updateCmd.Command
+}
+
+func scheduleUpdate(cmd *cobra.Command, name string, opts *updateFlags) error {
+	p := param.UpdateScheduleParam{}
+	if cmd.Flags().Changed(FLAG_CRON) {
+		p.Cron = &opts.cron
+	}
+	if cmd.Flags().Changed(FLAG_TENANT) {
+		p.TenantName = &opts.tenant
+	}
+	if cmd.Flags().Changed(FLAG_MODE) {
+		p.BackupMode = &opts.mode
+	}
+	if cmd.Flags().Changed(FLAG_PLUS_ARCHIVE) {
+		p.PlusArchive = &opts.plusArchive
+	}
+	if cmd.Flags().Changed(FLAG_SCENARIO) {
+		p.Scenario = &opts.scenario
+	}
+	if cmd.Flags().Changed(FLAG_DESCRIPTION) {
+		p.Description = &opts.description
+	}
+	if opts.enable || opts.disable {
+		enabled := opts.enable
+		p.Enabled = &enabled
+	}
+
+	var schedule bo.Schedule
+	stdio.StartLoadingf("update schedule %s", name)
+	if err := api.CallApiWithMethod(http.PATCH, constant.URI_API_V1+constant.URI_SCHEDULES+"/"+name, &p, &schedule); err != nil {
+		return err
+	}
+	stdio.LoadSuccessf("update schedule %s", name)
+	printSchedules([]bo.Schedule{schedule})
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenant
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/client/command"
+	clientconst "github.com/oceanbase/obshell/ob/client/constant"
+	cmdlib "github.com/oceanbase/obshell/ob/client/lib/cmd"
+	"github.com/oceanbase/obshell/ob/client/lib/stdio"
+	"github.com/oceanbase/obshell/ob/client/utils/api"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+type tenantCloneFlags struct {
+	newName            string
+	snapshot           string
+	unit               string
+	whitelist          string
+	rootPassword       string
+	sourceRootPassword string
+	verbose            bool
+}
+
+func newCloneCmd() *cobra.Command {
+	opts := &tenantCloneFlags{}
+	cloneCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_CLONE,
+		Short:   "Clone a new tenant from the tenant or its snapshot.",
+		PreRunE: cmdlib.ValidateArgTenantName,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			return tenantClone(cmd, args[0], opts)
+		}),
+		Example: `  obshell tenant clone t1 -n t1_qa -u s1
+  obshell tenant clone t1 -n t1_qa -u s1 --snapshot snap_daily --whitelist '%' --root_password ******`,
+	})
+	cloneCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<source-tenant-name>"}
+	cloneCmd.Flags().SortFlags = false
+	cloneCmd.VarsPs(&opts.newName, []string{FLAG_NEW_NAME, FLAG_NEW_NAME_SH}, "", "The name of the new tenant.", true)
+	cloneCmd.VarsPs(&opts.unit, []string{FLAG_UNIT, FLAG_UNIT_SH}, "", "The unit config name of the new tenant.", true)
+	cloneCmd.VarsPs(&opts.snapshot, []string{FLAG_SNAPSHOT}, "", "The snapshot of the source tenant to clone from, clone from the current state of the source tenant if not specified.", false)
+	cloneCmd.VarsPs(&opts.whitelist, []string{FLAG_WHITELIST}, "", "The whitelist of the new tenant, inherited from the source tenant if not specified.", false)
+	cloneCmd.VarsPs(&opts.rootPassword, []string{FLAG_ROOT_PASSWORD}, "", "The root password of the new tenant, inherited from the source tenant if not specified.", false)
+	cloneCmd.VarsPs(&opts.sourceRootPassword, []string{FLAG_SOURCE_ROOT_PASSWORD}, "", "The root password of the source tenant.", false)
+	cloneCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return cloneCmd.Command
+}
+
+func tenantClone(cmd *cobra.Command, sourceTenantName string, opts *tenantCloneFlags) error {
+	cloneParam := &param.CloneTenantParam{
+		Name:           opts.newName,
+		SnapshotName:   opts.snapshot,
+		UnitConfigName: opts.unit,
+		RootPassword:   opts.rootPassword,
+	}
+	if cmd.Flags().Changed(FLAG_WHITELIST) {
+		cloneParam.Whitelist = &opts.whitelist
+	}
+	if cmd.Flags().Changed(FLAG_SOURCE_ROOT_PASSWORD) {
+		cloneParam.SourceRootPassword = &opts.sourceRootPassword
+	}
+
+	uri := constant.URI_TENANT_API_PREFIX + "/" + sourceTenantName + constant.URI_CLONE
+	dag, err := api.CallApiAndPrintStage(uri, cloneParam)
+	if err != nil {
+		return errors.Wrapf(err, "clone tenant '%s' from '%s' failed", opts.newName, sourceTenantName)
+	}
+	stdio.Verbosef("Clone tenant successfully, DAG ID: %s", dag.GenericID)
+	return nil
+}
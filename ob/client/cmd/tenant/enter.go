@@ -23,6 +23,8 @@ import (
 	"github.com/oceanbase/obshell/ob/client/cmd/cluster"
 	"github.com/oceanbase/obshell/ob/client/cmd/tenant/parameter"
 	"github.com/oceanbase/obshell/ob/client/cmd/tenant/replica"
+	"github.com/oceanbase/obshell/ob/client/cmd/tenant/snapshot"
+	"github.com/oceanbase/obshell/ob/client/cmd/tenant/standby"
 	"github.com/oceanbase/obshell/ob/client/cmd/tenant/variable"
 	"github.com/oceanbase/obshell/ob/client/command"
 	clientconst "github.com/oceanbase/obshell/ob/client/constant"
@@ -81,6 +83,13 @@ const (
 	FLAG_KMS_ENCRYPT_INFO    = "kms_encrypt_info"
 	FLAG_KMS_ENCRYPT_INFO_SH = "k"
 
+	// obshell tenant restore-table
+	CMD_RESTORE_TABLE    = "restore-table"
+	FLAG_TABLES          = "tables"
+	FLAG_DATABASES       = "databases"
+	FLAG_REMAP_TABLES    = "remap_tables"
+	FLAG_REMAP_DATABASES = "remap_databases"
+
 	// obshell tenant modify
 	CMD_MODIFY        = "modify"
 	FLAG_OLD_PASSWORD = "old_password"
@@ -119,6 +128,11 @@ const (
 
 	// obshell tenant archive-log close
 	CMD_NO_ARCHIVE_LOG = "noarchivelog"
+
+	// obshell tenant clone
+	CMD_CLONE                 = "clone"
+	FLAG_SNAPSHOT             = "snapshot"
+	FLAG_SOURCE_ROOT_PASSWORD = "source_root_password"
 )
 
 func NewTenantCmd() *cobra.Command {
@@ -143,8 +157,12 @@ func NewTenantCmd() *cobra.Command {
 	tenantCmd.AddCommand(newRenameCmd())
 	tenantCmd.AddCommand(newBackupCmd())
 	tenantCmd.AddCommand(newRestoreCmd())
+	tenantCmd.AddCommand(newRestoreTableCmd())
 	tenantCmd.AddCommand(newArchiveLogCmd())
 	tenantCmd.AddCommand(newNoArchiveLogCmd())
+	tenantCmd.AddCommand(standby.NewStandbyCmd())
+	tenantCmd.AddCommand(snapshot.NewSnapshotCmd())
+	tenantCmd.AddCommand(newCloneCmd())
 
 	return tenantCmd.Command
 }
@@ -0,0 +1,201 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenant
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/client/cmd/tenant/replica"
+	"github.com/oceanbase/obshell/ob/client/command"
+	clientconst "github.com/oceanbase/obshell/ob/client/constant"
+	cmdlib "github.com/oceanbase/obshell/ob/client/lib/cmd"
+	"github.com/oceanbase/obshell/ob/client/lib/stdio"
+	"github.com/oceanbase/obshell/ob/client/utils/api"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+type TenantRestoreTableFlags struct {
+	TenantName string
+
+	DataBackupUri string
+	ArchiveLogUri string
+
+	Tables         string
+	Databases      string
+	RemapTables    string
+	RemapDatabases string
+
+	Timestamp      string
+	SCN            int64
+	PrimaryZone    string
+	Concurrency    string
+	Decryption     string
+	KmsEncryptInfo string
+
+	verbose     bool
+	skipConfirm bool
+
+	replica.ZoneParamsFlags
+}
+
+func newRestoreTableCmd() *cobra.Command {
+	opts := &TenantRestoreTableFlags{}
+	restoreTableCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_RESTORE_TABLE,
+		Short:   "Restore tables or databases from backup into an existing tenant",
+		PreRunE: cmdlib.ValidateArgTenantName,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			stdio.SetSilenceMode(false)
+
+			opts.TenantName = args[0]
+			return tenantRestoreTable(cmd, opts)
+		}),
+		Example: restoreTableCmdExample(),
+	})
+
+	restoreTableCmd.Flags().SortFlags = false
+	restoreTableCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name>"}
+	restoreTableCmd.VarsPs(&opts.DataBackupUri, []string{FLAG_DATA_BACKUP_URI, FLAG_DATA_BACKUP_URI_SH}, "", "The directory path where the backups are stored.", true)
+	restoreTableCmd.VarsPs(&opts.Tables, []string{FLAG_TABLES}, "", "The tables to restore, separated by commas, such as 'db1.t1,db1.t2'.", false)
+	restoreTableCmd.VarsPs(&opts.Databases, []string{FLAG_DATABASES}, "", "The databases to restore, separated by commas.", false)
+	restoreTableCmd.VarsPs(&opts.RemapTables, []string{FLAG_REMAP_TABLES}, "", "The remap rules of tables, separated by commas, such as 'db1.t1:db2.t1_new'.", false)
+	restoreTableCmd.VarsPs(&opts.RemapDatabases, []string{FLAG_REMAP_DATABASES}, "", "The remap rules of databases, separated by commas, such as 'db1:db2'.", false)
+
+	restoreTableCmd.VarsPs(&opts.Zones, []string{FLAG_ZONE, FLAG_ZONE_SH}, "", "The zones of the auxiliary tenant.", false)
+	restoreTableCmd.VarsPs(&opts.UnitNum, []string{FLAG_UNIT_NUM}, 1, "The number of units in each zone of the auxiliary tenant.", false)
+	restoreTableCmd.VarsPs(&opts.UnitConfigName, []string{FLAG_UNIT, FLAG_UNIT_SH}, "", "The unit config name of the auxiliary tenant.", false)
+	restoreTableCmd.VarsPs(&opts.PrimaryZone, []string{FLAG_PRIMARY_ZONE, FLAG_PRIMARY_ZONE_SH}, "", "The primary zone of the auxiliary tenant.", false)
+
+	restoreTableCmd.VarsPs(&opts.Timestamp, []string{FLAG_TIMESTAMP, FLAG_TIMESTAMP_SH}, "", "The timestamp to restore to.", false)
+	restoreTableCmd.VarsPs(&opts.SCN, []string{FLAG_SCN, FLAG_SCN_SH}, int64(0), "The SCN to restore to.", false)
+	restoreTableCmd.VarsPs(&opts.ArchiveLogUri, []string{FLAG_ARCHIVE_LOG_URI, FLAG_ARCHIVE_LOG_URI_SH}, "", "The directory path where the archive logs are stored.", false)
+	restoreTableCmd.VarsPs(&opts.Concurrency, []string{FLAG_CONCURRENCY, FLAG_CONCURRENCY_SH}, "", "The number of threads to use for the restore operation.", false)
+	restoreTableCmd.VarsPs(&opts.Decryption, []string{FLAG_DECRYPTION, FLAG_DECRYPTION_SH}, "", "The decryption password for all backups.", false)
+	restoreTableCmd.VarsPs(&opts.KmsEncryptInfo, []string{FLAG_KMS_ENCRYPT_INFO, FLAG_KMS_ENCRYPT_INFO_SH}, "", "The KMS encryption information.", false)
+
+	restoreTableCmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt", false)
+	restoreTableCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+
+	return restoreTableCmd.Command
+}
+
+func tenantRestoreTable(cmd *cobra.Command, opts *TenantRestoreTableFlags) error {
+	param, err := opts.toRestoreTableParam(cmd)
+	if err != nil {
+		return err
+	}
+
+	msg := "Please confirm if you need to restore tables into tenant " + opts.TenantName
+	res, err := stdio.Confirm(msg)
+	if err != nil {
+		return errors.Wrap(err, "ask for restore table confirmation failed")
+	}
+	if !res {
+		return errors.Occur(errors.ErrCliOperationCancelled)
+	}
+
+	uri := constant.URI_TENANT_API_PREFIX + "/" + opts.TenantName + constant.URI_RESTORE + constant.URI_TABLE
+	dag, err := api.CallApiAndPrintStage(uri, param)
+	if err != nil {
+		return err
+	}
+	log.Info("Restore table successfully, DAG ID: ", dag.DagID)
+	return nil
+}
+
+func splitFlagList(value string) []string {
+	res := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+func (f *TenantRestoreTableFlags) toRestoreTableParam(cmd *cobra.Command) (*param.RestoreTableParam, error) {
+	zoneList, err := replica.BuildZoneParams(cmd, &f.ZoneParamsFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	restoreTableParam := &param.RestoreTableParam{
+		RestoreStorageParam: param.RestoreStorageParam{
+			DataBackupUri: f.DataBackupUri,
+		},
+		Tables:         splitFlagList(f.Tables),
+		Databases:      splitFlagList(f.Databases),
+		RemapTables:    splitFlagList(f.RemapTables),
+		RemapDatabases: splitFlagList(f.RemapDatabases),
+		ZoneList:       zoneList,
+	}
+	stdio.Verbosef("Zone list of auxiliary tenant is %v", restoreTableParam.ZoneList)
+
+	if f.Timestamp != "" {
+		timestamp, err := time.Parse(time.RFC3339, f.Timestamp)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid timestamp")
+		}
+		restoreTableParam.Timestamp = &timestamp
+	}
+
+	if f.SCN != 0 {
+		restoreTableParam.SCN = &f.SCN
+	}
+
+	if f.ArchiveLogUri != "" {
+		restoreTableParam.ArchiveLogUri = &f.ArchiveLogUri
+	}
+
+	if f.PrimaryZone != "" {
+		restoreTableParam.PrimaryZone = &f.PrimaryZone
+	}
+
+	if f.Concurrency != "" {
+		concurrency, err := strconv.Atoi(f.Concurrency)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid concurrency")
+		}
+		restoreTableParam.Concurrency = &concurrency
+	}
+
+	if f.Decryption != "" {
+		pwds := strings.Split(strings.TrimSpace(f.Decryption), ",")
+		restoreTableParam.Decryption = &pwds
+	}
+
+	if f.KmsEncryptInfo != "" {
+		restoreTableParam.KmsEncryptInfo = &f.KmsEncryptInfo
+	}
+
+	return restoreTableParam, nil
+}
+
+func restoreTableCmdExample() string {
+	return `  Restore a dropped table into tenant t1 as a new table, using an auxiliary tenant on unit1:
+    obshell tenant restore-table t1 --tables db1.orders --remap_tables db1.orders:db1.orders_restored --timestamp "2021-01-01T00:00:00.000+08:00" -z "zone1" -u unit1 -d '/path/to/backup/data' -a '/path/to/backup/clog'
+`
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/lib/http"
+	"github.com/oceanbase/obshell/ob/client/command"
+	clientconst "github.com/oceanbase/obshell/ob/client/constant"
+	cmdlib "github.com/oceanbase/obshell/ob/client/lib/cmd"
+	"github.com/oceanbase/obshell/ob/client/lib/stdio"
+	"github.com/oceanbase/obshell/ob/client/utils/api"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+func newCreateCmd() *cobra.Command {
+	var name string
+	var verbose bool
+	createCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_CREATE,
+		Short:   "Create a snapshot for the tenant.",
+		PreRunE: cmdlib.ValidateArgTenantName,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(verbose)
+			return snapshotCreate(args[0], name)
+		}),
+		Example: `  obshell tenant snapshot create t1
+  obshell tenant snapshot create t1 -n snap_daily`,
+	})
+	createCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name>"}
+	createCmd.Flags().SortFlags = false
+	createCmd.VarsPs(&name, []string{FLAG_NAME, FLAG_NAME_SH}, "", "The name of the snapshot, generated by OceanBase if not specified.", false)
+	createCmd.VarsPs(&verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return createCmd.Command
+}
+
+func snapshotCreate(tenantName, name string) error {
+	uri := constant.URI_TENANT_API_PREFIX + "/" + tenantName + constant.URI_SNAPSHOTS
+	if err := api.CallApiWithMethod(http.POST, uri, &param.CreateTenantSnapshotParam{Name: name}, nil); err != nil {
+		return errors.Wrapf(err, "create snapshot for tenant '%s' failed", tenantName)
+	}
+	stdio.Successf("Start to create snapshot for tenant %s, use 'obshell tenant snapshot show %s' to check the status", tenantName, tenantName)
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/agent/lib/http"
+	"github.com/oceanbase/obshell/ob/client/command"
+	clientconst "github.com/oceanbase/obshell/ob/client/constant"
+	cmdlib "github.com/oceanbase/obshell/ob/client/lib/cmd"
+	"github.com/oceanbase/obshell/ob/client/lib/stdio"
+	"github.com/oceanbase/obshell/ob/client/utils/api"
+)
+
+type snapshotDropFlags struct {
+	name        string
+	verbose     bool
+	skipConfirm bool
+}
+
+func newDropCmd() *cobra.Command {
+	opts := &snapshotDropFlags{}
+	dropCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_DROP,
+		Short:   "Drop a snapshot of the tenant.",
+		PreRunE: cmdlib.ValidateArgTenantName,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			return snapshotDrop(args[0], opts.name)
+		}),
+		Example: `  obshell tenant snapshot drop t1 -n snap_daily`,
+	})
+	dropCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name>"}
+	dropCmd.Flags().SortFlags = false
+	dropCmd.VarsPs(&opts.name, []string{FLAG_NAME, FLAG_NAME_SH}, "", "The name of the snapshot.", true)
+	dropCmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt", false)
+	dropCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return dropCmd.Command
+}
+
+func snapshotDrop(tenantName, name string) error {
+	pass, err := stdio.Confirmf("Please confirm if you need to drop snapshot %s of tenant %s", name, tenantName)
+	if err != nil {
+		return errors.Wrap(err, "ask for confirmation failed")
+	}
+	if !pass {
+		return nil
+	}
+
+	uri := constant.URI_TENANT_API_PREFIX + "/" + tenantName + constant.URI_SNAPSHOTS + "/" + name
+	if err := api.CallApiWithMethod(http.DELETE, uri, nil, nil); err != nil {
+		return errors.Wrapf(err, "drop snapshot '%s' of tenant '%s' failed", name, tenantName)
+	}
+	stdio.Successf("Drop snapshot %s of tenant %s successfully", name, tenantName)
+	return nil
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/ob/client/command"
+)
+
+const (
+	CMD_SNAPSHOT = "snapshot"
+
+	// obshell tenant snapshot create
+	CMD_CREATE   = "create"
+	FLAG_NAME    = "name"
+	FLAG_NAME_SH = "n"
+
+	// obshell tenant snapshot show
+	CMD_SHOW = "show"
+
+	// obshell tenant snapshot drop
+	CMD_DROP = "drop"
+)
+
+func NewSnapshotCmd() *cobra.Command {
+	snapshotCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_SNAPSHOT,
+		Short: "Manage the snapshots of the tenant.",
+	})
+	snapshotCmd.AddCommand(newCreateCmd())
+	snapshotCmd.AddCommand(newShowCmd())
+	snapshotCmd.AddCommand(newDropCmd())
+	return snapshotCmd.Command
+}
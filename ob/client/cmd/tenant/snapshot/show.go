@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/lib/http"
+	"github.com/oceanbase/obshell/ob/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/ob/client/command"
+	clientconst "github.com/oceanbase/obshell/ob/client/constant"
+	cmdlib "github.com/oceanbase/obshell/ob/client/lib/cmd"
+	"github.com/oceanbase/obshell/ob/client/lib/stdio"
+	"github.com/oceanbase/obshell/ob/client/utils/api"
+)
+
+var showHeader = []string{"Snapshot ID", "Snapshot Name", "Status", "Snapshot Scn", "Type", "Create Time"}
+
+func newShowCmd() *cobra.Command {
+	var verbose bool
+	showCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_SHOW,
+		Short:   "Show the snapshots of the tenant.",
+		PreRunE: cmdlib.ValidateArgTenantName,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(verbose)
+			return snapshotShow(args[0])
+		}),
+		Example: `  obshell tenant snapshot show t1`,
+	})
+	showCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name>"}
+	showCmd.VarsPs(&verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return showCmd.Command
+}
+
+func snapshotShow(tenantName string) error {
+	var snapshots []bo.TenantSnapshot
+	uri := constant.URI_TENANT_API_PREFIX + "/" + tenantName + constant.URI_SNAPSHOTS
+	if err := api.CallApiWithMethod(http.GET, uri, nil, &snapshots); err != nil {
+		return err
+	}
+
+	data := make([][]string, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		data = append(data, []string{fmt.Sprint(snapshot.SnapshotID), snapshot.SnapshotName, snapshot.Status,
+			fmt.Sprint(snapshot.SnapshotScn), snapshot.Type, snapshot.CreateTime.Format("2006-01-02 15:04:05")})
+	}
+	stdio.PrintTable(showHeader, data)
+	return nil
+}
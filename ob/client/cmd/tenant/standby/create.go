@@ -0,0 +1,141 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package standby
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/client/cmd/tenant/replica"
+	"github.com/oceanbase/obshell/ob/client/command"
+	clientconst "github.com/oceanbase/obshell/ob/client/constant"
+	cmdlib "github.com/oceanbase/obshell/ob/client/lib/cmd"
+	"github.com/oceanbase/obshell/ob/client/lib/stdio"
+	"github.com/oceanbase/obshell/ob/client/utils/api"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+type standbyCreateFlags struct {
+	sourceType string
+
+	primaryInfoFlags
+
+	dataBackupUri string
+	archiveLogUri string
+	decryption    string
+
+	primaryZone string
+	verbose     bool
+	replica.ZoneParamsFlags
+}
+
+type primaryInfoFlags struct {
+	primaryIpList   string
+	primaryTenant   string
+	primaryUser     string
+	primaryPassword string
+}
+
+func (f *primaryInfoFlags) toPrimaryInfo() *param.StandbyPrimaryInfo {
+	if f.primaryIpList == "" && f.primaryTenant == "" && f.primaryUser == "" {
+		return nil
+	}
+	return &param.StandbyPrimaryInfo{
+		IpList:     strings.Split(f.primaryIpList, ","),
+		TenantName: f.primaryTenant,
+		User:       f.primaryUser,
+		Password:   f.primaryPassword,
+	}
+}
+
+func (f *primaryInfoFlags) setFlags(cmd *command.Command) {
+	cmd.VarsPs(&f.primaryIpList, []string{FLAG_PRIMARY_IP_LIST}, "", "The 'ip:sql_port' list of the primary tenant's observers, separated by ','.", false)
+	cmd.VarsPs(&f.primaryTenant, []string{FLAG_PRIMARY_TENANT}, "", "The name of the primary tenant.", false)
+	cmd.VarsPs(&f.primaryUser, []string{FLAG_PRIMARY_USER}, "", "The user of the primary tenant used to fetch logs.", false)
+	cmd.VarsPs(&f.primaryPassword, []string{FLAG_PRIMARY_PASSWORD}, "", "The password of the primary tenant user.", false)
+}
+
+func newCreateCmd() *cobra.Command {
+	opts := &standbyCreateFlags{}
+	createCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_CREATE,
+		Short:   "Create a standby tenant.",
+		PreRunE: cmdlib.ValidateArgTenantName,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			return standbyCreate(cmd, args[0], opts)
+		}),
+		Example: `  obshell tenant standby create t1_standby --primary_ip_list 10.10.10.1:2881,10.10.10.2:2881 --primary_tenant t1 --primary_user rep_user --primary_password ****** -u s1
+  obshell tenant standby create t1_standby --type LOCATION -d 'file:///data/backup/t1/data' -a 'file:///data/backup/t1/clog' -u s1`,
+	})
+
+	createCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name>"}
+	createCmd.Flags().SortFlags = false
+	createCmd.VarsPs(&opts.sourceType, []string{FLAG_TYPE}, constant.STANDBY_SOURCE_TYPE_SERVICE, "The log restore source type, SERVICE or LOCATION.", false)
+	opts.primaryInfoFlags.setFlags(createCmd)
+	createCmd.VarsPs(&opts.dataBackupUri, []string{FLAG_DATA_BACKUP_URI, FLAG_DATA_BACKUP_URI_SH}, "", "The directory path where the backups of the primary tenant are stored.", false)
+	createCmd.VarsPs(&opts.archiveLogUri, []string{FLAG_ARCHIVE_LOG_URI, FLAG_ARCHIVE_LOG_URI_SH}, "", "The directory path where the archive logs of the primary tenant are stored.", false)
+	createCmd.VarsPs(&opts.decryption, []string{FLAG_DECRYPTION, FLAG_DECRYPTION_SH}, "", "The decryption password for all backups.", false)
+	createCmd.VarsPs(&opts.Zones, []string{FLAG_ZONE, FLAG_ZONE_SH}, "", "The zones of the tenant.", false)
+	createCmd.VarsPs(&opts.UnitNum, []string{FLAG_UNIT_NUM}, 1, "The number of units in each zone.", false)
+	createCmd.VarsPs(&opts.UnitConfigName, []string{FLAG_UNIT, FLAG_UNIT_SH}, "", "The unit config name.", false)
+	createCmd.VarsPs(&opts.ReplicaType, []string{FLAG_REPLICA_TYPE}, "", "The replica type of the tenant.", false)
+	createCmd.VarsPs(&opts.primaryZone, []string{FLAG_PRIMARY_ZONE, FLAG_PRIMARY_ZONE_SH}, "", "The primary zone of the tenant.", false)
+	createCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+
+	createCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return replica.FlagErrorFunc(cmd, err, &opts.ZoneParamsFlags)
+	})
+	return createCmd.Command
+}
+
+func standbyCreate(cmd *cobra.Command, tenantName string, opts *standbyCreateFlags) error {
+	zoneList, err := replica.BuildZoneParams(cmd, &opts.ZoneParamsFlags)
+	if err != nil {
+		return err
+	}
+	stdio.Verbosef("Zone list is %v", zoneList)
+
+	createParam := &param.CreateStandbyTenantParam{
+		Type:          strings.ToUpper(opts.sourceType),
+		PrimaryInfo:   opts.primaryInfoFlags.toPrimaryInfo(),
+		DataBackupUri: opts.dataBackupUri,
+		ArchiveLogUri: opts.archiveLogUri,
+		ZoneList:      zoneList,
+	}
+	if opts.primaryZone != "" {
+		createParam.PrimaryZone = &opts.primaryZone
+	}
+	if opts.decryption != "" {
+		pwds := strings.Split(strings.TrimSpace(opts.decryption), ",")
+		createParam.Decryption = &pwds
+	}
+	if err = createParam.Check(); err != nil {
+		return err
+	}
+
+	uri := constant.URI_TENANT_API_PREFIX + "/" + tenantName + constant.URI_STANDBY
+	dag, err := api.CallApiAndPrintStage(uri, createParam)
+	if err != nil {
+		return errors.Wrapf(err, "create standby tenant '%s' failed", tenantName)
+	}
+	stdio.Verbosef("Create standby tenant successfully, DAG ID: %s", dag.GenericID)
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package standby
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/ob/client/command"
+)
+
+const (
+	CMD_STANDBY = "standby"
+
+	// obshell tenant standby create
+	CMD_CREATE              = "create"
+	FLAG_TYPE               = "type"
+	FLAG_PRIMARY_IP_LIST    = "primary_ip_list"
+	FLAG_PRIMARY_TENANT     = "primary_tenant"
+	FLAG_PRIMARY_USER       = "primary_user"
+	FLAG_PRIMARY_PASSWORD   = "primary_password"
+	FLAG_DATA_BACKUP_URI    = "data_backup_uri"
+	FLAG_DATA_BACKUP_URI_SH = "d"
+	FLAG_ARCHIVE_LOG_URI    = "archive_log_uri"
+	FLAG_ARCHIVE_LOG_URI_SH = "a"
+	FLAG_DECRYPTION         = "decryption"
+	FLAG_DECRYPTION_SH      = "D"
+	FLAG_ZONE               = "zone"
+	FLAG_ZONE_SH            = "z"
+	FLAG_UNIT_NUM           = "unit_num"
+	FLAG_UNIT               = "unit"
+	FLAG_UNIT_SH            = "u"
+	FLAG_REPLICA_TYPE       = "replica_type"
+	FLAG_PRIMARY_ZONE       = "primary_zone"
+	FLAG_PRIMARY_ZONE_SH    = "p"
+
+	// obshell tenant standby show
+	CMD_SHOW = "show"
+
+	// obshell tenant standby switchover
+	CMD_SWITCHOVER = "switchover"
+
+	// obshell tenant standby failover
+	CMD_FAILOVER = "failover"
+)
+
+func NewStandbyCmd() *cobra.Command {
+	standbyCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_STANDBY,
+		Short: "Manage the primary and standby tenants.",
+	})
+	standbyCmd.AddCommand(newCreateCmd())
+	standbyCmd.AddCommand(newShowCmd())
+	standbyCmd.AddCommand(newSwitchoverCmd())
+	standbyCmd.AddCommand(newFailoverCmd())
+	return standbyCmd.Command
+}
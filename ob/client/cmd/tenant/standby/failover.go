@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package standby
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/client/command"
+	clientconst "github.com/oceanbase/obshell/ob/client/constant"
+	cmdlib "github.com/oceanbase/obshell/ob/client/lib/cmd"
+	"github.com/oceanbase/obshell/ob/client/lib/stdio"
+	"github.com/oceanbase/obshell/ob/client/utils/api"
+)
+
+type standbyFailoverFlags struct {
+	verbose     bool
+	skipConfirm bool
+}
+
+func newFailoverCmd() *cobra.Command {
+	opts := &standbyFailoverFlags{}
+	failoverCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_FAILOVER,
+		Short:   "Activate a standby tenant to primary when the primary tenant is unavailable.",
+		PreRunE: cmdlib.ValidateArgTenantName,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			return standbyFailover(args[0])
+		}),
+		Example: `  obshell tenant standby failover t1_standby`,
+	})
+	failoverCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name>"}
+	failoverCmd.Flags().SortFlags = false
+	failoverCmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt", false)
+	failoverCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return failoverCmd.Command
+}
+
+func standbyFailover(tenantName string) error {
+	pass, err := stdio.Confirmf("Failover may lose the data which has not been synchronized from the primary tenant. Please confirm if you need to failover tenant %s", tenantName)
+	if err != nil {
+		return errors.Wrap(err, "ask for confirmation failed")
+	}
+	if !pass {
+		return nil
+	}
+
+	uri := constant.URI_TENANT_API_PREFIX + "/" + tenantName + constant.URI_STANDBY + constant.URI_FAILOVER
+	if _, err := api.CallApiAndPrintStage(uri, nil); err != nil {
+		return errors.Wrapf(err, "failover tenant '%s' failed", tenantName)
+	}
+	return nil
+}
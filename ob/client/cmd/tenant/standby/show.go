@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package standby
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/lib/http"
+	"github.com/oceanbase/obshell/ob/client/command"
+	clientconst "github.com/oceanbase/obshell/ob/client/constant"
+	cmdlib "github.com/oceanbase/obshell/ob/client/lib/cmd"
+	"github.com/oceanbase/obshell/ob/client/lib/stdio"
+	"github.com/oceanbase/obshell/ob/client/utils/api"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+var showHeader = []string{"Name", "Role", "Status", "Switchover Status", "Sync Scn", "Readable Scn", "Lag(s)", "Log Restore Source"}
+
+func newShowCmd() *cobra.Command {
+	var verbose bool
+	showCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_SHOW,
+		Short:   "Show the role and log synchronization status of the tenant.",
+		PreRunE: cmdlib.ValidateArgTenantName,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(verbose)
+			return standbyShow(args[0])
+		}),
+		Example: `  obshell tenant standby show t1_standby`,
+	})
+	showCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name>"}
+	showCmd.VarsPs(&verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return showCmd.Command
+}
+
+func standbyShow(tenantName string) error {
+	var status param.StandbyTenantStatus
+	uri := constant.URI_TENANT_API_PREFIX + "/" + tenantName + constant.URI_STANDBY
+	if err := api.CallApiWithMethod(http.GET, uri, nil, &status); err != nil {
+		return err
+	}
+
+	lag := "-"
+	if status.LagSeconds != nil {
+		lag = fmt.Sprint(*status.LagSeconds)
+	}
+	data := [][]string{{status.TenantName, status.TenantRole, status.Status, status.SwitchoverStatus,
+		fmt.Sprint(status.SyncScn), fmt.Sprint(status.ReadableScn), lag, status.LogRestoreSource}}
+	stdio.PrintTable(showHeader, data)
+	return nil
+}
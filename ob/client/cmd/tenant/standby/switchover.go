@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package standby
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+	"github.com/oceanbase/obshell/ob/client/command"
+	clientconst "github.com/oceanbase/obshell/ob/client/constant"
+	cmdlib "github.com/oceanbase/obshell/ob/client/lib/cmd"
+	"github.com/oceanbase/obshell/ob/client/lib/stdio"
+	"github.com/oceanbase/obshell/ob/client/utils/api"
+	"github.com/oceanbase/obshell/ob/param"
+)
+
+type standbySwitchoverFlags struct {
+	primaryInfoFlags
+	verbose     bool
+	skipConfirm bool
+}
+
+func newSwitchoverCmd() *cobra.Command {
+	opts := &standbySwitchoverFlags{}
+	switchoverCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_SWITCHOVER,
+		Short:   "Switch a primary tenant to standby, or a standby tenant to primary.",
+		PreRunE: cmdlib.ValidateArgTenantName,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			return standbySwitchover(args[0], opts)
+		}),
+		Example: `  obshell tenant standby switchover t1
+  obshell tenant standby switchover t1 --primary_ip_list 10.10.10.3:2881 --primary_tenant t1_standby --primary_user rep_user --primary_password ******
+  obshell tenant standby switchover t1_standby`,
+	})
+	switchoverCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<tenant-name>"}
+	switchoverCmd.Flags().SortFlags = false
+	opts.primaryInfoFlags.setFlags(switchoverCmd)
+	switchoverCmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt", false)
+	switchoverCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+	return switchoverCmd.Command
+}
+
+func standbySwitchover(tenantName string, opts *standbySwitchoverFlags) error {
+	pass, err := stdio.Confirmf("Please confirm if you need to switchover tenant %s", tenantName)
+	if err != nil {
+		return errors.Wrap(err, "ask for confirmation failed")
+	}
+	if !pass {
+		return nil
+	}
+
+	switchoverParam := &param.StandbySwitchoverParam{
+		PrimaryInfo: opts.primaryInfoFlags.toPrimaryInfo(),
+	}
+	uri := constant.URI_TENANT_API_PREFIX + "/" + tenantName + constant.URI_STANDBY + constant.URI_SWITCHOVER
+	if _, err := api.CallApiAndPrintStage(uri, switchoverParam); err != nil {
+		return errors.Wrapf(err, "switchover tenant '%s' failed", tenantName)
+	}
+	return nil
+}
@@ -27,4 +27,6 @@ const (
 	CMD_RECYCLEBIN = "recyclebin"
 	CMD_BACKUP     = "backup"
 	CMD_RESTORE    = "restore"
+	CMD_AUDIT      = "audit"
+	CMD_SCHEDULE   = "schedule"
 )
@@ -28,11 +28,13 @@ import (
 	"github.com/oceanbase/obshell/ob/agent/cmd/server"
 	"github.com/oceanbase/obshell/ob/agent/constant"
 	"github.com/oceanbase/obshell/ob/client/cmd/agent"
+	"github.com/oceanbase/obshell/ob/client/cmd/audit"
 	"github.com/oceanbase/obshell/ob/client/cmd/backup"
 	"github.com/oceanbase/obshell/ob/client/cmd/cluster"
 	"github.com/oceanbase/obshell/ob/client/cmd/pool"
 	"github.com/oceanbase/obshell/ob/client/cmd/recyclebin"
 	"github.com/oceanbase/obshell/ob/client/cmd/restore"
+	"github.com/oceanbase/obshell/ob/client/cmd/schedule"
 	"github.com/oceanbase/obshell/ob/client/cmd/task"
 	"github.com/oceanbase/obshell/ob/client/cmd/tenant"
 	"github.com/oceanbase/obshell/ob/client/cmd/unit"
@@ -58,6 +60,8 @@ func ObMain() {
 	cmds.AddCommand(recyclebin.NewRecyclebinCmd())
 	cmds.AddCommand(backup.NewBackupCmd())
 	cmds.AddCommand(restore.NewRestoreCmd())
+	cmds.AddCommand(audit.NewAuditCmd())
+	cmds.AddCommand(schedule.NewScheduleCmd())
 
 	var showDetailedVersion bool
 	cmds.Flags().BoolVarP(&showDetailedVersion, agentcmd.CMD_VERSION, agentcmd.CMD_V, false, "Display version for obshell and exit")
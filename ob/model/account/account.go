@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package account
+
+import "time"
+
+type Account struct {
+	Name        string    `json:"name"`
+	Role        Role      `json:"role"`
+	Tenants     []string  `json:"tenants"` // the tenants the account is limited to, empty means all tenants
+	Description string    `json:"description"`
+	CreateTime  time.Time `json:"create_time"`
+	UpdateTime  time.Time `json:"update_time"`
+}
+
+// IsTenantScoped checks whether the account is limited to some tenants.
+func (a *Account) IsTenantScoped() bool {
+	return len(a.Tenants) > 0
+}
+
+// HasTenant checks whether the account is allowed to access the tenant.
+func (a *Account) HasTenant(tenantName string) bool {
+	if !a.IsTenantScoped() {
+		return true
+	}
+	for _, tenant := range a.Tenants {
+		if tenant == tenantName {
+			return true
+		}
+	}
+	return false
+}
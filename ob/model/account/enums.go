@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package account
+
+type Role string
+
+const (
+	RoleAdmin    Role = "admin"
+	RoleOperator Role = "operator"
+	RoleReadonly Role = "readonly"
+)
+
+var AllRoles = []Role{RoleAdmin, RoleOperator, RoleReadonly}
+
+// Resource is the group of the agent api used for access control.
+type Resource string
+
+const (
+	ResourceTenant     Resource = "tenant"
+	ResourceBackup     Resource = "backup"
+	ResourceObcluster  Resource = "obcluster"
+	ResourceAgent      Resource = "agent"
+	ResourceCredential Resource = "credential"
+	ResourceAccount    Resource = "account"
+	ResourceTask       Resource = "task"
+	ResourceMonitor    Resource = "monitor"
+	ResourceSystem     Resource = "system"
+)
+
+type Action string
+
+const (
+	ActionRead  Action = "read"
+	ActionWrite Action = "write"
+)
@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package channel
+
+import (
+	"github.com/oceanbase/obshell/ob/model/alarm"
+)
+
+// Route decides which alerts are sent through the channel.
+// An alert matches the route only if all the matchers match its labels
+// and its severity is one of the severities, empty severities match all.
+type Route struct {
+	Matchers   []alarm.Matcher  `json:"matchers"`
+	Severities []alarm.Severity `json:"severities"`
+}
+
+// RetryPolicy controls how a failed delivery is retried,
+// the interval is doubled after each attempt.
+type RetryPolicy struct {
+	MaxRetries     int `json:"max_retries"`
+	BackoffSeconds int `json:"backoff_seconds"`
+}
+
+// WebhookConfig sends the alert to a generic HTTP endpoint.
+// The body is rendered by BodyTemplate (Go text/template), or the alert
+// in Alertmanager webhook format if the template is empty.
+type WebhookConfig struct {
+	Url          string            `json:"url" binding:"required"`
+	Method       string            `json:"method"`
+	Headers      map[string]string `json:"headers"`
+	BodyTemplate string            `json:"body_template"`
+}
+
+type EmailConfig struct {
+	SmtpHost        string   `json:"smtp_host" binding:"required"`
+	SmtpPort        int      `json:"smtp_port" binding:"required"`
+	Username        string   `json:"username"`
+	Password        string   `json:"password"`
+	From            string   `json:"from" binding:"required"`
+	To              []string `json:"to" binding:"required"`
+	UseTLS          bool     `json:"use_tls"` // Use implicit TLS, otherwise STARTTLS is used if the server supports it.
+	SubjectTemplate string   `json:"subject_template"`
+	BodyTemplate    string   `json:"body_template"`
+}
+
+// RobotConfig is used by the DingTalk, Feishu, WeCom and Slack robots.
+type RobotConfig struct {
+	Url string `json:"url" binding:"required"`
+	// Secret is used to sign the request, only for DingTalk and Feishu.
+	Secret       string `json:"secret"`
+	TextTemplate string `json:"text_template"`
+}
+
+type Channel struct {
+	Name        string         `json:"name" binding:"required"`
+	Type        ChannelType    `json:"type" binding:"required"`
+	Description string         `json:"description"`
+	Enabled     bool           `json:"enabled"`
+	Route       Route          `json:"route"`
+	Retry       RetryPolicy    `json:"retry"`
+	Webhook     *WebhookConfig `json:"webhook,omitempty"`
+	Email       *EmailConfig   `json:"email,omitempty"`
+	Robot       *RobotConfig   `json:"robot,omitempty"`
+}
+
+type ChannelParam struct {
+	Channel
+}
+
+type ChannelResponse struct {
+	Id        int64 `json:"id" binding:"required"`
+	CreatedAt int64 `json:"created_at" binding:"required"`
+	UpdatedAt int64 `json:"updated_at" binding:"required"`
+	Channel
+}
+
+type ChannelIdentity struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type Delivery struct {
+	Id           int64          `json:"id" binding:"required"`
+	ChannelName  string         `json:"channel_name" binding:"required"`
+	ChannelType  ChannelType    `json:"channel_type" binding:"required"`
+	RuleName     string         `json:"rule_name"`
+	Severity     alarm.Severity `json:"severity"`
+	AlertStatus  string         `json:"alert_status"`
+	Summary      string         `json:"summary"`
+	Status       DeliveryStatus `json:"status" binding:"required"`
+	Attempts     int            `json:"attempts" binding:"required"`
+	ErrorMessage string         `json:"error_message,omitempty"`
+	CreatedAt    int64          `json:"created_at" binding:"required"`
+}
+
+type ReceiverInfo struct {
+	Url   string `json:"url" binding:"required"`
+	Token string `json:"token" binding:"required"`
+}
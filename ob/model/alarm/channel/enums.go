@@ -0,0 +1,37 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package channel
+
+type ChannelType string
+
+const (
+	TypeWebhook  ChannelType = "webhook"
+	TypeEmail    ChannelType = "email"
+	TypeDingTalk ChannelType = "dingtalk"
+	TypeFeishu   ChannelType = "feishu"
+	TypeWeCom    ChannelType = "wecom"
+	TypeSlack    ChannelType = "slack"
+)
+
+var AllChannelTypes = []ChannelType{TypeWebhook, TypeEmail, TypeDingTalk, TypeFeishu, TypeWeCom, TypeSlack}
+
+type DeliveryStatus string
+
+const (
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
@@ -0,0 +1,30 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package channel
+
+type ChannelFilter struct {
+	Type    ChannelType `json:"type,omitempty"`
+	Keyword string      `json:"keyword,omitempty"`
+}
+
+type DeliveryFilter struct {
+	ChannelName string         `json:"channel_name,omitempty"`
+	Status      DeliveryStatus `json:"status,omitempty"`
+	StartTime   int64          `json:"start_time,omitempty"`
+	EndTime     int64          `json:"end_time,omitempty"`
+	Limit       int            `json:"limit,omitempty"`
+}
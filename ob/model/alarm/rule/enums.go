@@ -38,3 +38,16 @@ const (
 	HealthOk      RuleHealth = "ok"
 	HealthErr     RuleHealth = "err"
 )
+
+type Operator string
+
+const (
+	OperatorGT Operator = ">"
+	OperatorGE Operator = ">="
+	OperatorLT Operator = "<"
+	OperatorLE Operator = "<="
+	OperatorEQ Operator = "=="
+	OperatorNE Operator = "!="
+)
+
+var AllOperators = []Operator{OperatorGT, OperatorGE, OperatorLT, OperatorLE, OperatorEQ, OperatorNE}
@@ -24,4 +24,5 @@ type RuleFilter struct {
 	InstanceType oceanbase.OBInstanceType `json:"instance_type,omitempty"`
 	Severity     alarm.Severity           `json:"severity,omitempty"`
 	Keyword      string                   `json:"keyword,omitempty"`
+	Type         RuleType                 `json:"type,omitempty"`
 }
@@ -47,13 +47,39 @@ type Rule struct {
 	Description  string                   `json:"description" binding:"required"`
 }
 
+// RuleTemplate renders the query of the rule from the metric expression defined in metric_expr.yaml.
+type RuleTemplate struct {
+	Metric      string          `json:"metric" binding:"required"`
+	Filters     []common.KVPair `json:"filters,omitempty"`
+	GroupLabels []string        `json:"group_labels,omitempty"`
+	Interval    int             `json:"interval,omitempty"` // seconds, used to replace @INTERVAL
+	Operator    Operator        `json:"operator" binding:"required"`
+	Threshold   float64         `json:"threshold"`
+}
+
+// RuleParam is used to create or update a rule, either Template or Query should be specified.
+type RuleParam struct {
+	Name         string                   `json:"name" binding:"required"`
+	InstanceType oceanbase.OBInstanceType `json:"instance_type" binding:"required"`
+	Template     *RuleTemplate            `json:"template,omitempty"`
+	Query        string                   `json:"query,omitempty"`
+	Duration     int                      `json:"duration"`
+	Labels       []common.KVPair          `json:"labels"`
+	Severity     alarm.Severity           `json:"severity" binding:"required"`
+	Summary      string                   `json:"summary" binding:"required"`
+	Description  string                   `json:"description"`
+	Enabled      bool                     `json:"enabled"`
+}
+
 type RuleResponse struct {
-	State          RuleState  `json:"state" binding:"required"`
-	KeepFiringFor  int        `json:"keep_firing_for" binding:"required"`
-	Health         RuleHealth `json:"health" binding:"required"`
-	LastEvaluation int64      `json:"last_evaluation" binding:"required"`
-	EvaluationTime float64    `json:"evaluation_time" binding:"required"`
-	LastError      string     `json:"last_error,omitempty"`
+	Enabled        bool          `json:"enabled"`
+	Template       *RuleTemplate `json:"template,omitempty"`
+	State          RuleState     `json:"state" binding:"required"`
+	KeepFiringFor  int           `json:"keep_firing_for" binding:"required"`
+	Health         RuleHealth    `json:"health" binding:"required"`
+	LastEvaluation int64         `json:"last_evaluation" binding:"required"`
+	EvaluationTime float64       `json:"evaluation_time" binding:"required"`
+	LastError      string        `json:"last_error,omitempty"`
 	Rule
 }
 
@@ -143,6 +169,7 @@ func NewRuleResponse(promRule *promv1.AlertingRule) *RuleResponse {
 		Description:  description,
 	}
 	return &RuleResponse{
+		Enabled:        true,
 		State:          RuleState(promRule.State),
 		KeepFiringFor:  int(promRule.KeepFiringFor),
 		Health:         RuleHealth(promRule.Health),
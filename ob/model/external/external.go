@@ -24,6 +24,9 @@ type Auth struct {
 type PrometheusConfig struct {
 	Address string `json:"address"`
 	Auth    *Auth  `json:"auth,omitempty"`
+	// RuleFile is the local path where the alert rules managed by obshell are rendered to,
+	// it should be included in the rule_files of Prometheus.
+	RuleFile string `json:"rule_file,omitempty"`
 }
 
 type AlertmanagerConfig struct {
@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metric
+
+// CollectionConfig is the config of the embedded metric collection,
+// which serves the metric queries when no Prometheus is configured.
+type CollectionConfig struct {
+	Enabled        bool  `json:"enabled"`
+	Interval       int64 `json:"interval"`        // collect interval in seconds
+	RetentionHours int64 `json:"retention_hours"` // samples older than it will be removed
+	MaxSizeMB      int64 `json:"max_size_mb"`     // max size of the local storage
+}
+
+// Sample is a metric point collected by the embedded metric collection.
+type Sample struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels"`
+	Value  float64           `json:"value"`
+}
+
+// SeriesQuery selects the raw series from the local storage of an agent,
+// so that the queries can be evaluated over the samples collected by all the agents.
+type SeriesQuery struct {
+	Start    int64          `json:"start"` // in milliseconds
+	End      int64          `json:"end"`   // in milliseconds
+	Matchers []LabelMatcher `json:"matchers"`
+}
+
+// LabelMatcher is the label matcher of promql, the type is one of "=", "!=", "=~" and "!~".
+type LabelMatcher struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Series is a raw series in the local storage, the timestamps are in milliseconds.
+type Series struct {
+	Labels     map[string]string `json:"labels"`
+	Timestamps []int64           `json:"timestamps"`
+	Values     []float64         `json:"values"`
+}
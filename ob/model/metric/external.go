@@ -19,10 +19,12 @@ package metric
 type PrometheusQueryRangeResponse struct {
 	Status string `json:"status"`
 	Data   struct {
-		ResultType string `json:"resultType"`
-		Result     []struct {
-			Metric map[string]string `json:"metric"`
-			Values [][]interface{}   `json:"values"`
-		} `json:"result"`
+		ResultType string                  `json:"resultType"`
+		Result     []PrometheusRangeResult `json:"result"`
 	} `json:"data"`
 }
+
+type PrometheusRangeResult struct {
+	Metric map[string]string `json:"metric"`
+	Values [][]interface{}   `json:"values"`
+}
@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package param
+
+type CreateAccountParam struct {
+	Name        string   `json:"name" binding:"required"`
+	Password    string   `json:"password" binding:"required"`
+	Role        string   `json:"role" binding:"required"` // admin, operator or readonly
+	Tenants     []string `json:"tenants"`                 // the tenants the account is limited to, empty means all tenants
+	Description string   `json:"description"`
+}
+
+type UpdateAccountParam struct {
+	Role        *string   `json:"role"`
+	Tenants     *[]string `json:"tenants"`
+	Description *string   `json:"description"`
+}
+
+type ChangeAccountPasswordParam struct {
+	Password string `json:"password" binding:"required"`
+}
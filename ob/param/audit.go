@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package param
+
+import "time"
+
+type ListAuditLogParam struct {
+	User       string     `form:"user"`       // Operator of the request, the account name, "root", "agent" or "local"
+	Method     string     `form:"method"`     // HTTP method
+	KeyWord    string     `form:"key_word"`   // Keyword for searching (matches route or uri)
+	DagId      string     `form:"dag_id"`     // Generic id of the DAG created by the request
+	Successful *bool      `form:"successful"` // Whether the request succeeded
+	StartTime  *time.Time `form:"start_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndTime    *time.Time `form:"end_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	Page       int        `form:"page"`      // Page number, default is 1
+	PageSize   int        `form:"page_size"` // Page size, default is 20
+}
+
+type AuditRetentionParam struct {
+	RetentionDays int `json:"retention_days" binding:"required"`
+}
@@ -52,12 +52,20 @@ func NewBackupConfigParamForTenant(p *TenantBackupConfigParam) *BackupConfigPara
 }
 
 type TenantBackupConfigParam struct {
-	DataBaseUri    *string `json:"data_base_uri"`
-	ArchiveBaseUri *string `json:"archive_base_uri"`
+	DataBaseUri    *string                `json:"data_base_uri"`
+	ArchiveBaseUri *string                `json:"archive_base_uri"`
+	Encryption     *BackupEncryptionParam `json:"encryption"`
 	LogArchiveDestConf
 	BaseBackupConfigParam
 }
 
+// BackupEncryptionParam is the encryption config of the tenant backup,
+// which will be stored encrypted by obshell. Empty string means removing the config.
+type BackupEncryptionParam struct {
+	Password       *string `json:"password"`         // used to encrypt the backup sets when no encryption is specified in the backup
+	KmsEncryptInfo *string `json:"kms_encrypt_info"` // used to restore the tenant with transparent encryption
+}
+
 type BaseBackupConfigParam struct {
 	LogArchiveConcurrency *int                `json:"log_archive_concurrency"`
 	ArchiveLagTarget      *string             `json:"archive_lag_target"`
@@ -244,7 +252,8 @@ func (p *BackupConfigParam) checkArchiveLagTarget(t string) error {
 		return errors.Occur(errors.ErrObBackupArchiveLagTargetInvalid, constant.ARCHIVE_LAG_TARGET_HIGH)
 	}
 
-	if t == constant.PROTOCOL_S3 && duration < constant.ARCHIVE_LAG_TARGET_LOW_FOR_S3 {
+	// GCS is accessed by OceanBase through the S3 protocol, so the same lower bound applies.
+	if (t == constant.PROTOCOL_S3 || t == constant.PROTOCOL_GCS) && duration < constant.ARCHIVE_LAG_TARGET_LOW_FOR_S3 {
 		return errors.Occur(errors.ErrObBackupArchiveLagTargetForS3Invalid, constant.ARCHIVE_LAG_TARGET_LOW_FOR_S3)
 	}
 	return nil
@@ -0,0 +1,28 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package param
+
+type BackupRpoTargetParam struct {
+	RpoTarget          string  `json:"rpo_target" binding:"required"` // The recovery point objective, such as "5m".
+	FullBackupInterval *string `json:"full_backup_interval"`          // The max interval between two successful full backups, such as "7d". Empty means not checked.
+	IncBackupInterval  *string `json:"incremental_backup_interval"`   // The max interval between two successful backups of any type, such as "1d". Empty means not checked.
+}
+
+type BackupComplianceReportParam struct {
+	StartDate string `form:"start_date"` // Format: 2006-01-02, defaults to 6 days before the end date.
+	EndDate   string `form:"end_date"`   // Format: 2006-01-02, defaults to today.
+}
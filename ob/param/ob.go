@@ -67,6 +67,7 @@ type LocalScaleOutParam struct {
 	ParamExpectDeployNextStage   int               `json:"paramExpectDeployNextStage" binding:"required"`
 	ParamExpectStartNextStage    int               `json:"paramExpectStartNextStage" binding:"required"`
 	ParamExpectRollbackNextStage int               `json:"paramExpectRollbackNextStage" binding:"required"`
+	ParamExpectFinishStage       int               `json:"paramExpectFinishStage"` // the stage of the coordinate dag to finish watching, 0 means the last one
 }
 
 type ClusterScaleInParam struct {
@@ -74,6 +75,13 @@ type ClusterScaleInParam struct {
 	ForceKill bool           `json:"force_kill"` // default to false
 }
 
+type ReplaceObserverParam struct {
+	OldAgent            meta.AgentInfo    `json:"old_agent" binding:"required"`
+	NewAgent            meta.AgentInfo    `json:"new_agent" binding:"required"` // a single agent on the new host
+	ObConfigs           map[string]string `json:"ob_configs"`                   // the ports default to the ones of the old observer
+	TargetAgentPassword string            `json:"target_agent_password"`        // the password of the new agent
+}
+
 type ObInitParam struct {
 	ImportScript      bool   `json:"import_script"`
 	CreateProxyroUser bool   `json:"create_proxyro_user"`
@@ -117,6 +125,63 @@ type ObUpgradeParam struct {
 	UpgradeCheckParam
 	Mode         string `json:"mode" binding:"required"`
 	FreezeServer bool   `json:"freeze_server"`
+	DryRun       bool   `json:"dry_run"` // only return the upgrade plan without creating the dag
+}
+
+type AgentUpgradeParam struct {
+	UpgradeCheckParam
+	DryRun bool `json:"dry_run"` // only return the upgrade plan without creating the dag
+}
+
+// UpgradePlan is the rendered plan of an upgrade, which is returned in dry run mode.
+type UpgradePlan struct {
+	CurrentVersion   string                `json:"current_version"`
+	TargetVersion    string                `json:"target_version"`
+	Mode             string                `json:"mode,omitempty"`
+	Route            []UpgradeRouteHop     `json:"route"`
+	RequiredPackages []UpgradePlanPackage  `json:"required_packages"`
+	ZoneOrder        []string              `json:"zone_order"`
+	ZoneDowntime     []UpgradeZoneDowntime `json:"zone_downtime"`
+	ClusterDowntime  int                   `json:"cluster_downtime"` // in seconds, the whole cluster is unavailable only when upgrading with stopping service
+	Checks           []UpgradePlanCheck    `json:"checks"`
+	FailedChecks     int                   `json:"failed_checks"`
+	Stages           []string              `json:"stages"`
+}
+
+type UpgradeRouteHop struct {
+	Version           string `json:"version"`
+	Release           string `json:"release"`
+	RequireFromBinary bool   `json:"require_from_binary"`
+	// PreScripts and PostScripts are the scripts executed before and after the observers are restarted in the hop,
+	// in the order of execution. The paths are relative to the home path of the hop's package.
+	PreScripts  []string `json:"pre_scripts"`
+	PostScripts []string `json:"post_scripts"`
+}
+
+type UpgradePlanPackage struct {
+	Name     string `json:"name"`
+	Arch     string `json:"arch"`
+	Uploaded bool   `json:"uploaded"`
+}
+
+type UpgradeZoneDowntime struct {
+	Zone              string                  `json:"zone"`
+	Servers           int                     `json:"servers"`
+	EstimatedDowntime int                     `json:"estimated_downtime"` // in seconds, the sum of the windows
+	Windows           []UpgradeDowntimeWindow `json:"windows"`
+}
+
+// UpgradeDowntimeWindow is an estimated period in which the zone is unavailable.
+type UpgradeDowntimeWindow struct {
+	Hop      int `json:"hop"`      // index of the route
+	Start    int `json:"start"`    // in seconds, offset from the first hop starting to restart the observers
+	Duration int `json:"duration"` // in seconds
+}
+
+type UpgradePlanCheck struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
 }
 
 type Scope struct {
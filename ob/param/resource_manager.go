@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package param
+
+type CreateResourcePlanParam struct {
+	TenantRootPasswordParam
+	Plan    string `json:"plan" binding:"required"`
+	Comment string `json:"comment"`
+}
+
+type CreateConsumerGroupParam struct {
+	TenantRootPasswordParam
+	ConsumerGroup string `json:"consumer_group" binding:"required"`
+	Comment       string `json:"comment"`
+}
+
+// ResourcePlanDirective describes the resources a consumer group can use in a resource plan,
+// the unset fields keep the default value of OceanBase.
+type ResourcePlanDirective struct {
+	Comment          *string `json:"comment"`
+	MgmtP1           *int    `json:"mgmt_p1"`           // the weight of cpu
+	UtilizationLimit *int    `json:"utilization_limit"` // the upper limit of cpu, in percent
+	MinIops          *int    `json:"min_iops"`          // the reserved iops, in percent
+	MaxIops          *int    `json:"max_iops"`          // the upper limit of iops, in percent
+	WeightIops       *int    `json:"weight_iops"`       // the weight of iops
+}
+
+type CreateResourcePlanDirectiveParam struct {
+	TenantRootPasswordParam
+	ResourcePlanDirective
+	ConsumerGroup string `json:"consumer_group" binding:"required"`
+}
+
+type ModifyResourcePlanDirectiveParam struct {
+	TenantRootPasswordParam
+	ResourcePlanDirective
+}
+
+type SetResourceMappingParam struct {
+	TenantRootPasswordParam
+	Attribute     string `json:"attribute" binding:"required"` // USER, FUNCTION or COLUMN
+	Value         string `json:"value" binding:"required"`
+	ConsumerGroup string `json:"consumer_group"` // empty means remove the mapping
+}
+
+type ActivateResourcePlanParam struct {
+	TenantRootPasswordParam
+	Plan string `json:"plan"` // empty means deactivate the current plan
+}
@@ -113,3 +113,73 @@ func (p *QueryRestoreTasksParam) Format() {
 	}
 	p.CustomPageQuery.Format()
 }
+
+type RestoreTableParam struct {
+	RestoreStorageParam
+
+	// Tables to restore, in the form of 'db.table'.
+	Tables []string `json:"tables"`
+	// Databases to restore, all the tables in them will be restored.
+	Databases []string `json:"databases"`
+	// Remap rules in the form of 'db.table:new_db.new_table'.
+	RemapTables []string `json:"remap_tables"`
+	// Remap rules in the form of 'db:new_db'.
+	RemapDatabases []string `json:"remap_databases"`
+
+	Timestamp *time.Time `json:"timestamp" time_format:"2006-01-02T15:04:05.000Z07:00"`
+	SCN       *int64     `json:"scn"`
+
+	ZoneList    []ZoneParam `json:"zone_list" binding:"required"` // Zone list with unit config of the auxiliary tenant.
+	PrimaryZone *string     `json:"primary_zone"`
+	Concurrency *int        `json:"concurrency"`
+	Decryption  *[]string   `json:"decryption"`
+
+	KmsEncryptInfo *string `json:"kms_encrypt_info"`
+}
+
+func (p *RestoreTableParam) Format() {
+	if p.ArchiveLogUri == nil || *p.ArchiveLogUri == "" {
+		p.ArchiveLogUri = &p.DataBackupUri
+	}
+	if p.SCN != nil && *p.SCN == 0 {
+		p.SCN = nil
+	}
+	if p.Timestamp != nil && *p.Timestamp == constant.ZERO_TIME {
+		p.Timestamp = nil
+	}
+	if p.PrimaryZone == nil || *p.PrimaryZone == "" ||
+		strings.ToUpper(*p.PrimaryZone) == constant.PRIMARY_ZONE_RANDOM {
+		primaryZone := constant.PRIMARY_ZONE_RANDOM
+		p.PrimaryZone = &primaryZone
+	}
+}
+
+func (p *RestoreTableParam) Check() error {
+	p.Format()
+	if len(p.Tables) == 0 && len(p.Databases) == 0 {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "tables and databases", "cannot be both empty")
+	}
+	for _, table := range p.Tables {
+		if parts := strings.Split(table, "."); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "tables", "should be in the form of 'db.table'")
+		}
+	}
+	for _, rule := range p.RemapTables {
+		if parts := strings.Split(rule, ":"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "remap_tables", "should be in the form of 'db.table:new_db.new_table'")
+		}
+	}
+	for _, rule := range p.RemapDatabases {
+		if parts := strings.Split(rule, ":"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "remap_databases", "should be in the form of 'db:new_db'")
+		}
+	}
+	// RECOVER TABLE always restores to a specified point.
+	if p.Timestamp == nil && p.SCN == nil {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "timestamp or scn", "must be set")
+	}
+	if p.Timestamp != nil && p.SCN != nil {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "timestamp or scn", "cannot be set at the same time")
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package param
+
+type CreateScheduleParam struct {
+	Name        string `json:"name" binding:"required"`
+	JobType     string `json:"job_type" binding:"required"` // backup, compaction or inspection
+	Cron        string `json:"cron" binding:"required"`     // standard five-field cron expression, such as "0 2 * * *"
+	Enabled     *bool  `json:"enabled"`                     // default true
+	TenantName  string `json:"tenant_name"`                 // required by the backup and compaction jobs
+	BackupMode  string `json:"backup_mode"`                 // full or incremental, default full
+	PlusArchive bool   `json:"plus_archive"`
+	Scenario    string `json:"scenario"` // basic or performance, default basic
+	Description string `json:"description"`
+}
+
+type UpdateScheduleParam struct {
+	Cron        *string `json:"cron"`
+	Enabled     *bool   `json:"enabled"`
+	TenantName  *string `json:"tenant_name"`
+	BackupMode  *string `json:"backup_mode"`
+	PlusArchive *bool   `json:"plus_archive"`
+	Scenario    *string `json:"scenario"`
+	Description *string `json:"description"`
+}
+
+type ListScheduleRunsParam struct {
+	Limit int `json:"limit" form:"limit"`
+}
@@ -30,6 +30,10 @@ const (
 	Cos BackupStorageType = "cos"
 	Oss BackupStorageType = "oss"
 	S3  BackupStorageType = "s3"
+	// Azblob is Azure Blob Storage, the bucket name is the container.
+	Azblob BackupStorageType = "azblob"
+	// Gcs is Google Cloud Storage accessed with HMAC keys.
+	Gcs BackupStorageType = "gs"
 )
 
 type ObjectStoragePath struct {
@@ -38,7 +42,7 @@ type ObjectStoragePath struct {
 	ObjectName  string            `json:"object_name"`
 }
 
-var storageURIRegexp = regexp.MustCompile(`^(?:oss://|cos://|s3://)?([^/]+)(?:/(.*?)/?)?$`)
+var storageURIRegexp = regexp.MustCompile(`^(?:oss://|cos://|s3://|azblob://|gs://)?([^/]+)(?:/(.*?)/?)?$`)
 
 func ParseStorageUri(uri string) (*ObjectStoragePath, error) {
 	if uri == "" {
@@ -68,7 +72,7 @@ func ParseStorageUri(uri string) (*ObjectStoragePath, error) {
 	storageType := BackupStorageType(prefixParts[0])
 
 	switch storageType {
-	case Oss, Cos, S3:
+	case Oss, Cos, S3, Azblob, Gcs:
 	default:
 		return nil, oberrors.Occur(oberrors.ErrObStorageURIInvalid, fmt.Sprintf("unsupported storage type: %s", storageType))
 	}
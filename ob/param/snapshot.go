@@ -0,0 +1,36 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package param
+
+type CreateTenantSnapshotParam struct {
+	// Name is the name of the snapshot, generated by OceanBase if empty.
+	Name string `json:"name"`
+}
+
+type CloneTenantParam struct {
+	Name string `json:"name" binding:"required"` // The name of the new tenant.
+	// SnapshotName is the snapshot of the source tenant to clone from,
+	// clone from the current state of the source tenant if empty.
+	SnapshotName   string `json:"snapshot_name"`
+	UnitConfigName string `json:"unit_config_name" binding:"required"` // The unit config of the resource pool of the new tenant.
+
+	Whitelist    *string `json:"whitelist"`
+	RootPassword string  `json:"root_password"` // The new root password of the new tenant, inherited from the source tenant if empty.
+	// SourceRootPassword is the root password of the source tenant, which is
+	// required when resetting the root password if obshell does not know it.
+	SourceRootPassword *string `json:"source_root_password"`
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package param
+
+import (
+	"strings"
+
+	"github.com/oceanbase/obshell/ob/agent/constant"
+	"github.com/oceanbase/obshell/ob/agent/errors"
+)
+
+// StandbyPrimaryInfo describes how the standby tenant connects to its primary
+// tenant when logs are transported through the network.
+type StandbyPrimaryInfo struct {
+	IpList     []string `json:"ip_list" binding:"required"` // "ip:sql_port" of the primary tenant's observers.
+	TenantName string   `json:"tenant_name" binding:"required"`
+	User       string   `json:"user" binding:"required"` // A user of the primary tenant with the privilege to read logs.
+	Password   string   `json:"password"`
+}
+
+type CreateStandbyTenantParam struct {
+	// Type is the log restore source type, "SERVICE"(default) or "LOCATION".
+	Type string `json:"type"`
+
+	// PrimaryInfo is required when the type is "SERVICE".
+	PrimaryInfo *StandbyPrimaryInfo `json:"primary_info"`
+
+	// DataBackupUri and ArchiveLogUri are required when the type is "LOCATION".
+	DataBackupUri string    `json:"data_backup_uri"`
+	ArchiveLogUri string    `json:"archive_log_uri"`
+	Decryption    *[]string `json:"decryption"`
+
+	ZoneList    []ZoneParam `json:"zone_list" binding:"required"` // Tenant zone list with unit config.
+	PrimaryZone *string     `json:"primary_zone"`
+}
+
+func (p *CreateStandbyTenantParam) Format() {
+	p.Type = strings.ToUpper(p.Type)
+	if p.Type == "" {
+		p.Type = constant.STANDBY_SOURCE_TYPE_SERVICE
+	}
+	if p.ArchiveLogUri == "" {
+		p.ArchiveLogUri = p.DataBackupUri
+	}
+	if p.PrimaryZone == nil || *p.PrimaryZone == "" ||
+		strings.ToUpper(*p.PrimaryZone) == constant.PRIMARY_ZONE_RANDOM {
+		primaryZone := constant.PRIMARY_ZONE_RANDOM
+		p.PrimaryZone = &primaryZone
+	}
+}
+
+func (p *CreateStandbyTenantParam) Check() error {
+	p.Format()
+	switch p.Type {
+	case constant.STANDBY_SOURCE_TYPE_SERVICE:
+		if p.PrimaryInfo == nil {
+			return errors.Occur(errors.ErrObStandbyPrimaryInfoEmpty)
+		}
+		return p.PrimaryInfo.Check()
+	case constant.STANDBY_SOURCE_TYPE_LOCATION:
+		if p.DataBackupUri == "" {
+			return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "data_backup_uri", "cannot be empty")
+		}
+	default:
+		return errors.Occur(errors.ErrObStandbySourceTypeInvalid, p.Type, constant.STANDBY_SOURCE_TYPE_SERVICE, constant.STANDBY_SOURCE_TYPE_LOCATION)
+	}
+	return nil
+}
+
+func (p *StandbyPrimaryInfo) Check() error {
+	if len(p.IpList) == 0 {
+		return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "ip_list", "cannot be empty")
+	}
+	for _, addr := range p.IpList {
+		if !strings.Contains(addr, ":") {
+			return errors.Occur(errors.ErrCommonIllegalArgumentWithMessage, "ip_list", "should be in the format of 'ip:sql_port'")
+		}
+	}
+	return nil
+}
+
+// LogRestoreSource renders the value of LOG_RESTORE_SOURCE for the primary tenant.
+func (p *StandbyPrimaryInfo) LogRestoreSource() string {
+	return "SERVICE=" + strings.Join(p.IpList, ";") + " USER=" + p.User + "@" + p.TenantName + " PASSWORD=" + p.Password
+}
+
+type StandbySwitchoverParam struct {
+	// PrimaryInfo is the new primary tenant which the current primary tenant
+	// will fetch logs from after being switched to standby. Optional.
+	PrimaryInfo *StandbyPrimaryInfo `json:"primary_info"`
+}
+
+type StandbyTenantStatus struct {
+	TenantName       string `json:"tenant_name"`
+	TenantRole       string `json:"tenant_role"`
+	Status           string `json:"status"`
+	SwitchoverStatus string `json:"switchover_status"`
+	SwitchoverEpoch  int64  `json:"switchover_epoch"`
+	SyncScn          int64  `json:"sync_scn"`
+	ReplayableScn    int64  `json:"replayable_scn"`
+	ReadableScn      int64  `json:"readable_scn"`
+	RecoveryUntilScn int64  `json:"recovery_until_scn"`
+	LogMode          string `json:"log_mode"`
+	// LagSeconds is how far the standby tenant's readable scn is behind now, only for standby tenant.
+	LagSeconds       *int64 `json:"lag_seconds,omitempty"`
+	LogRestoreSource string `json:"log_restore_source"`
+}
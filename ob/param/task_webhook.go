@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package param
+
+type CreateTaskWebhookParam struct {
+	Name        string   `json:"name" binding:"required"`
+	Url         string   `json:"url" binding:"required"`
+	Events      []string `json:"events"`  // such as "dag.failed", empty means all the event types
+	Secret      string   `json:"secret"`  // used to sign the body, the signature is sent in the X-Obshell-Signature header
+	Enabled     *bool    `json:"enabled"` // default true
+	Description string   `json:"description"`
+}
+
+type UpdateTaskWebhookParam struct {
+	Url         *string   `json:"url"`
+	Events      *[]string `json:"events"`
+	Secret      *string   `json:"secret"` // empty string removes the secret
+	Enabled     *bool     `json:"enabled"`
+	Description *string   `json:"description"`
+}
+
+type TaskEventStreamParam struct {
+	DagId string `json:"dag_id" form:"dag_id"` // generic id of the dag
+	Types string `json:"types" form:"types"`   // comma separated event types, empty means all
+	Local bool   `json:"local" form:"local"`   // only stream the events of the agent, used when merging the events of the cluster
+}
@@ -68,3 +68,49 @@ func agentUpgradeHandler(c *gin.Context) {
 	dag, err := upgrade.AgentUpgrade(param)
 	common.SendResponse(c, dag, err)
 }
+
+// @ID seekdbUpgradeCheck
+// @Summary check seekdb upgrade
+// @Description check seekdb upgrade
+// @Tags upgrade
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param body body param.UpgradeCheckParam true "seekdb upgrade check params"
+// @Success 200 object http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/seekdb/upgrade/check [post]
+func seekdbUpgradeCheckHandler(c *gin.Context) {
+	var param param.UpgradeCheckParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	task, err := upgrade.SeekdbUpgradeCheck(param)
+	common.SendResponse(c, task, err)
+}
+
+// @ID seekdbUpgrade
+// @Summary upgrade seekdb
+// @Description upgrade seekdb, the downstream standby must be upgraded first
+// @Tags upgrade
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param body body param.UpgradeCheckParam true "seekdb upgrade params"
+// @Success 200 object http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/seekdb/upgrade [post]
+func seekdbUpgradeHandler(c *gin.Context) {
+	var param param.UpgradeCheckParam
+	if err := c.BindJSON(&param); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	dag, err := upgrade.SeekdbUpgrade(param)
+	common.SendResponse(c, dag, err)
+}
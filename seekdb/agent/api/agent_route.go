@@ -109,10 +109,15 @@ func InitOcsAgentRoutes(s *http2.State, r *gin.Engine, isLocalRoute bool) {
 	seekdb.PATCH(constant.URI_PARAMETERS, setParametersHandler)
 	// for charsets
 	seekdb.GET(constant.URI_CHARSETS, getObserverCharsets)
+	// for upgrade
+	seekdb.POST(constant.URI_UPGRADE, seekdbUpgradeHandler)
+	seekdb.POST(constant.URI_UPGRADE+constant.URI_CHECK, seekdbUpgradeCheckHandler)
 
 	InitUserRoutes(seekdb, isLocalRoute)
 	InitDatabaseRoutes(seekdb, isLocalRoute)
 	InitStandbyRoutes(r, seekdb, isLocalRoute)
+	InitBackupRoutes(seekdb, isLocalRoute)
+	InitSessionRoutes(seekdb, isLocalRoute)
 
 	// agent routes
 	agent.POST(constant.URI_UPGRADE, agentUpgradeHandler)
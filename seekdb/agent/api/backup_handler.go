@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/obshell/seekdb/agent/api/common"
+	backupexec "github.com/oceanbase/obshell/seekdb/agent/executor/backup"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+// @ID putSeekdbBackupConfig
+// @Summary Set the backup config of seekdb
+// @Tags seekdb
+// @Accept application/json
+// @Produce application/json
+// @Param body body param.BackupConfigParam true "backup config"
+// @Param X-OCS-Header header string true "Authorization"
+// @Success 200 object http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/seekdb/backup/config [put]
+func backupConfigHandler(c *gin.Context) {
+	var p param.BackupConfigParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	data, err := backupexec.SetBackupConfig(&p)
+	common.SendResponse(c, data, err)
+}
+
+// @ID postSeekdbBackup
+// @Summary Start a backup of seekdb, the archive log is opened if needed
+// @Tags seekdb
+// @Accept application/json
+// @Produce application/json
+// @Param body body param.BackupParam true "backup params"
+// @Param X-OCS-Header header string true "Authorization"
+// @Success 200 object http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/seekdb/backup [post]
+func backupHandler(c *gin.Context) {
+	var p param.BackupParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	data, err := backupexec.Backup(&p)
+	common.SendResponse(c, data, err)
+}
+
+// @ID getSeekdbBackupOverview
+// @Summary Get the backup destinations, archive log status and backup jobs of seekdb
+// @Tags seekdb
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Success 200 object http.OcsAgentResponse{data=param.BackupOverview}
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/seekdb/backup/overview [get]
+func backupOverviewHandler(c *gin.Context) {
+	data, err := backupexec.GetBackupOverview()
+	common.SendResponse(c, data, err)
+}
+
+// @ID getSeekdbBackupHistory
+// @Summary List the finished backup jobs of seekdb
+// @Tags seekdb
+// @Produce application/json
+// @Param limit query int false "max number of jobs, 20 by default"
+// @Param X-OCS-Header header string true "Authorization"
+// @Success 200 object http.OcsAgentResponse{data=[]param.BackupJob}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/seekdb/backup/history [get]
+func backupHistoryHandler(c *gin.Context) {
+	var p param.BackupHistoryParam
+	if err := c.BindQuery(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	data, err := backupexec.GetBackupHistory(&p)
+	common.SendResponse(c, data, err)
+}
+
+// @ID postSeekdbRestore
+// @Summary Restore a backup into the local fresh seekdb
+// @Tags seekdb
+// @Accept application/json
+// @Produce application/json
+// @Param body body param.RestoreParam true "restore params"
+// @Param X-OCS-Header header string true "Authorization"
+// @Success 200 object http.OcsAgentResponse{data=task.DagDetailDTO}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/seekdb/restore [post]
+func restoreHandler(c *gin.Context) {
+	var p param.RestoreParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	data, err := backupexec.Restore(&p)
+	common.SendResponse(c, data, err)
+}
+
+// @ID getSeekdbRestoreOverview
+// @Summary Get the running or the last restore job of seekdb
+// @Tags seekdb
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Success 200 object http.OcsAgentResponse{data=param.RestoreOverview}
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/seekdb/restore/overview [get]
+func restoreOverviewHandler(c *gin.Context) {
+	data, err := backupexec.GetRestoreOverview()
+	common.SendResponse(c, data, err)
+}
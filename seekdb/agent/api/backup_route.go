@@ -0,0 +1,43 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/obshell/seekdb/agent/api/common"
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+)
+
+// InitBackupRoutes registers the backup and restore API routes under the
+// /api/v1/seekdb group.
+func InitBackupRoutes(seekdbGroup *gin.RouterGroup, isLocalRoute bool) {
+	backup := seekdbGroup.Group(constant.URI_BACKUP_GROUP)
+	restore := seekdbGroup.Group(constant.URI_RESTORE_GROUP)
+	if !isLocalRoute {
+		backup.Use(common.Verify())
+		restore.Use(common.Verify())
+	}
+
+	backup.PUT(constant.URI_CONFIG, backupConfigHandler)
+	backup.POST("", backupHandler)
+	backup.GET(constant.URI_OVERVIEW, backupOverviewHandler)
+	backup.GET(constant.URI_HISTORY, backupHistoryHandler)
+
+	restore.POST("", restoreHandler)
+	restore.GET(constant.URI_OVERVIEW, restoreOverviewHandler)
+}
@@ -0,0 +1,233 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/oceanbase/obshell/seekdb/agent/api/common"
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/agent/executor/observer"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+func InitSessionRoutes(seekdbGroup *gin.RouterGroup, isLocalRoute bool) {
+	sessions := seekdbGroup.Group(constant.URI_SESSIONS)
+	if !isLocalRoute {
+		sessions.Use(common.Verify())
+	}
+
+	sessions.GET("", getSessionsHandler)
+	sessions.GET(constant.URI_STATS, getSessionStatsHandler)
+	sessions.GET(constant.URI_PATH_PARAM_SESSION_ID, getSessionHandler)
+	sessions.DELETE("", killSessionsHandler)
+	sessions.DELETE(constant.URI_QUERIES, killSessionQueriesHandler)
+
+	seekdbGroup.GET(constant.URI_DEADLOCKS, listDeadLocksHandler)
+	seekdbGroup.GET(constant.URI_TOP_SLOW_SQLS, getTopSlowSqlsHandler)
+}
+
+// @ID getSessions
+// @Summary get sessions
+// @Description get sessions of seekdb
+// @Tags seekdb
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param page query uint64 false "page"
+// @Param size query uint64 false "size"
+// @Param user query string false "db user"
+// @Param db query string false "db name"
+// @Param host query string false "client ip"
+// @Param id query string false "session id"
+// @Param active_only query boolean false "active only"
+// @Param sort query string false "sort"
+// @Success 200 object http.OcsAgentResponse{data=bo.PaginatedTenantSessions}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/seekdb/sessions [get]
+func getSessionsHandler(c *gin.Context) {
+	p := &param.QueryTenantSessionParam{}
+	if err := c.BindQuery(p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	p.Format()
+	sessions, err := observer.GetSessions(p)
+	common.SendResponse(c, sessions, err)
+}
+
+// @ID getSession
+// @Summary get session
+// @Description get session of seekdb
+// @Tags seekdb
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param session_id path string true "session id"
+// @Success 200 object http.OcsAgentResponse{data=bo.TenantSession}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 404 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/seekdb/sessions/{session_id} [get]
+func getSessionHandler(c *gin.Context) {
+	session, err := observer.GetSession(c.Param(constant.URI_PARAM_SESSION_ID))
+	common.SendResponse(c, session, err)
+}
+
+// @ID getSessionStats
+// @Summary get session stats
+// @Description get session stats of seekdb, aggregated by db, user and client
+// @Tags seekdb
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Success 200 object http.OcsAgentResponse{data=bo.TenantSessionStats}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/seekdb/sessions/stats [get]
+func getSessionStatsHandler(c *gin.Context) {
+	stats, err := observer.GetSessionStats()
+	common.SendResponse(c, stats, err)
+}
+
+// @ID killSessions
+// @Summary kill sessions
+// @Description kill sessions of seekdb
+// @Tags seekdb
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param body body param.KillTenantSessionsParam true "kill sessions param"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/seekdb/sessions [delete]
+func killSessionsHandler(c *gin.Context) {
+	var p param.KillTenantSessionsParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	common.SendResponse(c, nil, observer.KillSessions(p.SessionIds))
+}
+
+// @ID killSessionQueries
+// @Summary kill session queries
+// @Description kill the running queries of sessions of seekdb
+// @Tags seekdb
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param body body param.KillTenantSessionQueryParam true "kill session query param"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/seekdb/sessions/queries [delete]
+func killSessionQueriesHandler(c *gin.Context) {
+	var p param.KillTenantSessionQueryParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	common.SendResponse(c, nil, observer.KillSessionQueries(p.SessionIds))
+}
+
+// @ID listDeadLocks
+// @Summary list deadlocks
+// @Description list deadlocks of seekdb, ordered by report time desc
+// @Tags seekdb
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param page query uint64 false "page"
+// @Param size query uint64 false "size"
+// @Success 200 object http.OcsAgentResponse{data=bo.PaginatedDeadLocks}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/seekdb/deadlocks [get]
+func listDeadLocksHandler(c *gin.Context) {
+	p := &param.QueryTenantDeadLocksParam{}
+	if err := c.BindQuery(p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	p.Format()
+	deadlocks, err := observer.ListDeadLocks(p)
+	common.SendResponse(c, deadlocks, err)
+}
+
+// @ID getTopSlowSqls
+// @Summary get top slow sqls
+// @Description get the slow sqls of seekdb ranked by the number of slow executions, limited to the top n.
+// @Tags seekdb
+// @Accept application/json
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Param start_time query string true "start time, RFC3339"
+// @Param end_time query string true "end time, RFC3339"
+// @Param limit query string false "top n"
+// @Success 200 object http.OcsAgentResponse{data=[]bo.SlowSql}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 401 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/seekdb/top-slow-sqls [get]
+func getTopSlowSqlsHandler(c *gin.Context) {
+	// Require the SQL processing end time to be between start_time and end_time.
+	p := param.QueryTopSlowSqlParam{Top: constant.DEFAULT_TOP_SLOW_SQL_LIMIT}
+	if top := c.Query("limit"); top != "" {
+		parsedTop, err := strconv.Atoi(top)
+		if err != nil || parsedTop <= 0 {
+			common.SendResponse(c, nil, errors.Occur(errors.ErrRequestQueryParamIllegal, "limit"))
+			return
+		}
+		p.Top = parsedTop
+	}
+	var err error
+	if p.StartTime, err = parseTimeQuery(c, "start_time"); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	if p.EndTime, err = parseTimeQuery(c, "end_time"); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	slowSqls, err := observer.GetTopSlowSqls(&p)
+	common.SendResponse(c, slowSqls, err)
+}
+
+func parseTimeQuery(c *gin.Context, key string) (time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return time.Time{}, errors.Occur(errors.ErrRequestQueryParamEmpty, key)
+	}
+	parsedTime, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.Occur(errors.ErrRequestQueryParamIllegal, key)
+	}
+	return parsedTime, nil
+}
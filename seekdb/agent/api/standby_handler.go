@@ -176,6 +176,40 @@ func standbyTokenHandler(c *gin.Context) {
 	common.SendResponse(c, encrypted, nil)
 }
 
+// @ID getStandbyFailover
+// @Summary Get the automatic failover config, controller state and recent events
+// @Tags seekdb
+// @Produce application/json
+// @Param X-OCS-Header header string true "Authorization"
+// @Success 200 object http.OcsAgentResponse{data=param.FailoverStatusResp}
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/seekdb/standby/failover [get]
+func standbyFailoverStatusHandler(c *gin.Context) {
+	data, err := standbyexec.GetFailoverStatus()
+	common.SendResponse(c, data, err)
+}
+
+// @ID putStandbyFailover
+// @Summary Enable, disable or tune the automatic failover of this standby
+// @Tags seekdb
+// @Accept application/json
+// @Produce application/json
+// @Param body body param.FailoverConfigParam true "failover config"
+// @Param X-OCS-Header header string true "Authorization"
+// @Success 200 object http.OcsAgentResponse{data=param.FailoverConfig}
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /api/v1/seekdb/standby/failover [put]
+func standbyFailoverConfigHandler(c *gin.Context) {
+	var p param.FailoverConfigParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	data, err := standbyexec.SetFailoverConfig(p)
+	common.SendResponse(c, data, err)
+}
+
 // ─── Internal RPC handlers (Token auth, peer-to-peer only) ──────────────────
 
 // @ID rpcSwitchoverToPrimary
@@ -256,3 +290,25 @@ func rpcPairDeleteHandler(c *gin.Context) {
 	})
 	common.SendResponse(c, gin.H{"deleted": true}, err)
 }
+
+// @ID rpcFence
+// @Summary Internal RPC: fence this node after the standby has been activated by an automatic failover
+// @Description Sets the local seekdb read only if it is still PRIMARY and removes the pair record of the caller.
+// @Tags seekdb
+// @Accept application/json
+// @Produce application/json
+// @Param body body param.RpcFenceParam true "rpc fence params"
+// @Param X-OCS-Header header string true "Authorization"
+// @Success 200 object http.OcsAgentResponse
+// @Failure 400 object http.OcsAgentResponse
+// @Failure 500 object http.OcsAgentResponse
+// @Router /rpc/v1/seekdb/standby/fence [post]
+func rpcFenceHandler(c *gin.Context) {
+	var p param.RpcFenceParam
+	if err := c.BindJSON(&p); err != nil {
+		common.SendResponse(c, nil, err)
+		return
+	}
+	err := standbyexec.ExecuteFence(p)
+	common.SendResponse(c, nil, err)
+}
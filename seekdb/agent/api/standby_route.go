@@ -39,6 +39,8 @@ func InitStandbyRoutes(r *gin.Engine, seekdbGroup *gin.RouterGroup, isLocalRoute
 	standby.POST(constant.URI_SWITCHOVER, standbySwitchoverHandler)
 	standby.POST(constant.URI_ACTIVATE, standbyActivateHandler)
 	standby.POST(constant.URI_TOKEN, standbyTokenHandler)
+	standby.GET(constant.URI_FAILOVER, standbyFailoverStatusHandler)
+	standby.PUT(constant.URI_FAILOVER, standbyFailoverConfigHandler)
 
 	// ── Internal RPC (/rpc/v1/*) — Token auth, TCP only ──
 	if !isLocalRoute {
@@ -49,5 +51,6 @@ func InitStandbyRoutes(r *gin.Engine, seekdbGroup *gin.RouterGroup, isLocalRoute
 		rpcStandby.GET(constant.URI_STANDBY_STATUS, rpcStandbyStatusHandler)
 		rpcStandby.POST(constant.URI_SWITCHOVER_TO_PRIMARY, rpcSwitchoverToPrimaryHandler)
 		rpcStandby.DELETE(constant.URI_PAIR, rpcPairDeleteHandler)
+		rpcStandby.POST(constant.URI_FENCE, rpcFenceHandler)
 	}
 }
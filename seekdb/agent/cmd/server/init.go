@@ -29,6 +29,7 @@ import (
 	"github.com/oceanbase/obshell/seekdb/agent/config"
 	"github.com/oceanbase/obshell/seekdb/agent/constant"
 	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	backupexec "github.com/oceanbase/obshell/seekdb/agent/executor/backup"
 	"github.com/oceanbase/obshell/seekdb/agent/executor/observer"
 	standbyexec "github.com/oceanbase/obshell/seekdb/agent/executor/standby"
 	"github.com/oceanbase/obshell/seekdb/agent/executor/upgrade"
@@ -247,6 +248,7 @@ func (a *Agent) initTask() {
 	observer.RegisterObStartTask()
 	upgrade.RegisterUpgradeTask()
 	standbyexec.RegisterTasks()
+	backupexec.RegisterTasks()
 }
 
 // Check if the ob config file exists.
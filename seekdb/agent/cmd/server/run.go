@@ -25,6 +25,7 @@ import (
 	"github.com/oceanbase/obshell/seekdb/agent/engine"
 	"github.com/oceanbase/obshell/seekdb/agent/errors"
 	"github.com/oceanbase/obshell/seekdb/agent/executor/observer"
+	standbyexec "github.com/oceanbase/obshell/seekdb/agent/executor/standby"
 	"github.com/oceanbase/obshell/seekdb/agent/lib/process"
 	"github.com/oceanbase/obshell/seekdb/agent/meta"
 	"github.com/oceanbase/obshell/seekdb/agent/repository/db/oceanbase"
@@ -47,6 +48,7 @@ func (a *Agent) run() (err error) {
 	}
 
 	a.handleOBMeta()
+	standbyexec.StartFailoverController()
 	return nil
 }
 
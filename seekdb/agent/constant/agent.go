@@ -94,6 +94,7 @@ const (
 	PKG_OCEANBASE_CE_LIBS    = "oceanbase-ce-libs"
 	PKG_OCEANBASE            = "oceanbase"
 	PKG_OCEANBASE_STANDALONE = "oceanbase-standalone"
+	PKG_SEEKDB               = "seekdb"
 )
 
 var SUPPORT_PKG_NAMES_MAP = map[oceanbase.OBType][]string{
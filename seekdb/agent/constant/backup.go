@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package constant
+
+import "time"
+
+// URI segments for backup and restore API.
+const (
+	URI_BACKUP_GROUP  = "/backup"
+	URI_RESTORE_GROUP = "/restore"
+	URI_CONFIG        = "/config"
+	URI_OVERVIEW      = "/overview"
+	URI_HISTORY       = "/history"
+
+	URI_SEEKDB_BACKUP_API_PREFIX  = URI_API_V1 + URI_SEEKDB_GROUP + URI_BACKUP_GROUP
+	URI_SEEKDB_RESTORE_API_PREFIX = URI_API_V1 + URI_SEEKDB_GROUP + URI_RESTORE_GROUP
+)
+
+// Storage uri prefixes and protocols supported by lib/system.StorageInterface.
+const (
+	PREFIX_OSS    = "oss://"
+	PREFIX_COS    = "cos://"
+	PREFIX_S3     = "s3://"
+	PREFIX_FILE   = "file://"
+	PREFIX_AZBLOB = "azblob://"
+	PREFIX_GCS    = "gs://"
+
+	PROTOCOL_OSS    = "oss"
+	PROTOCOL_COS    = "cos"
+	PROTOCOL_S3     = "s3"
+	PROTOCOL_FILE   = "file"
+	PROTOCOL_AZBLOB = "azblob"
+	PROTOCOL_GCS    = "gs"
+
+	// GCS_DEFAULT_HOST is the endpoint of the S3-interoperable XML API of Google Cloud Storage.
+	GCS_DEFAULT_HOST = "https://storage.googleapis.com"
+)
+
+// Backup options and the values reported by the backup views.
+const (
+	BACKUP_DIR_CLOG = "clog"
+	BACKUP_DIR_DATA = "data"
+
+	BACKUP_MODE_FULL        = "full"
+	BACKUP_MODE_INCREMENTAL = "incremental"
+
+	BINDING_MODE_OPTIONAL  = "OPTIONAL"
+	BINDING_MODE_MANDATORY = "MANDATORY"
+
+	LOG_ARCHIVE_CONCURRENCY_LOW  = 0
+	LOG_ARCHIVE_CONCURRENCY_HIGH = 100
+
+	ARCHIVE_LAG_TARGET_HIGH       = time.Hour * 2
+	ARCHIVE_LAG_TARGET_LOW_FOR_S3 = time.Minute
+
+	ARCHIVELOG_STATUS_DOING       = "DOING"
+	ARCHIVELOG_STATUS_INTERRUPTED = "INTERRUPTED"
+
+	BACKUP_JOB_STATUS_COMPLETED = "COMPLETED"
+	RESTORE_STATUS_SUCCESS      = "SUCCESS"
+
+	BACKUP_HISTORY_DEFAULT_LIMIT = 20
+	BACKUP_HISTORY_MAX_LIMIT     = 1000
+)
+
+// DAG and task node names for backup and restore operations.
+const (
+	DAG_SET_BACKUP_CONFIG = "Set backup config"
+	DAG_BACKUP            = "Backup seekdb"
+	DAG_RESTORE           = "Restore seekdb"
+
+	TASK_CHECK_BACKUP_CONFIG = "Check backup config"
+	TASK_SET_BACKUP_CONFIG   = "Set backup config"
+	TASK_OPEN_ARCHIVE_LOG    = "Open archive log"
+	TASK_START_BACKUP        = "Start backup"
+	TASK_WAIT_BACKUP_FINISH  = "Wait backup finish"
+	TASK_RESTORE_PRECHECK    = "Restore precheck"
+	TASK_START_RESTORE       = "Start restore"
+	TASK_WAIT_RESTORE_FINISH = "Wait restore finish"
+)
+
+// Context parameter keys passed between backup and restore task nodes.
+const (
+	PARAM_BACKUP_CONFIG  = "backup_config"
+	PARAM_BACKUP         = "backup"
+	PARAM_RESTORE        = "restore"
+	PARAM_BACKUP_JOB_ID  = "backup_job_id"
+	PARAM_RESTORE_JOB_ID = "restore_job_id"
+)
+
+// Polling intervals used when waiting for archive log, backup and restore jobs.
+const (
+	ARCHIVE_LOG_CHECK_INTERVAL = 5 * time.Second
+	BACKUP_CHECK_INTERVAL      = 10 * time.Second
+	RESTORE_CHECK_INTERVAL     = 10 * time.Second
+	ARCHIVE_LOG_CHECK_TIMES    = 120
+)
@@ -16,6 +16,8 @@
 
 package constant
 
+import "time"
+
 // URI segments for standby API.
 const (
 	URI_RPC_V1 = "/rpc/v1"
@@ -26,6 +28,8 @@ const (
 	URI_SWITCHOVER            = "/switchover"
 	URI_ACTIVATE              = "/activate"
 	URI_SWITCHOVER_TO_PRIMARY = "/switchover-to-primary"
+	URI_FAILOVER              = "/failover"
+	URI_FENCE                 = "/fence"
 
 	URI_SEEKDB_STANDBY_API_PREFIX = URI_API_V1 + URI_SEEKDB_GROUP + URI_STANDBY_GROUP
 	URI_SEEKDB_STANDBY_RPC_PREFIX = URI_RPC_V1 + URI_SEEKDB_GROUP + URI_STANDBY_GROUP
@@ -82,3 +86,27 @@ const (
 // SyncDelayThresholdSeconds mirrors OCP's standby_tenant_sync_delay_too_long
 // alarm threshold (ocp_alarm_template.yaml: 600s).
 const SyncDelayThresholdSeconds uint64 = 600
+
+// Automatic failover defaults and bounds. The failover controller runs on the
+// standby and activates it when the upstream primary stays unreachable for the
+// outage window while the last observed lag is below the max lag.
+const (
+	DefaultFailoverOutageWindowSeconds  = 60
+	DefaultFailoverMaxLagSeconds        = 30
+	DefaultFailoverProbeIntervalSeconds = 5
+	MinFailoverOutageWindowSeconds      = 10
+	MinFailoverProbeIntervalSeconds     = 1
+	MaxFailoverProbeIntervalSeconds     = 60
+
+	FailoverSqlProbeTimeout = 3 * time.Second
+	FailoverEventsLimit     = 50
+)
+
+// Event types recorded by the failover controller.
+const (
+	FailoverEventOutageDetected = "OUTAGE_DETECTED"
+	FailoverEventPrimaryBack    = "PRIMARY_RECOVERED"
+	FailoverEventSkipped        = "FAILOVER_SKIPPED"
+	FailoverEventTriggered      = "FAILOVER_TRIGGERED"
+	FailoverEventFenced         = "PRIMARY_FENCED"
+)
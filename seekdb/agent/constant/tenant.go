@@ -21,4 +21,11 @@ const (
 
 	TENANT_SYS    = "sys"
 	TENANT_SYS_ID = 1
+
+	// SQL whose elapsed time exceeds this threshold (in microseconds) is considered slow.
+	SLOW_SQL_THRESHOLD = 100000
+	// DEFAULT_TOP_SLOW_SQL_LIMIT is used when the request does not specify a limit.
+	DEFAULT_TOP_SLOW_SQL_LIMIT = 10
+
+	SESSION_STATE_ACTIVE = "ACTIVE"
 )
@@ -87,6 +87,10 @@ const (
 	URI_GLOBAL_PRIVILEGE  = "/global-privilege"
 	URI_STATS             = "/stats"
 	URI_LOCK              = "/lock"
+	URI_SESSIONS          = "/sessions"
+	URI_QUERIES           = "/queries"
+	URI_DEADLOCKS         = "/deadlocks"
+	URI_TOP_SLOW_SQLS     = "/top-slow-sqls"
 
 	URI_PARAM_NAME          = "name"
 	URI_PATH_PARAM_NAME     = "/:" + URI_PARAM_NAME
@@ -99,6 +103,9 @@ const (
 	URI_PARAM_DATABASE      = "database"
 	URI_PATH_PARAM_DATABASE = "/:" + URI_PARAM_DATABASE
 
+	URI_PARAM_SESSION_ID      = "session_id"
+	URI_PATH_PARAM_SESSION_ID = "/:" + URI_PARAM_SESSION_ID
+
 	URI_TASK_API_PREFIX   = URI_API_V1 + URI_TASK_GROUP
 	URI_AGENT_API_PREFIX  = URI_API_V1 + URI_AGENT_GROUP
 	URI_SEEKDB_API_PREFIX = URI_API_V1 + URI_SEEKDB_GROUP
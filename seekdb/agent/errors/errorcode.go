@@ -95,6 +95,7 @@ var (
 	ErrObParameterNotExist             = NewErrorCode("seekdb.Parameter.NotExist", badRequest, "err.seekdb.parameter.not.exist")               // "parameter '%s' is not exist"
 	ErrObVariableInvalid               = NewErrorCode("seekdb.Variable.Invalid", illegalArgument, "err.seekdb.variable.invalid")               // "variable '%s' is invalid: %s"
 	ErrObVariableNotExist              = NewErrorCode("seekdb.Variable.NotExist", notFound, "err.seekdb.variable.not.exist")                   // "variable '%s' is not exist"
+	ErrObSessionNotExist               = NewErrorCode("seekdb.Session.NotExist", notFound, "err.seekdb.session.not.exist")                     // "session '%s' is not exist"
 
 	// OB.Cluster
 	ErrObClusterUnderMaintenance        = NewErrorCode("seekdb.UnderMaintenance", known, "err.seekdb.under.maintenance")
@@ -114,6 +115,12 @@ var (
 	ErrObPackageNameNotSupport = NewErrorCode("seekdb.Package.Name.NotSupport", illegalArgument, "err.seekdb.package.name.not.support")
 	ErrObPackageMissingFile    = NewErrorCode("seekdb.Package.MissingFile", unexpected, "err.seekdb.package.missing.file")
 
+	// OB.Upgrade
+	ErrObUpgradeToLowerVersion     = NewErrorCode("seekdb.Upgrade.ToLowerVersion", illegalArgument, "err.seekdb.upgrade.to.lower.version")
+	ErrObUpgradeStandbyNotUpgraded = NewErrorCode("seekdb.Upgrade.StandbyNotUpgraded", badRequest, "err.seekdb.upgrade.standby.not.upgraded")
+	ErrObUpgradeStandbyUnreachable = NewErrorCode("seekdb.Upgrade.StandbyUnreachable", badRequest, "err.seekdb.upgrade.standby.unreachable")
+	ErrObUpgradeVersionMismatch    = NewErrorCode("seekdb.Upgrade.VersionMismatch", unexpected, "err.seekdb.upgrade.version.mismatch")
+
 	ErrPackageReleaseFormatInvalid    = NewErrorCode("Package.ReleaseFormat.Invalid", illegalArgument, "err.package.release.format.invalid")
 	ErrPackageCompressionNotSupported = NewErrorCode("Package.Compression.NotSupported", illegalArgument, "err.package.compression.not.supported")
 	ErrPackageFormatInvalid           = NewErrorCode("Package.Format.Invalid", illegalArgument, "err.package.format.invalid")
@@ -167,6 +174,8 @@ var (
 	ErrRequestHeaderTypeInvalid                  = NewErrorCode("Request.Header.Type.Invalid", unexpected, "err.request.header.type.invalid")
 	ErrRequestHeaderNotFound                     = NewErrorCode("Request.Header.NotFound", badRequest, "err.request.header.not.found")
 	ErrRequestAesKeyNotFound                     = NewErrorCode("Request.AES.KeyNotFound", badRequest, "err.request.aes.key.not.found")
+	ErrRequestQueryParamEmpty                    = NewErrorCode("Request.Query.Param.Empty", badRequest, "err.request.query.param.empty")
+	ErrRequestQueryParamIllegal                  = NewErrorCode("Request.Query.Param.Illegal", badRequest, "err.request.query.param.illegal")
 
 	// Security
 	ErrSecurityUserPermissionDenied                     = NewErrorCode("Security.User.PermissionDenied", unauthorized, "err.security.user.permission.denied")
@@ -200,6 +209,26 @@ var (
 	ErrStandbyActivateLocalNotStandby = NewErrorCode("Standby.Activate.LocalNotStandby", badRequest, "err.standby.activate.local.not.standby")
 	ErrStandbyUpstreamStillHealthy    = NewErrorCode("Standby.Activate.UpstreamStillHealthy", badRequest, "err.standby.activate.upstream.still.healthy")
 
+	// Standby.Failover
+	ErrStandbyFailoverConfigInvalid = NewErrorCode("Standby.Failover.ConfigInvalid", illegalArgument, "err.standby.failover.config.invalid")
+
+	// Backup
+	ErrBackupStorageURIInvalid            = NewErrorCode("Backup.Storage.URIInvalid", illegalArgument, "err.backup.storage.uri.invalid")
+	ErrBackupDestNotSet                   = NewErrorCode("Backup.Dest.NotSet", badRequest, "err.backup.dest.not.set")
+	ErrBackupModeInvalid                  = NewErrorCode("Backup.Mode.Invalid", illegalArgument, "err.backup.mode.invalid")
+	ErrBackupBindingInvalid               = NewErrorCode("Backup.Binding.Invalid", illegalArgument, "err.backup.binding.invalid")
+	ErrBackupArchiveLagTargetInvalid      = NewErrorCode("Backup.ArchiveLagTarget.Invalid", illegalArgument, "err.backup.archive.lag.target.invalid")
+	ErrBackupLogArchiveConcurrencyInvalid = NewErrorCode("Backup.LogArchiveConcurrency.Invalid", illegalArgument, "err.backup.log.archive.concurrency.invalid")
+	ErrBackupJobRunning                   = NewErrorCode("Backup.Job.Running", badRequest, "err.backup.job.running")
+	ErrBackupJobFailed                    = NewErrorCode("Backup.Job.Failed", known, "err.backup.job.failed")
+	ErrBackupArchiveLogNotDoing           = NewErrorCode("Backup.ArchiveLog.NotDoing", known, "err.backup.archive.log.not.doing")
+
+	// Restore
+	ErrRestoreInstanceNotFresh = NewErrorCode("Restore.Instance.NotFresh", badRequest, "err.restore.instance.not.fresh")
+	ErrRestoreSourceEmpty      = NewErrorCode("Restore.Source.Empty", badRequest, "err.restore.source.empty")
+	ErrRestoreJobRunning       = NewErrorCode("Restore.Job.Running", badRequest, "err.restore.job.running")
+	ErrRestoreJobFailed        = NewErrorCode("Restore.Job.Failed", known, "err.restore.job.failed")
+
 	// Task
 	ErrTaskNotFound                        = NewErrorCode("Task.NotFound", notFound, "err.task.not.found")
 	ErrTaskNotFoundWithReason              = NewErrorCode("Task.NotFound", notFound, "err.task.not.found.with.reason")
@@ -0,0 +1,313 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"strings"
+	"time"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/engine/task"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/system"
+	"github.com/oceanbase/obshell/seekdb/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+// Backup checks the param and creates the Backup DAG.
+// Flow: OpenArchiveLog → StartBackup → WaitBackupFinish
+func Backup(p *param.BackupParam) (*task.DagDetailDTO, error) {
+	if err := checkBackupParam(p); err != nil {
+		return nil, err
+	}
+
+	builder := task.NewTemplateBuilder(constant.DAG_BACKUP).
+		AddTask(newOpenArchiveLogTask(), false).
+		AddTask(newStartBackupTask(), false).
+		AddTask(newWaitBackupFinishTask(), false).
+		SetMaintenance(task.GlobalMaintenance())
+
+	ctx := task.NewTaskContext().
+		SetParam(constant.PARAM_BACKUP, p).
+		SetParam(task.FAILURE_EXIT_MAINTENANCE, true)
+
+	dag, err := localTaskService.CreateDagInstanceByTemplate(builder.Build(), ctx)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+func checkBackupParam(p *param.BackupParam) error {
+	if p.Mode == "" {
+		p.Mode = constant.BACKUP_MODE_FULL
+	}
+	p.Mode = strings.ToLower(p.Mode)
+	if p.Mode != constant.BACKUP_MODE_FULL && p.Mode != constant.BACKUP_MODE_INCREMENTAL {
+		return errors.Occur(errors.ErrBackupModeInvalid, p.Mode, constant.BACKUP_MODE_FULL, constant.BACKUP_MODE_INCREMENTAL)
+	}
+
+	dataDest, err := backupService.GetDataBackupDest()
+	if err != nil {
+		return errors.Wrap(err, "get data_backup_dest")
+	}
+	archiveDest, err := backupService.GetLogArchiveDest()
+	if err != nil {
+		return errors.Wrap(err, "get log_archive_dest")
+	}
+	if dataDest == "" || archiveDest == "" {
+		return errors.Occur(errors.ErrBackupDestNotSet)
+	}
+
+	job, err := backupService.GetRunningBackupJob()
+	if err != nil {
+		return errors.Wrap(err, "get running backup job")
+	}
+	if job != nil {
+		return errors.Occur(errors.ErrBackupJobRunning, job.JobID)
+	}
+	return nil
+}
+
+// OpenArchiveLogTask opens the archive log if it is not opened yet and waits
+// until it is DOING, which is required by the backup.
+type OpenArchiveLogTask struct {
+	task.Task
+}
+
+func newOpenArchiveLogTask() *OpenArchiveLogTask {
+	t := &OpenArchiveLogTask{
+		Task: *task.NewSubTask(constant.TASK_OPEN_ARCHIVE_LOG),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanPass().SetCanCancel()
+	return t
+}
+
+func (t *OpenArchiveLogTask) Execute() error {
+	archiveLog, err := backupService.GetArchiveLog()
+	if err != nil {
+		return errors.Wrap(err, "get archive log status")
+	}
+	if archiveLog == nil || archiveLog.Status != constant.ARCHIVELOG_STATUS_DOING {
+		t.ExecuteLog("Opening archive log")
+		if err = backupService.OpenArchiveLog(); err != nil {
+			return errors.Wrap(err, "open archive log")
+		}
+	}
+
+	status := ""
+	for i := 0; i < constant.ARCHIVE_LOG_CHECK_TIMES; i++ {
+		t.TimeoutCheck()
+		if archiveLog, err = backupService.GetArchiveLog(); err != nil {
+			return errors.Wrap(err, "get archive log status")
+		}
+		if archiveLog != nil {
+			status = archiveLog.Status
+			if status == constant.ARCHIVELOG_STATUS_DOING {
+				t.ExecuteLog("Archive log is DOING")
+				return nil
+			}
+			if status == constant.ARCHIVELOG_STATUS_INTERRUPTED {
+				break
+			}
+		}
+		t.ExecuteLogf("Archive log status is '%s', waiting", status)
+		time.Sleep(constant.ARCHIVE_LOG_CHECK_INTERVAL)
+	}
+	return errors.Occur(errors.ErrBackupArchiveLogNotDoing, status)
+}
+
+// StartBackupTask issues the backup and records the backup job id.
+type StartBackupTask struct {
+	task.Task
+}
+
+func newStartBackupTask() *StartBackupTask {
+	t := &StartBackupTask{
+		Task: *task.NewSubTask(constant.TASK_START_BACKUP),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanPass().SetCanCancel()
+	return t
+}
+
+func (t *StartBackupTask) Execute() error {
+	var p param.BackupParam
+	if err := t.GetContext().GetParamWithValue(constant.PARAM_BACKUP, &p); err != nil {
+		return err
+	}
+
+	job, err := backupService.GetRunningBackupJob()
+	if err != nil {
+		return errors.Wrap(err, "get running backup job")
+	}
+	if job == nil {
+		t.ExecuteLogf("Starting %s backup", p.Mode)
+		if err = backupService.StartBackup(p.Mode == constant.BACKUP_MODE_INCREMENTAL, p.Encryption, p.PlusArchive); err != nil {
+			return errors.Wrap(err, "start backup")
+		}
+		// The job is inserted asynchronously, wait for it a while.
+		for i := 0; i < constant.ARCHIVE_LOG_CHECK_TIMES && job == nil; i++ {
+			t.TimeoutCheck()
+			time.Sleep(time.Second)
+			if job, err = backupService.GetRunningBackupJob(); err != nil {
+				return errors.Wrap(err, "get running backup job")
+			}
+		}
+		if job == nil {
+			return errors.New("backup job not found after the backup is started")
+		}
+	} else {
+		// The task is retried after the backup has been started.
+		t.ExecuteLogf("Backup job %d is already running", job.JobID)
+	}
+	t.ExecuteLogf("Backup job %d started", job.JobID)
+	t.GetContext().SetData(constant.PARAM_BACKUP_JOB_ID, job.JobID)
+	return nil
+}
+
+// WaitBackupFinishTask waits for the backup job to finish and checks its result.
+type WaitBackupFinishTask struct {
+	task.Task
+}
+
+func newWaitBackupFinishTask() *WaitBackupFinishTask {
+	t := &WaitBackupFinishTask{
+		Task: *task.NewSubTask(constant.TASK_WAIT_BACKUP_FINISH),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanPass().SetCanCancel()
+	return t
+}
+
+func (t *WaitBackupFinishTask) Execute() error {
+	var jobID int64
+	if err := t.GetContext().GetDataWithValue(constant.PARAM_BACKUP_JOB_ID, &jobID); err != nil {
+		return err
+	}
+	for {
+		t.TimeoutCheck()
+		job, err := backupService.GetBackupJobHistory(jobID)
+		if err != nil {
+			return errors.Wrap(err, "get backup job history")
+		}
+		if job != nil {
+			if job.Status != constant.BACKUP_JOB_STATUS_COMPLETED {
+				return errors.Occur(errors.ErrBackupJobFailed, jobID, job.Status, job.Comment)
+			}
+			t.ExecuteLogf("Backup job %d completed, backup set id is %d", jobID, job.BackupSetID)
+			return nil
+		}
+		if job, err = backupService.GetRunningBackupJob(); err != nil {
+			return errors.Wrap(err, "get running backup job")
+		} else if job != nil {
+			t.ExecuteLogf("Backup job %d is %s", job.JobID, job.Status)
+		}
+		time.Sleep(constant.BACKUP_CHECK_INTERVAL)
+	}
+}
+
+// GetBackupOverview returns the backup destinations with the secrets masked,
+// the archive log status, the running backup job and the last finished one.
+func GetBackupOverview() (*param.BackupOverview, error) {
+	overview := &param.BackupOverview{}
+	dataDest, err := backupService.GetDataBackupDest()
+	if err != nil {
+		return nil, errors.Wrap(err, "get data_backup_dest")
+	}
+	overview.DataBackupDest = maskDest(dataDest)
+	archiveDest, err := backupService.GetLogArchiveDest()
+	if err != nil {
+		return nil, errors.Wrap(err, "get log_archive_dest")
+	}
+	overview.LogArchiveDest = maskDest(archiveDest)
+
+	archiveLog, err := backupService.GetArchiveLog()
+	if err != nil {
+		return nil, errors.Wrap(err, "get archive log status")
+	}
+	if archiveLog != nil {
+		overview.ArchiveLog = &param.ArchiveLogStatus{
+			Status:        archiveLog.Status,
+			StartScn:      archiveLog.StartScnDisplay,
+			CheckpointScn: archiveLog.CheckpointScnDisplay,
+			Delay:         archiveLog.Delay,
+			Comment:       archiveLog.Comment,
+		}
+	}
+
+	running, err := backupService.GetRunningBackupJob()
+	if err != nil {
+		return nil, errors.Wrap(err, "get running backup job")
+	}
+	if running != nil {
+		overview.RunningJob = convertBackupJob(running)
+	}
+	history, err := backupService.ListBackupJobHistory(1)
+	if err != nil {
+		return nil, errors.Wrap(err, "get backup job history")
+	}
+	if len(history) > 0 {
+		overview.LastJob = convertBackupJob(&history[0])
+	}
+	return overview, nil
+}
+
+// GetBackupHistory returns the latest finished backup jobs, newest first.
+func GetBackupHistory(p *param.BackupHistoryParam) ([]param.BackupJob, error) {
+	limit := p.Limit
+	if limit <= 0 {
+		limit = constant.BACKUP_HISTORY_DEFAULT_LIMIT
+	} else if limit > constant.BACKUP_HISTORY_MAX_LIMIT {
+		limit = constant.BACKUP_HISTORY_MAX_LIMIT
+	}
+	jobs, err := backupService.ListBackupJobHistory(limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "list backup job history")
+	}
+	res := make([]param.BackupJob, 0, len(jobs))
+	for i := range jobs {
+		res = append(res, *convertBackupJob(&jobs[i]))
+	}
+	return res, nil
+}
+
+// maskDest hides the secret in the destination, only the query params are
+// dropped when it is not a valid storage uri.
+func maskDest(dest string) string {
+	if dest == "" {
+		return ""
+	}
+	storage, err := system.GetStorageInterfaceByURI(dest)
+	if err != nil {
+		return strings.Split(dest, "?")[0]
+	}
+	return storage.GenerateURIWithoutSecret()
+}
+
+func convertBackupJob(job *oceanbase.DbaObBackupJob) *param.BackupJob {
+	return &param.BackupJob{
+		JobID:          job.JobID,
+		BackupSetID:    job.BackupSetID,
+		BackupType:     job.BackupType,
+		PlusArchivelog: strings.EqualFold(job.PlusArchivelog, "ON"),
+		Status:         job.Status,
+		StartTimestamp: job.StartTimestamp,
+		EndTimestamp:   job.EndTimestamp,
+		Result:         job.Result,
+		Comment:        job.Comment,
+		Path:           maskDest(job.Path),
+	}
+}
@@ -0,0 +1,221 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"strings"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/engine/task"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/system"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+// BackupConf is the checked backup config carried by the Set backup config DAG.
+// The destinations are full uris with the secrets, empty means unchanged.
+type BackupConf struct {
+	DataDest              string `json:"data_dest"`
+	ArchiveDest           string `json:"archive_dest"`
+	Binding               string `json:"binding"`
+	LogArchiveConcurrency *int   `json:"log_archive_concurrency"`
+	ArchiveLagTarget      string `json:"archive_lag_target"`
+}
+
+// resolveDest returns the full uri of the destination, the override uri is used as is
+// and the base uri is joined with dir. Empty means the destination is unchanged.
+func resolveDest(baseUri, overrideUri *string, dir string) (string, error) {
+	if overrideUri != nil && *overrideUri != "" {
+		storage, err := system.GetStorageInterfaceByURI(*overrideUri)
+		if err != nil {
+			return "", err
+		}
+		return storage.GenerateURI(), nil
+	}
+	if baseUri != nil && *baseUri != "" {
+		storage, err := system.GetStorageInterfaceByURI(*baseUri)
+		if err != nil {
+			return "", err
+		}
+		return storage.NewWithObjectKey(dir).GenerateURI(), nil
+	}
+	return "", nil
+}
+
+// checkBackupConfigParam checks the param and resolves the destinations.
+func checkBackupConfigParam(p *param.BackupConfigParam) (*BackupConf, error) {
+	conf := &BackupConf{}
+	var err error
+	if conf.DataDest, err = resolveDest(p.BackupBaseUri, p.DataBaseUri, constant.BACKUP_DIR_DATA); err != nil {
+		return nil, err
+	}
+	if conf.ArchiveDest, err = resolveDest(p.BackupBaseUri, p.ArchiveBaseUri, constant.BACKUP_DIR_CLOG); err != nil {
+		return nil, err
+	}
+
+	if p.Binding != nil && *p.Binding != "" {
+		conf.Binding = strings.ToUpper(*p.Binding)
+		if conf.Binding != constant.BINDING_MODE_OPTIONAL && conf.Binding != constant.BINDING_MODE_MANDATORY {
+			return nil, errors.Occur(errors.ErrBackupBindingInvalid, *p.Binding, constant.BINDING_MODE_OPTIONAL, constant.BINDING_MODE_MANDATORY)
+		}
+	}
+
+	if p.LogArchiveConcurrency != nil {
+		if *p.LogArchiveConcurrency < constant.LOG_ARCHIVE_CONCURRENCY_LOW || *p.LogArchiveConcurrency > constant.LOG_ARCHIVE_CONCURRENCY_HIGH {
+			return nil, errors.Occur(errors.ErrBackupLogArchiveConcurrencyInvalid, constant.LOG_ARCHIVE_CONCURRENCY_LOW, constant.LOG_ARCHIVE_CONCURRENCY_HIGH)
+		}
+		conf.LogArchiveConcurrency = p.LogArchiveConcurrency
+	}
+
+	if p.ArchiveLagTarget != nil && *p.ArchiveLagTarget != "" {
+		conf.ArchiveLagTarget = strings.ToLower(*p.ArchiveLagTarget)
+		if err = checkArchiveLagTarget(conf.ArchiveLagTarget, conf.ArchiveDest); err != nil {
+			return nil, err
+		}
+	}
+	return conf, nil
+}
+
+func checkArchiveLagTarget(target, archiveDest string) error {
+	duration, err := system.ParseTime(target)
+	if err != nil {
+		return err
+	}
+	if duration > constant.ARCHIVE_LAG_TARGET_HIGH {
+		return errors.Occur(errors.ErrBackupArchiveLagTargetInvalid, target, "must not be greater than "+constant.ARCHIVE_LAG_TARGET_HIGH.String())
+	}
+	if archiveDest == "" {
+		if archiveDest, err = backupService.GetLogArchiveDest(); err != nil {
+			return err
+		}
+	}
+	if archiveDest == "" {
+		return nil
+	}
+	t, err := system.GetResourceType(archiveDest)
+	if err != nil {
+		return err
+	}
+	// GCS is accessed by seekdb through the S3 protocol, so the same lower bound applies.
+	if (t == constant.PROTOCOL_S3 || t == constant.PROTOCOL_GCS) && duration < constant.ARCHIVE_LAG_TARGET_LOW_FOR_S3 {
+		return errors.Occur(errors.ErrBackupArchiveLagTargetInvalid, target, "must not be less than "+constant.ARCHIVE_LAG_TARGET_LOW_FOR_S3.String()+" for s3")
+	}
+	return nil
+}
+
+// SetBackupConfig checks the param and creates the Set backup config DAG.
+// Flow: CheckBackupConfig → SetBackupConfig
+func SetBackupConfig(p *param.BackupConfigParam) (*task.DagDetailDTO, error) {
+	conf, err := checkBackupConfigParam(p)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := task.NewTemplateBuilder(constant.DAG_SET_BACKUP_CONFIG).
+		AddTask(newCheckBackupConfigTask(), false).
+		AddTask(newSetBackupConfigTask(), false).
+		SetMaintenance(task.GlobalMaintenance())
+
+	ctx := task.NewTaskContext().
+		SetParam(constant.PARAM_BACKUP_CONFIG, conf).
+		SetParam(task.FAILURE_EXIT_MAINTENANCE, true)
+
+	dag, err := localTaskService.CreateDagInstanceByTemplate(builder.Build(), ctx)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+// CheckBackupConfigTask checks that obshell can write to the destinations.
+type CheckBackupConfigTask struct {
+	task.Task
+}
+
+func newCheckBackupConfigTask() *CheckBackupConfigTask {
+	t := &CheckBackupConfigTask{
+		Task: *task.NewSubTask(constant.TASK_CHECK_BACKUP_CONFIG),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanPass().SetCanCancel()
+	return t
+}
+
+func (t *CheckBackupConfigTask) Execute() error {
+	var conf BackupConf
+	if err := t.GetContext().GetParamWithValue(constant.PARAM_BACKUP_CONFIG, &conf); err != nil {
+		return err
+	}
+	for _, dest := range []string{conf.DataDest, conf.ArchiveDest} {
+		if dest == "" {
+			continue
+		}
+		storage, err := system.GetStorageInterfaceByURI(dest)
+		if err != nil {
+			return err
+		}
+		t.ExecuteLogf("Checking write permission of %s", storage.GenerateURIWithoutSecret())
+		if err = storage.CheckWritePermission(); err != nil {
+			return errors.Wrapf(err, "check write permission of %s", storage.GenerateURIWithoutSecret())
+		}
+	}
+	return nil
+}
+
+// SetBackupConfigTask sets the backup parameters of seekdb.
+type SetBackupConfigTask struct {
+	task.Task
+}
+
+func newSetBackupConfigTask() *SetBackupConfigTask {
+	t := &SetBackupConfigTask{
+		Task: *task.NewSubTask(constant.TASK_SET_BACKUP_CONFIG),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanPass().SetCanCancel()
+	return t
+}
+
+func (t *SetBackupConfigTask) Execute() error {
+	var conf BackupConf
+	if err := t.GetContext().GetParamWithValue(constant.PARAM_BACKUP_CONFIG, &conf); err != nil {
+		return err
+	}
+	if conf.DataDest != "" {
+		t.ExecuteLog("Setting data_backup_dest")
+		if err := backupService.SetDataBackupDest(system.ObStorageURI(conf.DataDest)); err != nil {
+			return errors.Wrap(err, "set data_backup_dest")
+		}
+	}
+	if conf.ArchiveDest != "" {
+		t.ExecuteLog("Setting log_archive_dest")
+		if err := backupService.SetLogArchiveDest(system.ObStorageURI(conf.ArchiveDest), conf.Binding); err != nil {
+			return errors.Wrap(err, "set log_archive_dest")
+		}
+	}
+	if conf.LogArchiveConcurrency != nil {
+		t.ExecuteLogf("Setting log_archive_concurrency to %d", *conf.LogArchiveConcurrency)
+		if err := backupService.SetLogArchiveConcurrency(*conf.LogArchiveConcurrency); err != nil {
+			return errors.Wrap(err, "set log_archive_concurrency")
+		}
+	}
+	if conf.ArchiveLagTarget != "" {
+		t.ExecuteLogf("Setting archive_lag_target to %s", conf.ArchiveLagTarget)
+		if err := backupService.SetArchiveLagTarget(conf.ArchiveLagTarget); err != nil {
+			return errors.Wrap(err, "set archive_lag_target")
+		}
+	}
+	return nil
+}
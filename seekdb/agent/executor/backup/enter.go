@@ -0,0 +1,41 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"github.com/oceanbase/obshell/seekdb/agent/engine/task"
+	"github.com/oceanbase/obshell/seekdb/agent/service/backup"
+	taskservice "github.com/oceanbase/obshell/seekdb/agent/service/task"
+)
+
+var (
+	backupService    = backup.BackupService{}
+	localTaskService = taskservice.NewLocalTaskService()
+)
+
+// RegisterTasks registers all backup and restore task types with the task engine
+// so they can be restored and executed after an obshell restart.
+func RegisterTasks() {
+	task.RegisterTaskType(CheckBackupConfigTask{})
+	task.RegisterTaskType(SetBackupConfigTask{})
+	task.RegisterTaskType(OpenArchiveLogTask{})
+	task.RegisterTaskType(StartBackupTask{})
+	task.RegisterTaskType(WaitBackupFinishTask{})
+	task.RegisterTaskType(RestorePreCheckTask{})
+	task.RegisterTaskType(StartRestoreTask{})
+	task.RegisterTaskType(WaitRestoreFinishTask{})
+}
@@ -0,0 +1,241 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"strings"
+	"time"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/engine/task"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/system"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+// Restore checks the param and creates the Restore DAG, which restores the
+// backup into the local seekdb. The local seekdb must be a fresh instance
+// without any user database.
+// Flow: RestorePreCheck → StartRestore → WaitRestoreFinish
+func Restore(p *param.RestoreParam) (*task.DagDetailDTO, error) {
+	if p.ArchiveLogUri == "" {
+		p.ArchiveLogUri = p.DataBackupUri
+	}
+	for _, uri := range []string{p.DataBackupUri, p.ArchiveLogUri} {
+		if _, err := system.GetStorageInterfaceByURI(uri); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkRestorePreConditions(p); err != nil {
+		return nil, err
+	}
+
+	builder := task.NewTemplateBuilder(constant.DAG_RESTORE).
+		AddTask(newRestorePreCheckTask(), false).
+		AddTask(newStartRestoreTask(), false).
+		AddTask(newWaitRestoreFinishTask(), false).
+		SetMaintenance(task.GlobalMaintenance())
+
+	ctx := task.NewTaskContext().
+		SetParam(constant.PARAM_RESTORE, p).
+		SetParam(task.FAILURE_EXIT_MAINTENANCE, true)
+
+	dag, err := localTaskService.CreateDagInstanceByTemplate(builder.Build(), ctx)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(dag), nil
+}
+
+// checkRestorePreConditions validates that the restore can proceed:
+//  1. No restore job is running.
+//  2. The local seekdb has no user database.
+//  3. The data backup uri is not empty.
+func checkRestorePreConditions(p *param.RestoreParam) error {
+	job, err := backupService.GetRestoreProgress()
+	if err != nil {
+		return errors.Wrap(err, "get restore progress")
+	}
+	if job != nil {
+		return errors.Occur(errors.ErrRestoreJobRunning, job.JobID)
+	}
+
+	dbs, err := backupService.ListUserDatabases()
+	if err != nil {
+		return errors.Wrap(err, "list user databases")
+	}
+	if len(dbs) > 0 {
+		return errors.Occur(errors.ErrRestoreInstanceNotFresh, strings.Join(dbs, ","))
+	}
+
+	storage, err := system.GetStorageInterfaceByURI(p.DataBackupUri)
+	if err != nil {
+		return err
+	}
+	files, err := storage.ListFiles("")
+	if err != nil {
+		return errors.Wrapf(err, "list files under %s", storage.GenerateURIWithoutSecret())
+	}
+	if len(files) == 0 {
+		return errors.Occur(errors.ErrRestoreSourceEmpty, storage.GenerateURIWithoutSecret())
+	}
+	return nil
+}
+
+// RestorePreCheckTask rechecks the restore pre-conditions when the DAG runs.
+type RestorePreCheckTask struct {
+	task.Task
+}
+
+func newRestorePreCheckTask() *RestorePreCheckTask {
+	t := &RestorePreCheckTask{
+		Task: *task.NewSubTask(constant.TASK_RESTORE_PRECHECK),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanPass().SetCanCancel()
+	return t
+}
+
+func (t *RestorePreCheckTask) Execute() error {
+	var p param.RestoreParam
+	if err := t.GetContext().GetParamWithValue(constant.PARAM_RESTORE, &p); err != nil {
+		return err
+	}
+	t.ExecuteLog("Checking restore pre-conditions")
+	return checkRestorePreConditions(&p)
+}
+
+// StartRestoreTask issues the restore and records the restore job id.
+type StartRestoreTask struct {
+	task.Task
+}
+
+func newStartRestoreTask() *StartRestoreTask {
+	t := &StartRestoreTask{
+		Task: *task.NewSubTask(constant.TASK_START_RESTORE),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanPass().SetCanCancel()
+	return t
+}
+
+func (t *StartRestoreTask) Execute() error {
+	var p param.RestoreParam
+	if err := t.GetContext().GetParamWithValue(constant.PARAM_RESTORE, &p); err != nil {
+		return err
+	}
+
+	job, err := backupService.GetRestoreProgress()
+	if err != nil {
+		return errors.Wrap(err, "get restore progress")
+	}
+	if job == nil {
+		t.ExecuteLog("Starting restore")
+		if err = backupService.StartRestore(system.ObStorageURI(p.DataBackupUri), system.ObStorageURI(p.ArchiveLogUri), p.Timestamp, p.Decryption); err != nil {
+			return errors.Wrap(err, "start restore")
+		}
+		for i := 0; i < constant.ARCHIVE_LOG_CHECK_TIMES && job == nil; i++ {
+			t.TimeoutCheck()
+			time.Sleep(time.Second)
+			if job, err = backupService.GetRestoreProgress(); err != nil {
+				return errors.Wrap(err, "get restore progress")
+			}
+		}
+		if job == nil {
+			return errors.New("restore job not found after the restore is started")
+		}
+	} else {
+		t.ExecuteLogf("Restore job %d is already running", job.JobID)
+	}
+	t.ExecuteLogf("Restore job %d started", job.JobID)
+	t.GetContext().SetData(constant.PARAM_RESTORE_JOB_ID, job.JobID)
+	return nil
+}
+
+// WaitRestoreFinishTask waits for the restore job to finish and checks its result.
+type WaitRestoreFinishTask struct {
+	task.Task
+}
+
+func newWaitRestoreFinishTask() *WaitRestoreFinishTask {
+	t := &WaitRestoreFinishTask{
+		Task: *task.NewSubTask(constant.TASK_WAIT_RESTORE_FINISH),
+	}
+	t.SetCanRetry().SetCanContinue().SetCanPass().SetCanCancel()
+	return t
+}
+
+func (t *WaitRestoreFinishTask) Execute() error {
+	var jobID int64
+	if err := t.GetContext().GetDataWithValue(constant.PARAM_RESTORE_JOB_ID, &jobID); err != nil {
+		return err
+	}
+	for {
+		t.TimeoutCheck()
+		job, err := backupService.GetRestoreProgress()
+		if err != nil {
+			return errors.Wrap(err, "get restore progress")
+		}
+		if job != nil && job.JobID == jobID {
+			t.ExecuteLogf("Restore job %d is %s, restore progress %s, recover progress %s", jobID, job.Status, job.RestoreProgress, job.RecoverProgress)
+			time.Sleep(constant.RESTORE_CHECK_INTERVAL)
+			continue
+		}
+		history, err := backupService.GetLastRestoreHistory()
+		if err != nil {
+			return errors.Wrap(err, "get restore history")
+		}
+		if history == nil || history.JobID != jobID {
+			time.Sleep(constant.RESTORE_CHECK_INTERVAL)
+			continue
+		}
+		if history.Status != constant.RESTORE_STATUS_SUCCESS {
+			return errors.Occur(errors.ErrRestoreJobFailed, jobID, history.Status, history.Comment)
+		}
+		t.ExecuteLogf("Restore job %d succeeded", jobID)
+		return nil
+	}
+}
+
+// GetRestoreOverview returns the running restore job, or the last finished
+// one when no restore is running. Nil means seekdb has never been restored.
+func GetRestoreOverview() (*param.RestoreOverview, error) {
+	job, err := backupService.GetRestoreProgress()
+	if err != nil {
+		return nil, errors.Wrap(err, "get restore progress")
+	}
+	running := job != nil
+	if !running {
+		if job, err = backupService.GetLastRestoreHistory(); err != nil {
+			return nil, errors.Wrap(err, "get restore history")
+		}
+		if job == nil {
+			return nil, nil
+		}
+	}
+	return &param.RestoreOverview{
+		Running:           running,
+		JobID:             job.JobID,
+		Status:            job.Status,
+		RestoreScnDisplay: job.RestoreScnDisplay,
+		StartTimestamp:    job.StartTimestamp,
+		FinishTimestamp:   job.FinishTimestamp,
+		BackupSetList:     job.BackupSetList,
+		BackupPieceList:   job.BackupPieceList,
+		RestoreProgress:   job.RestoreProgress,
+		RecoverProgress:   job.RecoverProgress,
+		Comment:           job.Comment,
+	}, nil
+}
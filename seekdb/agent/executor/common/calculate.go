@@ -0,0 +1,27 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import "math"
+
+// CalculateTotalPages calculates the total number of pages
+func CalculateTotalPages(totalElements uint64, pageSize uint64) uint64 {
+	if pageSize == 0 {
+		return 0
+	}
+	return uint64(math.Ceil(float64(totalElements) / float64(pageSize)))
+}
@@ -0,0 +1,220 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package observer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/agent/executor/common"
+	"github.com/oceanbase/obshell/seekdb/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/seekdb/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+// allowedSessionSortFields defines valid ORDER BY columns for sessions.
+var allowedSessionSortFields = map[string]bool{
+	"ID": true, "SVR_IP": true, "SVR_PORT": true, "SQL_PORT": true,
+	"USER": true, "DB": true, "TENANT": true, "HOST": true,
+	"USER_CLIENT_IP": true, "COMMAND": true, "TIME": true,
+	"STATE": true, "INFO": true, "PROXY_SESSID": true,
+	"ACTION": true, "MODULE": true, "CLIENT_INFO": true,
+	"LEVEL": true, "SAMPLE_PERCENTAGE": true, "RECORD_POLICY": true,
+}
+
+func GetSessions(p *param.QueryTenantSessionParam) (*bo.PaginatedTenantSessions, error) {
+	// Validate sort fields to prevent SQL injection.
+	if p.SortBy != "" && !allowedSessionSortFields[strings.ToUpper(p.SortBy)] {
+		p.SortBy = ""
+		p.SortOrder = ""
+	}
+	if p.SortOrder != "" && strings.ToUpper(p.SortOrder) != "DESC" && strings.ToUpper(p.SortOrder) != "ASC" {
+		p.SortOrder = "ASC"
+	}
+
+	sessions, total, err := tenantService.GetSessions(p)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query sessions")
+	}
+	sessionBos := make([]bo.TenantSession, 0, len(sessions))
+	for _, session := range sessions {
+		sessionBos = append(sessionBos, *session.ToBo())
+	}
+	return &bo.PaginatedTenantSessions{
+		Contents: sessionBos,
+		Page: bo.CustomPage{
+			Number:        p.Page,
+			Size:          p.Size,
+			TotalPages:    common.CalculateTotalPages(uint64(total), p.Size),
+			TotalElements: uint64(total),
+		},
+	}, nil
+}
+
+func GetSession(sessionId string) (*bo.TenantSession, error) {
+	session, err := tenantService.GetSession(sessionId)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to query session %s", sessionId)
+	}
+	if session == nil {
+		return nil, errors.Occur(errors.ErrObSessionNotExist, sessionId)
+	}
+	return session.ToBo(), nil
+}
+
+func GetSessionStats() (*bo.TenantSessionStats, error) {
+	sessions, err := tenantService.ListSessions()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query sessions")
+	}
+	stats := bo.TenantSessionStats{
+		TotalCount:  len(sessions),
+		DbStats:     make([]bo.TenantSessionDbStats, 0),
+		UserStats:   make([]bo.TenantSessionUserStats, 0),
+		ClientStats: make([]bo.TenantSessionClientStats, 0),
+	}
+	dbStats := make(map[string]*bo.TenantSessionDbStats)
+	userStats := make(map[string]*bo.TenantSessionUserStats)
+	clientStats := make(map[string]*bo.TenantSessionClientStats)
+	for _, session := range sessions {
+		active := session.State == constant.SESSION_STATE_ACTIVE
+		if active {
+			stats.ActiveCount++
+		}
+		if session.Time > stats.MaxActiveTime {
+			stats.MaxActiveTime = session.Time
+		}
+
+		if dbStats[session.Db] == nil {
+			dbStats[session.Db] = &bo.TenantSessionDbStats{DbName: session.Db}
+		}
+		userName := session.User
+		if userStats[userName] == nil {
+			userStats[userName] = &bo.TenantSessionUserStats{UserName: userName}
+		}
+		clientIp := strings.Split(session.Host, ":")[0]
+		if clientStats[clientIp] == nil {
+			clientStats[clientIp] = &bo.TenantSessionClientStats{ClientIp: clientIp}
+		}
+		dbStats[session.Db].TotalCount++
+		userStats[userName].TotalCount++
+		clientStats[clientIp].TotalCount++
+		if active {
+			dbStats[session.Db].ActiveCount++
+			userStats[userName].ActiveCount++
+			clientStats[clientIp].ActiveCount++
+		}
+	}
+
+	for _, dbStat := range dbStats {
+		stats.DbStats = append(stats.DbStats, *dbStat)
+	}
+	for _, userStat := range userStats {
+		stats.UserStats = append(stats.UserStats, *userStat)
+	}
+	for _, clientStat := range clientStats {
+		stats.ClientStats = append(stats.ClientStats, *clientStat)
+	}
+	return &stats, nil
+}
+
+func KillSessions(sessionIds []int) error {
+	for _, id := range sessionIds {
+		if err := tenantService.KillSession(id); err != nil {
+			return errors.Wrapf(err, "Failed to kill session %d", id)
+		}
+	}
+	return nil
+}
+
+func KillSessionQueries(sessionIds []int) error {
+	for _, id := range sessionIds {
+		if err := tenantService.KillSessionQuery(id); err != nil {
+			return errors.Wrapf(err, "Failed to kill query of session %d", id)
+		}
+	}
+	return nil
+}
+
+func ListDeadLocks(p *param.QueryTenantDeadLocksParam) (*bo.PaginatedDeadLocks, error) {
+	deadlockEvents, err := tenantService.ListDeadLockEvents()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query deadlock events")
+	}
+	deadlockBos := make([]bo.DeadLock, 0)
+	for _, events := range buildDeadLockEventMap(deadlockEvents) {
+		deadlock := bo.DeadLock{
+			EventId:    events[0].EventId,
+			ReportTime: events[0].ReportTime,
+			Size:       int64(len(events)),
+			Nodes:      make([]bo.DeadLockNode, 0, len(events)),
+		}
+		for _, event := range events {
+			deadlock.Nodes = append(deadlock.Nodes, *event.ToDeadLockNode())
+		}
+		deadlockBos = append(deadlockBos, deadlock)
+	}
+	sort.Slice(deadlockBos, func(i, j int) bool {
+		return deadlockBos[i].ReportTime.After(deadlockBos[j].ReportTime)
+	})
+
+	total := uint64(len(deadlockBos))
+	page := bo.CustomPage{
+		Number:        p.Page,
+		Size:          p.Size,
+		TotalPages:    common.CalculateTotalPages(total, p.Size),
+		TotalElements: total,
+	}
+	offset := (p.Page - 1) * p.Size
+	if offset >= total {
+		return &bo.PaginatedDeadLocks{Page: page, Contents: make([]bo.DeadLock, 0)}, nil
+	}
+	end := offset + p.Size
+	if end > total {
+		end = total
+	}
+	return &bo.PaginatedDeadLocks{Page: page, Contents: deadlockBos[offset:end]}, nil
+}
+
+// buildDeadLockEventMap groups the deadlock events by event id,
+// incomplete cycles are dropped.
+func buildDeadLockEventMap(deadlockEvents []oceanbase.DeadLockEvent) map[string][]*oceanbase.DeadLockEvent {
+	deadLockEventMap := make(map[string][]*oceanbase.DeadLockEvent)
+	for i := range deadlockEvents {
+		event := &deadlockEvents[i]
+		deadLockEventMap[event.EventId] = append(deadLockEventMap[event.EventId], event)
+	}
+	for eventId, events := range deadLockEventMap {
+		if len(events) != events[0].CycleSize {
+			delete(deadLockEventMap, eventId)
+		}
+	}
+	return deadLockEventMap
+}
+
+func GetTopSlowSqls(p *param.QueryTopSlowSqlParam) ([]bo.SlowSql, error) {
+	slowSqls, err := tenantService.GetTopSlowSqls(p.Top, p.StartTime.UnixMicro(), p.EndTime.UnixMicro())
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query slow sqls")
+	}
+	if slowSqls == nil {
+		slowSqls = make([]bo.SlowSql, 0)
+	}
+	return slowSqls, nil
+}
@@ -0,0 +1,346 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package standby
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/agent/meta"
+	"github.com/oceanbase/obshell/seekdb/agent/repository/model/sqlite"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+// failoverState is the in-memory state of the failover controller. It is
+// reset whenever the upstream primary is reachable again or the local node
+// is no longer a STANDBY.
+type failoverState struct {
+	mu           sync.Mutex
+	outageSince  time.Time
+	lastLag      *int64
+	lagSampledAt time.Time
+	skipped      bool // FAILOVER_SKIPPED has been recorded in the current outage
+	triggered    bool // Activate has been created in the current outage
+}
+
+var controllerState = &failoverState{}
+
+func (s *failoverState) reset() {
+	s.outageSince = time.Time{}
+	s.lastLag = nil
+	s.lagSampledAt = time.Time{}
+	s.skipped = false
+	s.triggered = false
+}
+
+// StartFailoverController starts the failover controller in the background.
+// The controller is always running but only acts on a STANDBY with failover
+// enabled; fencing of the old primary after a failover runs regardless.
+func StartFailoverController() {
+	go func() {
+		for {
+			interval := time.Duration(constant.DefaultFailoverProbeIntervalSeconds) * time.Second
+			config, err := standbyService.GetFailoverConfig()
+			if err != nil {
+				log.WithError(err).Warn("failover controller: get failover config failed")
+			} else {
+				interval = time.Duration(config.ProbeIntervalSeconds) * time.Second
+				fenceOldPrimaries()
+				checkFailover(config)
+			}
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// checkFailover runs one probe round on the standby.
+//  1. The upstream is alive if either its obshell answers the status RPC or
+//     its SQL port accepts connections; the lag is sampled while it is alive.
+//  2. Once the upstream has been unreachable for the outage window, the
+//     standby is activated if the last sampled lag is within max lag, and
+//     it was sampled no earlier than the outage window plus one probe
+//     interval ago, i.e. right before the outage.
+func checkFailover(config *sqlite.SeekdbFailoverConfig) {
+	state := controllerState
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if !config.Enabled {
+		state.reset()
+		return
+	}
+	local, err := standbyService.GetLocalStatus()
+	if err != nil || local.Role != "STANDBY" {
+		state.reset()
+		return
+	}
+	upstream, err := standbyService.GetUpstreamPeer()
+	if err != nil || upstream == nil {
+		state.reset()
+		return
+	}
+
+	peerStatus, rpcErr := callPeerGetStatus(upstream.PeerHost, upstream.PeerObshellPort)
+	if rpcErr == nil || probeSqlPort(upstream.PeerHost, config.PrimarySqlPort) {
+		if !state.outageSince.IsZero() {
+			recordFailoverEvent(constant.FailoverEventPrimaryBack, upstream, fmt.Sprintf("upstream primary is reachable again after %s", time.Since(state.outageSince).Truncate(time.Second)))
+		}
+		state.reset()
+		if rpcErr == nil {
+			var lag int64
+			if peerStatus.Local.SyncScn > local.SyncScn {
+				lag = int64(peerStatus.Local.SyncScn-local.SyncScn) / 1_000_000_000
+			}
+			state.lastLag = &lag
+			state.lagSampledAt = time.Now()
+		}
+		return
+	}
+
+	if state.outageSince.IsZero() {
+		state.outageSince = time.Now()
+		recordFailoverEvent(constant.FailoverEventOutageDetected, upstream, fmt.Sprintf("upstream primary is unreachable: %v", rpcErr))
+		return
+	}
+	if state.triggered || time.Since(state.outageSince) < time.Duration(config.OutageWindowSeconds)*time.Second {
+		return
+	}
+
+	maxLagAge := time.Duration(config.OutageWindowSeconds+config.ProbeIntervalSeconds) * time.Second
+	if state.lastLag == nil || time.Since(state.lagSampledAt) > maxLagAge {
+		if !state.skipped {
+			sampled := "never"
+			if state.lastLag != nil {
+				sampled = state.lagSampledAt.Format(time.DateTime)
+			}
+			recordFailoverEvent(constant.FailoverEventSkipped, upstream, fmt.Sprintf("no lag is observed within %s before the outage (last observed: %s), failover needs a manual Activate", maxLagAge, sampled))
+			state.skipped = true
+		}
+		return
+	}
+	if *state.lastLag > int64(config.MaxLagSeconds) {
+		if !state.skipped {
+			recordFailoverEvent(constant.FailoverEventSkipped, upstream, fmt.Sprintf("last observed lag %ds exceeds max lag %ds, failover needs a manual Activate", *state.lastLag, config.MaxLagSeconds))
+			state.skipped = true
+		}
+		return
+	}
+
+	// Keep the credentials of the old primary before Activate removes the
+	// peer record, so that it can be fenced once it comes back.
+	event := &sqlite.SeekdbFailoverEvent{
+		EventType:       constant.FailoverEventTriggered,
+		PeerHost:        upstream.PeerHost,
+		PeerObshellPort: upstream.PeerObshellPort,
+		PeerToken:       upstream.PeerToken,
+		PeerPublicKey:   upstream.PeerPublicKey,
+		FencePending:    upstream.PeerToken != "" && upstream.PeerPublicKey != "",
+	}
+	dag, err := CreateActivateDag()
+	if err != nil {
+		log.WithError(err).Warn("failover controller: create Activate DAG failed")
+		return
+	}
+	event.DagID = dag.GenericID
+	event.Message = fmt.Sprintf("upstream primary has been unreachable since %s, last observed lag %ds, Activate DAG %s created",
+		state.outageSince.Format(time.DateTime), *state.lastLag, dag.GenericID)
+	if !event.FencePending {
+		event.Message += ", the old primary cannot be fenced because its public key is unknown"
+	}
+	if err = standbyService.AddFailoverEvent(event); err != nil {
+		log.WithError(err).Warn("failover controller: record failover event failed")
+	}
+	log.Warnf("failover controller: %s", event.Message)
+	state.triggered = true
+}
+
+// probeSqlPort reports whether the SQL port of the host accepts TCP connections.
+func probeSqlPort(host string, port int) bool {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, strconv.Itoa(port)), constant.FailoverSqlProbeTimeout)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// fenceOldPrimaries asks every old primary replaced by an automatic failover
+// to fence itself. Unreachable primaries are retried in the next round.
+func fenceOldPrimaries() {
+	events, err := standbyService.GetFencePendingEvents()
+	if err != nil {
+		log.WithError(err).Warn("failover controller: get fence pending events failed")
+		return
+	}
+	for _, event := range events {
+		p := param.RpcFenceParam{
+			CallerHost:        meta.OCS_AGENT.GetIp(),
+			CallerObshellPort: meta.OCS_AGENT.GetPort(),
+		}
+		if err := callPeerRpcFence(event.PeerHost, event.PeerObshellPort, event.PeerToken, event.PeerPublicKey, p); err != nil {
+			log.WithError(err).Debugf("failover controller: fence old primary %s:%d failed", event.PeerHost, event.PeerObshellPort)
+			continue
+		}
+		if err := standbyService.FinishFence(event.ID); err != nil {
+			log.WithError(err).Warn("failover controller: finish fence failed")
+			continue
+		}
+		recordFailoverEvent(constant.FailoverEventFenced, &sqlite.SeekdbStandbyPeer{PeerHost: event.PeerHost, PeerObshellPort: event.PeerObshellPort},
+			"old primary is back and has been set to read only, rebuild it as a standby before pairing again")
+	}
+}
+
+func recordFailoverEvent(eventType string, peer *sqlite.SeekdbStandbyPeer, message string) {
+	log.Warnf("failover controller: [%s] %s:%d %s", eventType, peer.PeerHost, peer.PeerObshellPort, message)
+	if err := standbyService.AddFailoverEvent(&sqlite.SeekdbFailoverEvent{
+		EventType:       eventType,
+		PeerHost:        peer.PeerHost,
+		PeerObshellPort: peer.PeerObshellPort,
+		Message:         message,
+	}); err != nil {
+		log.WithError(err).Warn("failover controller: record failover event failed")
+	}
+}
+
+// ExecuteFence runs on the old primary when the new primary asks it to fence
+// itself after a failover. A node that is still PRIMARY is set to read only
+// and its pair record with the caller is removed. Other roles need no fencing.
+func ExecuteFence(p param.RpcFenceParam) error {
+	local, err := standbyService.GetLocalStatus()
+	if err != nil {
+		return fmt.Errorf("failed to query local seekdb status: %w", err)
+	}
+	if local.Role != "PRIMARY" {
+		log.Infof("fence requested by %s:%d, local role is %s, nothing to do", p.CallerHost, p.CallerObshellPort, local.Role)
+		return nil
+	}
+	log.Warnf("fence requested by the new primary %s:%d, set local seekdb read only", p.CallerHost, p.CallerObshellPort)
+	if err = standbyService.SetReadOnly(); err != nil {
+		return err
+	}
+	if _, err = standbyService.DeletePairRecord(param.PairDeleteParam{
+		PeerHost:        p.CallerHost,
+		PeerObshellPort: p.CallerObshellPort,
+	}); err != nil {
+		log.WithError(err).Infof("delete pair record of %s:%d failed", p.CallerHost, p.CallerObshellPort)
+	}
+	return nil
+}
+
+// GetFailoverStatus returns the failover config, the controller state and the latest events.
+func GetFailoverStatus() (*param.FailoverStatusResp, error) {
+	config, err := standbyService.GetFailoverConfig()
+	if err != nil {
+		return nil, err
+	}
+	events, err := standbyService.ListFailoverEvents(constant.FailoverEventsLimit)
+	if err != nil {
+		return nil, err
+	}
+	resp := &param.FailoverStatusResp{
+		Config: convertFailoverConfig(config),
+		Events: make([]param.FailoverEvent, 0, len(events)),
+	}
+	for _, e := range events {
+		resp.Events = append(resp.Events, param.FailoverEvent{
+			ID:              e.ID,
+			EventType:       e.EventType,
+			PeerHost:        e.PeerHost,
+			PeerObshellPort: e.PeerObshellPort,
+			Message:         e.Message,
+			DagID:           e.DagID,
+			FencePending:    e.FencePending,
+			CreatedAt:       e.CreatedAt,
+		})
+	}
+
+	controllerState.mu.Lock()
+	defer controllerState.mu.Unlock()
+	if !controllerState.outageSince.IsZero() {
+		since := controllerState.outageSince
+		resp.OutageSince = &since
+	}
+	if controllerState.lastLag != nil {
+		lag := *controllerState.lastLag
+		sampledAt := controllerState.lagSampledAt
+		resp.LastLagSeconds = &lag
+		resp.LastLagSampledAt = &sampledAt
+	}
+	return resp, nil
+}
+
+// SetFailoverConfig validates and saves the failover config.
+func SetFailoverConfig(p param.FailoverConfigParam) (*param.FailoverConfig, error) {
+	config, err := standbyService.GetFailoverConfig()
+	if err != nil {
+		return nil, err
+	}
+	if p.Enabled != nil {
+		config.Enabled = *p.Enabled
+	}
+	if p.OutageWindowSeconds != nil {
+		if *p.OutageWindowSeconds < constant.MinFailoverOutageWindowSeconds {
+			return nil, errors.Occur(errors.ErrStandbyFailoverConfigInvalid,
+				fmt.Sprintf("outage_window_seconds must not be less than %d", constant.MinFailoverOutageWindowSeconds))
+		}
+		config.OutageWindowSeconds = *p.OutageWindowSeconds
+	}
+	if p.MaxLagSeconds != nil {
+		if *p.MaxLagSeconds < 0 {
+			return nil, errors.Occur(errors.ErrStandbyFailoverConfigInvalid, "max_lag_seconds must not be negative")
+		}
+		config.MaxLagSeconds = *p.MaxLagSeconds
+	}
+	if p.ProbeIntervalSeconds != nil {
+		if *p.ProbeIntervalSeconds < constant.MinFailoverProbeIntervalSeconds || *p.ProbeIntervalSeconds > constant.MaxFailoverProbeIntervalSeconds {
+			return nil, errors.Occur(errors.ErrStandbyFailoverConfigInvalid,
+				fmt.Sprintf("probe_interval_seconds must be between %d and %d", constant.MinFailoverProbeIntervalSeconds, constant.MaxFailoverProbeIntervalSeconds))
+		}
+		config.ProbeIntervalSeconds = *p.ProbeIntervalSeconds
+	}
+	if p.PrimarySqlPort != nil {
+		if *p.PrimarySqlPort < 1 || *p.PrimarySqlPort > 65535 {
+			return nil, errors.Occur(errors.ErrStandbyInvalidPeerPort, *p.PrimarySqlPort)
+		}
+		config.PrimarySqlPort = *p.PrimarySqlPort
+	}
+	if config.OutageWindowSeconds < config.ProbeIntervalSeconds {
+		return nil, errors.Occur(errors.ErrStandbyFailoverConfigInvalid, "outage_window_seconds must not be less than probe_interval_seconds")
+	}
+	if err = standbyService.SaveFailoverConfig(config); err != nil {
+		return nil, err
+	}
+	res := convertFailoverConfig(config)
+	return &res, nil
+}
+
+func convertFailoverConfig(config *sqlite.SeekdbFailoverConfig) param.FailoverConfig {
+	return param.FailoverConfig{
+		Enabled:              config.Enabled,
+		OutageWindowSeconds:  config.OutageWindowSeconds,
+		MaxLagSeconds:        config.MaxLagSeconds,
+		ProbeIntervalSeconds: config.ProbeIntervalSeconds,
+		PrimarySqlPort:       config.PrimarySqlPort,
+	}
+}
@@ -124,3 +124,17 @@ func callPeerRpcSwitchoverToPrimary(host string, port int, p param.RpcSwitchover
 		&meta.AgentInfo{Ip: host, Port: port},
 		uri, agenthttp.POST, encBody, nil, headers)
 }
+
+// callPeerRpcFence asks the old primary to fence itself. The token and public
+// key are taken from the failover event because the peer record has already
+// been removed by Activate.
+func callPeerRpcFence(host string, port int, token, publicKey string, p param.RpcFenceParam) error {
+	uri := constant.URI_SEEKDB_STANDBY_RPC_PREFIX + constant.URI_FENCE
+	encBody, headers, err := secure.BuildStandbyBodyAndHeader(uri, token, publicKey, p)
+	if err != nil {
+		return err
+	}
+	return agenthttp.SendRequestAndBuildReturn(
+		&meta.AgentInfo{Ip: host, Port: port},
+		uri, agenthttp.POST, encBody, nil, headers)
+}
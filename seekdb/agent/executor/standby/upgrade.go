@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package standby
+
+import (
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/pkg"
+	"github.com/oceanbase/obshell/seekdb/agent/meta"
+)
+
+// CheckDownstreamUpgraded makes sure every downstream standby of the local
+// node already runs targetBuildVersion or newer. A standby must never fall
+// behind its primary, so the standby of a pair is upgraded first.
+// Nodes without downstream peers pass the check directly.
+func CheckDownstreamUpgraded(targetBuildVersion string) error {
+	peers, err := standbyService.GetPeers()
+	if err != nil {
+		return err
+	}
+	for _, peer := range peers {
+		if peer.Direction != constant.STANDBY_DIRECTION_DOWNSTREAM {
+			continue
+		}
+		addr := meta.NewAgentInfo(peer.PeerHost, peer.PeerObshellPort).String()
+		peerStatus, err := QueryPeerStatus(peer.PeerHost, peer.PeerObshellPort)
+		if err != nil {
+			return errors.Occur(errors.ErrObUpgradeStandbyUnreachable, addr, err.Error())
+		}
+		if peerStatus.Version == "" {
+			// The peer obshell is too old to report its seekdb version.
+			return errors.Occur(errors.ErrObUpgradeStandbyNotUpgraded, addr, "unknown", targetBuildVersion)
+		}
+		if pkg.CompareVersion(peerStatus.Version, targetBuildVersion) < 0 {
+			return errors.Occur(errors.ErrObUpgradeStandbyNotUpgraded, addr, peerStatus.Version, targetBuildVersion)
+		}
+	}
+	return nil
+}
@@ -36,6 +36,11 @@ const (
 
 	PARAM_TARGET_AGENT_BUILD_VERSION = "targetAgentBuildVersion"
 
+	// for seekdb upgrade
+	PARAM_PKG_NAME                    = "pkgName"
+	PARAM_TARGET_SEEKDB_BUILD_VERSION = "targetSeekdbBuildVersion"
+	PARAM_SEEKDB_PARAMETERS           = "seekdbParameters"
+
 	DATA_SKIP_START_TASK = "skipStartTask"
 
 	// for upgrade
@@ -52,11 +57,18 @@ const (
 	TASK_GET_ALL_REQUIRED_PKGS         = "Download all required packages"
 	TASK_INSTALL_ALL_REQUIRED_PKGS     = "Unpack all required packages"
 	TASK_UPGRADE_POST_TABLE_MAINTAIN   = "Upgrade post table maintain"
+	TASK_BACKUP_SEEKDB_FOR_UPGRADE     = "Backup seekdb for upgrade"
+	TASK_BACKUP_SEEKDB_PARAMETERS      = "Backup seekdb parameters"
+	TASK_REINSTALL_AND_RESTART_SEEKDB  = "Reinstall and restart seekdb"
+	TASK_SEEKDB_UPGRADE_HEALTH_CHECK   = "Seekdb post-upgrade health check"
+	TASK_RESTORE_SEEKDB_PARAMETERS     = "Restore seekdb parameters"
 
 	// dag name
 	DAG_CHECK_AND_UPGRADE_OBSHELL = "Check and upgrade obshell"
 	DAG_UPGRADE_OBSHELL           = "Upgrade obshell"
 	DAG_UPGRADE_CHECK_OBSHELL     = "Upgrade check obshell"
+	DAG_UPGRADE_SEEKDB            = "Upgrade seekdb"
+	DAG_UPGRADE_CHECK_SEEKDB      = "Upgrade check seekdb"
 )
 
 var (
@@ -80,4 +92,11 @@ func RegisterUpgradeTask() {
 	task.RegisterTaskType(InstallNewAgentTask{})
 	task.RegisterTaskType(RestartAgentTask{})
 	task.RegisterTaskType(UpgradePostTableMaintainTask{})
+
+	// seekdb upgrade
+	task.RegisterTaskType(BackupSeekdbForUpgradeTask{})
+	task.RegisterTaskType(BackupSeekdbParametersTask{})
+	task.RegisterTaskType(ReinstallAndRestartSeekdbTask{})
+	task.RegisterTaskType(SeekdbUpgradeHealthCheckTask{})
+	task.RegisterTaskType(RestoreSeekdbParametersTask{})
 }
@@ -40,6 +40,7 @@ const (
 
 type GetAllRequiredPkgsTask struct {
 	task.Task
+	pkgName             string
 	upgradeDir          string
 	targetBuildNumber   string
 	targetVersion       string
@@ -91,7 +92,14 @@ func (t *GetAllRequiredPkgsTask) getParams() (err error) {
 	if err = t.GetContext().GetParamWithValue(PARAM_DISTRIBUTION, &t.distribution); err != nil {
 		return err
 	}
-	t.ExecuteLogf("The required upgrade package is %v", fmt.Sprintf("%s-%s", t.targetVersion, t.targetBuildNumber))
+	// The obshell package is downloaded when the package name is not specified.
+	t.pkgName = constant.PKG_OBSHELL
+	if t.GetContext().GetParam(PARAM_PKG_NAME) != nil {
+		if err = t.GetContext().GetParamWithValue(PARAM_PKG_NAME, &t.pkgName); err != nil {
+			return err
+		}
+	}
+	t.ExecuteLogf("The required upgrade package is %v", fmt.Sprintf("%s-%s-%s", t.pkgName, t.targetVersion, t.targetBuildNumber))
 
 	return nil
 }
@@ -110,7 +118,7 @@ func (t *GetAllRequiredPkgsTask) getAllRequiredPkgs() (err error) {
 	var pkgInfo sqlite.UpgradePkgInfo
 	arch := global.Architecture
 
-	pkgInfo, err = obclusterService.GetUpgradePkgInfoByVersionAndRelease(constant.PKG_OBSHELL, t.targetVersion, t.targetBuildNumber, t.distribution, arch)
+	pkgInfo, err = obclusterService.GetUpgradePkgInfoByVersionAndRelease(t.pkgName, t.targetVersion, t.targetBuildNumber, t.distribution, arch)
 	if err != nil {
 		return err
 	}
@@ -18,6 +18,7 @@ package upgrade
 
 import (
 	"mime/multipart"
+	"strings"
 
 	"github.com/cavaliergopher/rpm"
 	log "github.com/sirupsen/logrus"
@@ -41,6 +42,10 @@ var defaultFilesForAgent = []string{
 	"/home/admin/oceanbase/bin/obshell",
 }
 
+var defaultFilesForSeekdb = []string{
+	"/home/admin/oceanbase/bin/seekdb",
+}
+
 type upgradeRpmPkgInfo struct {
 	rpmFile      multipart.File
 	rpmPkg       *rpm.Package
@@ -73,10 +78,12 @@ func (r *upgradeRpmPkgInfo) CheckUpgradePkg(forUpload bool) (err error) {
 	switch r.rpmPkg.Name() {
 	case constant.PKG_OBSHELL:
 		err = r.fileCheck()
+	case constant.PKG_SEEKDB:
+		err = r.seekdbFileCheck()
 	case constant.PKG_OCEANBASE_CE:
 		log.Warn("oceanbase-ce is not supported")
 	default:
-		err = errors.Occur(errors.ErrObPackageNameNotSupport, r.rpmPkg.Name(), constant.PKG_OBSHELL)
+		err = errors.Occur(errors.ErrObPackageNameNotSupport, r.rpmPkg.Name(), strings.Join([]string{constant.PKG_OBSHELL, constant.PKG_SEEKDB}, ", "))
 	}
 	if err != nil {
 		return
@@ -92,6 +99,14 @@ func (r *upgradeRpmPkgInfo) fileCheck() (err error) {
 	return r.findAllExpectedFiles(defaultFilesForAgent)
 }
 
+func (r *upgradeRpmPkgInfo) seekdbFileCheck() (err error) {
+	// Check for the necessary files required for the seekdb upgrade process.
+	if err = r.checkVersion(); err != nil {
+		return errors.Wrap(err, "failed to check version and release")
+	}
+	return r.findAllExpectedFiles(defaultFilesForSeekdb)
+}
+
 func (r *upgradeRpmPkgInfo) checkVersion() (err error) {
 	log.Info("version is ", r.version)
 	r.release, r.distribution, err = pkg.SplitRelease(r.rpmPkg.Release())
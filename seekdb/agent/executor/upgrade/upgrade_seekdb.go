@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package upgrade
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/seekdb/agent/engine/task"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+func SeekdbUpgrade(param param.UpgradeCheckParam) (*task.DagDetailDTO, error) {
+	if err := preCheckForSeekdbUpgrade(param); err != nil {
+		log.WithError(err).Error("pre check for seekdb upgrade failed")
+		return nil, err
+	}
+	seekdbUpgradeTemplate := buildSeekdbUpgradeTemplate(param)
+	seekdbUpgradeTaskContext := buildSeekdbUpgradeTaskContext(param)
+	seekdbUpgradeDag, err := taskService.CreateDagInstanceByTemplate(seekdbUpgradeTemplate, seekdbUpgradeTaskContext)
+	if err != nil {
+		return nil, err
+	}
+	return task.NewDagDetailDTO(seekdbUpgradeDag), nil
+}
+
+func buildSeekdbUpgradeTemplate(param param.UpgradeCheckParam) *task.Template {
+	name := fmt.Sprintf("%s %s-%s", DAG_UPGRADE_SEEKDB, param.Version, param.Release)
+	return task.NewTemplateBuilder(name).
+		SetMaintenance(task.GlobalMaintenance()).
+		AddTask(newCreateUpgradeDirTask(), false).
+		AddTask(newGetAllRequiredPkgsTask(), false).
+		AddTask(newCheckAllRequiredPkgsTask(), false).
+		AddTask(newInstallAllRequiredPkgsTask(), false).
+		AddTask(newBackupSeekdbForUpgradeTask(), false).
+		AddTask(newBackupSeekdbParametersTask(), false).
+		AddTask(newReinstallAndRestartSeekdbTask(), false).
+		AddTask(newSeekdbUpgradeHealthCheckTask(), false).
+		AddTask(newRestoreSeekdbParametersTask(), false).
+		AddTask(newRemoveUpgradeCheckDirTask(), false).
+		Build()
+}
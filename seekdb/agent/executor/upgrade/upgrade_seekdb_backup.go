@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package upgrade
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/engine/task"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/path"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/system"
+)
+
+type BackupSeekdbForUpgradeTask struct {
+	task.Task
+	upgradeCheckTaskDir string
+	backupDir           string
+}
+
+func newBackupSeekdbForUpgradeTask() *BackupSeekdbForUpgradeTask {
+	newTask := &BackupSeekdbForUpgradeTask{
+		Task: *task.NewSubTask(TASK_BACKUP_SEEKDB_FOR_UPGRADE),
+	}
+	newTask.
+		SetCanRetry().
+		SetCanContinue().
+		SetCanRollback().
+		SetCanPass().
+		SetCanCancel()
+	return newTask
+}
+
+func (t *BackupSeekdbForUpgradeTask) Execute() (err error) {
+	if t.IsContinue() {
+		t.ExecuteLog("The task is continuing.")
+		if err = t.Rollback(); err != nil {
+			return err
+		}
+	}
+
+	if err = t.backupSeekdbForUpgrade(); err != nil {
+		return
+	}
+	return nil
+}
+
+func (t *BackupSeekdbForUpgradeTask) getParams() (err error) {
+	if err = t.GetLocalDataWithValue(PARAM_UPGRADE_CHECK_TASK_DIR, &t.upgradeCheckTaskDir); err != nil {
+		return err
+	}
+
+	t.backupDir = filepath.Join(t.upgradeCheckTaskDir, "backup")
+	return nil
+}
+
+func (t *BackupSeekdbForUpgradeTask) backupSeekdbForUpgrade() error {
+	t.ExecuteLog("Backup the seekdb binary.")
+	if err := t.getParams(); err != nil {
+		return err
+	}
+
+	t.SetLocalData(DATA_BACKUP_DIR, t.backupDir)
+	t.ExecuteLogf("The directory for backup is %s", t.backupDir)
+	t.ExecuteLogf("Backup %s", path.ObserverBinPath())
+	if err := system.CopyFile(path.ObserverBinPath(), filepath.Join(t.backupDir, constant.PROC_SEEKDB)); err != nil {
+		return errors.Wrap(err, "failed to copy seekdb binary")
+	}
+	return nil
+}
+
+func (t *BackupSeekdbForUpgradeTask) Rollback() (err error) {
+	t.ExecuteLog("Rolling back...")
+	if err = t.getParams(); err != nil {
+		return err
+	}
+	t.ExecuteLog("Delete " + t.backupDir)
+	if err = os.RemoveAll(t.backupDir); err != nil {
+		return err
+	}
+	t.ExecuteLog("Successfully deleted")
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package upgrade
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/engine/task"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/agent/executor/standby"
+	"github.com/oceanbase/obshell/seekdb/agent/global"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/binary"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/pkg"
+	"github.com/oceanbase/obshell/seekdb/agent/meta"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+func SeekdbUpgradeCheck(param param.UpgradeCheckParam) (*task.DagDetailDTO, error) {
+	if err := preCheckForSeekdbUpgrade(param); err != nil {
+		return nil, err
+	}
+	seekdbUpgradeCheckTemplate := buildSeekdbUpgradeCheckTemplate(param)
+	seekdbUpgradeCheckTaskContext := buildSeekdbUpgradeTaskContext(param)
+	seekdbUpgradeCheckDag, err := taskService.CreateDagInstanceByTemplate(seekdbUpgradeCheckTemplate, seekdbUpgradeCheckTaskContext)
+	if err != nil {
+		log.WithError(err).Error("create dag instance by template failed")
+		return nil, err
+	}
+	return task.NewDagDetailDTO(seekdbUpgradeCheckDag), nil
+}
+
+func preCheckForSeekdbUpgrade(param param.UpgradeCheckParam) (err error) {
+	log.Info("Starting seekdb upgrade pre-check.")
+	if !meta.OCS_AGENT.IsClusterAgent() {
+		return errors.Occur(errors.ErrAgentIdentifyNotSupportOperation, meta.OCS_AGENT.String(), meta.OCS_AGENT.GetIdentity(), meta.CLUSTER_AGENT)
+	}
+	if err := checkUpgradeDir(&param.UpgradeDir); err != nil {
+		return err
+	}
+	targetBuildVersion, err := checkSeekdbTargetVersionSupport(param.Version, param.Release)
+	if err != nil {
+		return err
+	}
+	if err := findSeekdbTargetPkg(param.Version, param.Release); err != nil {
+		return err
+	}
+	// The standby of a pair must be upgraded before its primary.
+	return standby.CheckDownstreamUpgraded(targetBuildVersion)
+}
+
+func checkSeekdbTargetVersionSupport(version, release string) (string, error) {
+	buildNumber, _, err := pkg.SplitRelease(release)
+	if err != nil {
+		return "", err
+	}
+
+	currentBuildVersion, _, err := binary.GetMyOBVersion()
+	if err != nil {
+		return "", errors.Wrap(err, "get current seekdb version failed")
+	}
+	targetBuildVersion := fmt.Sprintf("%s-%s", version, buildNumber)
+	if pkg.CompareVersion(targetBuildVersion, currentBuildVersion) <= 0 {
+		return "", errors.Occur(errors.ErrObUpgradeToLowerVersion, targetBuildVersion, currentBuildVersion)
+	}
+	return targetBuildVersion, nil
+}
+
+func findSeekdbTargetPkg(version, release string) error {
+	buildNumber, distribution, _ := pkg.SplitRelease(release)
+	_, err := obclusterService.GetUpgradePkgInfoByVersionAndRelease(constant.PKG_SEEKDB, version, buildNumber, distribution, global.Architecture)
+	if err != nil {
+		return errors.Occur(errors.ErrAgentPackageNotFound, fmt.Sprintf("%s-%s-%s.%s.rpm", constant.PKG_SEEKDB, version, release, global.Architecture))
+	}
+	return nil
+}
+
+func buildSeekdbUpgradeTaskContext(param param.UpgradeCheckParam) *task.TaskContext {
+	buildNumber, _, _ := pkg.SplitRelease(param.Release)
+	return buildAgentUpgradeCheckTaskContext(param).
+		SetParam(PARAM_PKG_NAME, constant.PKG_SEEKDB).
+		SetParam(PARAM_TARGET_SEEKDB_BUILD_VERSION, fmt.Sprintf("%s-%s", param.Version, buildNumber))
+}
+
+func buildSeekdbUpgradeCheckTemplate(param param.UpgradeCheckParam) *task.Template {
+	name := fmt.Sprintf("%s %s-%s", DAG_UPGRADE_CHECK_SEEKDB, param.Version, param.Release)
+	return task.NewTemplateBuilder(name).
+		SetMaintenance(task.UnMaintenance()).
+		AddTask(newCreateUpgradeDirTask(), false).
+		AddTask(newGetAllRequiredPkgsTask(), false).
+		AddTask(newCheckAllRequiredPkgsTask(), false).
+		AddTask(newInstallAllRequiredPkgsTask(), false).
+		AddTask(newRemoveUpgradeCheckDirTask(), false).
+		Build()
+}
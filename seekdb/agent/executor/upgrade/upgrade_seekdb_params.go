@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package upgrade
+
+import (
+	"github.com/oceanbase/obshell/seekdb/agent/engine/task"
+	"github.com/oceanbase/obshell/seekdb/agent/repository/model/oceanbase"
+)
+
+const readonlyEditLevel = "READONLY"
+
+type BackupSeekdbParametersTask struct {
+	task.Task
+}
+
+func newBackupSeekdbParametersTask() *BackupSeekdbParametersTask {
+	newTask := &BackupSeekdbParametersTask{
+		Task: *task.NewSubTask(TASK_BACKUP_SEEKDB_PARAMETERS),
+	}
+	newTask.
+		SetCanRetry().
+		SetCanContinue().
+		SetCanCancel()
+	return newTask
+}
+
+// Execute records every parameter that has been changed from its default
+// value, so that the values survive a default change in the new version.
+func (t *BackupSeekdbParametersTask) Execute() (err error) {
+	t.ExecuteLog("Starting backup of parameters.")
+	params, err := obclusterService.GetAllUnhiddenParameters()
+	if err != nil {
+		return err
+	}
+	modified := make([]oceanbase.ObParameters, 0)
+	seen := make(map[string]bool)
+	for _, param := range params {
+		if seen[param.Name] || param.EditLevel == readonlyEditLevel || param.Value == param.DefaultValue {
+			continue
+		}
+		seen[param.Name] = true
+		t.ExecuteLogf("backup param: %s = '%s'", param.Name, param.Value)
+		modified = append(modified, param)
+	}
+	t.GetContext().SetParam(PARAM_SEEKDB_PARAMETERS, modified)
+	return nil
+}
+
+type RestoreSeekdbParametersTask struct {
+	task.Task
+	params []oceanbase.ObParameters
+}
+
+func newRestoreSeekdbParametersTask() *RestoreSeekdbParametersTask {
+	newTask := &RestoreSeekdbParametersTask{
+		Task: *task.NewSubTask(TASK_RESTORE_SEEKDB_PARAMETERS),
+	}
+	newTask.SetCanContinue().SetCanRetry()
+	return newTask
+}
+
+func (t *RestoreSeekdbParametersTask) Execute() (err error) {
+	if err = t.GetContext().GetParamWithValue(PARAM_SEEKDB_PARAMETERS, &t.params); err != nil {
+		return err
+	}
+	t.ExecuteLog("start to restore parameters")
+	current, err := obclusterService.GetAllUnhiddenParameters()
+	if err != nil {
+		return err
+	}
+	currentValues := make(map[string]string)
+	for _, param := range current {
+		currentValues[param.Name] = param.Value
+	}
+
+	needRestore := make([]oceanbase.ObParameters, 0)
+	for _, param := range t.params {
+		value, ok := currentValues[param.Name]
+		if !ok {
+			t.ExecuteLogf("param %s does not exist in the new version, skip it", param.Name)
+			continue
+		}
+		if value == param.Value {
+			continue
+		}
+		t.ExecuteLogf("restore param: %s from '%s' to '%s'", param.Name, value, param.Value)
+		needRestore = append(needRestore, param)
+	}
+	return obclusterService.RestoreParamsForUpgrade(needRestore)
+}
@@ -0,0 +1,261 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package upgrade
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/engine/task"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/agent/executor/observer"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/binary"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/path"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/process"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/system"
+)
+
+const (
+	STOP_SEEKDB_MAX_RETRY_TIME     = 15
+	STOP_SEEKDB_MAX_RETRY_INTERVAL = 5 * time.Second
+)
+
+type ReinstallAndRestartSeekdbTask struct {
+	task.Task
+	rpmPkgInfo rpmPacakgeInstallInfo
+	backupDir  string
+}
+
+func newReinstallAndRestartSeekdbTask() *ReinstallAndRestartSeekdbTask {
+	newTask := &ReinstallAndRestartSeekdbTask{
+		Task: *task.NewSubTask(TASK_REINSTALL_AND_RESTART_SEEKDB),
+	}
+	newTask.
+		SetCanRetry().
+		SetCanRollback().
+		SetCanContinue().
+		SetCanCancel()
+	return newTask
+}
+
+func (t *ReinstallAndRestartSeekdbTask) getParams() (err error) {
+	if err = t.GetContext().GetDataWithValue(PARAM_UPGRADE_PKG_INSTALL_INFO, &t.rpmPkgInfo); err != nil {
+		return err
+	}
+	return t.GetLocalDataWithValue(DATA_BACKUP_DIR, &t.backupDir)
+}
+
+func (t *ReinstallAndRestartSeekdbTask) Execute() (err error) {
+	if err = t.getParams(); err != nil {
+		return err
+	}
+
+	t.ExecuteLog("stop seekdb")
+	if err = stopSeekdb(t); err != nil {
+		return err
+	}
+	t.ExecuteLog("reinstall seekdb")
+	src := filepath.Join(t.rpmPkgInfo.RpmPkgHomepath, constant.DIR_BIN, constant.PROC_SEEKDB)
+	if err = installSeekdbBinary(t, src); err != nil {
+		return err
+	}
+	t.ExecuteLog("start seekdb")
+	if err = startSeekdbAndWait(t); err != nil {
+		t.ExecuteErrorLog(err)
+		t.ExecuteLog("The new seekdb failed to start, roll back to the previous binary.")
+		if rollbackErr := rollbackToPreviousSeekdb(t, t.backupDir); rollbackErr != nil {
+			return errors.Wrapf(err, "rollback to the previous seekdb failed: %v", rollbackErr)
+		}
+		return err
+	}
+	t.ExecuteLog("reinstall and restart seekdb success")
+	return nil
+}
+
+func (t *ReinstallAndRestartSeekdbTask) Rollback() (err error) {
+	t.ExecuteLog("Rolling back...")
+	if err = t.getParams(); err != nil {
+		return err
+	}
+	return rollbackToPreviousSeekdb(t, t.backupDir)
+}
+
+type SeekdbUpgradeHealthCheckTask struct {
+	task.Task
+	targetVersion      string
+	targetBuildVersion string
+}
+
+func newSeekdbUpgradeHealthCheckTask() *SeekdbUpgradeHealthCheckTask {
+	newTask := &SeekdbUpgradeHealthCheckTask{
+		Task: *task.NewSubTask(TASK_SEEKDB_UPGRADE_HEALTH_CHECK),
+	}
+	newTask.
+		SetCanRetry().
+		SetCanContinue().
+		SetCanPass().
+		SetCanCancel()
+	return newTask
+}
+
+func (t *SeekdbUpgradeHealthCheckTask) getParams() (err error) {
+	if err = t.GetContext().GetParamWithValue(PARAM_VERSION, &t.targetVersion); err != nil {
+		return err
+	}
+	return t.GetContext().GetParamWithValue(PARAM_TARGET_SEEKDB_BUILD_VERSION, &t.targetBuildVersion)
+}
+
+func (t *SeekdbUpgradeHealthCheckTask) Execute() (err error) {
+	if err = t.getParams(); err != nil {
+		return err
+	}
+	// The new seekdb has started and may have changed the data, so it is not rolled back automatically.
+	if err = t.healthCheck(); err != nil {
+		t.ExecuteErrorLog(err)
+		t.ExecuteLog("Post-upgrade health check failed, retry after fixing it, pass it, or roll back the task to restore the previous binary.")
+		return err
+	}
+	t.ExecuteLog("post-upgrade health check passed")
+	return nil
+}
+
+func (t *SeekdbUpgradeHealthCheckTask) healthCheck() error {
+	t.ExecuteLog("check the seekdb binary version")
+	binaryVersion, _, err := binary.GetMyOBVersion()
+	if err != nil {
+		return errors.Wrap(err, "get seekdb binary version failed")
+	}
+	if binaryVersion != t.targetBuildVersion {
+		return errors.Occur(errors.ErrObUpgradeVersionMismatch, binaryVersion, t.targetBuildVersion)
+	}
+
+	t.ExecuteLog("wait seekdb available")
+	if err = waitSeekdbAvailable(t); err != nil {
+		return err
+	}
+
+	t.ExecuteLog("check the running seekdb version")
+	runningVersion, err := obclusterService.GetObVersion()
+	if err != nil {
+		return errors.Wrap(err, "get running seekdb version failed")
+	}
+	if runningVersion != t.targetVersion {
+		return errors.Occur(errors.ErrObUpgradeVersionMismatch, runningVersion, t.targetVersion)
+	}
+	t.ExecuteLogf("seekdb is running with version %s", runningVersion)
+	return nil
+}
+
+func stopSeekdb(t task.ExecutableTask) error {
+	exist, err := process.CheckObserverProcess()
+	if err != nil {
+		return errors.Occur(errors.ErrObServerProcessCheckFailed, err.Error())
+	}
+	if !exist {
+		t.ExecuteLog("seekdb is not running")
+		return nil
+	}
+	pid, err := process.GetObserverPid()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < STOP_SEEKDB_MAX_RETRY_TIME; i++ {
+		t.ExecuteLogf("Kill seekdb process %s", pid)
+		if err := exec.Command("kill", "-9", pid).Run(); err != nil {
+			log.Warn("Kill seekdb process failed")
+		}
+
+		time.Sleep(STOP_SEEKDB_MAX_RETRY_INTERVAL)
+		t.TimeoutCheck()
+
+		if _, err := os.Stat(fmt.Sprintf("/proc/%s", pid)); err != nil {
+			if os.IsNotExist(err) {
+				t.ExecuteLog("Successfully killed the seekdb process")
+				return nil
+			}
+			log.Warnf("Check seekdb process failed: %v", err)
+		}
+	}
+	return errors.Occur(errors.ErrObClusterAsyncOperationTimeout, "kill seekdb process")
+}
+
+func installSeekdbBinary(t task.ExecutableTask, src string) error {
+	t.ExecuteLogf("copy seekdb from '%s' to '%s'", src, path.ObserverBinPath())
+	if err := os.RemoveAll(path.ObserverBinPath()); err != nil {
+		return err
+	}
+	if err := system.CopyFile(src, path.ObserverBinPath()); err != nil {
+		return errors.Wrap(err, "copy seekdb binary failed")
+	}
+	return nil
+}
+
+func startSeekdbAndWait(t task.ExecutableTask) error {
+	if err := observer.SafeStartObserver(); err != nil {
+		return errors.Wrap(err, "start seekdb failed")
+	}
+	return waitSeekdbAvailable(t)
+}
+
+func waitSeekdbAvailable(t task.ExecutableTask) error {
+	for i := 1; i <= constant.TICK_NUM_FOR_OB_STATUS_CHECK; i++ {
+		exist, err := process.CheckObserverProcess()
+		if err != nil {
+			return errors.Occur(errors.ErrObServerProcessCheckFailed, err.Error())
+		} else if !exist {
+			return errors.Occur(errors.ErrObServerProcessNotExist)
+		}
+		if _, err = obclusterService.GetObVersion(); err == nil {
+			return nil
+		}
+		log.Infof("seekdb is not available: %v, retry [%d/%d]", err, i, constant.TICK_NUM_FOR_OB_STATUS_CHECK)
+		time.Sleep(constant.TICK_INTERVAL_FOR_OB_STATUS_CHECK)
+		t.TimeoutCheck()
+	}
+	return errors.Occur(errors.ErrObClusterAsyncOperationTimeout, "wait seekdb available")
+}
+
+// rollbackToPreviousSeekdb puts the backed up seekdb binary back and restarts seekdb with it.
+func rollbackToPreviousSeekdb(t task.ExecutableTask, backupDir string) error {
+	backupBin := filepath.Join(backupDir, constant.PROC_SEEKDB)
+	if _, err := os.Stat(backupBin); err != nil {
+		return errors.Wrapf(err, "backup seekdb binary %s is not available", backupBin)
+	}
+	t.ExecuteLog("stop seekdb")
+	if err := stopSeekdb(t); err != nil {
+		return err
+	}
+	t.ExecuteLog("restore the previous seekdb binary")
+	if err := installSeekdbBinary(t, backupBin); err != nil {
+		return err
+	}
+	t.ExecuteLog("start the previous seekdb")
+	if err := startSeekdbAndWait(t); err != nil {
+		return err
+	}
+	if version, _, err := binary.GetMyOBVersion(); err == nil {
+		t.ExecuteLogf("seekdb has been rolled back to %s", version)
+	}
+	return nil
+}
@@ -0,0 +1,642 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package system
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	log "github.com/sirupsen/logrus"
+	"github.com/tencentyun/cos-go-sdk-v5"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/agent/meta"
+)
+
+const (
+	host           = "host"
+	accessID       = "access_id"
+	accessKey      = "access_key"
+	appID          = "appid"
+	s3Region       = "s3_region"
+	deleteMode     = "delete_mode"
+	forcePathStyle = "force_path_style"
+)
+
+type StorageInterface interface {
+	GenerateURI() string
+	GenerateURIWithoutSecret() string
+	GenerateURIWhitoutParams() string
+	GenerateQueryParams() string
+	GetResourceType() string
+	CheckWritePermission() error
+	NewWithObjectKey(string) StorageInterface
+	// ListFiles returns the names of the files and directories right under the subpath.
+	ListFiles(subpath string) ([]string, error)
+	// ReadFile returns the content of the file at the subpath.
+	ReadFile(subpath string) ([]byte, error)
+}
+
+type OSSConfig struct {
+	BaseConf
+}
+
+type BaseConf struct {
+	BucketName string
+	ObjectKey  string
+	Host       string
+	AccessID   string
+	AccessKey  string
+	DeleteMode string
+}
+
+func (c *OSSConfig) NewWithObjectKey(subpath string) StorageInterface {
+	copy := new(OSSConfig)
+	*copy = *c
+	copy.ObjectKey = fmt.Sprintf("%s/%s", c.ObjectKey, subpath)
+	return copy
+}
+
+func (c *OSSConfig) GenerateURI() (res string) {
+	res = fmt.Sprintf("%s&%s=%s&%s=%s", c.GenerateURIWithoutSecret(), accessID, c.AccessID, accessKey, c.AccessKey)
+	return
+}
+
+func (c *OSSConfig) GenerateURIWithoutSecret() (res string) {
+	res = fmt.Sprintf("%s%s/%s?%s=%s", constant.PREFIX_OSS, c.BucketName, c.ObjectKey, host, c.Host)
+	if c.DeleteMode != "" {
+		res += fmt.Sprintf("&%s=%s", deleteMode, c.DeleteMode)
+	}
+	return
+}
+
+func (c *OSSConfig) GenerateURIWhitoutParams() string {
+	return fmt.Sprintf("%s%s/%s", constant.PREFIX_OSS, c.BucketName, c.ObjectKey)
+}
+
+func (c *OSSConfig) GenerateQueryParams() string {
+	return fmt.Sprintf("%s=%s&%s=%s&%s=%s", host, c.Host, accessID, c.AccessID, accessKey, c.AccessKey)
+}
+
+func (c *OSSConfig) GetResourceType() string {
+	return constant.PROTOCOL_OSS
+}
+
+func (c *OSSConfig) newBucket() (*oss.Bucket, error) {
+	client, err := oss.New(c.Host, c.AccessID, c.AccessKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "create oss client")
+	}
+	log.Info("OSS client created")
+
+	ossBucket, err := client.Bucket(c.BucketName)
+	if err != nil {
+		return nil, errors.Wrap(err, "get oss bucket")
+	}
+	log.Infof("OSS bucket %s created", c.BucketName)
+	return ossBucket, nil
+}
+
+func (c *OSSConfig) CheckWritePermission() error {
+	ossBucket, err := c.newBucket()
+	if err != nil {
+		return err
+	}
+
+	emptyContent := bytes.NewReader([]byte(""))
+	testFile := path.Join(c.ObjectKey, meta.OCS_AGENT.GetIp(), fmt.Sprint(meta.OCS_AGENT.GetPort()))
+	log.Infof("test file: %s", testFile)
+	if err = ossBucket.PutObject(testFile, emptyContent); err != nil {
+		return errors.Wrap(err, "put object")
+	}
+
+	if err = ossBucket.DeleteObject(testFile); err != nil {
+		return errors.Wrap(err, "delete object")
+	}
+
+	return nil
+}
+
+type COSConfig struct {
+	BaseConf
+	AppID string
+}
+
+func (c *COSConfig) NewWithObjectKey(subpath string) StorageInterface {
+	copy := new(COSConfig)
+	*copy = *c
+	copy.ObjectKey = fmt.Sprintf("%s/%s", c.ObjectKey, subpath)
+	return copy
+}
+
+func (c *COSConfig) GetResourceType() string {
+	return constant.PROTOCOL_COS
+}
+
+func (c *COSConfig) GenerateQueryParams() string {
+	return fmt.Sprintf("%s=%s&%s=%s&%s=%s&%s=%s", host, c.Host, accessID, c.AccessID, accessKey, c.AccessKey, appID, c.AppID)
+}
+
+func (c *COSConfig) GenerateURIWhitoutParams() string {
+	return fmt.Sprintf("%s%s/%s", constant.PREFIX_COS, c.BucketName, c.ObjectKey)
+}
+
+func (c *COSConfig) GenerateURI() (res string) {
+	res = fmt.Sprintf("%s&%s=%s&%s=%s", c.GenerateURIWithoutSecret(), accessID, c.AccessID, accessKey, c.AccessKey)
+	return
+}
+
+func (c *COSConfig) GenerateURIWithoutSecret() (res string) {
+	res = fmt.Sprintf("%s%s/%s?%s=%s", constant.PREFIX_COS, c.BucketName, c.ObjectKey, host, c.Host)
+	if c.AppID != "" {
+		res += fmt.Sprintf("&%s=%s", appID, c.AppID)
+	}
+	if c.DeleteMode != "" {
+		res += fmt.Sprintf("&%s=%s", deleteMode, c.DeleteMode)
+	}
+	return
+}
+
+func (c *COSConfig) newClient() (*cos.Client, error) {
+	cosURL := fmt.Sprintf("https://%s.%s", c.BucketName, c.Host)
+	u, err := url.Parse(cosURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse cos uri")
+	}
+
+	b := &cos.BaseURL{
+		BucketURL: u,
+	}
+	client := cos.NewClient(b, &http.Client{
+		Transport: &cos.AuthorizationTransport{
+			SecretID:  c.AccessID,
+			SecretKey: c.AccessKey,
+		},
+	})
+	log.Info("COS client created")
+	return client, nil
+}
+
+func (c *COSConfig) CheckWritePermission() error {
+	client, err := c.newClient()
+	if err != nil {
+		return err
+	}
+
+	emptyContent := bytes.NewReader([]byte(""))
+	testFile := path.Join(c.ObjectKey, meta.OCS_AGENT.GetIp(), fmt.Sprint(meta.OCS_AGENT.GetPort()))
+	_, err = client.Object.Put(context.Background(), testFile, emptyContent, nil)
+	if err != nil {
+		return errors.Wrap(err, "put object")
+	}
+
+	_, err = client.Object.Delete(context.Background(), testFile)
+	if err != nil {
+		return errors.Wrap(err, "delete cos object")
+	}
+
+	return nil
+}
+
+type S3Config struct {
+	BaseConf
+	S3Region       string
+	ForcePathStyle bool
+}
+
+func (c *S3Config) NewWithObjectKey(subpath string) StorageInterface {
+	copy := new(S3Config)
+	*copy = *c
+	copy.ObjectKey = fmt.Sprintf("%s/%s", c.ObjectKey, subpath)
+	return copy
+}
+
+func (c *S3Config) GetResourceType() string {
+	return constant.PROTOCOL_S3
+}
+
+func (c *S3Config) GenerateURI() (res string) {
+	res = fmt.Sprintf("%s&%s=%s&%s=%s", c.GenerateURIWithoutSecret(), accessID, c.AccessID, accessKey, c.AccessKey)
+	return
+}
+
+func (c *S3Config) GenerateURIWithoutSecret() (res string) {
+	res = fmt.Sprintf("%s%s/%s?%s=%s", constant.PREFIX_S3, c.BucketName, c.ObjectKey, host, c.Host)
+	if c.S3Region != "" {
+		res += fmt.Sprintf("&%s=%s", s3Region, c.S3Region)
+	}
+	if c.DeleteMode != "" {
+		res += fmt.Sprintf("&%s=%s", deleteMode, c.DeleteMode)
+	}
+	return
+}
+
+func (c *S3Config) GenerateURIWhitoutParams() string {
+	return fmt.Sprintf("%s%s/%s", constant.PREFIX_S3, c.BucketName, c.ObjectKey)
+}
+
+func (c *S3Config) GenerateQueryParams() string {
+	return fmt.Sprintf("%s=%s&%s=%s&%s=%s", host, c.Host, accessID, c.AccessID, accessKey, c.AccessKey)
+}
+
+func (c *S3Config) newClient() (svc *s3.S3, err error) {
+	var sess *session.Session
+	if c.S3Region != "" {
+		sess, err = session.NewSession(&aws.Config{
+			Region:      aws.String(c.S3Region),
+			Credentials: credentials.NewStaticCredentials(c.AccessID, c.AccessKey, ""),
+		})
+	} else {
+		awsConfig := &aws.Config{
+			Region:      aws.String("auto"),
+			Endpoint:    aws.String(c.Host),
+			Credentials: credentials.NewStaticCredentials(c.AccessID, c.AccessKey, ""),
+		}
+		if c.ForcePathStyle {
+			awsConfig.S3ForcePathStyle = aws.Bool(true)
+		}
+		sess, err = session.NewSession(awsConfig)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "create s3 session")
+	}
+
+	svc = s3.New(sess)
+	log.Info("S3 client created")
+	return svc, nil
+}
+
+func (c *S3Config) CheckWritePermission() (err error) {
+	svc, err := c.newClient()
+	if err != nil {
+		return err
+	}
+
+	emptyContent := bytes.NewReader([]byte(""))
+	testFile := path.Join(c.ObjectKey, meta.OCS_AGENT.GetIp(), fmt.Sprint(meta.OCS_AGENT.GetPort()))
+	_, err = svc.PutObject(
+		&s3.PutObjectInput{
+			Bucket: aws.String(c.BucketName),
+			Key:    aws.String(testFile),
+			Body:   emptyContent,
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "put s3 object")
+	}
+	log.Infof("put s3 object %s", testFile)
+
+	_, err = svc.DeleteObject(
+		&s3.DeleteObjectInput{
+			Bucket: aws.String(c.BucketName),
+			Key:    aws.String(testFile),
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "delete s3 object")
+	}
+
+	return nil
+}
+
+// AzBlobConfig is the config of Azure Blob Storage, the bucket name is the container,
+// the host is the blob service endpoint and the access id is the storage account name.
+type AzBlobConfig struct {
+	BaseConf
+}
+
+func (c *AzBlobConfig) NewWithObjectKey(subpath string) StorageInterface {
+	copy := new(AzBlobConfig)
+	*copy = *c
+	copy.ObjectKey = fmt.Sprintf("%s/%s", c.ObjectKey, subpath)
+	return copy
+}
+
+func (c *AzBlobConfig) GetResourceType() string {
+	return constant.PROTOCOL_AZBLOB
+}
+
+func (c *AzBlobConfig) GenerateURI() (res string) {
+	res = fmt.Sprintf("%s&%s=%s&%s=%s", c.GenerateURIWithoutSecret(), accessID, c.AccessID, accessKey, c.AccessKey)
+	return
+}
+
+func (c *AzBlobConfig) GenerateURIWithoutSecret() (res string) {
+	res = fmt.Sprintf("%s%s/%s?%s=%s", constant.PREFIX_AZBLOB, c.BucketName, c.ObjectKey, host, c.Host)
+	if c.DeleteMode != "" {
+		res += fmt.Sprintf("&%s=%s", deleteMode, c.DeleteMode)
+	}
+	return
+}
+
+func (c *AzBlobConfig) GenerateURIWhitoutParams() string {
+	return fmt.Sprintf("%s%s/%s", constant.PREFIX_AZBLOB, c.BucketName, c.ObjectKey)
+}
+
+func (c *AzBlobConfig) GenerateQueryParams() string {
+	return fmt.Sprintf("%s=%s&%s=%s&%s=%s", host, c.Host, accessID, c.AccessID, accessKey, c.AccessKey)
+}
+
+// serviceURL returns the blob service url, https is used when the host has no scheme.
+// Emulators such as Azurite use a path-style url like http://127.0.0.1:10000/devstoreaccount1.
+func (c *AzBlobConfig) serviceURL() string {
+	if strings.Contains(c.Host, "://") {
+		return c.Host
+	}
+	return "https://" + c.Host
+}
+
+func (c *AzBlobConfig) newClient() (*azblob.Client, error) {
+	cred, err := azblob.NewSharedKeyCredential(c.AccessID, c.AccessKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "create azblob credential")
+	}
+	client, err := azblob.NewClientWithSharedKeyCredential(c.serviceURL(), cred, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "create azblob client")
+	}
+	log.Info("Azure Blob client created")
+	return client, nil
+}
+
+func (c *AzBlobConfig) CheckWritePermission() error {
+	client, err := c.newClient()
+	if err != nil {
+		return err
+	}
+
+	testFile := path.Join(c.ObjectKey, meta.OCS_AGENT.GetIp(), fmt.Sprint(meta.OCS_AGENT.GetPort()))
+	log.Infof("test file: %s", testFile)
+	if _, err = client.UploadBuffer(context.Background(), c.BucketName, testFile, []byte(""), nil); err != nil {
+		return errors.Wrap(err, "put azblob object")
+	}
+
+	if _, err = client.DeleteBlob(context.Background(), c.BucketName, testFile, nil); err != nil {
+		return errors.Wrap(err, "delete azblob object")
+	}
+
+	return nil
+}
+
+// GCSConfig is the config of Google Cloud Storage accessed with HMAC keys.
+// OceanBase reaches GCS through its S3-interoperable XML API, so the generated
+// uri is in the s3:// form, while the gs:// form is accepted as input.
+type GCSConfig struct {
+	S3Config
+}
+
+func (c *GCSConfig) NewWithObjectKey(subpath string) StorageInterface {
+	copy := new(GCSConfig)
+	*copy = *c
+	copy.ObjectKey = fmt.Sprintf("%s/%s", c.ObjectKey, subpath)
+	return copy
+}
+
+func (c *GCSConfig) GetResourceType() string {
+	return constant.PROTOCOL_GCS
+}
+
+type NFSConfig struct {
+	Path string
+}
+
+func (c *NFSConfig) NewWithObjectKey(subpath string) StorageInterface {
+	copy := new(NFSConfig)
+	*copy = *c
+	copy.Path = fmt.Sprintf("%s/%s", c.Path, subpath)
+	return copy
+}
+
+func (c *NFSConfig) GetResourceType() string {
+	return constant.PROTOCOL_FILE
+}
+
+func (c *NFSConfig) GenerateQueryParams() string {
+	return ""
+}
+
+func (c *NFSConfig) GenerateURIWhitoutParams() string {
+	return c.GenerateURIWithoutSecret()
+}
+
+func (c *NFSConfig) GenerateURI() (res string) {
+	return c.GenerateURIWithoutSecret()
+}
+
+func (c *NFSConfig) GenerateURIWithoutSecret() (res string) {
+	return fmt.Sprintf("%s%s", constant.PREFIX_FILE, c.Path)
+}
+
+func (c *NFSConfig) CheckWritePermission() error {
+	if err := os.MkdirAll(c.Path, 0755); err != nil {
+		return err
+	}
+
+	if _, err := os.Open(c.Path); err != nil {
+		return errors.Wrap(err, "open nfs path")
+	}
+
+	testFile := path.Join(c.Path, meta.OCS_AGENT.String())
+	f, err := os.Create(testFile)
+	if err != nil {
+		return errors.Wrap(err, "create test file")
+	}
+	defer f.Close()
+	if err := os.Remove(testFile); err != nil {
+		return errors.Wrap(err, "remove test file")
+	}
+	return nil
+}
+
+func GetStorageInterfaceByURI(uri string) (StorageInterface, error) {
+	if strings.HasPrefix(uri, constant.PREFIX_OSS) {
+		return GetOSSStorage(uri)
+	} else if strings.HasPrefix(uri, constant.PREFIX_COS) {
+		return GetCOSStorage(uri)
+	} else if strings.HasPrefix(uri, constant.PREFIX_S3) {
+		return GetS3Storage(uri)
+	} else if strings.HasPrefix(uri, constant.PREFIX_FILE) {
+		return GetNFSStorage(uri)
+	} else if strings.HasPrefix(uri, constant.PREFIX_AZBLOB) {
+		return GetAzBlobStorage(uri)
+	} else if strings.HasPrefix(uri, constant.PREFIX_GCS) {
+		return GetGCSStorage(uri)
+	} else {
+		return nil, errors.Occur(errors.ErrBackupStorageURIInvalid, "invalid uri protocol")
+	}
+}
+
+// ObStorageURI returns the uri in the form accepted by OceanBase.
+// The gs:// uri is converted into the s3:// form of the S3-interoperable api of GCS.
+func ObStorageURI(uri string) string {
+	if !strings.HasPrefix(uri, constant.PREFIX_GCS) {
+		return uri
+	}
+	storage, err := GetGCSStorage(uri)
+	if err != nil {
+		log.WithError(err).Warn("parse gcs uri failed")
+		return uri
+	}
+	return storage.GenerateURI()
+}
+
+func GetResourceType(uri string) (t string, err error) {
+	if strings.HasPrefix(uri, constant.PREFIX_OSS) {
+		t = constant.PROTOCOL_OSS
+	} else if strings.HasPrefix(uri, constant.PREFIX_COS) {
+		t = constant.PROTOCOL_COS
+	} else if strings.HasPrefix(uri, constant.PREFIX_S3) {
+		t = constant.PROTOCOL_S3
+	} else if strings.HasPrefix(uri, constant.PREFIX_FILE) {
+		t = constant.PROTOCOL_FILE
+	} else if strings.HasPrefix(uri, constant.PREFIX_AZBLOB) {
+		t = constant.PROTOCOL_AZBLOB
+	} else if strings.HasPrefix(uri, constant.PREFIX_GCS) {
+		t = constant.PROTOCOL_GCS
+	} else {
+		err = errors.Occur(errors.ErrBackupStorageURIInvalid, "invalid path type")
+	}
+	return
+}
+
+func GetOSSStorage(url string) (StorageInterface, error) {
+	conf := &OSSConfig{}
+	urlWithoutScheme := strings.TrimPrefix(url, constant.PREFIX_OSS)
+	if _, err := conf.parseParams(urlWithoutScheme); err != nil {
+		return nil, errors.Wrap(err, "parse oss config")
+	}
+	return conf, nil
+}
+
+func GetCOSStorage(url string) (StorageInterface, error) {
+	conf := &COSConfig{}
+	urlWithoutScheme := strings.TrimPrefix(url, constant.PREFIX_COS)
+	params, err := conf.parseParams(urlWithoutScheme)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse cos config")
+	}
+	conf.AppID = params.Get(appID)
+	if conf.AppID == "" {
+		return nil, errors.Occur(errors.ErrBackupStorageURIInvalid, "cos appid is required")
+	}
+	return conf, nil
+}
+
+func GetS3Storage(url string) (StorageInterface, error) {
+	conf := &S3Config{}
+	urlWithoutScheme := strings.TrimPrefix(url, constant.PREFIX_S3)
+	params, err := conf.parseParams(urlWithoutScheme)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse s3 config")
+	}
+	conf.S3Region = params.Get(s3Region)
+	if params.Get(forcePathStyle) == "true" {
+		conf.ForcePathStyle = true
+	}
+	return conf, nil
+}
+
+// GetAzBlobStorage parses the azblob uri, the host defaults to the public endpoint of the account.
+func GetAzBlobStorage(url string) (StorageInterface, error) {
+	conf := &AzBlobConfig{}
+	urlWithoutScheme := strings.TrimPrefix(url, constant.PREFIX_AZBLOB)
+	if _, err := conf.parseParams(urlWithoutScheme); err != nil {
+		return nil, errors.Wrap(err, "parse azblob config")
+	}
+	if conf.AccessID == "" {
+		return nil, errors.Occur(errors.ErrBackupStorageURIInvalid, "azblob access_id(storage account name) is required")
+	}
+	if conf.Host == "" {
+		conf.Host = fmt.Sprintf("https://%s.blob.core.windows.net", conf.AccessID)
+	}
+	return conf, nil
+}
+
+// GetGCSStorage parses the gs uri, the host defaults to the public endpoint of GCS.
+// Path-style access is always used, which is also required by emulators such as fake-gcs-server.
+func GetGCSStorage(url string) (StorageInterface, error) {
+	conf := &GCSConfig{}
+	urlWithoutScheme := strings.TrimPrefix(url, constant.PREFIX_GCS)
+	if _, err := conf.parseParams(urlWithoutScheme); err != nil {
+		return nil, errors.Wrap(err, "parse gcs config")
+	}
+	if conf.Host == "" {
+		conf.Host = constant.GCS_DEFAULT_HOST
+	}
+	conf.ForcePathStyle = true
+	return conf, nil
+}
+
+func GetNFSStorage(url string) (StorageInterface, error) {
+	conf := &NFSConfig{}
+	conf.Path = strings.TrimPrefix(url, constant.PREFIX_FILE)
+	if strings.ContainsAny(conf.Path, "?") {
+		return nil, errors.Occur(errors.ErrBackupStorageURIInvalid, "invalid file path, contains invalid character '?'")
+	}
+	if !strings.HasPrefix(conf.Path, "/") {
+		return nil, errors.Occur(errors.ErrBackupStorageURIInvalid, "invalid file path, must start with '/'")
+	}
+	return conf, nil
+}
+
+func (c *BaseConf) parseParams(urlWithoutScheme string) (url.Values, error) {
+	parts := strings.SplitN(urlWithoutScheme, "/", 2)
+	if len(parts) < 2 {
+		return nil, errors.Occur(errors.ErrBackupStorageURIInvalid, "invalid url format")
+	}
+
+	c.BucketName = parts[0]
+	log.Info("c.BucketName is ", c.BucketName)
+
+	rest := parts[1]
+	queryParamsStart := strings.Index(rest, "?")
+	if queryParamsStart == -1 || queryParamsStart == len(rest)-1 {
+		return nil, errors.Occur(errors.ErrBackupStorageURIInvalid, "invalid url format, missing query params")
+	}
+
+	c.ObjectKey = rest[:queryParamsStart]
+	c.ObjectKey = strings.TrimRight(c.ObjectKey, "/")
+	log.Info("c.ObjectKey is ", c.ObjectKey)
+
+	queryParams := rest[queryParamsStart+1:]
+	fixedQueryParams := strings.Replace(queryParams, "+", "%2B", -1)
+	params, err := url.ParseQuery(fixedQueryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Host = params.Get(host)
+	c.AccessID = params.Get(accessID)
+	c.AccessKey = params.Get(accessKey)
+	c.DeleteMode = params.Get(deleteMode)
+
+	log.Info("c.Host is ", c.Host)
+	return params, nil
+}
@@ -0,0 +1,256 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package system
+
+import (
+	"context"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/tencentyun/cos-go-sdk-v5"
+
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+)
+
+// objectDirPrefix returns the object prefix used to list the directory objectKey/subpath.
+func objectDirPrefix(objectKey, subpath string) string {
+	prefix := strings.Trim(path.Join(objectKey, subpath), "/")
+	if prefix == "" {
+		return ""
+	}
+	return prefix + "/"
+}
+
+// objectPath returns the object key of the file objectKey/subpath.
+func objectPath(objectKey, subpath string) string {
+	return strings.Trim(path.Join(objectKey, subpath), "/")
+}
+
+// appendEntry appends the name of key relative to prefix into names.
+func appendEntry(names []string, prefix, key string) []string {
+	name := strings.Trim(strings.TrimPrefix(key, prefix), "/")
+	if name == "" {
+		return names
+	}
+	return append(names, name)
+}
+
+func (c *OSSConfig) ListFiles(subpath string) ([]string, error) {
+	bucket, err := c.newBucket()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := objectDirPrefix(c.ObjectKey, subpath)
+	names := make([]string, 0)
+	token := ""
+	for {
+		res, err := bucket.ListObjectsV2(oss.Prefix(prefix), oss.Delimiter("/"), oss.ContinuationToken(token))
+		if err != nil {
+			return nil, errors.Wrapf(err, "list oss objects with prefix '%s'", prefix)
+		}
+		for _, obj := range res.Objects {
+			names = appendEntry(names, prefix, obj.Key)
+		}
+		for _, dir := range res.CommonPrefixes {
+			names = appendEntry(names, prefix, dir)
+		}
+		if !res.IsTruncated {
+			break
+		}
+		token = res.NextContinuationToken
+	}
+	return names, nil
+}
+
+func (c *OSSConfig) ReadFile(subpath string) ([]byte, error) {
+	bucket, err := c.newBucket()
+	if err != nil {
+		return nil, err
+	}
+
+	key := objectPath(c.ObjectKey, subpath)
+	body, err := bucket.GetObject(key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get oss object '%s'", key)
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+func (c *COSConfig) ListFiles(subpath string) ([]string, error) {
+	client, err := c.newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := objectDirPrefix(c.ObjectKey, subpath)
+	names := make([]string, 0)
+	opt := &cos.BucketGetOptions{
+		Prefix:    prefix,
+		Delimiter: "/",
+	}
+	for {
+		res, _, err := client.Bucket.Get(context.Background(), opt)
+		if err != nil {
+			return nil, errors.Wrapf(err, "list cos objects with prefix '%s'", prefix)
+		}
+		for _, obj := range res.Contents {
+			names = appendEntry(names, prefix, obj.Key)
+		}
+		for _, dir := range res.CommonPrefixes {
+			names = appendEntry(names, prefix, dir)
+		}
+		if !res.IsTruncated {
+			break
+		}
+		opt.Marker = res.NextMarker
+	}
+	return names, nil
+}
+
+func (c *COSConfig) ReadFile(subpath string) ([]byte, error) {
+	client, err := c.newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	key := objectPath(c.ObjectKey, subpath)
+	resp, err := client.Object.Get(context.Background(), key, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get cos object '%s'", key)
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+func (c *S3Config) ListFiles(subpath string) ([]string, error) {
+	svc, err := c.newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := objectDirPrefix(c.ObjectKey, subpath)
+	names := make([]string, 0)
+	input := &s3.ListObjectsV2Input{
+		Bucket:    aws.String(c.BucketName),
+		Prefix:    aws.String(prefix),
+		Delimiter: aws.String("/"),
+	}
+	err = svc.ListObjectsV2Pages(input, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, obj := range page.Contents {
+			names = appendEntry(names, prefix, aws.StringValue(obj.Key))
+		}
+		for _, dir := range page.CommonPrefixes {
+			names = appendEntry(names, prefix, aws.StringValue(dir.Prefix))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "list s3 objects with prefix '%s'", prefix)
+	}
+	return names, nil
+}
+
+func (c *S3Config) ReadFile(subpath string) ([]byte, error) {
+	svc, err := c.newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	key := objectPath(c.ObjectKey, subpath)
+	out, err := svc.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(c.BucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "get s3 object '%s'", key)
+	}
+	defer out.Body.Close()
+	return io.ReadAll(out.Body)
+}
+
+func (c *AzBlobConfig) ListFiles(subpath string) ([]string, error) {
+	client, err := c.newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := objectDirPrefix(c.ObjectKey, subpath)
+	names := make([]string, 0)
+	pager := client.ServiceClient().NewContainerClient(c.BucketName).NewListBlobsHierarchyPager("/", &container.ListBlobsHierarchyOptions{
+		Prefix: &prefix,
+	})
+	for pager.More() {
+		page, err := pager.NextPage(context.Background())
+		if err != nil {
+			return nil, errors.Wrapf(err, "list azblob objects with prefix '%s'", prefix)
+		}
+		if page.Segment == nil {
+			continue
+		}
+		for _, blob := range page.Segment.BlobItems {
+			if blob.Name != nil {
+				names = appendEntry(names, prefix, *blob.Name)
+			}
+		}
+		for _, dir := range page.Segment.BlobPrefixes {
+			if dir.Name != nil {
+				names = appendEntry(names, prefix, *dir.Name)
+			}
+		}
+	}
+	return names, nil
+}
+
+func (c *AzBlobConfig) ReadFile(subpath string) ([]byte, error) {
+	client, err := c.newClient()
+	if err != nil {
+		return nil, err
+	}
+
+	key := objectPath(c.ObjectKey, subpath)
+	resp, err := client.DownloadStream(context.Background(), c.BucketName, key, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get azblob object '%s'", key)
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+func (c *NFSConfig) ListFiles(subpath string) ([]string, error) {
+	dir := path.Join(c.Path, subpath)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read nfs dir '%s'", dir)
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names, nil
+}
+
+func (c *NFSConfig) ReadFile(subpath string) ([]byte, error) {
+	return os.ReadFile(path.Join(c.Path, subpath))
+}
@@ -177,6 +177,8 @@ var SqliteTables = []interface{}{
 	sqlite.UpgradePkgInfo{},
 	sqlite.UpgradePkgChunk{},
 	sqlite.SeekdbStandbyPeer{},
+	sqlite.SeekdbFailoverConfig{},
+	sqlite.SeekdbFailoverEvent{},
 }
 
 // MigrateSqliteTables will check if the sqlite tables exist, if not, it will create them.
@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package oceanbase
+
+import "time"
+
+// DbaObArchivelog maps to oceanbase.DBA_OB_ARCHIVELOG, the archive log status of seekdb.
+type DbaObArchivelog struct {
+	DestNo               int        `gorm:"column:DEST_NO"`
+	Status               string     `gorm:"column:STATUS"`
+	StartScnDisplay      *time.Time `gorm:"column:START_SCN_DISPLAY"`
+	CheckpointScnDisplay *time.Time `gorm:"column:CHECKPOINT_SCN_DISPLAY"`
+	Delay                float64    `gorm:"column:DELAY"`
+	Path                 string     `gorm:"column:PATH"`
+	Comment              string     `gorm:"column:COMMENT"`
+}
+
+func (DbaObArchivelog) TableName() string {
+	return "oceanbase.DBA_OB_ARCHIVELOG"
+}
+
+// DbaObBackupJob maps to oceanbase.DBA_OB_BACKUP_JOBS and DBA_OB_BACKUP_JOB_HISTORY,
+// which share the same columns for the running and the finished backup jobs.
+type DbaObBackupJob struct {
+	JobID          int64      `gorm:"column:JOB_ID"`
+	BackupSetID    int64      `gorm:"column:BACKUP_SET_ID"`
+	PlusArchivelog string     `gorm:"column:PLUS_ARCHIVELOG"`
+	BackupType     string     `gorm:"column:BACKUP_TYPE"`
+	StartTimestamp *time.Time `gorm:"column:START_TIMESTAMP"`
+	EndTimestamp   *time.Time `gorm:"column:END_TIMESTAMP"`
+	Status         string     `gorm:"column:STATUS"`
+	Result         int64      `gorm:"column:RESULT"`
+	Comment        string     `gorm:"column:COMMENT"`
+	Path           string     `gorm:"column:PATH"`
+}
+
+// DbaObRestoreJob maps to oceanbase.DBA_OB_RESTORE_PROGRESS and DBA_OB_RESTORE_HISTORY.
+// Progress columns are only reported by the former and the finish columns by the latter.
+type DbaObRestoreJob struct {
+	JobID             int64  `gorm:"column:JOB_ID"`
+	RestoreScn        int64  `gorm:"column:RESTORE_SCN"`
+	RestoreScnDisplay string `gorm:"column:RESTORE_SCN_DISPLAY"`
+	Status            string `gorm:"column:STATUS"`
+	StartTimestamp    string `gorm:"column:START_TIMESTAMP"`
+	FinishTimestamp   string `gorm:"column:FINISH_TIMESTAMP"`
+	BackupSetList     string `gorm:"column:BACKUP_SET_LIST"`
+	BackupPieceList   string `gorm:"column:BACKUP_PIECE_LIST"`
+	RecoverProgress   string `gorm:"column:RECOVER_PROGRESS"`
+	RestoreProgress   string `gorm:"column:RESTORE_PROGRESS"`
+	Comment           string `gorm:"column:COMMENT"`
+}
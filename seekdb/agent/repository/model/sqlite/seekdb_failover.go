@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlite
+
+import "time"
+
+// SeekdbFailoverConfig holds the automatic failover settings of this node.
+// The table keeps a single row whose ID is always 1.
+type SeekdbFailoverConfig struct {
+	ID                   int64 `gorm:"primaryKey"`
+	Enabled              bool  `gorm:"not null;default:false"`
+	OutageWindowSeconds  int   `gorm:"type:bigint;not null"`
+	MaxLagSeconds        int   `gorm:"type:bigint;not null"`
+	ProbeIntervalSeconds int   `gorm:"type:bigint;not null"`
+	PrimarySqlPort       int   `gorm:"type:bigint;not null"`
+	UpdatedAt            time.Time
+}
+
+// SeekdbFailoverEvent records one event of the failover controller.
+// A FAILOVER_TRIGGERED event keeps the token and public key of the old primary
+// until it has been fenced, because the peer record is removed by Activate.
+type SeekdbFailoverEvent struct {
+	ID              int64  `gorm:"primaryKey;autoIncrement"`
+	EventType       string `gorm:"type:varchar(32);not null"`
+	PeerHost        string `gorm:"type:varchar(128)"`
+	PeerObshellPort int    `gorm:"type:bigint"`
+	Message         string `gorm:"type:text"`
+	DagID           string `gorm:"type:varchar(64)"`
+	FencePending    bool   `gorm:"not null;default:false"`
+	PeerToken       string `gorm:"type:text"`
+	PeerPublicKey   string `gorm:"type:text"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
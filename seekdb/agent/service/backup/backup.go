@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"fmt"
+	"strings"
+
+	oceanbasedb "github.com/oceanbase/obshell/seekdb/agent/repository/db/oceanbase"
+	"github.com/oceanbase/obshell/seekdb/agent/repository/model/oceanbase"
+)
+
+func (s *BackupService) execOBSql(sql string) error {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	return db.Exec(sql).Error
+}
+
+// SetDataBackupDest issues ALTER SYSTEM SET DATA_BACKUP_DEST.
+func (s *BackupService) SetDataBackupDest(dest string) error {
+	return s.execOBSql(fmt.Sprintf("ALTER SYSTEM SET DATA_BACKUP_DEST = '%s'", dest))
+}
+
+// SetLogArchiveDest issues ALTER SYSTEM SET LOG_ARCHIVE_DEST, binding is optional.
+func (s *BackupService) SetLogArchiveDest(location, binding string) error {
+	subsql := fmt.Sprintf("LOCATION=%s", location)
+	if binding != "" {
+		subsql = fmt.Sprintf("%s BINDING=%s", subsql, binding)
+	}
+	return s.execOBSql(fmt.Sprintf("ALTER SYSTEM SET LOG_ARCHIVE_DEST = '%s'", subsql))
+}
+
+// SetLogArchiveConcurrency issues ALTER SYSTEM SET LOG_ARCHIVE_CONCURRENCY.
+func (s *BackupService) SetLogArchiveConcurrency(concurrency int) error {
+	return s.execOBSql(fmt.Sprintf("ALTER SYSTEM SET LOG_ARCHIVE_CONCURRENCY = %d", concurrency))
+}
+
+// SetArchiveLagTarget issues ALTER SYSTEM SET ARCHIVE_LAG_TARGET.
+func (s *BackupService) SetArchiveLagTarget(target string) error {
+	return s.execOBSql(fmt.Sprintf("ALTER SYSTEM SET ARCHIVE_LAG_TARGET = '%s'", target))
+}
+
+// GetDataBackupDest returns the configured data backup destination, which contains the secret.
+func (s *BackupService) GetDataBackupDest() (value string, err error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return "", err
+	}
+	err = db.Table(DBA_OB_BACKUP_PARAMETER).Where("NAME = 'data_backup_dest'").Select("VALUE").Scan(&value).Error
+	return
+}
+
+// GetLogArchiveDest returns the configured archive log destination, which contains the secret.
+func (s *BackupService) GetLogArchiveDest() (value string, err error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return "", err
+	}
+	err = db.Table(DBA_OB_ARCHIVE_DEST).Where("NAME = 'path'").Select("VALUE").Scan(&value).Error
+	return
+}
+
+// GetArchiveLog returns the archive log status, nil if archive log has never been opened.
+func (s *BackupService) GetArchiveLog() (*oceanbase.DbaObArchivelog, error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	var rows []oceanbase.DbaObArchivelog
+	if err = db.Table(DBA_OB_ARCHIVELOG).Order("DEST_NO").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
+
+// OpenArchiveLog issues ALTER SYSTEM ARCHIVELOG.
+func (s *BackupService) OpenArchiveLog() error {
+	return s.execOBSql("ALTER SYSTEM ARCHIVELOG")
+}
+
+// StartBackup issues ALTER SYSTEM BACKUP [INCREMENTAL] DATABASE.
+// The backup set is encrypted when encryption is not empty.
+func (s *BackupService) StartBackup(incremental bool, encryption string, plusArchive bool) error {
+	sql := "ALTER SYSTEM BACKUP DATABASE"
+	if incremental {
+		sql = "ALTER SYSTEM BACKUP INCREMENTAL DATABASE"
+	}
+	if plusArchive {
+		sql = fmt.Sprintf("%s PLUS ARCHIVELOG", sql)
+	}
+	if encryption != "" {
+		encryption = strings.ReplaceAll(encryption, "\"", "\\\"")
+		sql = fmt.Sprintf("SET ENCRYPTION ON IDENTIFIED BY \"%s\" ONLY; %s", encryption, sql)
+	}
+	return s.execOBSql(sql)
+}
+
+// GetRunningBackupJob returns the running backup job, nil if there is none.
+func (s *BackupService) GetRunningBackupJob() (*oceanbase.DbaObBackupJob, error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	var jobs []oceanbase.DbaObBackupJob
+	if err = db.Table(DBA_OB_BACKUP_JOBS).Order("JOB_ID DESC").Limit(1).Scan(&jobs).Error; err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	return &jobs[0], nil
+}
+
+// GetBackupJobHistory returns the finished backup job by id, nil if it is not finished yet.
+func (s *BackupService) GetBackupJobHistory(jobID int64) (*oceanbase.DbaObBackupJob, error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	var jobs []oceanbase.DbaObBackupJob
+	if err = db.Table(DBA_OB_BACKUP_JOB_HISTORY).Where("JOB_ID = ?", jobID).Scan(&jobs).Error; err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	return &jobs[0], nil
+}
+
+// ListBackupJobHistory returns the latest finished backup jobs, newest first.
+func (s *BackupService) ListBackupJobHistory(limit int) (jobs []oceanbase.DbaObBackupJob, err error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = db.Table(DBA_OB_BACKUP_JOB_HISTORY).Order("JOB_ID DESC").Limit(limit).Scan(&jobs).Error
+	return
+}
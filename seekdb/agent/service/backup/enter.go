@@ -0,0 +1,32 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+// BackupService issues the backup, archive log and restore SQL commands
+// of seekdb and queries the corresponding views.
+type BackupService struct{}
+
+const (
+	DBA_OB_ARCHIVELOG         = "oceanbase.DBA_OB_ARCHIVELOG"
+	DBA_OB_ARCHIVE_DEST       = "oceanbase.DBA_OB_ARCHIVE_DEST"
+	DBA_OB_BACKUP_PARAMETER   = "oceanbase.DBA_OB_BACKUP_PARAMETER"
+	DBA_OB_BACKUP_JOBS        = "oceanbase.DBA_OB_BACKUP_JOBS"
+	DBA_OB_BACKUP_JOB_HISTORY = "oceanbase.DBA_OB_BACKUP_JOB_HISTORY"
+	DBA_OB_RESTORE_PROGRESS   = "oceanbase.DBA_OB_RESTORE_PROGRESS"
+	DBA_OB_RESTORE_HISTORY    = "oceanbase.DBA_OB_RESTORE_HISTORY"
+	DBA_OB_DATABASES          = "oceanbase.DBA_OB_DATABASES"
+)
@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package backup
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	oceanbasedb "github.com/oceanbase/obshell/seekdb/agent/repository/db/oceanbase"
+	"github.com/oceanbase/obshell/seekdb/agent/repository/model/oceanbase"
+)
+
+// builtinDatabases are the databases created by seekdb itself, a fresh
+// instance has no database other than them.
+var builtinDatabases = []string{"information_schema", "oceanbase", "mysql", "test", "__public", "__recyclebin", "SYS", "LBACSYS", "ORAAUDITOR"}
+
+// ListUserDatabases returns the databases created by users.
+func (s *BackupService) ListUserDatabases() (names []string, err error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = db.Table(DBA_OB_DATABASES).Where("DATABASE_NAME NOT IN ?", builtinDatabases).Pluck("DATABASE_NAME", &names).Error
+	return
+}
+
+// StartRestore issues ALTER SYSTEM RESTORE from the data backup and archive log uris.
+// The restore stops at the timestamp if set, otherwise at the end of the archive log.
+func (s *BackupService) StartRestore(dataBackupUri, archiveLogUri string, timestamp *time.Time, decryption []string) error {
+	sql := fmt.Sprintf("ALTER SYSTEM RESTORE FROM \"%s, %s\"", dataBackupUri, archiveLogUri)
+	if timestamp != nil {
+		sql = fmt.Sprintf("%s UNTIL TIME = \"%s\"", sql, timestamp.Format("2006-01-02 15:04:05.000000"))
+	}
+	if len(decryption) > 0 {
+		pwds := make([]string, 0, len(decryption))
+		for _, pwd := range decryption {
+			pwds = append(pwds, fmt.Sprintf("'%s'", strings.ReplaceAll(pwd, "'", "\\'")))
+		}
+		sql = fmt.Sprintf("SET DECRYPTION IDENTIFIED BY %s; %s", strings.Join(pwds, ","), sql)
+	}
+	return s.execOBSql(sql)
+}
+
+// GetRestoreProgress returns the running restore job, nil if there is none.
+func (s *BackupService) GetRestoreProgress() (*oceanbase.DbaObRestoreJob, error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	var jobs []oceanbase.DbaObRestoreJob
+	if err = db.Table(DBA_OB_RESTORE_PROGRESS).Order("JOB_ID DESC").Limit(1).Scan(&jobs).Error; err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	return &jobs[0], nil
+}
+
+// GetLastRestoreHistory returns the latest finished restore job, nil if there is none.
+func (s *BackupService) GetLastRestoreHistory() (*oceanbase.DbaObRestoreJob, error) {
+	db, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	var jobs []oceanbase.DbaObRestoreJob
+	if err = db.Table(DBA_OB_RESTORE_HISTORY).Order("JOB_ID DESC").Limit(1).Scan(&jobs).Error; err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	return &jobs[0], nil
+}
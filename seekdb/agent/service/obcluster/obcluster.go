@@ -18,6 +18,7 @@ package obcluster
 
 import (
 	"encoding/hex"
+	"fmt"
 	"mime/multipart"
 	"os"
 	"strings"
@@ -255,6 +256,20 @@ func (obclusterService *ObclusterService) GetObParametersForUpgrade(params []str
 	return
 }
 
+func (obclusterService *ObclusterService) RestoreParamsForUpgrade(params []oceanbase.ObParameters) (err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	for _, param := range params {
+		sql := fmt.Sprintf("ALTER SYSTEM SET %s = '%s'", param.Name, strings.ReplaceAll(param.Value, "'", "\\'"))
+		if err = oceanbaseDb.Exec(sql).Error; err != nil {
+			return errors.Wrapf(err, "restore parameter %s failed", param.Name)
+		}
+	}
+	return nil
+}
+
 func (*ObclusterService) GetAllUnhiddenParameters() ([]oceanbase.ObParameters, error) {
 	oceanbaseDb, err := oceanbasedb.GetInstance()
 	if err != nil {
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package standby
+
+import (
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	sqlitedb "github.com/oceanbase/obshell/seekdb/agent/repository/db/sqlite"
+	"github.com/oceanbase/obshell/seekdb/agent/repository/model/sqlite"
+)
+
+const failoverConfigID = 1
+
+// GetFailoverConfig returns the failover config, or the disabled default
+// config if it has never been set.
+func (s *StandbyService) GetFailoverConfig() (*sqlite.SeekdbFailoverConfig, error) {
+	db, err := sqlitedb.GetSqliteInstance()
+	if err != nil {
+		return nil, err
+	}
+	var configs []sqlite.SeekdbFailoverConfig
+	if err = db.Where("id = ?", failoverConfigID).Find(&configs).Error; err != nil {
+		return nil, err
+	}
+	if len(configs) == 0 {
+		return &sqlite.SeekdbFailoverConfig{
+			ID:                   failoverConfigID,
+			OutageWindowSeconds:  constant.DefaultFailoverOutageWindowSeconds,
+			MaxLagSeconds:        constant.DefaultFailoverMaxLagSeconds,
+			ProbeIntervalSeconds: constant.DefaultFailoverProbeIntervalSeconds,
+			PrimarySqlPort:       constant.DEFAULT_MYSQL_PORT,
+		}, nil
+	}
+	return &configs[0], nil
+}
+
+// SaveFailoverConfig inserts or updates the failover config.
+func (s *StandbyService) SaveFailoverConfig(config *sqlite.SeekdbFailoverConfig) error {
+	db, err := sqlitedb.GetSqliteInstance()
+	if err != nil {
+		return err
+	}
+	config.ID = failoverConfigID
+	return db.Save(config).Error
+}
+
+// AddFailoverEvent records a failover event.
+func (s *StandbyService) AddFailoverEvent(event *sqlite.SeekdbFailoverEvent) error {
+	db, err := sqlitedb.GetSqliteInstance()
+	if err != nil {
+		return err
+	}
+	return db.Create(event).Error
+}
+
+// ListFailoverEvents returns the latest failover events, newest first.
+func (s *StandbyService) ListFailoverEvents(limit int) ([]sqlite.SeekdbFailoverEvent, error) {
+	db, err := sqlitedb.GetSqliteInstance()
+	if err != nil {
+		return nil, err
+	}
+	var events []sqlite.SeekdbFailoverEvent
+	err = db.Order("id DESC").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+// GetFencePendingEvents returns the failovers whose old primary has not been fenced yet.
+func (s *StandbyService) GetFencePendingEvents() ([]sqlite.SeekdbFailoverEvent, error) {
+	db, err := sqlitedb.GetSqliteInstance()
+	if err != nil {
+		return nil, err
+	}
+	var events []sqlite.SeekdbFailoverEvent
+	err = db.Where("fence_pending = ?", true).Find(&events).Error
+	return events, err
+}
+
+// FinishFence marks the old primary of the failover as fenced and drops its credentials.
+func (s *StandbyService) FinishFence(id int64) error {
+	db, err := sqlitedb.GetSqliteInstance()
+	if err != nil {
+		return err
+	}
+	return db.Model(&sqlite.SeekdbFailoverEvent{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"fence_pending": false, "peer_token": "", "peer_public_key": ""}).Error
+}
+
+// SetReadOnly executes SET GLOBAL read_only = ON, which rejects writes from
+// users without the SUPER privilege.
+func (s *StandbyService) SetReadOnly() error {
+	return s.execOBSql("SET GLOBAL read_only = ON")
+}
@@ -17,6 +17,9 @@
 package standby
 
 import (
+	log "github.com/sirupsen/logrus"
+
+	"github.com/oceanbase/obshell/seekdb/agent/lib/binary"
 	oceanbasedb "github.com/oceanbase/obshell/seekdb/agent/repository/db/oceanbase"
 	"github.com/oceanbase/obshell/seekdb/param"
 )
@@ -51,11 +54,19 @@ func (s *StandbyService) GetLocalStatus() (param.LocalStandbyStatus, error) {
 	var clusterName string
 	_ = db.Raw("SELECT VALUE FROM oceanbase.V$OB_PARAMETERS WHERE NAME = ? LIMIT 1", "cluster").Scan(&clusterName).Error
 
+	// The build version lets the primary verify that its standby has been
+	// upgraded first.
+	version, _, err := binary.GetMyOBVersion()
+	if err != nil {
+		log.Warnf("get seekdb build version failed: %v", err)
+	}
+
 	return param.LocalStandbyStatus{
 		Role:             row.Role,
 		InstanceName:     clusterName,
 		LogRestoreSource: row.LogRestoreSource,
 		SyncScn:          row.SyncScn,
 		ReadableScn:      row.ReadableScn,
+		Version:          version,
 	}, nil
 }
@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tenant
+
+import (
+	"fmt"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	oceanbasedb "github.com/oceanbase/obshell/seekdb/agent/repository/db/oceanbase"
+	"github.com/oceanbase/obshell/seekdb/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/seekdb/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+const (
+	// Slow executions are grouped by sql_id, the time window is applied to the
+	// end time of the execution (request_time + elapsed_time).
+	TOP_SLOW_SQL_SQL = `
+        SELECT
+            sql_id,
+            db_name,
+            MAX(user_name) AS user_name,
+            MAX(query_sql) AS query_sql,
+            COUNT(*) AS count,
+            MAX(elapsed_time) AS max_elapsed_time,
+            AVG(elapsed_time) AS avg_elapsed_time
+        FROM
+            oceanbase.GV$OB_SQL_AUDIT
+        WHERE
+            char_length(sql_id) != 0
+            AND is_inner_sql = 0
+            AND elapsed_time > ?
+            AND (request_time + elapsed_time) > ?
+            AND (request_time + elapsed_time) < ?
+        GROUP BY
+            sql_id, db_name
+        ORDER BY
+            count DESC, max_elapsed_time DESC
+        LIMIT ?
+    `
+)
+
+// GetSessions returns the sessions matching the query of the current page
+// and the total count of the matched sessions.
+func (t *TenantService) GetSessions(p *param.QueryTenantSessionParam) (sessions []oceanbase.TenantSession, total int64, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, 0, err
+	}
+	query := oceanbaseDb.Model(&oceanbase.TenantSession{})
+	if p.User != "" {
+		query = query.Where("USER LIKE ?", "%"+p.User+"%")
+	}
+	if p.Db != "" {
+		query = query.Where("DB LIKE ?", "%"+p.Db+"%")
+	}
+	if p.Host != "" {
+		query = query.Where("USER_CLIENT_IP LIKE ?", "%"+p.Host+"%")
+	}
+	if p.SessionId != 0 {
+		query = query.Where("ID = ?", p.SessionId)
+	}
+	if p.ActiveOnly {
+		query = query.Where("STATE = ?", constant.SESSION_STATE_ACTIVE)
+	}
+	if err = query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if p.SortBy != "" && p.SortOrder != "" {
+		query = query.Order(fmt.Sprintf("%s %s", p.SortBy, p.SortOrder))
+	}
+	offset := (p.Page - 1) * p.Size
+	err = query.Offset(int(offset)).Limit(int(p.Size)).Find(&sessions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return sessions, total, nil
+}
+
+func (t *TenantService) GetSession(sessionId string) (session *oceanbase.TenantSession, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Model(&oceanbase.TenantSession{}).Where("ID = ?", sessionId).Scan(&session).Error
+	return
+}
+
+func (t *TenantService) ListSessions() (sessions []oceanbase.TenantSession, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Model(&oceanbase.TenantSession{}).Scan(&sessions).Error
+	return
+}
+
+func (t *TenantService) KillSession(sessionId int) error {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	return oceanbaseDb.Exec("KILL ?", sessionId).Error
+}
+
+func (t *TenantService) KillSessionQuery(sessionId int) error {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return err
+	}
+	return oceanbaseDb.Exec("KILL QUERY ?", sessionId).Error
+}
+
+func (t *TenantService) ListDeadLockEvents() (deadlocks []oceanbase.DeadLockEvent, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Model(&oceanbase.DeadLockEvent{}).Scan(&deadlocks).Error
+	return
+}
+
+// GetTopSlowSqls returns at most top slow SQLs which finished between startTime
+// and endTime (both in microseconds), ordered by the number of slow executions.
+func (t *TenantService) GetTopSlowSqls(top int, startTime int64, endTime int64) (res []bo.SlowSql, err error) {
+	oceanbaseDb, err := oceanbasedb.GetInstance()
+	if err != nil {
+		return nil, err
+	}
+	err = oceanbaseDb.Raw(TOP_SLOW_SQL_SQL, constant.SLOW_SQL_THRESHOLD, startTime, endTime, top).Scan(&res).Error
+	return
+}
@@ -0,0 +1,284 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package instance
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/engine/task"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/http"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/path"
+	"github.com/oceanbase/obshell/seekdb/client/command"
+	clientconst "github.com/oceanbase/obshell/seekdb/client/constant"
+	cmdlib "github.com/oceanbase/obshell/seekdb/client/lib/cmd"
+	"github.com/oceanbase/obshell/seekdb/client/lib/stdio"
+	"github.com/oceanbase/obshell/seekdb/client/utils/api"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+type BackupConfigFlags struct {
+	backupBaseUri         string
+	dataBaseUri           string
+	archiveBaseUri        string
+	binding               string
+	logArchiveConcurrency int
+	archiveLagTarget      string
+	verbose               bool
+}
+
+type BackupStartFlags struct {
+	mode        string
+	encryption  string
+	plusArchive bool
+	verbose     bool
+}
+
+type BackupHistoryFlags struct {
+	limit   int
+	verbose bool
+}
+
+func newBackupCmd() *cobra.Command {
+	backupCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_BACKUP,
+		Short: "Manage the backup of seekdb.",
+	})
+	backupCmd.AddCommand(newBackupConfigCmd())
+	backupCmd.AddCommand(newBackupStartCmd())
+	backupCmd.AddCommand(newBackupShowCmd())
+	backupCmd.AddCommand(newBackupHistoryCmd())
+	return backupCmd.Command
+}
+
+func newBackupConfigCmd() *cobra.Command {
+	opts := &BackupConfigFlags{}
+	configCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_CONFIG,
+		Short:   "Set the backup destinations and archive log parameters.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return backupConfig(cmd, opts)
+		}),
+		Example: backupConfigCmdExample(),
+	})
+
+	configCmd.Flags().SortFlags = false
+	configCmd.VarsPs(&opts.backupBaseUri, []string{FLAG_BACKUP_BASE_URI, FLAG_BACKUP_BASE_URI_SH}, "", "The base uri of the backup, the data and clog directories are created under it.", false)
+	configCmd.VarsPs(&opts.dataBaseUri, []string{FLAG_DATA_BASE_URI}, "", "The uri of the data backup, overrides the data directory of the backup base uri.", false)
+	configCmd.VarsPs(&opts.archiveBaseUri, []string{FLAG_ARCHIVE_BASE_URI}, "", "The uri of the archive log, overrides the clog directory of the backup base uri.", false)
+	configCmd.VarsPs(&opts.binding, []string{FLAG_BINDING}, "", "The binding mode of the archive log, 'optional' or 'mandatory'.", false)
+	configCmd.VarsPs(&opts.logArchiveConcurrency, []string{FLAG_LOG_ARCHIVE_CONCURRENCY}, 0, "The concurrency of the archive log.", false)
+	configCmd.VarsPs(&opts.archiveLagTarget, []string{FLAG_ARCHIVE_LAG_TARGET}, "", "The archive lag target, such as '120s'.", false)
+	configCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return configCmd.Command
+}
+
+func backupConfig(cmd *cobra.Command, flags *BackupConfigFlags) error {
+	p := &param.BackupConfigParam{}
+	if flags.backupBaseUri != "" {
+		p.BackupBaseUri = &flags.backupBaseUri
+	}
+	if flags.dataBaseUri != "" {
+		p.DataBaseUri = &flags.dataBaseUri
+	}
+	if flags.archiveBaseUri != "" {
+		p.ArchiveBaseUri = &flags.archiveBaseUri
+	}
+	if flags.binding != "" {
+		p.Binding = &flags.binding
+	}
+	if cmd.Flags().Changed(FLAG_LOG_ARCHIVE_CONCURRENCY) {
+		p.LogArchiveConcurrency = &flags.logArchiveConcurrency
+	}
+	if flags.archiveLagTarget != "" {
+		p.ArchiveLagTarget = &flags.archiveLagTarget
+	}
+
+	uri := constant.URI_SEEKDB_BACKUP_API_PREFIX + constant.URI_CONFIG
+	stdio.Verbosef("Calling API %s", uri)
+	var dag task.DagDetailDTO
+	if err := http.SendPutRequestViaUnixSocket(path.ObshellSocketPath(), uri, p, &dag); err != nil {
+		return err
+	}
+	return api.NewDagHandler(&dag).PrintDagStage()
+}
+
+func newBackupStartCmd() *cobra.Command {
+	opts := &BackupStartFlags{}
+	startCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_START,
+		Short:   "Start a backup of seekdb, the archive log is opened if needed.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return backupStart(opts)
+		}),
+		Example: backupStartCmdExample(),
+	})
+
+	startCmd.Flags().SortFlags = false
+	startCmd.VarsPs(&opts.mode, []string{FLAG_MODE, FLAG_MODE_SH}, constant.BACKUP_MODE_FULL, "The backup mode, 'full' or 'incremental'.", false)
+	startCmd.VarsPs(&opts.encryption, []string{FLAG_ENCRYPTION}, "", "The password to encrypt the backup set.", false)
+	startCmd.VarsPs(&opts.plusArchive, []string{FLAG_PLUS_ARCHIVE}, false, "Back up the archive log together with the data.", false)
+	startCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return startCmd.Command
+}
+
+func backupStart(flags *BackupStartFlags) error {
+	p := &param.BackupParam{
+		Mode:        flags.mode,
+		Encryption:  flags.encryption,
+		PlusArchive: flags.plusArchive,
+	}
+	_, err := api.CallApiAndPrintStage(constant.URI_SEEKDB_BACKUP_API_PREFIX, p)
+	return err
+}
+
+func newBackupShowCmd() *cobra.Command {
+	var verbose bool
+	showCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_SHOW,
+		Short:   "Show the backup destinations, archive log status and backup jobs.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(verbose)
+			stdio.SetSilenceMode(false)
+			return backupShow()
+		}),
+		Example: `  obshell seekdb backup show`,
+	})
+
+	showCmd.Flags().SortFlags = false
+	showCmd.VarsPs(&verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return showCmd.Command
+}
+
+func backupShow() error {
+	uri := constant.URI_SEEKDB_BACKUP_API_PREFIX + constant.URI_OVERVIEW
+	stdio.Verbosef("Calling API %s", uri)
+	var overview param.BackupOverview
+	if err := http.SendGetRequestViaUnixSocket(path.ObshellSocketPath(), uri, nil, &overview); err != nil {
+		return err
+	}
+
+	archiveStatus, checkpoint := "N/A", "N/A"
+	if overview.ArchiveLog != nil {
+		archiveStatus = overview.ArchiveLog.Status
+		if overview.ArchiveLog.CheckpointScn != nil {
+			checkpoint = overview.ArchiveLog.CheckpointScn.Format(time.DateTime)
+		}
+	}
+	stdio.PrintTable([]string{"Item", "Value"}, [][]string{
+		{"Data Backup Dest", valueOrNA(overview.DataBackupDest)},
+		{"Log Archive Dest", valueOrNA(overview.LogArchiveDest)},
+		{"Archive Log Status", archiveStatus},
+		{"Archive Checkpoint", checkpoint},
+	})
+
+	var jobs []param.BackupJob
+	if overview.RunningJob != nil {
+		jobs = append(jobs, *overview.RunningJob)
+	}
+	if overview.LastJob != nil {
+		jobs = append(jobs, *overview.LastJob)
+	}
+	printBackupJobs(jobs)
+	return nil
+}
+
+func newBackupHistoryCmd() *cobra.Command {
+	opts := &BackupHistoryFlags{}
+	historyCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_HISTORY,
+		Short:   "List the finished backup jobs.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return backupHistory(opts)
+		}),
+		Example: `  obshell seekdb backup history -l 10`,
+	})
+
+	historyCmd.Flags().SortFlags = false
+	historyCmd.VarsPs(&opts.limit, []string{FLAG_LIMIT, FLAG_LIMIT_SH}, constant.BACKUP_HISTORY_DEFAULT_LIMIT, "The max number of jobs to list.", false)
+	historyCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return historyCmd.Command
+}
+
+func backupHistory(flags *BackupHistoryFlags) error {
+	uri := fmt.Sprintf("%s%s?limit=%d", constant.URI_SEEKDB_BACKUP_API_PREFIX, constant.URI_HISTORY, flags.limit)
+	stdio.Verbosef("Calling API %s", uri)
+	var jobs []param.BackupJob
+	if err := http.SendGetRequestViaUnixSocket(path.ObshellSocketPath(), uri, nil, &jobs); err != nil {
+		return err
+	}
+	printBackupJobs(jobs)
+	return nil
+}
+
+func printBackupJobs(jobs []param.BackupJob) {
+	if len(jobs) == 0 {
+		stdio.Print("No backup job found.")
+		return
+	}
+	data := make([][]string, 0, len(jobs))
+	for _, job := range jobs {
+		start, end := "", ""
+		if job.StartTimestamp != nil {
+			start = job.StartTimestamp.Format(time.DateTime)
+		}
+		if job.EndTimestamp != nil {
+			end = job.EndTimestamp.Format(time.DateTime)
+		}
+		data = append(data, []string{
+			strconv.FormatInt(job.JobID, 10),
+			strconv.FormatInt(job.BackupSetID, 10),
+			job.BackupType,
+			job.Status,
+			start,
+			end,
+			job.Comment,
+		})
+	}
+	stdio.PrintTable([]string{"Job ID", "Backup Set ID", "Type", "Status", "Start Time", "End Time", "Comment"}, data)
+}
+
+func valueOrNA(s string) string {
+	if s == "" {
+		return "N/A"
+	}
+	return s
+}
+
+func backupConfigCmdExample() string {
+	return `  obshell seekdb backup config -u file:///data/backup
+  obshell seekdb backup config -u "s3://bucket/backup?host=s3.us-east-1.amazonaws.com&access_id=xxx&access_key=xxx&s3_region=us-east-1" --archive_lag_target 120s`
+}
+
+func backupStartCmdExample() string {
+	return `  obshell seekdb backup start
+  obshell seekdb backup start -m incremental --plus_archive`
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compaction
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/http"
+	"github.com/oceanbase/obshell/seekdb/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/seekdb/client/command"
+	clientconst "github.com/oceanbase/obshell/seekdb/client/constant"
+	cmdlib "github.com/oceanbase/obshell/seekdb/client/lib/cmd"
+	"github.com/oceanbase/obshell/seekdb/client/lib/stdio"
+	"github.com/oceanbase/obshell/seekdb/client/utils/api"
+	"github.com/oceanbase/obshell/seekdb/client/utils/printer"
+)
+
+const (
+	CMD_COMPACTION = "compaction"
+
+	// obshell seekdb compaction show
+	CMD_SHOW = "show"
+
+	// obshell seekdb compaction start
+	CMD_START = "start"
+
+	// obshell seekdb compaction clear-error
+	CMD_CLEAR_ERROR = "clear-error"
+)
+
+type compactionShowFlags struct {
+	output  string
+	verbose bool
+}
+
+func NewCompactionCmd() *cobra.Command {
+	compactionCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_COMPACTION,
+		Short: "Display and manage the major compaction of seekdb.",
+	})
+	compactionCmd.AddCommand(newShowCmd())
+	compactionCmd.AddCommand(newStartCmd())
+	compactionCmd.AddCommand(newClearErrorCmd())
+	return compactionCmd.Command
+}
+
+func newShowCmd() *cobra.Command {
+	opts := &compactionShowFlags{}
+	showCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_SHOW,
+		Short:   "Show the status of the major compaction.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if err := printer.CheckOutputFormat(opts.output); err != nil {
+				return err
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			return showCompaction(opts.output)
+		}),
+		Example: `  obshell seekdb compaction show`,
+	})
+	showCmd.Flags().SortFlags = false
+	showCmd.VarsPs(&opts.output, []string{clientconst.FLAG_OUTPUT, clientconst.FLAG_OUTPUT_SH}, clientconst.OUTPUT_FORMAT_TABLE, "Output format, 'table' or 'json'.", false)
+	showCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return showCmd.Command
+}
+
+func showCompaction(output string) error {
+	var compaction bo.TenantCompaction
+	if err := api.CallApiWithMethod(http.GET, constant.URI_SEEKDB_API_PREFIX+constant.URI_COMPACTION, nil, &compaction); err != nil {
+		return err
+	}
+	return printer.PrintWithFormat(output, compaction, func() {
+		stdio.PrintTable([]string{"Item", "Value"}, [][]string{
+			{"Status", compaction.Status},
+			{"Frozen SCN", fmt.Sprint(compaction.FrozenScn)},
+			{"Frozen Time", formatTime(compaction.FrozenTime)},
+			{"Global Broadcast SCN", fmt.Sprint(compaction.GlobalBroadcastScn)},
+			{"Last SCN", fmt.Sprint(compaction.LastScn)},
+			{"Start Time", formatTime(compaction.StartTime)},
+			{"Last Finish Time", formatTime(compaction.LastFinishTime)},
+			{"Is Error", compaction.IsError},
+			{"Is Suspended", compaction.IsSuspended},
+		})
+	})
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format(time.DateTime)
+}
+
+func newStartCmd() *cobra.Command {
+	var verbose bool
+	startCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_START,
+		Short:   "Trigger a major compaction.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(verbose)
+			stdio.StartLoading("trigger major compaction")
+			if err := api.CallApiWithMethod(http.POST, constant.URI_SEEKDB_API_PREFIX+constant.URI_COMPACT, nil, nil); err != nil {
+				return err
+			}
+			stdio.LoadSuccess("trigger major compaction")
+			return nil
+		}),
+		Example: `  obshell seekdb compaction start`,
+	})
+	startCmd.VarsPs(&verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return startCmd.Command
+}
+
+func newClearErrorCmd() *cobra.Command {
+	var verbose bool
+	clearErrorCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_CLEAR_ERROR,
+		Short:   "Clear the error of the major compaction.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(verbose)
+			stdio.StartLoading("clear major compaction error")
+			if err := api.CallApiWithMethod(http.DELETE, constant.URI_SEEKDB_API_PREFIX+constant.URI_COMPACTION_ERROR, nil, nil); err != nil {
+				return err
+			}
+			stdio.LoadSuccess("clear major compaction error")
+			return nil
+		}),
+		Example: `  obshell seekdb compaction clear-error`,
+	})
+	clearErrorCmd.VarsPs(&verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return clearErrorCmd.Command
+}
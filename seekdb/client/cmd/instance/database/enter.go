@@ -0,0 +1,257 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package database
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/http"
+	"github.com/oceanbase/obshell/seekdb/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/seekdb/client/command"
+	clientconst "github.com/oceanbase/obshell/seekdb/client/constant"
+	"github.com/oceanbase/obshell/seekdb/client/lib/stdio"
+	"github.com/oceanbase/obshell/seekdb/client/utils/api"
+	"github.com/oceanbase/obshell/seekdb/client/utils/printer"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+const (
+	CMD_DATABASE = "database"
+
+	// obshell seekdb database create
+	CMD_CREATE = "create"
+	// obshell seekdb database drop
+	CMD_DROP = "drop"
+	// obshell seekdb database modify
+	CMD_MODIFY = "modify"
+	// obshell seekdb database show
+	CMD_SHOW = "show"
+
+	FLAG_COLLATION    = "collation"
+	FLAG_COLLATION_SH = "c"
+	FLAG_READ_ONLY    = "read_only"
+)
+
+type databaseFlags struct {
+	collation string
+	readOnly  bool
+	verbose   bool
+}
+
+type databaseShowFlags struct {
+	output  string
+	verbose bool
+}
+
+type databaseDropFlags struct {
+	skipConfirm bool
+	verbose     bool
+}
+
+func NewDatabaseCmd() *cobra.Command {
+	databaseCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_DATABASE,
+		Short: "Display and manage the databases of seekdb.",
+	})
+	databaseCmd.AddCommand(newCreateCmd())
+	databaseCmd.AddCommand(newDropCmd())
+	databaseCmd.AddCommand(newModifyCmd())
+	databaseCmd.AddCommand(newShowCmd())
+	return databaseCmd.Command
+}
+
+func newCreateCmd() *cobra.Command {
+	opts := &databaseFlags{}
+	createCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_CREATE,
+		Short: "Create a database.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.Occur(errors.ErrCliUsageError, "database name is required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			return createDatabase(cmd, args[0], opts)
+		}),
+		Example: `  obshell seekdb database create db1
+  obshell seekdb database create db1 -c utf8mb4_bin`,
+	})
+	createCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<database-name>"}
+	createCmd.Flags().SortFlags = false
+	createCmd.VarsPs(&opts.collation, []string{FLAG_COLLATION, FLAG_COLLATION_SH}, "", "The collation of the database.", false)
+	createCmd.VarsPs(&opts.readOnly, []string{FLAG_READ_ONLY}, false, "Create the database as read only.", false)
+	createCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return createCmd.Command
+}
+
+func createDatabase(cmd *cobra.Command, name string, opts *databaseFlags) error {
+	p := param.CreateDatabaseParam{DbName: name}
+	if opts.collation != "" {
+		p.Collation = &opts.collation
+	}
+	if cmd.Flags().Changed(FLAG_READ_ONLY) {
+		p.ReadOnly = &opts.readOnly
+	}
+	stdio.StartLoadingf("create database %s", name)
+	if err := api.CallApiWithMethod(http.POST, constant.URI_SEEKDB_API_PREFIX+constant.URI_DATABASE_GROUP, p, nil); err != nil {
+		return err
+	}
+	stdio.LoadSuccessf("create database %s", name)
+	return nil
+}
+
+func newDropCmd() *cobra.Command {
+	opts := &databaseDropFlags{}
+	dropCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_DROP,
+		Short: "Drop a database.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.Occur(errors.ErrCliUsageError, "database name is required")
+			}
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			stdio.SetVerboseMode(opts.verbose)
+			return dropDatabase(args[0])
+		}),
+		Example: `  obshell seekdb database drop db1`,
+	})
+	dropCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<database-name>"}
+	dropCmd.Flags().SortFlags = false
+	dropCmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt.", false)
+	dropCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return dropCmd.Command
+}
+
+func dropDatabase(name string) error {
+	pass, err := stdio.Confirmf("Please confirm if you need to drop database %s", name)
+	if err != nil {
+		return errors.Wrap(err, "ask for confirmation failed")
+	}
+	if !pass {
+		return nil
+	}
+	stdio.StartLoadingf("drop database %s", name)
+	if err := api.CallApiWithMethod(http.DELETE, constant.URI_SEEKDB_API_PREFIX+constant.URI_DATABASE_GROUP+"/"+name, nil, nil); err != nil {
+		return err
+	}
+	stdio.LoadSuccessf("drop database %s", name)
+	return nil
+}
+
+func newModifyCmd() *cobra.Command {
+	opts := &databaseFlags{}
+	modifyCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_MODIFY,
+		Short: "Modify the collation or read only attribute of a database.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.Occur(errors.ErrCliUsageError, "database name is required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			return modifyDatabase(cmd, args[0], opts)
+		}),
+		Example: `  obshell seekdb database modify db1 --read_only=true`,
+	})
+	modifyCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<database-name>"}
+	modifyCmd.Flags().SortFlags = false
+	modifyCmd.VarsPs(&opts.collation, []string{FLAG_COLLATION, FLAG_COLLATION_SH}, "", "The collation of the database.", false)
+	modifyCmd.VarsPs(&opts.readOnly, []string{FLAG_READ_ONLY}, false, "Whether the database is read only.", false)
+	modifyCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return modifyCmd.Command
+}
+
+func modifyDatabase(cmd *cobra.Command, name string, opts *databaseFlags) error {
+	p := param.ModifyDatabaseParam{}
+	if opts.collation != "" {
+		p.Collation = &opts.collation
+	}
+	if cmd.Flags().Changed(FLAG_READ_ONLY) {
+		p.ReadOnly = &opts.readOnly
+	}
+	if p.Collation == nil && p.ReadOnly == nil {
+		return errors.Occurf(errors.ErrCliUsageError, "at least one of '--%s' and '--%s' is required", FLAG_COLLATION, FLAG_READ_ONLY)
+	}
+	stdio.StartLoadingf("modify database %s", name)
+	if err := api.CallApiWithMethod(http.PUT, constant.URI_SEEKDB_API_PREFIX+constant.URI_DATABASE_GROUP+"/"+name, p, nil); err != nil {
+		return err
+	}
+	stdio.LoadSuccessf("modify database %s", name)
+	return nil
+}
+
+func newShowCmd() *cobra.Command {
+	opts := &databaseShowFlags{}
+	showCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_SHOW,
+		Short: "Show all databases or the specified database.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if err := printer.CheckOutputFormat(opts.output); err != nil {
+				return err
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			if len(args) > 0 {
+				return showDatabase(args[0], opts.output)
+			}
+			return listDatabases(opts.output)
+		}),
+		Example: `  obshell seekdb database show
+  obshell seekdb database show db1 -o json`,
+	})
+	showCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "[database-name]"}
+	showCmd.Flags().SortFlags = false
+	showCmd.VarsPs(&opts.output, []string{clientconst.FLAG_OUTPUT, clientconst.FLAG_OUTPUT_SH}, clientconst.OUTPUT_FORMAT_TABLE, "Output format, 'table' or 'json'.", false)
+	showCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return showCmd.Command
+}
+
+func listDatabases(output string) error {
+	databases := make([]bo.Database, 0)
+	if err := api.CallApiWithMethod(http.GET, constant.URI_SEEKDB_API_PREFIX+constant.URI_DATABASES_GROUP, nil, &databases); err != nil {
+		return err
+	}
+	return printer.PrintWithFormat(output, databases, func() {
+		printDatabases(databases)
+	})
+}
+
+func showDatabase(name string, output string) error {
+	var database bo.Database
+	if err := api.CallApiWithMethod(http.GET, constant.URI_SEEKDB_API_PREFIX+constant.URI_DATABASE_GROUP+"/"+name, nil, &database); err != nil {
+		return err
+	}
+	return printer.PrintWithFormat(output, database, func() {
+		printDatabases([]bo.Database{database})
+	})
+}
+
+func printDatabases(databases []bo.Database) {
+	data := make([][]string, 0, len(databases))
+	for _, db := range databases {
+		data = append(data, []string{
+			db.DbName,
+			db.Charset,
+			db.Collation,
+			strconv.FormatBool(db.ReadOnly),
+			time.Unix(db.CreateTime, 0).Format(time.DateTime),
+		})
+	}
+	stdio.PrintTable([]string{"Name", "Charset", "Collation", "Read Only", "Create Time"}, data)
+}
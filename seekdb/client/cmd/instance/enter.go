@@ -22,6 +22,13 @@ import (
 	"github.com/oceanbase/obshell/seekdb/agent/config"
 	"github.com/oceanbase/obshell/seekdb/agent/global"
 	ocsagentlog "github.com/oceanbase/obshell/seekdb/agent/log"
+	"github.com/oceanbase/obshell/seekdb/client/cmd/instance/compaction"
+	"github.com/oceanbase/obshell/seekdb/client/cmd/instance/database"
+	"github.com/oceanbase/obshell/seekdb/client/cmd/instance/parameter"
+	"github.com/oceanbase/obshell/seekdb/client/cmd/instance/pkg"
+	"github.com/oceanbase/obshell/seekdb/client/cmd/instance/standby"
+	"github.com/oceanbase/obshell/seekdb/client/cmd/instance/user"
+	"github.com/oceanbase/obshell/seekdb/client/cmd/instance/variable"
 	"github.com/oceanbase/obshell/seekdb/client/command"
 	clientconst "github.com/oceanbase/obshell/seekdb/client/constant"
 )
@@ -59,6 +66,34 @@ const (
 
 	// CMD_SHOW represents the "show" command used to display information about the cluster status.
 	CMD_SHOW = "show"
+
+	// CMD_BACKUP represents the "backup" command used to manage the backup of seekdb.
+	CMD_BACKUP = "backup"
+	// Subcommands of the "backup" command.
+	CMD_CONFIG  = "config"
+	CMD_HISTORY = "history"
+	// Flags for the "backup" command.
+	FLAG_BACKUP_BASE_URI         = "backup_base_uri"
+	FLAG_BACKUP_BASE_URI_SH      = "u"
+	FLAG_DATA_BASE_URI           = "data_base_uri"
+	FLAG_ARCHIVE_BASE_URI        = "archive_base_uri"
+	FLAG_BINDING                 = "binding"
+	FLAG_LOG_ARCHIVE_CONCURRENCY = "log_archive_concurrency"
+	FLAG_ARCHIVE_LAG_TARGET      = "archive_lag_target"
+	FLAG_ENCRYPTION              = "encryption"
+	FLAG_PLUS_ARCHIVE            = "plus_archive"
+	FLAG_LIMIT                   = "limit"
+	FLAG_LIMIT_SH                = "l"
+
+	// CMD_RESTORE represents the "restore" command used to restore a backup into seekdb.
+	CMD_RESTORE = "restore"
+	// Flags for the "restore" command.
+	FLAG_DATA_BACKUP_URI    = "data_backup_uri"
+	FLAG_DATA_BACKUP_URI_SH = "d"
+	FLAG_ARCHIVE_LOG_URI    = "archive_log_uri"
+	FLAG_ARCHIVE_LOG_URI_SH = "a"
+	FLAG_TIMESTAMP          = "timestamp"
+	FLAG_DECRYPTION         = "decryption"
 )
 
 const (
@@ -82,5 +117,15 @@ func NewSeekdbCmd() *cobra.Command {
 	seekdbCmd.AddCommand(newStartCmd())
 	seekdbCmd.AddCommand(newShowCmd())
 	seekdbCmd.AddCommand(newStopCmd())
+	seekdbCmd.AddCommand(newBackupCmd())
+	seekdbCmd.AddCommand(newRestoreCmd())
+	seekdbCmd.AddCommand(newUpgradeCmd())
+	seekdbCmd.AddCommand(user.NewUserCmd())
+	seekdbCmd.AddCommand(database.NewDatabaseCmd())
+	seekdbCmd.AddCommand(parameter.NewParameterCmd())
+	seekdbCmd.AddCommand(variable.NewVariableCmd())
+	seekdbCmd.AddCommand(standby.NewStandbyCmd())
+	seekdbCmd.AddCommand(compaction.NewCompactionCmd())
+	seekdbCmd.AddCommand(pkg.NewPackageCmd())
 	return seekdbCmd.Command
 }
@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parameter
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/http"
+	"github.com/oceanbase/obshell/seekdb/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/seekdb/client/command"
+	clientconst "github.com/oceanbase/obshell/seekdb/client/constant"
+	"github.com/oceanbase/obshell/seekdb/client/lib/stdio"
+	"github.com/oceanbase/obshell/seekdb/client/utils/api"
+	"github.com/oceanbase/obshell/seekdb/client/utils/printer"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+const (
+	CMD_PARAMETER = "parameter"
+
+	// obshell seekdb parameter show
+	CMD_SHOW = "show"
+
+	// obshell seekdb parameter set
+	CMD_SET = "set"
+)
+
+type parameterShowFlags struct {
+	output  string
+	verbose bool
+}
+
+func NewParameterCmd() *cobra.Command {
+	parameterCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_PARAMETER,
+		Short: "Display and manage the seekdb parameters.",
+	})
+	parameterCmd.AddCommand(newShowCmd())
+	parameterCmd.AddCommand(newSetCmd())
+	return parameterCmd.Command
+}
+
+func newShowCmd() *cobra.Command {
+	opts := &parameterShowFlags{}
+	showCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_SHOW,
+		Short: "Show the parameters, '%' can be used as a wildcard in the filter.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if err := printer.CheckOutputFormat(opts.output); err != nil {
+				return err
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			filter := ""
+			if len(args) > 0 {
+				filter = args[0]
+			}
+			return showParameter(filter, opts.output)
+		}),
+		Example: `  obshell seekdb parameter show
+  obshell seekdb parameter show memstore_limit_percentage
+  obshell seekdb parameter show "%log%" -o json`,
+	})
+	showCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "[parameter]"}
+	showCmd.Flags().SortFlags = false
+	showCmd.VarsPs(&opts.output, []string{clientconst.FLAG_OUTPUT, clientconst.FLAG_OUTPUT_SH}, clientconst.OUTPUT_FORMAT_TABLE, "Output format, 'table' or 'json'.", false)
+	showCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return showCmd.Command
+}
+
+func showParameter(filter string, output string) error {
+	var query map[string]string
+	if filter != "" {
+		query = map[string]string{"filter": filter}
+	}
+	parameters := make([]oceanbase.GvObParameter, 0)
+	if err := api.CallApiWithMethod(http.GET, constant.URI_SEEKDB_API_PREFIX+constant.URI_PARAMETERS, query, &parameters); err != nil {
+		return err
+	}
+	if len(parameters) == 0 && filter != "" {
+		return errors.Occur(errors.ErrCliNotFound, filter)
+	}
+	return printer.PrintWithFormat(output, parameters, func() {
+		data := make([][]string, 0, len(parameters))
+		for _, p := range parameters {
+			data = append(data, []string{p.Name, p.Value, p.DataType, p.EditLevel})
+		}
+		stdio.PrintTable([]string{"Name", "Value", "Data Type", "Edit Level"}, data)
+	})
+}
+
+func newSetCmd() *cobra.Command {
+	var verbose bool
+	setCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_SET,
+		Short: "Set the specified parameters.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.Occur(errors.ErrCliUsageError, "parameter is required")
+			}
+			stdio.SetVerboseMode(verbose)
+			return setParameter(args[0])
+		}),
+		Example: `  obshell seekdb parameter set memstore_limit_percentage=60,enable_sql_audit=true`,
+	})
+	setCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<name=value>"}
+	setCmd.VarsPs(&verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return setCmd.Command
+}
+
+func setParameter(str string) error {
+	parameters, err := BuildVariableOrParameterMap(str)
+	if err != nil {
+		return err
+	}
+	params := param.SetParametersParam{
+		Parameters: parameters,
+	}
+
+	stdio.StartLoading("set parameter(s)")
+	if err := api.CallApiWithMethod(http.PATCH, constant.URI_SEEKDB_API_PREFIX+constant.URI_PARAMETERS, params, nil); err != nil {
+		return err
+	}
+	stdio.LoadSuccess("set parameter(s)")
+	return nil
+}
+
+// BuildVariableOrParameterMap parses 'name1=value1,name2=value2' into a map,
+// numbers and booleans are converted to their own types.
+func BuildVariableOrParameterMap(str string) (map[string]interface{}, error) {
+	m := make(map[string]interface{})
+	if len(str) == 0 {
+		return m, nil
+	}
+	items := strings.Split(str, ",")
+	for _, item := range items {
+		kv := strings.SplitN(item, "=", 2)
+		if len(kv) != 2 || len(kv[0]) == 0 || len(kv[1]) == 0 {
+			return nil, errors.Occurf(errors.ErrCliUsageError, "error format: %s, should be name=value", item)
+		}
+		m[kv[0]] = kv[1]
+		if number, err := strconv.Atoi(kv[1]); err == nil {
+			m[kv[0]] = number
+		} else if floatValue, err := strconv.ParseFloat(kv[1], 64); err == nil {
+			m[kv[0]] = floatValue
+		} else if strings.ToLower(kv[1]) == "true" {
+			m[kv[0]] = true
+		} else if strings.ToLower(kv[1]) == "false" {
+			m[kv[0]] = false
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,196 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pkg
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/http"
+	"github.com/oceanbase/obshell/seekdb/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/seekdb/client/command"
+	clientconst "github.com/oceanbase/obshell/seekdb/client/constant"
+	cmdlib "github.com/oceanbase/obshell/seekdb/client/lib/cmd"
+	"github.com/oceanbase/obshell/seekdb/client/lib/stdio"
+	"github.com/oceanbase/obshell/seekdb/client/utils/api"
+	"github.com/oceanbase/obshell/seekdb/client/utils/printer"
+	"github.com/oceanbase/obshell/seekdb/client/utils/rpm"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+const (
+	CMD_PACKAGE = "package"
+
+	// obshell seekdb package upload <file>
+	CMD_UPLOAD = "upload"
+
+	// obshell seekdb package show
+	CMD_SHOW = "show"
+
+	// obshell seekdb package delete
+	CMD_DELETE = "delete"
+	// Flags for the "delete" command.
+	FLAG_NAME                 = "name"
+	FLAG_NAME_SH              = "n"
+	FLAG_VERSION              = "version"
+	FLAG_VERSION_SH           = "V"
+	FLAG_RELEASE_DISTRIBUTION = "release_distribution"
+	FLAG_ARCHITECTURE         = "architecture"
+	FLAG_ARCHITECTURE_SH      = "a"
+)
+
+type packageShowFlags struct {
+	output  string
+	verbose bool
+}
+
+type packageDeleteFlags struct {
+	param.DeletePackageParam
+	skipConfirm bool
+	verbose     bool
+}
+
+func uri() string {
+	return constant.URI_API_V1 + constant.URI_UPGRADE + constant.URI_PACKAGE
+}
+
+func NewPackageCmd() *cobra.Command {
+	packageCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_PACKAGE,
+		Short: "Upload and manage the upgrade packages stored in the agent.",
+	})
+	packageCmd.AddCommand(newUploadCmd())
+	packageCmd.AddCommand(newShowCmd())
+	packageCmd.AddCommand(newDeleteCmd())
+	return packageCmd.Command
+}
+
+func newUploadCmd() *cobra.Command {
+	var verbose bool
+	uploadCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_UPLOAD,
+		Short: "Upload an rpm package to the agent.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.Occur(errors.ErrCliUsageError, "package file is required")
+			}
+			stdio.SetVerboseMode(verbose)
+			for _, file := range args {
+				if err := rpm.CallUploadPkgAndPrint(filepath.Dir(file), filepath.Base(file)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}),
+		Example: `  obshell seekdb package upload /tmp/seekdb-1.0.0.0-100000012025102910.el7.x86_64.rpm`,
+	})
+	uploadCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<package-file>..."}
+	uploadCmd.VarsPs(&verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return uploadCmd.Command
+}
+
+func newShowCmd() *cobra.Command {
+	opts := &packageShowFlags{}
+	showCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_SHOW,
+		Short:   "Show the packages uploaded to the agent.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if err := printer.CheckOutputFormat(opts.output); err != nil {
+				return err
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			return showPackages(opts.output)
+		}),
+		Example: `  obshell seekdb package show`,
+	})
+	showCmd.Flags().SortFlags = false
+	showCmd.VarsPs(&opts.output, []string{clientconst.FLAG_OUTPUT, clientconst.FLAG_OUTPUT_SH}, clientconst.OUTPUT_FORMAT_TABLE, "Output format, 'table' or 'json'.", false)
+	showCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return showCmd.Command
+}
+
+func showPackages(output string) error {
+	var pkgs []bo.UpgradePkgInfo
+	if err := api.CallApiWithMethod(http.GET, uri()+constant.URI_INFO, nil, &pkgs); err != nil {
+		return err
+	}
+	return printer.PrintWithFormat(output, pkgs, func() {
+		if len(pkgs) == 0 {
+			stdio.Print("No package found.")
+			return
+		}
+		data := make([][]string, 0, len(pkgs))
+		for _, pkg := range pkgs {
+			data = append(data, []string{
+				pkg.Name,
+				pkg.Version,
+				pkg.ReleaseDistribution,
+				pkg.Architecture,
+				fmt.Sprint(pkg.Size),
+				pkg.Md5,
+				pkg.GmtModify.Local().Format(time.DateTime),
+			})
+		}
+		stdio.PrintTable([]string{"Name", "Version", "Release", "Architecture", "Size", "MD5", "Modify Time"}, data)
+	})
+}
+
+func newDeleteCmd() *cobra.Command {
+	opts := &packageDeleteFlags{}
+	deleteCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_DELETE,
+		Short:   "Delete a package uploaded to the agent.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			stdio.SetVerboseMode(opts.verbose)
+			return deletePackage(&opts.DeletePackageParam)
+		}),
+		Example: `  obshell seekdb package delete -n seekdb -V 1.0.0.0 --release_distribution 100000012025102910.el7 -a x86_64`,
+	})
+	deleteCmd.Flags().SortFlags = false
+	deleteCmd.VarsPs(&opts.Name, []string{FLAG_NAME, FLAG_NAME_SH}, "", "The name of the package.", true)
+	deleteCmd.VarsPs(&opts.Version, []string{FLAG_VERSION, FLAG_VERSION_SH}, "", "The version of the package.", true)
+	deleteCmd.VarsPs(&opts.ReleaseDistribution, []string{FLAG_RELEASE_DISTRIBUTION}, "", "The release and distribution of the package, e.g. 100000012025102910.el7.", true)
+	deleteCmd.VarsPs(&opts.Architecture, []string{FLAG_ARCHITECTURE, FLAG_ARCHITECTURE_SH}, "", "The architecture of the package, e.g. x86_64.", true)
+	deleteCmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt.", false)
+	deleteCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return deleteCmd.Command
+}
+
+func deletePackage(p *param.DeletePackageParam) error {
+	fullName := fmt.Sprintf("%s-%s-%s.%s", p.Name, p.Version, p.ReleaseDistribution, p.Architecture)
+	pass, err := stdio.Confirmf("Please confirm if you need to delete package %s", fullName)
+	if err != nil {
+		return errors.Wrap(err, "ask for confirmation failed")
+	}
+	if !pass {
+		return nil
+	}
+	stdio.StartLoadingf("delete package %s", fullName)
+	if err := api.CallApiWithMethod(http.DELETE, uri(), p, nil); err != nil {
+		return err
+	}
+	stdio.LoadSuccessf("delete package %s", fullName)
+	return nil
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package instance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/http"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/path"
+	"github.com/oceanbase/obshell/seekdb/client/command"
+	clientconst "github.com/oceanbase/obshell/seekdb/client/constant"
+	cmdlib "github.com/oceanbase/obshell/seekdb/client/lib/cmd"
+	"github.com/oceanbase/obshell/seekdb/client/lib/stdio"
+	"github.com/oceanbase/obshell/seekdb/client/utils/api"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+type RestoreStartFlags struct {
+	dataBackupUri string
+	archiveLogUri string
+	timestamp     string
+	decryption    string
+	verbose       bool
+	skipConfirm   bool
+}
+
+func newRestoreCmd() *cobra.Command {
+	restoreCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_RESTORE,
+		Short: "Restore a backup into the local seekdb.",
+	})
+	restoreCmd.AddCommand(newRestoreStartCmd())
+	restoreCmd.AddCommand(newRestoreShowCmd())
+	return restoreCmd.Command
+}
+
+func newRestoreStartCmd() *cobra.Command {
+	opts := &RestoreStartFlags{}
+	startCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_START,
+		Short:   "Restore a backup into the local fresh seekdb.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return restoreStart(opts)
+		}),
+		Example: restoreStartCmdExample(),
+	})
+
+	startCmd.Flags().SortFlags = false
+	startCmd.VarsPs(&opts.dataBackupUri, []string{FLAG_DATA_BACKUP_URI, FLAG_DATA_BACKUP_URI_SH}, "", "The uri of the data backup.", true)
+	startCmd.VarsPs(&opts.archiveLogUri, []string{FLAG_ARCHIVE_LOG_URI, FLAG_ARCHIVE_LOG_URI_SH}, "", "The uri of the archive log, the data backup uri by default.", false)
+	startCmd.VarsPs(&opts.timestamp, []string{FLAG_TIMESTAMP}, "", "Restore until the local time, such as '2006-01-02 15:04:05'. The end of the archive log by default.", false)
+	startCmd.VarsPs(&opts.decryption, []string{FLAG_DECRYPTION}, "", "The passwords of the encrypted backup sets, separated by ','.", false)
+	startCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	startCmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation of restore operation.", false)
+	return startCmd.Command
+}
+
+func restoreStart(flags *RestoreStartFlags) error {
+	p := &param.RestoreParam{
+		DataBackupUri: flags.dataBackupUri,
+		ArchiveLogUri: flags.archiveLogUri,
+	}
+	if flags.timestamp != "" {
+		t, err := time.ParseInLocation(time.DateTime, flags.timestamp, time.Local)
+		if err != nil {
+			return errors.Occur(errors.ErrCliUsageError, fmt.Sprintf("invalid timestamp '%s', the format should be '%s'", flags.timestamp, time.DateTime))
+		}
+		p.Timestamp = &t
+	}
+	for _, pwd := range strings.Split(flags.decryption, ",") {
+		if pwd != "" {
+			p.Decryption = append(p.Decryption, pwd)
+		}
+	}
+
+	res, err := stdio.Confirm("Please confirm if you need to restore the backup into the local seekdb.")
+	if err != nil {
+		return errors.Wrap(err, "ask for restore confirmation failed")
+	}
+	if !res {
+		return errors.Occur(errors.ErrCliOperationCancelled)
+	}
+
+	_, err = api.CallApiAndPrintStage(constant.URI_SEEKDB_RESTORE_API_PREFIX, p)
+	return err
+}
+
+func newRestoreShowCmd() *cobra.Command {
+	var verbose bool
+	showCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_SHOW,
+		Short:   "Show the running or the last restore job.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(verbose)
+			stdio.SetSilenceMode(false)
+			return restoreShow()
+		}),
+		Example: `  obshell seekdb restore show`,
+	})
+
+	showCmd.Flags().SortFlags = false
+	showCmd.VarsPs(&verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return showCmd.Command
+}
+
+func restoreShow() error {
+	uri := constant.URI_SEEKDB_RESTORE_API_PREFIX + constant.URI_OVERVIEW
+	stdio.Verbosef("Calling API %s", uri)
+	var overview *param.RestoreOverview
+	if err := http.SendGetRequestViaUnixSocket(path.ObshellSocketPath(), uri, nil, &overview); err != nil {
+		return err
+	}
+	if overview == nil || overview.JobID == 0 {
+		stdio.Print("No restore job found.")
+		return nil
+	}
+	stdio.PrintTable([]string{"Item", "Value"}, [][]string{
+		{"Job ID", strconv.FormatInt(overview.JobID, 10)},
+		{"Running", strconv.FormatBool(overview.Running)},
+		{"Status", overview.Status},
+		{"Restore Scn", valueOrNA(overview.RestoreScnDisplay)},
+		{"Start Time", valueOrNA(overview.StartTimestamp)},
+		{"Finish Time", valueOrNA(overview.FinishTimestamp)},
+		{"Restore Progress", valueOrNA(overview.RestoreProgress)},
+		{"Recover Progress", valueOrNA(overview.RecoverProgress)},
+		{"Comment", valueOrNA(overview.Comment)},
+	})
+	return nil
+}
+
+func restoreStartCmdExample() string {
+	return `  obshell seekdb restore start -d file:///data/backup/data -a file:///data/backup/clog
+  obshell seekdb restore start -d file:///data/backup/data -a file:///data/backup/clog --timestamp "2024-01-01 12:00:00"`
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package standby
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/seekdb/client/command"
+)
+
+const (
+	CMD_STANDBY = "standby"
+
+	// obshell seekdb standby show
+	CMD_SHOW = "show"
+	// obshell seekdb standby token
+	CMD_TOKEN = "token"
+	// obshell seekdb standby pair
+	CMD_PAIR = "pair"
+	// obshell seekdb standby unpair
+	CMD_UNPAIR = "unpair"
+	// obshell seekdb standby switchover
+	CMD_SWITCHOVER = "switchover"
+	// obshell seekdb standby activate
+	CMD_ACTIVATE = "activate"
+	// obshell seekdb standby failover show|config
+	CMD_FAILOVER = "failover"
+	CMD_CONFIG   = "config"
+
+	FLAG_PEER_HOST        = "peer_host"
+	FLAG_PEER_HOST_SH     = "H"
+	FLAG_PEER_PORT        = "peer_port"
+	FLAG_PEER_PORT_SH     = "P"
+	FLAG_PEER_RPC_PORT    = "peer_rpc_port"
+	FLAG_DIRECTION        = "direction"
+	FLAG_DIRECTION_SH     = "d"
+	FLAG_TOKEN            = "token"
+	FLAG_TOKEN_SH         = "t"
+	FLAG_FORCE            = "force"
+	FLAG_FORCE_SH         = "f"
+	FLAG_NOTIFY_PEER      = "notify_peer"
+	FLAG_DELAY_THRESHOLD  = "delay_threshold"
+	FLAG_ENABLED          = "enabled"
+	FLAG_OUTAGE_WINDOW    = "outage_window"
+	FLAG_MAX_LAG          = "max_lag"
+	FLAG_PROBE_INTERVAL   = "probe_interval"
+	FLAG_PRIMARY_SQL_PORT = "primary_sql_port"
+)
+
+type peerFlags struct {
+	peerHost string
+	peerPort int
+}
+
+func NewStandbyCmd() *cobra.Command {
+	standbyCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_STANDBY,
+		Short: "Display and manage the primary-standby relationship of seekdb.",
+	})
+	standbyCmd.AddCommand(newShowCmd())
+	standbyCmd.AddCommand(newTokenCmd())
+	standbyCmd.AddCommand(newPairCmd())
+	standbyCmd.AddCommand(newUnpairCmd())
+	standbyCmd.AddCommand(newSwitchoverCmd())
+	standbyCmd.AddCommand(newActivateCmd())
+	standbyCmd.AddCommand(newFailoverCmd())
+	return standbyCmd.Command
+}
+
+func addPeerFlags(cmd *command.Command, opts *peerFlags) {
+	cmd.VarsPs(&opts.peerHost, []string{FLAG_PEER_HOST, FLAG_PEER_HOST_SH}, "", "The host of the peer obshell.", true)
+	cmd.VarsPs(&opts.peerPort, []string{FLAG_PEER_PORT, FLAG_PEER_PORT_SH}, 0, "The port of the peer obshell.", true)
+}
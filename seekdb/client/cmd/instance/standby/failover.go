@@ -0,0 +1,180 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package standby
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/http"
+	"github.com/oceanbase/obshell/seekdb/client/command"
+	clientconst "github.com/oceanbase/obshell/seekdb/client/constant"
+	cmdlib "github.com/oceanbase/obshell/seekdb/client/lib/cmd"
+	"github.com/oceanbase/obshell/seekdb/client/lib/stdio"
+	"github.com/oceanbase/obshell/seekdb/client/utils/api"
+	"github.com/oceanbase/obshell/seekdb/client/utils/printer"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+type failoverConfigFlags struct {
+	enabled        bool
+	outageWindow   int
+	maxLag         int
+	probeInterval  int
+	primarySqlPort int
+	verbose        bool
+}
+
+func newFailoverCmd() *cobra.Command {
+	failoverCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_FAILOVER,
+		Short: "Display and configure the automatic failover of this standby node.",
+	})
+	failoverCmd.AddCommand(newFailoverShowCmd())
+	failoverCmd.AddCommand(newFailoverConfigCmd())
+	return failoverCmd.Command
+}
+
+func newFailoverShowCmd() *cobra.Command {
+	opts := &showFlags{}
+	showCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_SHOW,
+		Short:   "Show the automatic failover settings and recent events.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if err := printer.CheckOutputFormat(opts.output); err != nil {
+				return err
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			return showFailover(opts.output)
+		}),
+		Example: `  obshell seekdb standby failover show`,
+	})
+	showCmd.Flags().SortFlags = false
+	showCmd.VarsPs(&opts.output, []string{clientconst.FLAG_OUTPUT, clientconst.FLAG_OUTPUT_SH}, clientconst.OUTPUT_FORMAT_TABLE, "Output format, 'table' or 'json'.", false)
+	showCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return showCmd.Command
+}
+
+func showFailover(output string) error {
+	var status param.FailoverStatusResp
+	if err := api.CallApiWithMethod(http.GET, constant.URI_SEEKDB_STANDBY_API_PREFIX+constant.URI_FAILOVER, nil, &status); err != nil {
+		return err
+	}
+	return printer.PrintWithFormat(output, status, func() {
+		outageSince, lastLag, lastLagSampledAt := "", "", ""
+		if status.OutageSince != nil {
+			outageSince = status.OutageSince.Local().Format(time.DateTime)
+		}
+		if status.LastLagSeconds != nil {
+			lastLag = fmt.Sprint(*status.LastLagSeconds)
+		}
+		if status.LastLagSampledAt != nil {
+			lastLagSampledAt = status.LastLagSampledAt.Local().Format(time.DateTime)
+		}
+		printFailoverConfig(status.Config, [][]string{
+			{"Outage Since", outageSince},
+			{"Last Lag (s)", lastLag},
+			{"Last Lag Sampled At", lastLagSampledAt},
+		})
+		if len(status.Events) == 0 {
+			return
+		}
+		data := make([][]string, 0, len(status.Events))
+		for _, event := range status.Events {
+			peer := ""
+			if event.PeerHost != "" {
+				peer = fmt.Sprintf("%s:%d", event.PeerHost, event.PeerObshellPort)
+			}
+			data = append(data, []string{
+				event.CreatedAt.Local().Format(time.DateTime),
+				event.EventType,
+				peer,
+				event.DagID,
+				event.Message,
+			})
+		}
+		stdio.PrintTableWithTitle("Events", []string{"Time", "Type", "Peer", "Task ID", "Message"}, data)
+	})
+}
+
+func printFailoverConfig(config param.FailoverConfig, extra [][]string) {
+	rows := [][]string{
+		{"Enabled", strconv.FormatBool(config.Enabled)},
+		{"Outage Window (s)", strconv.Itoa(config.OutageWindowSeconds)},
+		{"Max Lag (s)", strconv.Itoa(config.MaxLagSeconds)},
+		{"Probe Interval (s)", strconv.Itoa(config.ProbeIntervalSeconds)},
+		{"Primary SQL Port", strconv.Itoa(config.PrimarySqlPort)},
+	}
+	stdio.PrintTable([]string{"Item", "Value"}, append(rows, extra...))
+}
+
+func newFailoverConfigCmd() *cobra.Command {
+	opts := &failoverConfigFlags{}
+	configCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_CONFIG,
+		Short:   "Modify the automatic failover settings, settings not specified are kept.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			return configFailover(cmd, opts)
+		}),
+		Example: `  obshell seekdb standby failover config --enabled=true --outage_window 60 --max_lag 30`,
+	})
+	configCmd.Flags().SortFlags = false
+	configCmd.VarsPs(&opts.enabled, []string{FLAG_ENABLED}, false, "Whether to enable the automatic failover.", false)
+	configCmd.VarsPs(&opts.outageWindow, []string{FLAG_OUTAGE_WINDOW}, 0, "How long in seconds the primary must be unreachable before failover.", false)
+	configCmd.VarsPs(&opts.maxLag, []string{FLAG_MAX_LAG}, 0, "The max replication lag in seconds allowed to failover.", false)
+	configCmd.VarsPs(&opts.probeInterval, []string{FLAG_PROBE_INTERVAL}, 0, "The interval in seconds to probe the primary.", false)
+	configCmd.VarsPs(&opts.primarySqlPort, []string{FLAG_PRIMARY_SQL_PORT}, 0, "The sql port of the primary used to probe it.", false)
+	configCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return configCmd.Command
+}
+
+func configFailover(cmd *cobra.Command, opts *failoverConfigFlags) error {
+	p := param.FailoverConfigParam{}
+	if cmd.Flags().Changed(FLAG_ENABLED) {
+		p.Enabled = &opts.enabled
+	}
+	if cmd.Flags().Changed(FLAG_OUTAGE_WINDOW) {
+		p.OutageWindowSeconds = &opts.outageWindow
+	}
+	if cmd.Flags().Changed(FLAG_MAX_LAG) {
+		p.MaxLagSeconds = &opts.maxLag
+	}
+	if cmd.Flags().Changed(FLAG_PROBE_INTERVAL) {
+		p.ProbeIntervalSeconds = &opts.probeInterval
+	}
+	if cmd.Flags().Changed(FLAG_PRIMARY_SQL_PORT) {
+		p.PrimarySqlPort = &opts.primarySqlPort
+	}
+	if p == (param.FailoverConfigParam{}) {
+		return errors.Occur(errors.ErrCliUsageError, "no failover setting specified")
+	}
+
+	var config param.FailoverConfig
+	if err := api.CallApiWithMethod(http.PUT, constant.URI_SEEKDB_STANDBY_API_PREFIX+constant.URI_FAILOVER, p, &config); err != nil {
+		return err
+	}
+	printFailoverConfig(config, nil)
+	return nil
+}
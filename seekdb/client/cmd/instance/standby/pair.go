@@ -0,0 +1,159 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package standby
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/http"
+	"github.com/oceanbase/obshell/seekdb/client/command"
+	clientconst "github.com/oceanbase/obshell/seekdb/client/constant"
+	cmdlib "github.com/oceanbase/obshell/seekdb/client/lib/cmd"
+	"github.com/oceanbase/obshell/seekdb/client/lib/stdio"
+	"github.com/oceanbase/obshell/seekdb/client/utils/api"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+type tokenFlags struct {
+	force   bool
+	verbose bool
+}
+
+type pairFlags struct {
+	peerFlags
+	peerRpcPort int
+	direction   string
+	token       string
+	verbose     bool
+}
+
+type unpairFlags struct {
+	peerFlags
+	notifyPeer  bool
+	skipConfirm bool
+	verbose     bool
+}
+
+func newTokenCmd() *cobra.Command {
+	opts := &tokenFlags{}
+	tokenCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_TOKEN,
+		Short:   "Generate the standby token used by the peer to pair with this node.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			var resp param.TokenResp
+			if err := api.CallApiWithMethod(http.POST, constant.URI_SEEKDB_STANDBY_API_PREFIX+constant.URI_TOKEN, param.TokenParam{Force: opts.force}, &resp); err != nil {
+				return err
+			}
+			stdio.Print(resp.Token)
+			return nil
+		}),
+		Example: `  obshell seekdb standby token`,
+	})
+	tokenCmd.Flags().SortFlags = false
+	tokenCmd.VarsPs(&opts.force, []string{FLAG_FORCE, FLAG_FORCE_SH}, false, "Regenerate the token even if one already exists.", false)
+	tokenCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return tokenCmd.Command
+}
+
+func newPairCmd() *cobra.Command {
+	opts := &pairFlags{}
+	pairCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_PAIR,
+		Short:   "Add or update a peer of this node.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetVerboseMode(opts.verbose)
+			return pair(opts)
+		}),
+		Example: `  obshell seekdb standby pair -H 10.0.0.2 -P 2886 --peer_rpc_port 2882 -d UPSTREAM -t <peer-token>`,
+	})
+	pairCmd.Flags().SortFlags = false
+	addPeerFlags(pairCmd, &opts.peerFlags)
+	pairCmd.VarsPs(&opts.peerRpcPort, []string{FLAG_PEER_RPC_PORT}, 0, "The rpc port of the peer seekdb.", true)
+	pairCmd.VarsPs(&opts.direction, []string{FLAG_DIRECTION, FLAG_DIRECTION_SH}, "", "The direction of the peer, 'UPSTREAM' if the peer is the primary of this node, 'DOWNSTREAM' if the peer is its standby.", true)
+	pairCmd.VarsPs(&opts.token, []string{FLAG_TOKEN, FLAG_TOKEN_SH}, "", "The standby token of the peer.", true)
+	pairCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return pairCmd.Command
+}
+
+func pair(opts *pairFlags) error {
+	direction := strings.ToUpper(opts.direction)
+	if direction != constant.STANDBY_DIRECTION_UPSTREAM && direction != constant.STANDBY_DIRECTION_DOWNSTREAM {
+		return errors.Occurf(errors.ErrCliUsageError, "direction should be '%s' or '%s'", constant.STANDBY_DIRECTION_UPSTREAM, constant.STANDBY_DIRECTION_DOWNSTREAM)
+	}
+	p := param.PairParam{
+		PeerHost:        opts.peerHost,
+		PeerObshellPort: opts.peerPort,
+		PeerRpcPort:     opts.peerRpcPort,
+		Direction:       direction,
+		Token:           opts.token,
+	}
+	stdio.StartLoadingf("pair with %s:%d", opts.peerHost, opts.peerPort)
+	if err := api.CallApiWithMethod(http.PUT, constant.URI_SEEKDB_STANDBY_API_PREFIX+constant.URI_PAIR, p, nil); err != nil {
+		return err
+	}
+	stdio.LoadSuccessf("pair with %s:%d", opts.peerHost, opts.peerPort)
+	return nil
+}
+
+func newUnpairCmd() *cobra.Command {
+	opts := &unpairFlags{}
+	unpairCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_UNPAIR,
+		Short:   "Remove a peer of this node.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			stdio.SetVerboseMode(opts.verbose)
+			return unpair(opts)
+		}),
+		Example: `  obshell seekdb standby unpair -H 10.0.0.2 -P 2886 --notify_peer`,
+	})
+	unpairCmd.Flags().SortFlags = false
+	addPeerFlags(unpairCmd, &opts.peerFlags)
+	unpairCmd.VarsPs(&opts.notifyPeer, []string{FLAG_NOTIFY_PEER}, false, "Remove this node from the peer as well.", false)
+	unpairCmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt.", false)
+	unpairCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return unpairCmd.Command
+}
+
+func unpair(opts *unpairFlags) error {
+	pass, err := stdio.Confirmf("Please confirm if you need to remove the peer %s:%d", opts.peerHost, opts.peerPort)
+	if err != nil {
+		return errors.Wrap(err, "ask for confirmation failed")
+	}
+	if !pass {
+		return nil
+	}
+	p := param.PairDeleteParam{
+		PeerHost:        opts.peerHost,
+		PeerObshellPort: opts.peerPort,
+		NotifyPeer:      opts.notifyPeer,
+	}
+	stdio.StartLoadingf("remove peer %s:%d", opts.peerHost, opts.peerPort)
+	if err := api.CallApiWithMethod(http.DELETE, constant.URI_SEEKDB_STANDBY_API_PREFIX+constant.URI_PAIR, p, nil); err != nil {
+		return err
+	}
+	stdio.LoadSuccessf("remove peer %s:%d", opts.peerHost, opts.peerPort)
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package standby
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/http"
+	"github.com/oceanbase/obshell/seekdb/client/command"
+	clientconst "github.com/oceanbase/obshell/seekdb/client/constant"
+	cmdlib "github.com/oceanbase/obshell/seekdb/client/lib/cmd"
+	"github.com/oceanbase/obshell/seekdb/client/lib/stdio"
+	"github.com/oceanbase/obshell/seekdb/client/utils/api"
+	"github.com/oceanbase/obshell/seekdb/client/utils/printer"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+type showFlags struct {
+	output  string
+	verbose bool
+}
+
+func newShowCmd() *cobra.Command {
+	opts := &showFlags{}
+	showCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_SHOW,
+		Short:   "Show the role of this node and the status of its peers.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if err := printer.CheckOutputFormat(opts.output); err != nil {
+				return err
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			return showStandby(opts.output)
+		}),
+		Example: `  obshell seekdb standby show`,
+	})
+	showCmd.Flags().SortFlags = false
+	showCmd.VarsPs(&opts.output, []string{clientconst.FLAG_OUTPUT, clientconst.FLAG_OUTPUT_SH}, clientconst.OUTPUT_FORMAT_TABLE, "Output format, 'table' or 'json'.", false)
+	showCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return showCmd.Command
+}
+
+func showStandby(output string) error {
+	var status param.StandbyStatusResp
+	if err := api.CallApiWithMethod(http.GET, constant.URI_SEEKDB_STANDBY_API_PREFIX+constant.URI_STANDBY_STATUS, nil, &status); err != nil {
+		return err
+	}
+	return printer.PrintWithFormat(output, status, func() {
+		local := status.Local
+		stdio.PrintTableWithTitle("Local", []string{"Role", "Version", "Log Restore Source", "Sync SCN", "Readable SCN", "Sync Status"}, [][]string{{
+			local.Role,
+			local.Version,
+			local.LogRestoreSource,
+			fmt.Sprint(local.SyncScn),
+			fmt.Sprint(local.ReadableScn),
+			local.SyncStatus,
+		}})
+		if len(status.Peers) == 0 {
+			stdio.Print("No peer found.")
+			return
+		}
+		data := make([][]string, 0, len(status.Peers))
+		for _, peer := range status.Peers {
+			lag := ""
+			if peer.LagSeconds != nil {
+				lag = fmt.Sprint(*peer.LagSeconds)
+			}
+			data = append(data, []string{
+				fmt.Sprintf("%s:%d", peer.PeerHost, peer.PeerObshellPort),
+				peer.Direction,
+				peer.Role,
+				fmt.Sprint(peer.SyncScn),
+				lag,
+				peer.SyncStatus,
+				peer.Error,
+			})
+		}
+		stdio.PrintTableWithTitle("Peers", []string{"Peer", "Direction", "Role", "Sync SCN", "Lag (s)", "Sync Status", "Error"}, data)
+	})
+}
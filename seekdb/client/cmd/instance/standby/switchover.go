@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package standby
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/client/command"
+	clientconst "github.com/oceanbase/obshell/seekdb/client/constant"
+	cmdlib "github.com/oceanbase/obshell/seekdb/client/lib/cmd"
+	"github.com/oceanbase/obshell/seekdb/client/lib/stdio"
+	"github.com/oceanbase/obshell/seekdb/client/utils/api"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+type switchoverFlags struct {
+	peerFlags
+	delayThreshold int
+	skipConfirm    bool
+	verbose        bool
+}
+
+type activateFlags struct {
+	skipConfirm bool
+	verbose     bool
+}
+
+func newSwitchoverCmd() *cobra.Command {
+	opts := &switchoverFlags{}
+	switchoverCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_SWITCHOVER,
+		Short:   "Switch the roles of this node and the specified peer.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			stdio.SetVerboseMode(opts.verbose)
+			return switchover(opts)
+		}),
+		Example: `  obshell seekdb standby switchover -H 10.0.0.2 -P 2886`,
+	})
+	switchoverCmd.Flags().SortFlags = false
+	addPeerFlags(switchoverCmd, &opts.peerFlags)
+	switchoverCmd.VarsPs(&opts.delayThreshold, []string{FLAG_DELAY_THRESHOLD}, constant.DefaultSwitchoverDelayThresholdSeconds, "The max replication lag in seconds allowed to switchover.", false)
+	switchoverCmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt.", false)
+	switchoverCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return switchoverCmd.Command
+}
+
+func switchover(opts *switchoverFlags) error {
+	pass, err := stdio.Confirmf("Please confirm if you need to switchover with %s:%d", opts.peerHost, opts.peerPort)
+	if err != nil {
+		return errors.Wrap(err, "ask for confirmation failed")
+	}
+	if !pass {
+		return nil
+	}
+	p := param.SwitchoverParam{
+		PeerHost:              opts.peerHost,
+		PeerObshellPort:       opts.peerPort,
+		DelayThresholdSeconds: opts.delayThreshold,
+	}
+	_, err = api.CallApiAndPrintStage(constant.URI_SEEKDB_STANDBY_API_PREFIX+constant.URI_SWITCHOVER, p)
+	return err
+}
+
+func newActivateCmd() *cobra.Command {
+	opts := &activateFlags{}
+	activateCmd := command.NewCommand(&cobra.Command{
+		Use:     CMD_ACTIVATE,
+		Short:   "Activate this standby node as a primary, used when the primary is lost.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			stdio.SetVerboseMode(opts.verbose)
+			return activate()
+		}),
+		Example: `  obshell seekdb standby activate`,
+	})
+	activateCmd.Flags().SortFlags = false
+	activateCmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt.", false)
+	activateCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return activateCmd.Command
+}
+
+func activate() error {
+	pass, err := stdio.Confirm("Please confirm if you need to activate this node as a primary")
+	if err != nil {
+		return errors.Wrap(err, "ask for confirmation failed")
+	}
+	if !pass {
+		return nil
+	}
+	_, err = api.CallApiAndPrintStage(constant.URI_SEEKDB_STANDBY_API_PREFIX+constant.URI_ACTIVATE, param.ActivateParam{})
+	return err
+}
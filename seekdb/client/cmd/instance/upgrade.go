@@ -0,0 +1,192 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package instance
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cavaliergopher/rpm"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/pkg"
+	"github.com/oceanbase/obshell/seekdb/client/command"
+	clientconst "github.com/oceanbase/obshell/seekdb/client/constant"
+	cmdlib "github.com/oceanbase/obshell/seekdb/client/lib/cmd"
+	"github.com/oceanbase/obshell/seekdb/client/lib/stdio"
+	"github.com/oceanbase/obshell/seekdb/client/utils/api"
+	"github.com/oceanbase/obshell/seekdb/client/utils/printer"
+	rpmutil "github.com/oceanbase/obshell/seekdb/client/utils/rpm"
+	"github.com/oceanbase/obshell/seekdb/param"
+	"github.com/oceanbase/obshell/seekdb/utils"
+)
+
+type seekdbUpgradeFlags struct {
+	pkgDir      string
+	version     string
+	upgradeDir  string
+	skipConfirm bool
+	verbose     bool
+}
+
+func newUpgradeCmd() *cobra.Command {
+	opts := &seekdbUpgradeFlags{}
+	upgradeCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_UPGRADE,
+		Short: "Upgrade seekdb to the specified version.",
+		Long: "Upgrade seekdb to the specified version. The seekdb binary is backed up and restored automatically if the new version fails to start. " +
+			"When seekdb is the primary of a standby pair, the standby must be upgraded first.",
+		PreRunE: cmdlib.ValidateArgs,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			stdio.SetVerboseMode(opts.verbose)
+			stdio.SetSilenceMode(false)
+			return seekdbUpgrade(opts)
+		}),
+		Example: upgradeCmdExample(),
+	})
+
+	upgradeCmd.Flags().SortFlags = false
+	upgradeCmd.VarsPs(&opts.pkgDir, []string{FLAG_PKG_DIR, FLAG_PKG_DIR_SH}, "", "The directory where the package is located", true)
+	upgradeCmd.VarsPs(&opts.version, []string{FLAG_VERSION, FLAG_VERSION_SH}, "", "Target build version for the seekdb upgrade", false)
+	upgradeCmd.VarsPs(&opts.upgradeDir, []string{FLAG_UPGRADE_DIR, FLAG_UPGRADE_DIR_SH}, "", "Temporary directory used by upgrade tasks", false)
+	upgradeCmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt", false)
+	upgradeCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output", false)
+
+	return upgradeCmd.Command
+}
+
+func seekdbUpgrade(opts *seekdbUpgradeFlags) (err error) {
+	stdio.Verbose("Upgrading seekdb to the specified version")
+	stdio.Verbosef("The specified params is %#+v", opts)
+
+	stdio.Verbosef("Checking if %s is a valid directory.", opts.pkgDir)
+	if err = utils.CheckPathExistAndValid(opts.pkgDir); err != nil {
+		return err
+	}
+	if opts.upgradeDir != "" {
+		stdio.Verbosef("Checking if %s is a valid directory.", opts.upgradeDir)
+		if err = utils.CheckPathValid(opts.upgradeDir); err != nil {
+			return err
+		}
+	}
+
+	// check if seekdb is under maintenance
+	isRunning, err := api.CheckOBMaintenance()
+	if err != nil {
+		return err
+	}
+	if !isRunning {
+		return errors.Occur(errors.ErrAgentCurrentUnderMaintenance)
+	}
+
+	pkgs, err := getAllSeekdbRpmsInDir(opts.pkgDir)
+	if err != nil {
+		return err
+	}
+
+	params, fileName, err := getSeekdbUpgradeParams(opts, pkgs)
+	if err != nil {
+		return err
+	}
+
+	if err = rpmutil.CallUploadPkgAndPrint(opts.pkgDir, fileName); err != nil {
+		return err
+	}
+	return callSeekdbUpgradeApi(params)
+}
+
+func callSeekdbUpgradeApi(params *param.UpgradeCheckParam) (err error) {
+	uri := constant.URI_SEEKDB_API_PREFIX + constant.URI_UPGRADE + constant.URI_CHECK
+	dag, err := api.CallApiAndPrintStage(uri, params)
+	if err != nil {
+		return err
+	}
+	log.Info("upgrade check dag: ", dag)
+
+	uri = constant.URI_SEEKDB_API_PREFIX + constant.URI_UPGRADE
+	dag, err = api.CallApi(uri, params)
+	if err != nil {
+		return err
+	}
+	dagHandler := api.NewDagHandler(dag)
+	dagHandler.SetRetryTimes(60)
+	return dagHandler.PrintDagStage()
+}
+
+// getSeekdbUpgradeParams picks the target package, which is the newest one
+// unless a target version is specified, and asks for confirmation.
+func getSeekdbUpgradeParams(opts *seekdbUpgradeFlags, pkgs map[string]*rpm.Package) (params *param.UpgradeCheckParam, fileName string, err error) {
+	var target *rpm.Package
+	var targetBV string
+	for name, p := range pkgs {
+		currentBV := fmt.Sprintf("%s-%s", p.Version(), strings.Split(p.Release(), ".")[0])
+		stdio.Verbosef("%s version is %s", name, currentBV)
+		if opts.version != "" && opts.version != currentBV && opts.version != p.Version() {
+			continue
+		}
+		if target == nil || pkg.CompareVersion(targetBV, currentBV) < 0 {
+			target, targetBV, fileName = p, currentBV, name
+		}
+	}
+	if target == nil {
+		return nil, "", errors.Occur(errors.ErrCliUpgradeNoValidTargetBuildVersionFound, opts.version)
+	}
+	stdio.Verbosef("The target version is %s", targetBV)
+
+	currentVersion := "unknown"
+	if info, err := api.GetObserverInfo(); err == nil && info.Version != "" {
+		currentVersion = info.Version
+	}
+	msg := fmt.Sprintf("Please confirm if you need to upgrade seekdb from %s to %s", currentVersion, targetBV)
+	res, err := stdio.Confirm(msg)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "ask for upgrade confirmation failed")
+	}
+	if !res {
+		return nil, "", errors.Occur(errors.ErrCliOperationCancelled)
+	}
+
+	params = &param.UpgradeCheckParam{
+		Version:    target.Version(),
+		Release:    target.Release(),
+		UpgradeDir: opts.upgradeDir,
+	}
+	log.Infof("upgrade params are %#+v", params)
+	return params, fileName, nil
+}
+
+func getAllSeekdbRpmsInDir(pkgDir string) (rpmPkgs map[string]*rpm.Package, err error) {
+	stdio.Printf("Getting all rpm packages in %s", pkgDir)
+	rpmPkgs, err = rpmutil.GetAllRpmsInDirByName(pkgDir, constant.PKG_SEEKDB)
+	if err != nil {
+		return nil, err
+	}
+	if len(rpmPkgs) == 0 {
+		return nil, errors.Occur(errors.ErrCliUpgradePackageNotFoundInPath, constant.PKG_SEEKDB, pkgDir)
+	}
+	printer.PrintPkgsTable(rpmPkgs)
+	return rpmPkgs, nil
+}
+
+func upgradeCmdExample() string {
+	return `  obshell seekdb upgrade -d /home/oceanbase/upgrade/
+  obshell seekdb upgrade -d /home/oceanbase/upgrade/ -V 1.1.0.0-100000012025 --port 2886`
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package user
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/http"
+	"github.com/oceanbase/obshell/seekdb/client/command"
+	clientconst "github.com/oceanbase/obshell/seekdb/client/constant"
+	"github.com/oceanbase/obshell/seekdb/client/lib/stdio"
+	"github.com/oceanbase/obshell/seekdb/client/utils/api"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+type userCreateFlags struct {
+	password         string
+	host             string
+	globalPrivileges string
+	dbPrivileges     string
+	verbose          bool
+}
+
+func newCreateCmd() *cobra.Command {
+	opts := &userCreateFlags{}
+	createCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_CREATE,
+		Short: "Create a user, the password is asked interactively if not specified.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.Occur(errors.ErrCliUsageError, "user name is required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			return createUser(args[0], opts)
+		}),
+		Example: `  obshell seekdb user create u1 -g "CREATE,SELECT" -d "db1:SELECT,INSERT;db2:SELECT"`,
+	})
+	createCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<user-name>"}
+	createCmd.Flags().SortFlags = false
+	createCmd.VarsPs(&opts.password, []string{FLAG_PASSWORD, FLAG_PASSWORD_SH}, "", "The password of the user.", false)
+	createCmd.VarsPs(&opts.host, []string{FLAG_HOST}, "", "The host the user is allowed to connect from, '%' by default.", false)
+	createCmd.VarsPs(&opts.globalPrivileges, []string{FLAG_GLOBAL_PRIVILEGES, FLAG_GLOBAL_PRIVILEGES_SH}, "", "The global privileges, separated by ','.", false)
+	createCmd.VarsPs(&opts.dbPrivileges, []string{FLAG_DB_PRIVILEGES, FLAG_DB_PRIVILEGES_SH}, "", "The database privileges, in the format of 'db1:privilege1,privilege2;db2:privilege1'.", false)
+	createCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return createCmd.Command
+}
+
+func createUser(name string, opts *userCreateFlags) error {
+	dbPrivileges, err := parseDbPrivileges(opts.dbPrivileges)
+	if err != nil {
+		return err
+	}
+	password, err := inputNewPassword(opts.password)
+	if err != nil {
+		return err
+	}
+	p := param.CreateUserParam{
+		UserName:         name,
+		Password:         password,
+		HostName:         opts.host,
+		GlobalPrivileges: parseGlobalPrivileges(opts.globalPrivileges),
+		DbPrivileges:     dbPrivileges,
+	}
+	stdio.StartLoadingf("create user %s", name)
+	if err := api.CallApiWithMethod(http.POST, constant.URI_SEEKDB_API_PREFIX+constant.URI_USER_GROUP, p, nil); err != nil {
+		return err
+	}
+	stdio.LoadSuccessf("create user %s", name)
+	return nil
+}
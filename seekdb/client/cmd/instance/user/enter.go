@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package user
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/client/command"
+	"github.com/oceanbase/obshell/seekdb/client/lib/stdio"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+const (
+	CMD_USER = "user"
+
+	// obshell seekdb user create
+	CMD_CREATE = "create"
+	// obshell seekdb user drop
+	CMD_DROP = "drop"
+	// obshell seekdb user show
+	CMD_SHOW = "show"
+	// obshell seekdb user modify
+	CMD_MODIFY = "modify"
+	// obshell seekdb user password
+	CMD_PASSWORD = "password"
+	// obshell seekdb user lock
+	CMD_LOCK = "lock"
+	// obshell seekdb user unlock
+	CMD_UNLOCK = "unlock"
+
+	FLAG_PASSWORD             = "password"
+	FLAG_PASSWORD_SH          = "p"
+	FLAG_HOST                 = "host"
+	FLAG_GLOBAL_PRIVILEGES    = "global_privileges"
+	FLAG_GLOBAL_PRIVILEGES_SH = "g"
+	FLAG_DB_PRIVILEGES        = "db_privileges"
+	FLAG_DB_PRIVILEGES_SH     = "d"
+)
+
+func NewUserCmd() *cobra.Command {
+	userCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_USER,
+		Short: "Display and manage the users of seekdb.",
+	})
+	userCmd.AddCommand(newCreateCmd())
+	userCmd.AddCommand(newDropCmd())
+	userCmd.AddCommand(newShowCmd())
+	userCmd.AddCommand(newModifyCmd())
+	userCmd.AddCommand(newPasswordCmd())
+	userCmd.AddCommand(newLockCmd())
+	userCmd.AddCommand(newUnlockCmd())
+	return userCmd.Command
+}
+
+func userUri(name string) string {
+	return constant.URI_SEEKDB_API_PREFIX + constant.URI_USER_GROUP + "/" + name
+}
+
+// parseGlobalPrivileges parses 'SELECT,INSERT' into a privilege list,
+// an empty string means no privilege.
+func parseGlobalPrivileges(str string) []string {
+	privileges := make([]string, 0)
+	for _, privilege := range strings.Split(str, ",") {
+		if privilege = strings.TrimSpace(privilege); privilege != "" {
+			privileges = append(privileges, strings.ToUpper(privilege))
+		}
+	}
+	return privileges
+}
+
+// parseDbPrivileges parses 'db1:SELECT,INSERT;db2:SELECT' into database privileges.
+func parseDbPrivileges(str string) ([]param.DbPrivilegeParam, error) {
+	dbPrivileges := make([]param.DbPrivilegeParam, 0)
+	for _, item := range strings.Split(str, ";") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		kv := strings.SplitN(item, ":", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, errors.Occurf(errors.ErrCliUsageError, "error format: %s, should be db:privilege1,privilege2", item)
+		}
+		dbPrivileges = append(dbPrivileges, param.DbPrivilegeParam{
+			DbName:     strings.TrimSpace(kv[0]),
+			Privileges: parseGlobalPrivileges(kv[1]),
+		})
+	}
+	return dbPrivileges, nil
+}
+
+// inputNewPassword asks for the new password twice when it is not given by flag.
+func inputNewPassword(password string) (string, error) {
+	if password != "" {
+		return password, nil
+	}
+	password, err := stdio.InputPassword("Enter the password: ")
+	if err != nil {
+		return "", err
+	}
+	confirm, err := stdio.InputPassword("Enter the password again: ")
+	if err != nil {
+		return "", err
+	}
+	if password != confirm {
+		return "", errors.Occur(errors.ErrCliUsageError, "the passwords entered twice are different")
+	}
+	if password == "" {
+		return "", errors.Occur(errors.ErrCliUsageError, "password is required")
+	}
+	return password, nil
+}
@@ -0,0 +1,206 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package user
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/http"
+	"github.com/oceanbase/obshell/seekdb/client/command"
+	clientconst "github.com/oceanbase/obshell/seekdb/client/constant"
+	"github.com/oceanbase/obshell/seekdb/client/lib/stdio"
+	"github.com/oceanbase/obshell/seekdb/client/utils/api"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+type userModifyFlags struct {
+	globalPrivileges string
+	dbPrivileges     string
+	verbose          bool
+}
+
+type userDropFlags struct {
+	skipConfirm bool
+	verbose     bool
+}
+
+type userPasswordFlags struct {
+	password string
+	verbose  bool
+}
+
+func newDropCmd() *cobra.Command {
+	opts := &userDropFlags{}
+	dropCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_DROP,
+		Short: "Drop a user.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.Occur(errors.ErrCliUsageError, "user name is required")
+			}
+			stdio.SetSkipConfirmMode(opts.skipConfirm)
+			stdio.SetVerboseMode(opts.verbose)
+			return dropUser(args[0])
+		}),
+		Example: `  obshell seekdb user drop u1`,
+	})
+	dropCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<user-name>"}
+	dropCmd.Flags().SortFlags = false
+	dropCmd.VarsPs(&opts.skipConfirm, []string{clientconst.FLAG_SKIP_CONFIRM, clientconst.FLAG_SKIP_CONFIRM_SH}, false, "Skip the confirmation prompt.", false)
+	dropCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return dropCmd.Command
+}
+
+func dropUser(name string) error {
+	pass, err := stdio.Confirmf("Please confirm if you need to drop user %s", name)
+	if err != nil {
+		return errors.Wrap(err, "ask for confirmation failed")
+	}
+	if !pass {
+		return nil
+	}
+	stdio.StartLoadingf("drop user %s", name)
+	if err := api.CallApiWithMethod(http.DELETE, userUri(name), nil, nil); err != nil {
+		return err
+	}
+	stdio.LoadSuccessf("drop user %s", name)
+	return nil
+}
+
+func newModifyCmd() *cobra.Command {
+	opts := &userModifyFlags{}
+	modifyCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_MODIFY,
+		Short: "Replace the global or database privileges of a user.",
+		Long:  "Replace the global or database privileges of a user, privileges not specified are revoked. The privileges of databases not specified are kept.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.Occur(errors.ErrCliUsageError, "user name is required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			return modifyUser(cmd, args[0], opts)
+		}),
+		Example: `  obshell seekdb user modify u1 -g "CREATE,SELECT"
+  obshell seekdb user modify u1 -d "db1:SELECT,INSERT;db2:SELECT"`,
+	})
+	modifyCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<user-name>"}
+	modifyCmd.Flags().SortFlags = false
+	modifyCmd.VarsPs(&opts.globalPrivileges, []string{FLAG_GLOBAL_PRIVILEGES, FLAG_GLOBAL_PRIVILEGES_SH}, "", "The global privileges, separated by ','. An empty value revokes all global privileges.", false)
+	modifyCmd.VarsPs(&opts.dbPrivileges, []string{FLAG_DB_PRIVILEGES, FLAG_DB_PRIVILEGES_SH}, "", "The database privileges, in the format of 'db1:privilege1,privilege2;db2:privilege1'.", false)
+	modifyCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return modifyCmd.Command
+}
+
+func modifyUser(cmd *cobra.Command, name string, opts *userModifyFlags) error {
+	globalChanged := cmd.Flags().Changed(FLAG_GLOBAL_PRIVILEGES)
+	dbChanged := cmd.Flags().Changed(FLAG_DB_PRIVILEGES)
+	if !globalChanged && !dbChanged {
+		return errors.Occurf(errors.ErrCliUsageError, "at least one of '--%s' and '--%s' is required", FLAG_GLOBAL_PRIVILEGES, FLAG_DB_PRIVILEGES)
+	}
+	if globalChanged {
+		p := param.ModifyUserGlobalPrivilegeParam{GlobalPrivileges: parseGlobalPrivileges(opts.globalPrivileges)}
+		stdio.StartLoadingf("modify global privileges of user %s", name)
+		if err := api.CallApiWithMethod(http.PUT, userUri(name)+constant.URI_GLOBAL_PRIVILEGES, p, nil); err != nil {
+			return err
+		}
+		stdio.LoadSuccessf("modify global privileges of user %s", name)
+	}
+	if dbChanged {
+		dbPrivileges, err := parseDbPrivileges(opts.dbPrivileges)
+		if err != nil {
+			return err
+		}
+		p := param.ModifyUserDbPrivilegeParam{DbPrivileges: dbPrivileges}
+		stdio.StartLoadingf("modify database privileges of user %s", name)
+		if err := api.CallApiWithMethod(http.PUT, userUri(name)+constant.URI_DB_PRIVILEGES, p, nil); err != nil {
+			return err
+		}
+		stdio.LoadSuccessf("modify database privileges of user %s", name)
+	}
+	return nil
+}
+
+func newPasswordCmd() *cobra.Command {
+	opts := &userPasswordFlags{}
+	passwordCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_PASSWORD,
+		Short: "Change the password of a user, the password is asked interactively if not specified.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.Occur(errors.ErrCliUsageError, "user name is required")
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			return changePassword(args[0], opts.password)
+		}),
+		Example: `  obshell seekdb user password u1`,
+	})
+	passwordCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<user-name>"}
+	passwordCmd.Flags().SortFlags = false
+	passwordCmd.VarsPs(&opts.password, []string{FLAG_PASSWORD, FLAG_PASSWORD_SH}, "", "The new password of the user.", false)
+	passwordCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return passwordCmd.Command
+}
+
+func changePassword(name string, password string) error {
+	password, err := inputNewPassword(password)
+	if err != nil {
+		return err
+	}
+	stdio.StartLoadingf("change password of user %s", name)
+	p := param.ChangeUserPasswordParam{Password: password}
+	if err := api.CallApiWithMethod(http.PUT, userUri(name)+constant.URI_PASSWORD, p, nil); err != nil {
+		return err
+	}
+	stdio.LoadSuccessf("change password of user %s", name)
+	return nil
+}
+
+func newLockCmd() *cobra.Command {
+	return newLockOrUnlockCmd(CMD_LOCK, "Lock a user.", http.POST)
+}
+
+func newUnlockCmd() *cobra.Command {
+	return newLockOrUnlockCmd(CMD_UNLOCK, "Unlock a user.", http.DELETE)
+}
+
+// newLockOrUnlockCmd builds the lock and unlock commands, which only differ
+// in the method sent to /user/{user}/lock.
+func newLockOrUnlockCmd(use string, short string, method string) *cobra.Command {
+	var verbose bool
+	lockCmd := command.NewCommand(&cobra.Command{
+		Use:   use,
+		Short: short,
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.Occur(errors.ErrCliUsageError, "user name is required")
+			}
+			stdio.SetVerboseMode(verbose)
+			stdio.StartLoadingf("%s user %s", use, args[0])
+			if err := api.CallApiWithMethod(method, userUri(args[0])+constant.URI_LOCK, nil, nil); err != nil {
+				return err
+			}
+			stdio.LoadSuccessf("%s user %s", use, args[0])
+			return nil
+		}),
+		Example: "  obshell seekdb user " + use + " u1",
+	})
+	lockCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<user-name>"}
+	lockCmd.VarsPs(&verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return lockCmd.Command
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package user
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/http"
+	"github.com/oceanbase/obshell/seekdb/agent/repository/model/bo"
+	"github.com/oceanbase/obshell/seekdb/client/command"
+	clientconst "github.com/oceanbase/obshell/seekdb/client/constant"
+	"github.com/oceanbase/obshell/seekdb/client/lib/stdio"
+	"github.com/oceanbase/obshell/seekdb/client/utils/api"
+	"github.com/oceanbase/obshell/seekdb/client/utils/printer"
+)
+
+type userShowFlags struct {
+	output  string
+	verbose bool
+}
+
+// userDetail is the output of 'obshell seekdb user show <user-name>'.
+type userDetail struct {
+	bo.ObUser
+	Stats *bo.ObUserStats `json:"stats"`
+}
+
+func newShowCmd() *cobra.Command {
+	opts := &userShowFlags{}
+	showCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_SHOW,
+		Short: "Show all users or the privileges and sessions of the specified user.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if err := printer.CheckOutputFormat(opts.output); err != nil {
+				return err
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			if len(args) > 0 {
+				return showUser(args[0], opts.output)
+			}
+			return listUsers(opts.output)
+		}),
+		Example: `  obshell seekdb user show
+  obshell seekdb user show u1 -o json`,
+	})
+	showCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "[user-name]"}
+	showCmd.Flags().SortFlags = false
+	showCmd.VarsPs(&opts.output, []string{clientconst.FLAG_OUTPUT, clientconst.FLAG_OUTPUT_SH}, clientconst.OUTPUT_FORMAT_TABLE, "Output format, 'table' or 'json'.", false)
+	showCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return showCmd.Command
+}
+
+func listUsers(output string) error {
+	users := make([]bo.ObUser, 0)
+	if err := api.CallApiWithMethod(http.GET, constant.URI_SEEKDB_API_PREFIX+constant.URI_USERS_GROUP, nil, &users); err != nil {
+		return err
+	}
+	return printer.PrintWithFormat(output, users, func() {
+		data := make([][]string, 0, len(users))
+		for _, user := range users {
+			data = append(data, []string{
+				user.UserName,
+				strconv.FormatBool(user.IsLocked),
+				user.CreateTime.Local().Format(time.DateTime),
+				strings.Join(user.AccessibleDatabases, ","),
+			})
+		}
+		stdio.PrintTable([]string{"User", "Locked", "Create Time", "Accessible Databases"}, data)
+	})
+}
+
+func showUser(name string, output string) error {
+	var detail userDetail
+	if err := api.CallApiWithMethod(http.GET, userUri(name), nil, &detail.ObUser); err != nil {
+		return err
+	}
+	if err := api.CallApiWithMethod(http.GET, userUri(name)+constant.URI_STATS, nil, &detail.Stats); err != nil {
+		return err
+	}
+	return printer.PrintWithFormat(output, detail, func() {
+		rows := [][]string{
+			{"User", detail.UserName},
+			{"Locked", strconv.FormatBool(detail.IsLocked)},
+			{"Create Time", detail.CreateTime.Local().Format(time.DateTime)},
+			{"Global Privileges", strings.Join(detail.GlobalPrivileges, ",")},
+		}
+		for _, dbPrivilege := range detail.DbPrivileges {
+			rows = append(rows, []string{fmt.Sprintf("Privileges on %s", dbPrivilege.DbName), strings.Join(dbPrivilege.Privileges, ",")})
+		}
+		if detail.Stats != nil && detail.Stats.Session != nil {
+			rows = append(rows, []string{"Sessions (Active/Total)", fmt.Sprintf("%d/%d", detail.Stats.Session.Active, detail.Stats.Session.Total)})
+		}
+		stdio.PrintTable([]string{"Item", "Value"}, rows)
+	})
+}
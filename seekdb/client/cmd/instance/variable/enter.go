@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package variable
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oceanbase/obshell/seekdb/agent/constant"
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	"github.com/oceanbase/obshell/seekdb/agent/lib/http"
+	"github.com/oceanbase/obshell/seekdb/agent/repository/model/oceanbase"
+	"github.com/oceanbase/obshell/seekdb/client/cmd/instance/parameter"
+	"github.com/oceanbase/obshell/seekdb/client/command"
+	clientconst "github.com/oceanbase/obshell/seekdb/client/constant"
+	"github.com/oceanbase/obshell/seekdb/client/lib/stdio"
+	"github.com/oceanbase/obshell/seekdb/client/utils/api"
+	"github.com/oceanbase/obshell/seekdb/client/utils/printer"
+	"github.com/oceanbase/obshell/seekdb/param"
+)
+
+const (
+	CMD_VARIABLE = "variable"
+
+	// obshell seekdb variable show
+	CMD_SHOW = "show"
+
+	// obshell seekdb variable set
+	CMD_SET = "set"
+)
+
+type variableShowFlags struct {
+	output  string
+	verbose bool
+}
+
+func NewVariableCmd() *cobra.Command {
+	variableCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_VARIABLE,
+		Short: "Display and manage the seekdb global variables.",
+	})
+	variableCmd.AddCommand(newShowCmd())
+	variableCmd.AddCommand(newSetCmd())
+	return variableCmd.Command
+}
+
+func newShowCmd() *cobra.Command {
+	opts := &variableShowFlags{}
+	showCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_SHOW,
+		Short: "Show the global variables, '%' can be used as a wildcard in the filter.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if err := printer.CheckOutputFormat(opts.output); err != nil {
+				return err
+			}
+			stdio.SetVerboseMode(opts.verbose)
+			filter := ""
+			if len(args) > 0 {
+				filter = args[0]
+			}
+			return showVariable(filter, opts.output)
+		}),
+		Example: `  obshell seekdb variable show
+  obshell seekdb variable show max_connections
+  obshell seekdb variable show "ob_%" -o json`,
+	})
+	showCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "[variable]"}
+	showCmd.Flags().SortFlags = false
+	showCmd.VarsPs(&opts.output, []string{clientconst.FLAG_OUTPUT, clientconst.FLAG_OUTPUT_SH}, clientconst.OUTPUT_FORMAT_TABLE, "Output format, 'table' or 'json'.", false)
+	showCmd.VarsPs(&opts.verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return showCmd.Command
+}
+
+func showVariable(filter string, output string) error {
+	var query map[string]string
+	if filter != "" {
+		query = map[string]string{"filter": filter}
+	}
+	variables := make([]oceanbase.DbaObSysVariable, 0)
+	if err := api.CallApiWithMethod(http.GET, constant.URI_SEEKDB_API_PREFIX+constant.URI_VARIABLES, query, &variables); err != nil {
+		return err
+	}
+	if len(variables) == 0 && filter != "" {
+		return errors.Occur(errors.ErrCliNotFound, filter)
+	}
+	return printer.PrintWithFormat(output, variables, func() {
+		data := make([][]string, 0, len(variables))
+		for _, v := range variables {
+			data = append(data, []string{v.Name, v.Value})
+		}
+		stdio.PrintTable([]string{"Name", "Value"}, data)
+	})
+}
+
+func newSetCmd() *cobra.Command {
+	var verbose bool
+	setCmd := command.NewCommand(&cobra.Command{
+		Use:   CMD_SET,
+		Short: "Set the specified global variables.",
+		RunE: command.WithErrorHandler(func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.Occur(errors.ErrCliUsageError, "variable is required")
+			}
+			stdio.SetVerboseMode(verbose)
+			return setVariable(args[0])
+		}),
+		Example: `  obshell seekdb variable set max_connections=10000,recyclebin=true`,
+	})
+	setCmd.Annotations = map[string]string{clientconst.ANNOTATION_ARGS: "<name=value>"}
+	setCmd.VarsPs(&verbose, []string{clientconst.FLAG_VERBOSE, clientconst.FLAG_VERBOSE_SH}, false, "Activate verbose output.", false)
+	return setCmd.Command
+}
+
+func setVariable(str string) error {
+	variables, err := parameter.BuildVariableOrParameterMap(str)
+	if err != nil {
+		return err
+	}
+	params := param.SetVariablesParam{
+		Variables: variables,
+	}
+
+	stdio.StartLoading("set global variable(s)")
+	if err := api.CallApiWithMethod(http.PATCH, constant.URI_SEEKDB_API_PREFIX+constant.URI_VARIABLES, params, nil); err != nil {
+		return err
+	}
+	stdio.LoadSuccess("set global variable(s)")
+	return nil
+}
@@ -32,6 +32,12 @@ const (
 	FLAG_DETAIL    = "show_detail"
 	FLAG_DETAIL_SH = "d"
 
+	FLAG_OUTPUT    = "output"
+	FLAG_OUTPUT_SH = "o"
+
+	OUTPUT_FORMAT_TABLE = "table"
+	OUTPUT_FORMAT_JSON  = "json"
+
 	ANNOTATION_ARGS = "args"
 
 	FLAG_OBSHELL_PORT = "port"
@@ -67,6 +67,34 @@ func CallApi(uri string, param interface{}) (*task.DagDetailDTO, error) {
 	return callApiHelper(sendRequest, uri, param)
 }
 
+// CallApiWithMethod sends the request to the local agent and fills the response
+// data into ret. The param is not logged since it may carry passwords.
+func CallApiWithMethod(method string, uri string, param interface{}, ret interface{}) error {
+	stdio.Verbosef("Calling API [%s]%s", method, uri)
+
+	var err error
+	switch method {
+	case http.GET:
+		err = http.SendGetRequestViaUnixSocket(path.ObshellSocketPath(), uri, param, ret)
+	case http.POST:
+		err = http.SendPostRequestViaUnixSocket(path.ObshellSocketPath(), uri, param, ret)
+	case http.PUT:
+		err = http.SendPutRequestViaUnixSocket(path.ObshellSocketPath(), uri, param, ret)
+	case http.PATCH:
+		err = http.SendPatchRequestViaUnixSocket(path.ObshellSocketPath(), uri, param, ret)
+	case http.DELETE:
+		err = http.SendDeleteRequestViaUnixSocket(path.ObshellSocketPath(), uri, param, ret)
+	default:
+		err = errors.Occur(errors.ErrRequestMethodNotSupport, method)
+	}
+	if err != nil {
+		stdio.Verbosef("Call API %s failed, error is %s", uri, err)
+		return err
+	}
+	stdio.Verbosef("Calling API %s OK.", uri)
+	return nil
+}
+
 func GetFailedDagLastLog(currentDag *task.DagDetailDTO) (res []string) {
 	nodes := currentDag.Nodes
 
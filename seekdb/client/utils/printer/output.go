@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package printer
+
+import (
+	"encoding/json"
+
+	"github.com/oceanbase/obshell/seekdb/agent/errors"
+	clientconst "github.com/oceanbase/obshell/seekdb/client/constant"
+	"github.com/oceanbase/obshell/seekdb/client/lib/stdio"
+)
+
+// CheckOutputFormat checks the value of the output flag.
+func CheckOutputFormat(format string) error {
+	if format != clientconst.OUTPUT_FORMAT_TABLE && format != clientconst.OUTPUT_FORMAT_JSON {
+		return errors.Occurf(errors.ErrCliUsageError, "unsupported output format '%s', should be '%s' or '%s'",
+			format, clientconst.OUTPUT_FORMAT_TABLE, clientconst.OUTPUT_FORMAT_JSON)
+	}
+	return nil
+}
+
+// PrintWithFormat prints data as indented json when the format is json,
+// otherwise it renders the data with printTable.
+func PrintWithFormat(format string, data interface{}, printTable func()) error {
+	if format != clientconst.OUTPUT_FORMAT_JSON {
+		printTable()
+		return nil
+	}
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal output failed")
+	}
+	stdio.Print(string(content))
+	return nil
+}
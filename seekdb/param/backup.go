@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2024 OceanBase.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package param
+
+import "time"
+
+// BackupConfigParam is used by PUT /api/v1/seekdb/backup/config.
+// BackupBaseUri is split into the data and clog directories, ArchiveBaseUri
+// and DataBaseUri override the corresponding directory when set.
+type BackupConfigParam struct {
+	BackupBaseUri         *string `json:"backup_base_uri"`
+	DataBaseUri           *string `json:"data_base_uri"`
+	ArchiveBaseUri        *string `json:"archive_base_uri"`
+	Binding               *string `json:"binding"` // OPTIONAL / MANDATORY
+	LogArchiveConcurrency *int    `json:"log_archive_concurrency"`
+	ArchiveLagTarget      *string `json:"archive_lag_target"`
+}
+
+// BackupParam is used by POST /api/v1/seekdb/backup.
+type BackupParam struct {
+	Mode        string `json:"mode"`       // full / incremental, full by default
+	Encryption  string `json:"encryption"` // password of the backup set, empty means not encrypted
+	PlusArchive bool   `json:"plus_archive"`
+}
+
+// BackupHistoryParam is used by GET /api/v1/seekdb/backup/history.
+type BackupHistoryParam struct {
+	Limit int `form:"limit"`
+}
+
+// RestoreParam is used by POST /api/v1/seekdb/restore.
+// ArchiveLogUri defaults to DataBackupUri, Timestamp defaults to the end of the archive log.
+type RestoreParam struct {
+	DataBackupUri string     `json:"data_backup_uri" binding:"required"`
+	ArchiveLogUri string     `json:"archive_log_uri"`
+	Timestamp     *time.Time `json:"timestamp"`
+	Decryption    []string   `json:"decryption"`
+}
+
+// ArchiveLogStatus describes the archive log of seekdb.
+type ArchiveLogStatus struct {
+	Status        string     `json:"status"`
+	StartScn      *time.Time `json:"start_scn,omitempty"`
+	CheckpointScn *time.Time `json:"checkpoint_scn,omitempty"`
+	Delay         float64    `json:"delay"`
+	Comment       string     `json:"comment,omitempty"`
+}
+
+// BackupJob describes a running or finished backup job.
+type BackupJob struct {
+	JobID          int64      `json:"job_id"`
+	BackupSetID    int64      `json:"backup_set_id"`
+	BackupType     string     `json:"backup_type"`
+	PlusArchivelog bool       `json:"plus_archivelog"`
+	Status         string     `json:"status"`
+	StartTimestamp *time.Time `json:"start_timestamp,omitempty"`
+	EndTimestamp   *time.Time `json:"end_timestamp,omitempty"`
+	Result         int64      `json:"result"`
+	Comment        string     `json:"comment,omitempty"`
+	Path           string     `json:"path"`
+}
+
+// BackupOverview is returned by GET /api/v1/seekdb/backup/overview.
+// The secrets in the destinations are masked.
+type BackupOverview struct {
+	DataBackupDest string            `json:"data_backup_dest"`
+	LogArchiveDest string            `json:"log_archive_dest"`
+	ArchiveLog     *ArchiveLogStatus `json:"archive_log,omitempty"`
+	RunningJob     *BackupJob        `json:"running_job,omitempty"`
+	LastJob        *BackupJob        `json:"last_job,omitempty"`
+}
+
+// RestoreOverview is returned by GET /api/v1/seekdb/restore/overview.
+// Running is false when no restore is in progress, the last finished restore is reported then.
+type RestoreOverview struct {
+	Running           bool   `json:"running"`
+	JobID             int64  `json:"job_id"`
+	Status            string `json:"status"`
+	RestoreScnDisplay string `json:"restore_scn_display"`
+	StartTimestamp    string `json:"start_timestamp"`
+	FinishTimestamp   string `json:"finish_timestamp,omitempty"`
+	BackupSetList     string `json:"backup_set_list"`
+	BackupPieceList   string `json:"backup_piece_list"`
+	RestoreProgress   string `json:"restore_progress,omitempty"`
+	RecoverProgress   string `json:"recover_progress,omitempty"`
+	Comment           string `json:"comment,omitempty"`
+}
@@ -16,6 +16,8 @@
 
 package param
 
+import "time"
+
 // TokenParam is used by POST /api/v1/seekdb/standby/token.
 type TokenParam struct {
 	Force bool `json:"force"`
@@ -80,6 +82,7 @@ type LocalStandbyStatus struct {
 	LogRestoreSource string `json:"log_restore_source"`
 	SyncScn          uint64 `json:"sync_scn"`
 	ReadableScn      uint64 `json:"readable_scn"`
+	Version          string `json:"version,omitempty"` // seekdb build version, e.g. 1.0.0.0-100000012025
 	// SyncStatus is populated by StandbyService.GetFullStatus (which has the
 	// upstream's sync_scn available) rather than by GetLocalStatus. PRIMARY
 	// nodes leave this empty.
@@ -104,3 +107,52 @@ type StandbyStatusResp struct {
 	Local LocalStandbyStatus  `json:"local"`
 	Peers []PeerStandbyStatus `json:"peers"`
 }
+
+// FailoverConfigParam is used by PUT /api/v1/seekdb/standby/failover.
+// Nil fields keep their current values.
+type FailoverConfigParam struct {
+	Enabled              *bool `json:"enabled"`
+	OutageWindowSeconds  *int  `json:"outage_window_seconds"`
+	MaxLagSeconds        *int  `json:"max_lag_seconds"`
+	ProbeIntervalSeconds *int  `json:"probe_interval_seconds"`
+	PrimarySqlPort       *int  `json:"primary_sql_port"`
+}
+
+// FailoverConfig describes the automatic failover settings of the local node.
+type FailoverConfig struct {
+	Enabled              bool `json:"enabled"`
+	OutageWindowSeconds  int  `json:"outage_window_seconds"`
+	MaxLagSeconds        int  `json:"max_lag_seconds"`
+	ProbeIntervalSeconds int  `json:"probe_interval_seconds"`
+	PrimarySqlPort       int  `json:"primary_sql_port"`
+}
+
+// FailoverEvent is one event recorded by the failover controller.
+type FailoverEvent struct {
+	ID              int64     `json:"id"`
+	EventType       string    `json:"event_type"`
+	PeerHost        string    `json:"peer_host,omitempty"`
+	PeerObshellPort int       `json:"peer_obshell_port,omitempty"`
+	Message         string    `json:"message"`
+	DagID           string    `json:"dag_id,omitempty"`
+	FencePending    bool      `json:"fence_pending"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// FailoverStatusResp is returned by GET /api/v1/seekdb/standby/failover.
+// OutageSince is set while the upstream primary is unreachable, LastLagSeconds
+// is the lag observed at LastLagSampledAt, the last time the primary was reachable.
+type FailoverStatusResp struct {
+	Config           FailoverConfig  `json:"config"`
+	OutageSince      *time.Time      `json:"outage_since,omitempty"`
+	LastLagSeconds   *int64          `json:"last_lag_seconds,omitempty"`
+	LastLagSampledAt *time.Time      `json:"last_lag_sampled_at,omitempty"`
+	Events           []FailoverEvent `json:"events"`
+}
+
+// RpcFenceParam is used by the internal POST /rpc/v1/seekdb/standby/fence RPC,
+// which the new primary calls to fence the old primary after a failover.
+type RpcFenceParam struct {
+	CallerHost        string `json:"caller_host" binding:"required"`
+	CallerObshellPort int    `json:"caller_obshell_port" binding:"required"`
+}